@@ -0,0 +1,32 @@
+// Команда ppo-tui запускает консольный интерфейс поверх того же app.App, что и HTTP-сервер.
+// Доменные события TUI записывает в outbox общей БД; доставляет их запущенный сервер.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"ppo/internal/app"
+	"ppo/internal/config"
+	"ppo/internal/storage/postgres"
+	"ppo/internal/tui"
+)
+
+func main() {
+	cfg, err := config.Load(flag.CommandLine, os.Args[1:])
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	pool, err := postgres.Connect(context.Background(), &cfg.DBConfig)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	err = tui.NewTUI(app.NewApp(pool, cfg)).Run()
+	pool.Close()
+	if err != nil {
+		log.Fatalln(err)
+	}
+}
@@ -0,0 +1,18 @@
+// Команда ppoctl — неинтерактивная утилита администратора для сценариев и cron:
+//
+//	ppoctl --config config.yaml -o json users list
+//	ppoctl skills add --name Go --description "Язык программирования"
+//	ppoctl -o csv reports export --company <id> > reports.csv
+//	ppoctl reports import --file reports.csv
+//
+// Коды возврата описаны в пакете internal/cli.
+package main
+
+import (
+	"os"
+	"ppo/internal/cli"
+)
+
+func main() {
+	os.Exit(cli.Main(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
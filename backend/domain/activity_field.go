@@ -18,6 +18,7 @@ type IActivityFieldRepository interface {
 	DeleteById(context.Context, uuid.UUID) error
 	Update(context.Context, *ActivityField) error
 	GetById(context.Context, uuid.UUID) (*ActivityField, error)
+	GetByIds(context.Context, []uuid.UUID) ([]*ActivityField, error)
 	GetMaxCost(context.Context) (float32, error)
 	GetAll(context.Context, int, bool) ([]*ActivityField, int, error)
 }
@@ -27,6 +28,7 @@ type IActivityFieldService interface {
 	DeleteById(context.Context, uuid.UUID) error
 	Update(context.Context, *ActivityField) error
 	GetById(context.Context, uuid.UUID) (*ActivityField, error)
+	GetByIds(context.Context, []uuid.UUID) ([]*ActivityField, error)
 	GetCostByCompanyId(context.Context, uuid.UUID) (float32, error)
 	GetMaxCost(context.Context) (float32, error)
 	GetAll(context.Context, int, bool) ([]*ActivityField, int, error)
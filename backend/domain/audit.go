@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditAction string
+
+const (
+	AuditCreate     AuditAction = "create"
+	AuditUpdate     AuditAction = "update"
+	AuditAmend      AuditAction = "amend"
+	AuditSubmit     AuditAction = "submit"
+	AuditReview     AuditAction = "review"
+	AuditDelete     AuditAction = "delete"
+	AuditAssignRole AuditAction = "assign_role"
+)
+
+type AuditEntity string
+
+const (
+	AuditEntityUser          AuditEntity = "user"
+	AuditEntityRole          AuditEntity = "role"
+	AuditEntityCompany       AuditEntity = "company"
+	AuditEntityFinReport     AuditEntity = "fin_report"
+	AuditEntityFinDocument   AuditEntity = "fin_report_document"
+	AuditEntityContact       AuditEntity = "contact"
+	AuditEntitySkill         AuditEntity = "skill"
+	AuditEntityUserSkill     AuditEntity = "user_skill"
+	AuditEntityActivityField AuditEntity = "activity_field"
+	AuditEntityReview        AuditEntity = "review"
+	AuditEntityWebhook       AuditEntity = "webhook"
+)
+
+// AuditEntry — запись журнала аудита об одном изменении сущности.
+// Before и After — JSON-снимки сущности до и после изменения; у созданной сущности
+// нет Before, у удалённой — After. ActorID равен uuid.Nil, если изменение
+// выполнено от имени системы (см. ActorFromContext).
+type AuditEntry struct {
+	ID         uuid.UUID
+	ActorID    uuid.UUID
+	ActorRole  string
+	Action     AuditAction
+	EntityType AuditEntity
+	EntityID   string
+	Before     json.RawMessage
+	After      json.RawMessage
+	CreatedAt  time.Time
+}
+
+// AuditFilter отбирает записи журнала; пустые поля не ограничивают выборку.
+// Период [From, To) задаётся по времени записи.
+type AuditFilter struct {
+	EntityType AuditEntity
+	EntityID   string
+	ActorID    uuid.UUID
+	From       time.Time
+	To         time.Time
+}
+
+// IAuditRepository хранит журнал аудита. Журнал только пополняется:
+// изменить или удалить записи нельзя.
+type IAuditRepository interface {
+	Append(context.Context, *AuditEntry) error
+	Find(context.Context, *AuditFilter, int) ([]*AuditEntry, int, error)
+}
+
+type IAuditService interface {
+	Find(context.Context, *AuditFilter, int) ([]*AuditEntry, int, error)
+}
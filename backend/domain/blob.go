@@ -0,0 +1,17 @@
+package domain
+
+import (
+	"context"
+	"io"
+)
+
+// IBlobStorage хранит содержимое файлов по ключу. Ключи выбирает приложение,
+// метаданные файлов хранятся отдельно, в БД.
+type IBlobStorage interface {
+	// Put записывает содержимое целиком, заменяя прежнее, и возвращает его размер.
+	Put(context.Context, string, io.Reader) (int64, error)
+	// Open возвращает содержимое по ключу или ошибку, оборачивающую ErrNotFound.
+	Open(context.Context, string) (io.ReadCloser, error)
+	// Delete удаляет содержимое; отсутствие ключа ошибкой не считается.
+	Delete(context.Context, string) error
+}
@@ -17,6 +17,7 @@ type ICompanyRepository interface {
 	Create(context.Context, *Company) error
 	GetById(context.Context, uuid.UUID) (*Company, error)
 	GetByOwnerId(context.Context, uuid.UUID, int, bool) ([]*Company, int, error)
+	GetByOwnerIds(context.Context, []uuid.UUID) ([]*Company, error)
 	GetAll(context.Context, int) ([]*Company, error)
 	Update(context.Context, *Company) error
 	DeleteById(context.Context, uuid.UUID) error
@@ -26,6 +27,7 @@ type ICompanyService interface {
 	Create(context.Context, *Company) error
 	GetById(context.Context, uuid.UUID) (*Company, error)
 	GetByOwnerId(context.Context, uuid.UUID, int, bool) ([]*Company, int, error)
+	GetByOwnerIds(context.Context, []uuid.UUID) ([]*Company, error)
 	GetAll(context.Context, int) ([]*Company, error)
 	Update(context.Context, *Company) error
 	DeleteById(context.Context, uuid.UUID) error
@@ -16,6 +16,7 @@ type IContactsRepository interface {
 	Create(context.Context, *Contact) error
 	GetById(context.Context, uuid.UUID) (*Contact, error)
 	GetByOwnerId(context.Context, uuid.UUID) ([]*Contact, error)
+	GetByOwnerIds(context.Context, []uuid.UUID) ([]*Contact, error)
 	Update(context.Context, *Contact) error
 	DeleteById(context.Context, uuid.UUID) error
 }
@@ -24,6 +25,7 @@ type IContactsService interface {
 	Create(context.Context, *Contact) error
 	GetById(context.Context, uuid.UUID) (*Contact, error)
 	GetByOwnerId(context.Context, uuid.UUID) ([]*Contact, error)
+	GetByOwnerIds(context.Context, []uuid.UUID) ([]*Contact, error)
 	Update(context.Context, *Contact) error
 	DeleteById(context.Context, uuid.UUID) error
 }
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"errors"
+	"ppo/pkg/i18n"
+)
+
+var (
+	ErrNotFound   = errors.New("объект не найден")
+	ErrValidation = errors.New("ошибка валидации")
+	ErrConflict   = errors.New("конфликт данных")
+	ErrForbidden  = errors.New("доступ запрещен")
+	// ErrInvalidCredentials не различает неизвестного пользователя и неверный
+	// пароль, чтобы по ответу нельзя было узнать, занято ли имя пользователя.
+	ErrInvalidCredentials = errors.New("неверное имя пользователя или пароль")
+)
+
+// kindError относит исходную ошибку к одной из категорий выше, не меняя её текст.
+type kindError struct {
+	kind error
+	err  error
+}
+
+func (e *kindError) Error() string {
+	return e.err.Error()
+}
+
+func (e *kindError) Unwrap() []error {
+	return []error{e.kind, e.err}
+}
+
+func NewNotFoundError(err error) error {
+	return &kindError{kind: ErrNotFound, err: err}
+}
+
+func NewConflictError(err error) error {
+	return &kindError{kind: ErrConflict, err: err}
+}
+
+// ValidationError описывает некорректное значение поля Field.
+// Текст ошибки берётся из каталога сообщений на языке по умолчанию.
+type ValidationError struct {
+	Field   string
+	Key     i18n.Key
+	Args    []any
+	Message string
+}
+
+func NewValidationError(field string, key i18n.Key, args ...any) error {
+	return &ValidationError{
+		Field:   field,
+		Key:     key,
+		Args:    args,
+		Message: i18n.T(i18n.Default, key, args...),
+	}
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+func (e *ValidationError) Localize(lang i18n.Lang) string {
+	if e.Key == "" {
+		return e.Message
+	}
+
+	return i18n.T(lang, e.Key, e.Args...)
+}
+
+// LocalizeError возвращает текст ошибки для пользователя на языке lang.
+// Ошибки без перевода возвращаются как есть.
+func LocalizeError(lang i18n.Lang, err error) string {
+	var validationErr *ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		return validationErr.Localize(lang)
+	case errors.Is(err, ErrNotFound):
+		return i18n.T(lang, i18n.MsgNotFound)
+	case errors.Is(err, ErrConflict):
+		return i18n.T(lang, i18n.MsgConflict)
+	case errors.Is(err, ErrForbidden):
+		return i18n.T(lang, i18n.MsgForbidden)
+	case errors.Is(err, ErrInvalidCredentials):
+		return i18n.T(lang, i18n.MsgInvalidCredentials)
+	}
+
+	return err.Error()
+}
@@ -0,0 +1,227 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventType — тип доменного события. Подписаться на любое из них
+// можно как внутри приложения через IEventBus, так и снаружи через вебхуки.
+type EventType string
+
+const (
+	EventCompanyCreated    EventType = "company.created"
+	EventCompanyUpdated    EventType = "company.updated"
+	EventCompanyDeleted    EventType = "company.deleted"
+	EventFinReportFiled    EventType = "fin_report.filed"
+	EventFinReportDeleted  EventType = "fin_report.deleted"
+	EventFinReportMissing  EventType = "fin_report.missing"
+	EventFinReportReviewed EventType = "fin_report.reviewed"
+	EventReviewPosted      EventType = "review.posted"
+	EventUserRoleChanged   EventType = "user.role_changed"
+	EventRatingChanged     EventType = "rating.changed"
+)
+
+var EventTypes = []EventType{
+	EventCompanyCreated,
+	EventCompanyUpdated,
+	EventCompanyDeleted,
+	EventFinReportFiled,
+	EventFinReportDeleted,
+	EventFinReportMissing,
+	EventFinReportReviewed,
+	EventReviewPosted,
+	EventUserRoleChanged,
+	EventRatingChanged,
+}
+
+func IsKnownEventType(eventType EventType) bool {
+	for _, t := range EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Event — доменное событие. Поля событий сериализуются в outbox
+// и в том же виде отправляются подписчикам вебхуков.
+type Event interface {
+	EventType() EventType
+}
+
+type CompanyCreated struct {
+	ID              uuid.UUID `json:"id"`
+	OwnerID         uuid.UUID `json:"owner_id"`
+	ActivityFieldID uuid.UUID `json:"activity_field_id"`
+	Name            string    `json:"name"`
+	City            string    `json:"city"`
+}
+
+func (CompanyCreated) EventType() EventType { return EventCompanyCreated }
+
+type CompanyUpdated struct {
+	ID              uuid.UUID `json:"id"`
+	OwnerID         uuid.UUID `json:"owner_id"`
+	ActivityFieldID uuid.UUID `json:"activity_field_id"`
+	Name            string    `json:"name"`
+	City            string    `json:"city"`
+}
+
+func (CompanyUpdated) EventType() EventType { return EventCompanyUpdated }
+
+type CompanyDeleted struct {
+	ID      uuid.UUID `json:"id"`
+	OwnerID uuid.UUID `json:"owner_id"`
+}
+
+func (CompanyDeleted) EventType() EventType { return EventCompanyDeleted }
+
+// FinancialReportFiled публикуется при добавлении отчёта и при его исправлении (Amended).
+type FinancialReportFiled struct {
+	ID        uuid.UUID `json:"id"`
+	CompanyID uuid.UUID `json:"company_id"`
+	Year      int       `json:"year"`
+	Quarter   int       `json:"quarter"`
+	Revenue   float32   `json:"revenue"`
+	Costs     float32   `json:"costs"`
+	Amended   bool      `json:"amended"`
+}
+
+func (FinancialReportFiled) EventType() EventType { return EventFinReportFiled }
+
+type FinancialReportDeleted struct {
+	ID        uuid.UUID `json:"id"`
+	CompanyID uuid.UUID `json:"company_id"`
+	Year      int       `json:"year"`
+	Quarter   int       `json:"quarter"`
+}
+
+func (FinancialReportDeleted) EventType() EventType { return EventFinReportDeleted }
+
+// FinancialReportMissing напоминает, что компания не подала отчёт
+// за последний завершившийся квартал. Публикуется фоновой задачей.
+type FinancialReportMissing struct {
+	CompanyID uuid.UUID `json:"company_id"`
+	OwnerID   uuid.UUID `json:"owner_id"`
+	Year      int       `json:"year"`
+	Quarter   int       `json:"quarter"`
+}
+
+func (FinancialReportMissing) EventType() EventType { return EventFinReportMissing }
+
+// FinancialReportReviewed публикуется, когда проверяющий подтвердил или отклонил отчёт.
+type FinancialReportReviewed struct {
+	ID        uuid.UUID          `json:"id"`
+	CompanyID uuid.UUID          `json:"company_id"`
+	Year      int                `json:"year"`
+	Quarter   int                `json:"quarter"`
+	Status    VerificationStatus `json:"status"`
+	Comment   string             `json:"comment"`
+}
+
+func (FinancialReportReviewed) EventType() EventType { return EventFinReportReviewed }
+
+type ReviewPosted struct {
+	ID         uuid.UUID `json:"id"`
+	TargetID   uuid.UUID `json:"target_id"`
+	ReviewerID uuid.UUID `json:"reviewer_id"`
+	Rating     int       `json:"rating"`
+}
+
+func (ReviewPosted) EventType() EventType { return EventReviewPosted }
+
+type UserRoleChanged struct {
+	UserID       uuid.UUID `json:"user_id"`
+	Role         string    `json:"role"`
+	PreviousRole string    `json:"previous_role"`
+}
+
+func (UserRoleChanged) EventType() EventType { return EventUserRoleChanged }
+
+// RatingChanged сообщает, что изменились данные, от которых зависит рейтинг
+// предпринимателя; актуальное значение отдаёт GET /entrepreneurs/{id}/rating.
+type RatingChanged struct {
+	UserID uuid.UUID `json:"user_id"`
+}
+
+func (RatingChanged) EventType() EventType { return EventRatingChanged }
+
+// DecodeEvent восстанавливает событие типа eventType из сериализованных данных.
+func DecodeEvent(eventType EventType, data []byte) (event Event, err error) {
+	switch eventType {
+	case EventCompanyCreated:
+		event, err = decodeEvent[CompanyCreated](data)
+	case EventCompanyUpdated:
+		event, err = decodeEvent[CompanyUpdated](data)
+	case EventCompanyDeleted:
+		event, err = decodeEvent[CompanyDeleted](data)
+	case EventFinReportFiled:
+		event, err = decodeEvent[FinancialReportFiled](data)
+	case EventFinReportDeleted:
+		event, err = decodeEvent[FinancialReportDeleted](data)
+	case EventFinReportMissing:
+		event, err = decodeEvent[FinancialReportMissing](data)
+	case EventFinReportReviewed:
+		event, err = decodeEvent[FinancialReportReviewed](data)
+	case EventReviewPosted:
+		event, err = decodeEvent[ReviewPosted](data)
+	case EventUserRoleChanged:
+		event, err = decodeEvent[UserRoleChanged](data)
+	case EventRatingChanged:
+		event, err = decodeEvent[RatingChanged](data)
+	default:
+		return nil, fmt.Errorf("неизвестный тип события: %s", eventType)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("разбор события %s: %w", eventType, err)
+	}
+
+	return event, nil
+}
+
+func decodeEvent[T Event](data []byte) (Event, error) {
+	var event T
+	err := json.Unmarshal(data, &event)
+	return event, err
+}
+
+// OutboxEvent — событие, сохранённое в outbox вместе с изменением, которое его породило.
+type OutboxEvent struct {
+	ID        uuid.UUID
+	Type      EventType
+	Payload   []byte
+	Event     Event
+	Attempts  int
+	CreatedAt time.Time
+}
+
+type EventHandler func(context.Context, *OutboxEvent) error
+
+type IOutboxRepository interface {
+	Add(context.Context, *OutboxEvent) error
+	ClaimNext(context.Context, time.Time) (*OutboxEvent, error)
+	MarkProcessed(context.Context, uuid.UUID) error
+	MarkFailed(context.Context, uuid.UUID, string, time.Time) error
+	DeleteProcessedBefore(context.Context, time.Time) (int, error)
+}
+
+// IEventBus публикует доменные события и раздаёт их подписчикам.
+// Publish записывает событие в outbox в транзакции из ctx, если она открыта,
+// поэтому подписчики узнают о событии только после фиксации изменения.
+type IEventBus interface {
+	Publish(context.Context, Event) error
+	Subscribe(EventType, EventHandler)
+}
+
+// ITransactor выполняет fn в одной транзакции: все обращения к хранилищу
+// с переданным в fn контекстом фиксируются или откатываются вместе.
+type ITransactor interface {
+	WithinTx(context.Context, func(context.Context) error) error
+}
@@ -2,9 +2,27 @@ package domain
 
 import (
 	"context"
+	"io"
+	"math"
+	"sort"
+	"time"
+
 	"github.com/google/uuid"
 )
 
+// VerificationStatus — состояние проверки показателей отчёта. Отчёт подаётся
+// неподтверждённым; владелец прикладывает документы и отправляет его на проверку,
+// проверяющий подтверждает или отклоняет его. Любое изменение отчёта возвращает
+// его в неподтверждённые.
+type VerificationStatus string
+
+const (
+	VerificationUnverified VerificationStatus = "unverified"
+	VerificationSubmitted  VerificationStatus = "submitted"
+	VerificationVerified   VerificationStatus = "verified"
+	VerificationRejected   VerificationStatus = "rejected"
+)
+
 type FinancialReport struct {
 	ID        uuid.UUID
 	CompanyID uuid.UUID
@@ -12,6 +30,49 @@ type FinancialReport struct {
 	Costs     float32
 	Year      int
 	Quarter   int
+	// Расширенные показатели необязательны: nil — показатель не указан.
+	// Активы, обязательства и капитал — на конец квартала.
+	EBITDA      *float32
+	NetProfit   *float32
+	Assets      *float32
+	Liabilities *float32
+	Equity      *float32
+	Headcount   *int
+	// ExportsShare — доля экспорта в выручке, в процентах.
+	ExportsShare *float32
+	Status       VerificationStatus
+	// ReviewComment — комментарий проверяющего; обязателен при отклонении.
+	ReviewComment string
+	// ReviewerID и ReviewedAt заполнены у подтверждённых и отклонённых отчётов.
+	ReviewerID uuid.UUID
+	ReviewedAt time.Time
+}
+
+// FinancialReportDocument — документ, подтверждающий показатели отчёта.
+// Содержимое хранится в IBlobStorage под ключом StorageKey.
+type FinancialReportDocument struct {
+	ID          uuid.UUID
+	ReportID    uuid.UUID
+	Name        string
+	ContentType string
+	Size        int64
+	StorageKey  string
+	// UploadedBy — загрузивший документ пользователь; uuid.Nil, если документ загружен от имени системы.
+	UploadedBy uuid.UUID
+	UploadedAt time.Time
+}
+
+// DocumentContentTypes — допустимые типы содержимого подтверждающих документов.
+var DocumentContentTypes = []string{"application/pdf", "image/png", "image/jpeg"}
+
+func IsDocumentContentType(contentType string) bool {
+	for _, t := range DocumentContentTypes {
+		if t == contentType {
+			return true
+		}
+	}
+
+	return false
 }
 
 type FinancialReportByPeriod struct {
@@ -26,6 +87,41 @@ type Period struct {
 	StartQuarter int
 	EndYear      int
 	EndQuarter   int
+	// AsOf — момент, на который берутся значения отчётов: из каждого отчёта
+	// используется последняя версия, поданная не позже AsOf, а отчёты, поданные
+	// позже, не учитываются. Нулевое значение — текущие версии. Версии хранят
+	// только выручку и расходы, расширенные показатели всегда текущие.
+	AsOf time.Time
+	// VerifiedOnly оставляет только подтверждённые отчёты. Учитывается текущий
+	// статус проверки, в том числе при заданном AsOf.
+	VerifiedOnly bool
+}
+
+// FinancialReportRevision — версия квартального отчёта. Первая версия создаётся
+// при подаче отчёта, следующие — при каждом изменении выручки или расходов;
+// текущая версия (Current) совпадает со значениями в FinancialReport.
+type FinancialReportRevision struct {
+	ReportID uuid.UUID
+	Version  int
+	Revenue  float32
+	Costs    float32
+	// AuthorID — пользователь, подавший версию; uuid.Nil, если она подана от имени системы.
+	AuthorID uuid.UUID
+	// Reason — причина исправления; обязательна для исправлений, поданных через Amend.
+	Reason  string
+	FiledAt time.Time
+	Current bool
+}
+
+// LastClosedQuarter возвращает последний квартал, завершившийся к now.
+func LastClosedQuarter(now time.Time) (year, quarter int) {
+	year, quarter = now.Year(), int(now.Month()-1)/3
+	if quarter == 0 {
+		year--
+		quarter = 4
+	}
+
+	return year, quarter
 }
 
 func (r *FinancialReportByPeriod) Revenue() (sum float32) {
@@ -52,12 +148,278 @@ func (r *FinancialReportByPeriod) Profit() (sum float32) {
 	return sum
 }
 
+// NetProfit — чистая прибыль за период. Для отчётов без указанной чистой
+// прибыли берётся разница выручки и расходов.
+func (r *FinancialReportByPeriod) NetProfit() (sum float32) {
+	for _, rep := range r.Reports {
+		if rep.NetProfit != nil {
+			sum += *rep.NetProfit
+		} else {
+			sum += rep.Revenue - rep.Costs
+		}
+	}
+
+	return sum
+}
+
+// latest возвращает последний по порядку отчёт периода, для которого ok истинно.
+// Балансовые показатели берутся на конец периода, то есть из него.
+func (r *FinancialReportByPeriod) latest(ok func(*FinancialReport) bool) *FinancialReport {
+	for i := len(r.Reports) - 1; i >= 0; i-- {
+		if ok(&r.Reports[i]) {
+			return &r.Reports[i]
+		}
+	}
+
+	return nil
+}
+
+// Margin — рентабельность продаж: чистая прибыль к выручке за период.
+// Не определена при нулевой выручке.
+func (r *FinancialReportByPeriod) Margin() (margin float32, ok bool) {
+	revenue := r.Revenue()
+	if revenue == 0 {
+		return 0, false
+	}
+
+	return r.NetProfit() / revenue, true
+}
+
+// ROE — рентабельность капитала: чистая прибыль за период к капиталу на конец
+// периода. Не определена, если капитал не указан или не положителен.
+func (r *FinancialReportByPeriod) ROE() (roe float32, ok bool) {
+	rep := r.latest(func(rep *FinancialReport) bool { return rep.Equity != nil })
+	if rep == nil || *rep.Equity <= 0 {
+		return 0, false
+	}
+
+	return r.NetProfit() / *rep.Equity, true
+}
+
+// DebtToEquity — отношение обязательств к капиталу на конец периода.
+// Не определено, если показатели не указаны или капитал не положителен.
+func (r *FinancialReportByPeriod) DebtToEquity() (ratio float32, ok bool) {
+	rep := r.latest(func(rep *FinancialReport) bool { return rep.Liabilities != nil && rep.Equity != nil })
+	if rep == nil || *rep.Equity <= 0 {
+		return 0, false
+	}
+
+	return *rep.Liabilities / *rep.Equity, true
+}
+
+// RevenuePerEmployee — выручка за период на одного сотрудника по численности
+// на конец периода. Не определена, если численность не указана или равна нулю.
+func (r *FinancialReportByPeriod) RevenuePerEmployee() (revenue float32, ok bool) {
+	rep := r.latest(func(rep *FinancialReport) bool { return rep.Headcount != nil && *rep.Headcount > 0 })
+	if rep == nil {
+		return 0, false
+	}
+
+	return r.Revenue() / float32(*rep.Headcount), true
+}
+
+// QuarterFigures — итоги квартала. Отчёты нескольких компаний за один квартал суммируются.
+type QuarterFigures struct {
+	Year    int
+	Quarter int
+	Revenue float32
+	Costs   float32
+	Profit  float32
+}
+
+// Growth — относительные величины по выручке, расходам и прибыли; nil, если
+// величину нельзя рассчитать.
+type Growth struct {
+	Revenue *float32
+	Costs   *float32
+	Profit  *float32
+}
+
+// QuarterGrowth — итоги квартала с ростом к предыдущему кварталу (QoQ) и к тому же
+// кварталу предыдущего года (YoY). Рост не определён, если в периоде нет отчётов
+// за базовый квартал или показатель в нём равен нулю.
+type QuarterGrowth struct {
+	QuarterFigures
+	QoQ Growth
+	YoY Growth
+}
+
+// FinancialAnalytics — динамика показателей за период по кварталам с отчётами.
+type FinancialAnalytics struct {
+	Quarters []QuarterGrowth
+	// CAGR — среднегодовой темп роста от первого до последнего квартала с отчётами.
+	// Не определён, если такой квартал один или показатель в нём не положителен.
+	CAGR Growth
+	// Volatility — стандартное отклонение квартальных темпов роста (QoQ);
+	// не определена, если их меньше двух.
+	Volatility Growth
+	// Best и Worst — кварталы с наибольшей и наименьшей прибылью; nil, если отчётов нет.
+	Best  *QuarterFigures
+	Worst *QuarterFigures
+}
+
+// кварталы нумеруются подряд: year*4 + quarter - 1
+func (q *QuarterFigures) index() int {
+	return q.Year*4 + q.Quarter - 1
+}
+
+// Quarters возвращает итоги по кварталам периода в хронологическом порядке.
+// Кварталы без отчётов пропускаются.
+func (r *FinancialReportByPeriod) Quarters() []QuarterFigures {
+	byIndex := make(map[int]*QuarterFigures)
+	indexes := make([]int, 0)
+	for _, rep := range r.Reports {
+		i := rep.Year*4 + rep.Quarter - 1
+		q, ok := byIndex[i]
+		if !ok {
+			q = &QuarterFigures{Year: rep.Year, Quarter: rep.Quarter}
+			byIndex[i] = q
+			indexes = append(indexes, i)
+		}
+
+		q.Revenue += rep.Revenue
+		q.Costs += rep.Costs
+		q.Profit += rep.Revenue - rep.Costs
+	}
+
+	sort.Ints(indexes)
+	quarters := make([]QuarterFigures, len(indexes))
+	for j, i := range indexes {
+		quarters[j] = *byIndex[i]
+	}
+
+	return quarters
+}
+
+// growth — относительное изменение от base к cur. Изменение отсчитывается от модуля
+// base, поэтому сокращение убытка считается ростом.
+func growth(cur, base float32) *float32 {
+	if base == 0 {
+		return nil
+	}
+
+	g := (cur - base) / float32(math.Abs(float64(base)))
+	return &g
+}
+
+func growthOf(cur, base *QuarterFigures) Growth {
+	if base == nil {
+		return Growth{}
+	}
+
+	return Growth{
+		Revenue: growth(cur.Revenue, base.Revenue),
+		Costs:   growth(cur.Costs, base.Costs),
+		Profit:  growth(cur.Profit, base.Profit),
+	}
+}
+
+func cagr(first, last float32, years float64) *float32 {
+	if first <= 0 || last <= 0 || years <= 0 {
+		return nil
+	}
+
+	g := float32(math.Pow(float64(last/first), 1/years) - 1)
+	return &g
+}
+
+func stdDev(values []float32) *float32 {
+	if len(values) < 2 {
+		return nil
+	}
+
+	var mean float64
+	for _, v := range values {
+		mean += float64(v)
+	}
+	mean /= float64(len(values))
+
+	var variance float64
+	for _, v := range values {
+		variance += (float64(v) - mean) * (float64(v) - mean)
+	}
+
+	dev := float32(math.Sqrt(variance / float64(len(values))))
+	return &dev
+}
+
+// Analytics рассчитывает рост показателей по кварталам периода, среднегодовой
+// темп роста, волатильность и лучший и худший кварталы.
+func (r *FinancialReportByPeriod) Analytics() *FinancialAnalytics {
+	quarters := r.Quarters()
+	analytics := &FinancialAnalytics{Quarters: make([]QuarterGrowth, len(quarters))}
+
+	byIndex := make(map[int]*QuarterFigures, len(quarters))
+	for i := range quarters {
+		byIndex[quarters[i].index()] = &quarters[i]
+	}
+
+	var revenue, costs, profit []float32
+	for i := range quarters {
+		q := &quarters[i]
+		g := QuarterGrowth{
+			QuarterFigures: *q,
+			QoQ:            growthOf(q, byIndex[q.index()-1]),
+			YoY:            growthOf(q, byIndex[q.index()-4]),
+		}
+		analytics.Quarters[i] = g
+
+		if g.QoQ.Revenue != nil {
+			revenue = append(revenue, *g.QoQ.Revenue)
+		}
+		if g.QoQ.Costs != nil {
+			costs = append(costs, *g.QoQ.Costs)
+		}
+		if g.QoQ.Profit != nil {
+			profit = append(profit, *g.QoQ.Profit)
+		}
+
+		if analytics.Best == nil || q.Profit > analytics.Best.Profit {
+			best := *q
+			analytics.Best = &best
+		}
+		if analytics.Worst == nil || q.Profit < analytics.Worst.Profit {
+			worst := *q
+			analytics.Worst = &worst
+		}
+	}
+
+	if len(quarters) > 1 {
+		first, last := &quarters[0], &quarters[len(quarters)-1]
+		years := float64(last.index()-first.index()) / 4
+		analytics.CAGR = Growth{
+			Revenue: cagr(first.Revenue, last.Revenue, years),
+			Costs:   cagr(first.Costs, last.Costs, years),
+			Profit:  cagr(first.Profit, last.Profit, years),
+		}
+	}
+
+	analytics.Volatility = Growth{
+		Revenue: stdDev(revenue),
+		Costs:   stdDev(costs),
+		Profit:  stdDev(profit),
+	}
+
+	return analytics
+}
+
 type IFinancialReportRepository interface {
 	Create(context.Context, *FinancialReport) error
 	GetById(context.Context, uuid.UUID) (*FinancialReport, error)
 	GetByCompany(context.Context, uuid.UUID, *Period) (*FinancialReportByPeriod, error)
+	// GetByCompanies возвращает отчёты за период для каждой из компаний, в том
+	// числе пустые для компаний без отчётов.
+	GetByCompanies(context.Context, []uuid.UUID, *Period) (map[uuid.UUID]*FinancialReportByPeriod, error)
 	Update(context.Context, *FinancialReport) error
 	DeleteById(context.Context, uuid.UUID) error
+	// AddRevision сохраняет новую версию отчёта, присваивая ей следующий номер.
+	AddRevision(context.Context, *FinancialReportRevision) error
+	GetRevisions(context.Context, uuid.UUID) ([]*FinancialReportRevision, error)
+	GetByStatus(context.Context, VerificationStatus, int) ([]*FinancialReport, int, error)
+	AddDocument(context.Context, *FinancialReportDocument) error
+	GetDocument(context.Context, uuid.UUID) (*FinancialReportDocument, error)
+	GetDocuments(context.Context, uuid.UUID) ([]*FinancialReportDocument, error)
+	DeleteDocument(context.Context, uuid.UUID) error
 }
 
 type IFinancialReportService interface {
@@ -65,6 +427,22 @@ type IFinancialReportService interface {
 	CreateByPeriod(context.Context, *FinancialReportByPeriod) error
 	GetById(context.Context, uuid.UUID) (*FinancialReport, error)
 	GetByCompany(context.Context, uuid.UUID, *Period) (*FinancialReportByPeriod, error)
+	GetByCompanies(context.Context, []uuid.UUID, *Period) (map[uuid.UUID]*FinancialReportByPeriod, error)
 	Update(context.Context, *FinancialReport) error
+	// Amend исправляет выручку и расходы отчёта с указанием причины, сохраняя прежнюю версию.
+	Amend(context.Context, *FinancialReportRevision) error
+	GetRevisions(context.Context, uuid.UUID) ([]*FinancialReportRevision, error)
 	DeleteById(context.Context, uuid.UUID) error
+	// Submit отправляет отчёт с приложенными документами на проверку.
+	Submit(context.Context, uuid.UUID) error
+	// Review подтверждает или отклоняет отправленный на проверку отчёт от имени
+	// пользователя из контекста; при отклонении нужен комментарий.
+	Review(context.Context, uuid.UUID, VerificationStatus, string) error
+	GetByStatus(context.Context, VerificationStatus, int) ([]*FinancialReport, int, error)
+	// AttachDocument сохраняет содержимое документа в хранилище и прикладывает его к отчёту.
+	AttachDocument(context.Context, *FinancialReportDocument, io.Reader) error
+	GetDocuments(context.Context, uuid.UUID) ([]*FinancialReportDocument, error)
+	// OpenDocument возвращает документ и его содержимое; содержимое закрывает вызывающий.
+	OpenDocument(context.Context, uuid.UUID) (*FinancialReportDocument, io.ReadCloser, error)
+	DeleteDocument(context.Context, uuid.UUID) error
 }
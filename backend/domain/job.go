@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type JobStatus string
+
+const (
+	JobRunning   JobStatus = "running"
+	JobSucceeded JobStatus = "succeeded"
+	JobFailed    JobStatus = "failed"
+)
+
+type JobTrigger string
+
+const (
+	JobTriggerSchedule JobTrigger = "schedule"
+	JobTriggerManual   JobTrigger = "manual"
+)
+
+// JobRun — запись об одном запуске фоновой задачи.
+type JobRun struct {
+	ID         uuid.UUID
+	Job        string
+	Trigger    JobTrigger
+	Status     JobStatus
+	Error      string
+	StartedAt  time.Time
+	FinishedAt time.Time
+}
+
+// Job — зарегистрированная фоновая задача и её состояние.
+type Job struct {
+	Name      string
+	Schedule  string
+	NextRunAt time.Time
+	LastRun   *JobRun
+}
+
+type IJobRepository interface {
+	// TryLock захватывает блокировку задачи, общую для всех экземпляров сервера.
+	// Если блокировка занята, ok равен false; иначе её освобождает release.
+	TryLock(context.Context, string) (release func(), ok bool, err error)
+	StartRun(context.Context, *JobRun) error
+	FinishRun(context.Context, *JobRun) error
+	GetLastRuns(context.Context) (map[string]*JobRun, error)
+	GetRuns(context.Context, string, int) ([]*JobRun, int, error)
+	DeleteRunsBefore(context.Context, time.Time) (int, error)
+}
+
+type IJobService interface {
+	GetJobs(context.Context) ([]*Job, error)
+	GetRuns(context.Context, string, int) ([]*JobRun, int, error)
+	Trigger(context.Context, string) (*JobRun, error)
+}
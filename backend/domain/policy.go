@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Actor — пользователь, от имени которого выполняется действие.
+type Actor struct {
+	ID   uuid.UUID
+	Role string
+}
+
+// IPolicyService проверяет, может ли пользователь изменять ресурс.
+// При нарушении политики возвращается ошибка, оборачивающая ErrForbidden.
+// Навыки пользователя политикой не проверяются: пара всегда составляется
+// из id пользователя, от имени которого выполняется действие.
+type IPolicyService interface {
+	CanManageCompany(context.Context, *Actor, uuid.UUID) error
+	CanManageFinReport(context.Context, *Actor, uuid.UUID) error
+	// CanReadFinDocuments разрешает просмотр документов отчёта его владельцу
+	// и проверяющим с правом finance:verify.
+	CanReadFinDocuments(context.Context, *Actor, uuid.UUID) error
+	CanManageContact(context.Context, *Actor, uuid.UUID) error
+	CanDeleteReview(context.Context, *Actor, uuid.UUID) error
+}
+
+type actorKey struct{}
+
+// WithActor сохраняет в контексте пользователя, от имени которого вызываются сервисы.
+// Изменения, сделанные с таким контекстом, записываются в журнал аудита с его id.
+func WithActor(ctx context.Context, actor *Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext возвращает пользователя, сохранённого WithActor, или nil,
+// если действие выполняется от имени системы: фоновой задачей, через ppoctl
+// или анонимно, как регистрация.
+func ActorFromContext(ctx context.Context) *Actor {
+	actor, _ := ctx.Value(actorKey{}).(*Actor)
+	return actor
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RatingSnapshot — рейтинг предпринимателя на конец квартала вместе с данными, по которым он рассчитан.
+// Рейтинг считается по отчётам за четыре квартала, заканчивающиеся кварталом снимка.
+type RatingSnapshot struct {
+	UserID  uuid.UUID
+	Year    int
+	Quarter int
+	Rating  float32
+	Revenue float32
+	Profit  float32
+	// CompanyID — наиболее прибыльная компания за период; uuid.Nil, если прибыльных компаний нет.
+	CompanyID  uuid.UUID
+	FieldCost  float32
+	MaxCost    float32
+	ComputedAt time.Time
+}
+
+type IRatingRepository interface {
+	Save(context.Context, *RatingSnapshot) error
+	GetHistory(context.Context, uuid.UUID, *Period) ([]*RatingSnapshot, error)
+}
+
+type IRatingService interface {
+	Recompute(context.Context, uuid.UUID, *Period) ([]*RatingSnapshot, error)
+	RecomputeHistory(context.Context, uuid.UUID) error
+	GetHistory(context.Context, uuid.UUID, *Period) ([]*RatingSnapshot, error)
+}
@@ -16,11 +16,19 @@ type Review struct {
 	Rating      int
 }
 
+// ReviewPage — страница отзывов и общее число страниц.
+type ReviewPage struct {
+	Reviews  []*Review
+	NumPages int
+}
+
 type IReviewRepository interface {
 	Create(context.Context, *Review) error
 	Get(context.Context, uuid.UUID) (*Review, error)
 	GetAllForReviewer(context.Context, uuid.UUID, int) ([]*Review, int, error)
 	GetAllForTarget(context.Context, uuid.UUID, int) ([]*Review, int, error)
+	// GetAllForTargets возвращает страницу page отзывов о каждом из объектов.
+	GetAllForTargets(context.Context, []uuid.UUID, int) (map[uuid.UUID]*ReviewPage, error)
 	Delete(context.Context, uuid.UUID) error
 }
 
@@ -29,5 +37,7 @@ type IReviewService interface {
 	Get(context.Context, uuid.UUID) (*Review, error)
 	GetAllForReviewer(context.Context, uuid.UUID, int) ([]*Review, int, error)
 	GetAllForTarget(context.Context, uuid.UUID, int) ([]*Review, int, error)
+	// GetAllForTargets возвращает страницу page отзывов о каждом из объектов.
+	GetAllForTargets(context.Context, []uuid.UUID, int) (map[uuid.UUID]*ReviewPage, error)
 	Delete(context.Context, uuid.UUID) error
 }
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type Permission string
+
+const (
+	PermUsersManage     Permission = "users:manage"
+	PermRolesManage     Permission = "roles:manage"
+	PermCatalogEdit     Permission = "catalog:edit"
+	PermReviewsModerate Permission = "reviews:moderate"
+	PermReviewsWrite    Permission = "reviews:write"
+	PermFinanceRead     Permission = "finance:read"
+	PermFinanceWrite    Permission = "finance:write"
+	PermFinanceVerify   Permission = "finance:verify"
+	PermCompaniesWrite  Permission = "companies:write"
+	PermContactsRead    Permission = "contacts:read"
+	PermContactsWrite   Permission = "contacts:write"
+	PermProfileEdit     Permission = "profile:edit"
+	PermWebhooksManage  Permission = "webhooks:manage"
+	PermJobsManage      Permission = "jobs:manage"
+	PermAuditRead       Permission = "audit:read"
+)
+
+var Permissions = []Permission{
+	PermUsersManage,
+	PermRolesManage,
+	PermCatalogEdit,
+	PermReviewsModerate,
+	PermReviewsWrite,
+	PermFinanceRead,
+	PermFinanceWrite,
+	PermFinanceVerify,
+	PermCompaniesWrite,
+	PermContactsRead,
+	PermContactsWrite,
+	PermProfileEdit,
+	PermWebhooksManage,
+	PermJobsManage,
+	PermAuditRead,
+}
+
+func IsKnownPermission(perm Permission) bool {
+	for _, p := range Permissions {
+		if p == perm {
+			return true
+		}
+	}
+
+	return false
+}
+
+type Role struct {
+	Name        string
+	Description string
+	Permissions []Permission
+}
+
+func (r *Role) HasPermission(perm Permission) bool {
+	for _, p := range r.Permissions {
+		if p == perm {
+			return true
+		}
+	}
+
+	return false
+}
+
+type IRoleRepository interface {
+	Create(context.Context, *Role) error
+	GetByName(context.Context, string) (*Role, error)
+	GetAll(context.Context) ([]*Role, error)
+	Update(context.Context, *Role) error
+	DeleteByName(context.Context, string) error
+	SetUserRole(context.Context, uuid.UUID, string) error
+}
+
+type IRoleService interface {
+	Create(context.Context, *Role) error
+	GetByName(context.Context, string) (*Role, error)
+	GetAll(context.Context) ([]*Role, error)
+	Update(context.Context, *Role) error
+	DeleteByName(context.Context, string) error
+	AssignToUser(context.Context, uuid.UUID, string) error
+	HasPermission(context.Context, string, Permission) (bool, error)
+}
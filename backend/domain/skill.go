@@ -15,6 +15,7 @@ type Skill struct {
 type ISkillRepository interface {
 	Create(context.Context, *Skill) error
 	GetById(context.Context, uuid.UUID) (*Skill, error)
+	GetByIds(context.Context, []uuid.UUID) ([]*Skill, error)
 	GetAll(context.Context, int) ([]*Skill, int, error)
 	Update(context.Context, *Skill) error
 	DeleteById(context.Context, uuid.UUID) error
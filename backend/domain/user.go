@@ -21,6 +21,7 @@ type IUserRepository interface {
 	Create(context.Context, *User) error
 	GetByUsername(context.Context, string) (*User, error)
 	GetById(context.Context, uuid.UUID) (*User, error)
+	GetByIds(context.Context, []uuid.UUID) ([]*User, error)
 	GetAll(context.Context, int) ([]*User, int, error)
 	Update(context.Context, *User) error
 	DeleteById(context.Context, uuid.UUID) error
@@ -30,6 +31,7 @@ type IUserService interface {
 	Create(context.Context, *User) error
 	GetByUsername(context.Context, string) (*User, error)
 	GetById(context.Context, uuid.UUID) (*User, error)
+	GetByIds(context.Context, []uuid.UUID) ([]*User, error)
 	GetAll(context.Context, int) ([]*User, int, error)
 	Update(context.Context, *User) error
 	DeleteById(context.Context, uuid.UUID) error
@@ -2,11 +2,18 @@ package domain
 
 import (
 	"context"
+	"time"
+
 	"github.com/google/uuid"
 )
 
 type IInteractor interface {
 	GetMostProfitableCompany(context.Context, *Period, []*Company) (*Company, error)
 	CalculateUserRating(context.Context, uuid.UUID) (float32, error)
+	// CalculateUserRatings рассчитывает рейтинги нескольких пользователей
+	// за фиксированное число запросов, независимо от числа пользователей.
+	CalculateUserRatings(context.Context, []uuid.UUID) (map[uuid.UUID]float32, error)
+	CalculateRatingSnapshot(context.Context, uuid.UUID, int, int, time.Time) (*RatingSnapshot, error)
 	GetUserFinancialReport(context.Context, uuid.UUID, *Period) (*FinancialReportByPeriod, error)
+	GetUserFinancialReports(context.Context, []uuid.UUID, *Period) (map[uuid.UUID]*FinancialReportByPeriod, error)
 }
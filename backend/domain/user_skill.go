@@ -14,6 +14,7 @@ type IUserSkillRepository interface {
 	Create(context.Context, *UserSkill) error
 	Delete(context.Context, *UserSkill) error
 	GetUserSkillsByUserId(context.Context, uuid.UUID, int, bool) ([]*UserSkill, int, error)
+	GetUserSkillsByUserIds(context.Context, []uuid.UUID) ([]*UserSkill, error)
 	GetUserSkillsBySkillId(context.Context, uuid.UUID, int) ([]*UserSkill, error)
 }
 
@@ -21,6 +22,8 @@ type IUserSkillService interface {
 	Create(context.Context, *UserSkill) error
 	Delete(context.Context, *UserSkill) error
 	GetSkillsForUser(context.Context, uuid.UUID, int, bool) ([]*Skill, int, error)
+	// GetSkillsForUsers возвращает все навыки каждого из пользователей.
+	GetSkillsForUsers(context.Context, []uuid.UUID) (map[uuid.UUID][]*Skill, error)
 	GetUsersForSkill(context.Context, uuid.UUID, int) ([]*User, error)
 	DeleteSkillsForUser(context.Context, uuid.UUID) error
 }
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WebhookSubscription struct {
+	ID         uuid.UUID
+	URL        string
+	Secret     string
+	EventTypes []EventType
+	CreatedAt  time.Time
+}
+
+func (s *WebhookSubscription) Accepts(eventType EventType) bool {
+	for _, t := range s.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	DeliveryFailed    DeliveryStatus = "failed"
+)
+
+// WebhookDelivery — доставка одного события одному подписчику.
+// URL, Secret и данные события заполняются только при выборке доставок для отправки.
+type WebhookDelivery struct {
+	ID             uuid.UUID
+	SubscriptionID uuid.UUID
+	EventID        uuid.UUID
+	EventType      EventType
+	Payload        []byte
+	EventCreatedAt time.Time
+	URL            string
+	Secret         string
+	Status         DeliveryStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastStatusCode int
+	LastError      string
+	CreatedAt      time.Time
+}
+
+// WebhookDeliveryAttempt — запись журнала об одной попытке доставки.
+type WebhookDeliveryAttempt struct {
+	DeliveryID uuid.UUID
+	Attempt    int
+	StatusCode int
+	Error      string
+	Duration   time.Duration
+	CreatedAt  time.Time
+}
+
+type IWebhookRepository interface {
+	CreateSubscription(context.Context, *WebhookSubscription) error
+	GetSubscriptionById(context.Context, uuid.UUID) (*WebhookSubscription, error)
+	GetSubscriptions(context.Context) ([]*WebhookSubscription, error)
+	DeleteSubscription(context.Context, uuid.UUID) error
+	GetDeliveries(context.Context, uuid.UUID, int) ([]*WebhookDelivery, int, error)
+	EnqueueDeliveries(context.Context, *OutboxEvent) (int, error)
+	ClaimDueDeliveries(context.Context, time.Time, time.Duration, int) ([]*WebhookDelivery, error)
+	SaveAttempt(context.Context, *WebhookDelivery, *WebhookDeliveryAttempt) error
+	DeleteFinishedDeliveriesBefore(context.Context, time.Time) (int, error)
+}
+
+type IWebhookService interface {
+	Subscribe(context.Context, *WebhookSubscription) error
+	GetSubscription(context.Context, uuid.UUID) (*WebhookSubscription, error)
+	GetSubscriptions(context.Context) ([]*WebhookSubscription, error)
+	Unsubscribe(context.Context, uuid.UUID) error
+	GetDeliveries(context.Context, uuid.UUID, int) ([]*WebhookDelivery, int, error)
+}
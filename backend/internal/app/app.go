@@ -3,18 +3,27 @@ package app
 import (
 	"ppo/domain"
 	"ppo/internal/config"
+	"ppo/internal/events"
 	"ppo/internal/interactors/user_activity_field"
+	"ppo/internal/scheduler"
 	"ppo/internal/services/activity_field"
+	"ppo/internal/services/audit"
 	"ppo/internal/services/auth"
 	"ppo/internal/services/company"
 	"ppo/internal/services/contact"
 	"ppo/internal/services/fin_report"
+	"ppo/internal/services/policy"
+	"ppo/internal/services/rating"
 	"ppo/internal/services/review"
+	"ppo/internal/services/role"
 	"ppo/internal/services/skill"
 	"ppo/internal/services/user"
 	"ppo/internal/services/user_skill"
+	"ppo/internal/services/webhook"
+	"ppo/internal/storage/blob"
 	"ppo/internal/storage/postgres"
 	"ppo/pkg/base"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -29,45 +38,115 @@ type App struct {
 	ActFieldSvc  domain.IActivityFieldService
 	CompSvc      domain.ICompanyService
 	RevSvc       domain.IReviewService
+	RoleSvc      domain.IRoleService
+	PolicySvc    domain.IPolicyService
+	WebhookSvc   domain.IWebhookService
+	RatingSvc    domain.IRatingService
+	JobSvc       domain.IJobService
+	AuditSvc     domain.IAuditService
 	Interactor   domain.IInteractor
-	Config       config.Config
+	// EventBus раздаёт доменные события подписчикам; обработка outbox запускается из main.go.
+	EventBus *events.Bus
+	// WebhookDispatcher доставляет события подписчикам; запускается из main.go.
+	WebhookDispatcher *webhook.Dispatcher
+	// Scheduler запускает фоновые задачи по расписанию; запускается из main.go.
+	Scheduler *scheduler.Scheduler
+	Config    config.Config
 }
 
 func NewApp(db *pgxpool.Pool, cfg *config.Config) *App {
 	authRepo := postgres.NewAuthRepository(db)
-	userRepo := postgres.NewUserRepository(db)
-	finRepo := postgres.NewFinReportRepository(db)
+	userRepo := postgres.NewUserRepository(db, cfg.Limits.PageSize)
+	finRepo := postgres.NewFinReportRepository(db, cfg.Limits.PageSize)
 	conRepo := postgres.NewContactRepository(db)
-	skillRepo := postgres.NewSkillRepository(db)
-	userSkillRepo := postgres.NewUserSkillRepository(db)
-	actFieldRepo := postgres.NewActivityFieldRepository(db)
-	compRepo := postgres.NewCompanyRepository(db)
-	revRepo := postgres.NewReviewRepository(db)
+	skillRepo := postgres.NewSkillRepository(db, cfg.Limits.PageSize)
+	userSkillRepo := postgres.NewUserSkillRepository(db, cfg.Limits.PageSize)
+	actFieldRepo := postgres.NewActivityFieldRepository(db, cfg.Limits.PageSize)
+	compRepo := postgres.NewCompanyRepository(db, cfg.Limits.PageSize)
+	revRepo := postgres.NewReviewRepository(db, cfg.Limits.PageSize)
+	roleRepo := postgres.NewRoleRepository(db)
+	webhookRepo := postgres.NewWebhookRepository(db, cfg.Limits.PageSize)
+	outboxRepo := postgres.NewOutboxRepository(db)
+	ratingRepo := postgres.NewRatingRepository(db)
+	jobRepo := postgres.NewJobRepository(db, cfg.Limits.PageSize)
+	auditRepo := postgres.NewAuditRepository(db, cfg.Limits.PageSize)
+
+	transactor := postgres.NewTransactor(db)
+	blobs := blob.NewLocalStorage(cfg.Storage.Dir)
+	bus := events.NewBus(outboxRepo, transactor)
 
 	crypto := base.NewHashCrypto()
 
-	authSvc := auth.NewService(authRepo, crypto, cfg.JwtKey)
-	userSvc := user.NewService(userRepo, compRepo, actFieldRepo)
-	finSvc := fin_report.NewService(finRepo)
-	conSvc := contact.NewService(conRepo)
-	skillSvc := skill.NewService(skillRepo)
-	userSkillSvc := user_skill.NewService(userSkillRepo, userRepo, skillRepo)
-	actFieldSvc := activity_field.NewService(actFieldRepo, compRepo)
-	compSvc := company.NewService(compRepo, actFieldRepo)
-	revSvc := review.NewService(revRepo)
-	interactor := user_activity_field.NewInteractor(userSvc, actFieldSvc, compSvc, finSvc)
+	// изменяющие вызовы сервисов записываются в журнал аудита; кэши стоят снаружи,
+	// чтобы снимки до и после изменения читались из хранилища
+	rec := audit.NewRecorder(auditRepo, transactor)
+	authSvc := audit.NewAuthService(auth.NewService(authRepo, crypto, cfg.JwtKey, cfg.Auth.TokenTTL), rec)
+	userSvc := user.NewCachedService(
+		audit.NewUserService(user.NewService(userRepo, compRepo, actFieldRepo, roleRepo), rec),
+		cfg.Cache.Size,
+		cfg.Cache.UserTTL,
+	)
+	finSvc := audit.NewFinReportService(fin_report.NewService(finRepo, blobs, transactor, bus), rec)
+	conSvc := audit.NewContactsService(contact.NewService(conRepo, cfg.Limits.MaxContacts), rec)
+	skillSvc := skill.NewCachedService(
+		audit.NewSkillService(skill.NewService(skillRepo), rec),
+		cfg.Cache.Size,
+		cfg.Cache.CatalogTTL,
+	)
+	userSkillSvc := audit.NewUserSkillService(user_skill.NewService(userSkillRepo, userRepo, skillRepo), rec)
+	actFieldSvc := activity_field.NewCachedService(
+		audit.NewActivityFieldService(activity_field.NewService(actFieldRepo, compRepo), rec),
+		cfg.Cache.Size,
+		cfg.Cache.CatalogTTL,
+	)
+	compSvc := audit.NewCompanyService(company.NewService(compRepo, actFieldRepo, transactor, bus), rec)
+	revSvc := audit.NewReviewService(review.NewService(revRepo, transactor, bus), rec)
+	roleSvc := audit.NewRoleService(role.NewService(roleRepo, userRepo, transactor, bus), rec, userRepo)
+	policySvc := policy.NewService(compRepo, finRepo, conRepo, revRepo, roleRepo)
+	webhookSvc := audit.NewWebhookService(webhook.NewService(webhookRepo), rec)
+	auditSvc := audit.NewService(auditRepo)
+	interactor := user_activity_field.NewInteractor(userSvc, actFieldSvc, compSvc, finSvc, cfg.Tax, cfg.Rating)
+	ratingSvc := rating.NewService(ratingRepo, interactor)
+	dispatcher := webhook.NewDispatcher(webhookRepo, nil)
+
+	subscribe(bus, compSvc, ratingSvc, userSvc, dispatcher)
+
+	sched := scheduler.NewScheduler(jobRepo)
+	err := registerJobs(sched, &jobs{
+		bus:         bus,
+		compSvc:     compSvc,
+		finSvc:      finSvc,
+		ratingSvc:   ratingSvc,
+		jobRepo:     jobRepo,
+		outboxRepo:  outboxRepo,
+		webhookRepo: webhookRepo,
+		now:         time.Now,
+	})
+	if err != nil {
+		// расписания задаются в коде, ошибка в них — ошибка программиста
+		panic(err)
+	}
 
 	return &App{
-		AuthSvc:      authSvc,
-		UserSvc:      userSvc,
-		FinSvc:       finSvc,
-		ConSvc:       conSvc,
-		SkillSvc:     skillSvc,
-		UserSkillSvc: userSkillSvc,
-		ActFieldSvc:  actFieldSvc,
-		CompSvc:      compSvc,
-		RevSvc:       revSvc,
-		Interactor:   interactor,
-		Config:       *cfg,
+		AuthSvc:           authSvc,
+		UserSvc:           userSvc,
+		FinSvc:            finSvc,
+		ConSvc:            conSvc,
+		SkillSvc:          skillSvc,
+		UserSkillSvc:      userSkillSvc,
+		ActFieldSvc:       actFieldSvc,
+		CompSvc:           compSvc,
+		RevSvc:            revSvc,
+		RoleSvc:           roleSvc,
+		PolicySvc:         policySvc,
+		WebhookSvc:        webhookSvc,
+		RatingSvc:         ratingSvc,
+		JobSvc:            sched,
+		AuditSvc:          auditSvc,
+		Interactor:        interactor,
+		EventBus:          bus,
+		WebhookDispatcher: dispatcher,
+		Scheduler:         sched,
+		Config:            *cfg,
 	}
 }
@@ -0,0 +1,151 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"ppo/domain"
+	"ppo/internal/scheduler"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// retention — срок хранения журналов: доставок вебхуков, обработанных событий outbox и запусков задач.
+const retention = 30 * 24 * time.Hour
+
+// registerJobs регистрирует фоновые задачи. Время в расписаниях — локальное время сервера.
+func registerJobs(s *scheduler.Scheduler, j *jobs) error {
+	for _, job := range []struct {
+		name string
+		spec string
+		fn   scheduler.JobFunc
+	}{
+		{"rating-recompute", "0 3 * * *", j.recomputeRatings},
+		{"missing-report-reminders", "0 9 * * 1", j.remindMissingReports},
+		{"cleanup", "30 4 * * *", j.cleanup},
+	} {
+		err := s.Register(job.name, job.spec, job.fn)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type jobs struct {
+	bus         domain.IEventBus
+	compSvc     domain.ICompanyService
+	finSvc      domain.IFinancialReportService
+	ratingSvc   domain.IRatingService
+	jobRepo     domain.IJobRepository
+	outboxRepo  domain.IOutboxRepository
+	webhookRepo domain.IWebhookRepository
+	now         func() time.Time
+}
+
+// forEachCompany обходит все компании постранично.
+func (j *jobs) forEachCompany(ctx context.Context, fn func(*domain.Company) error) error {
+	for page := 1; ; page++ {
+		companies, err := j.compSvc.GetAll(ctx, page)
+		if err != nil {
+			return err
+		}
+		if len(companies) == 0 {
+			return nil
+		}
+
+		for _, company := range companies {
+			err = fn(company)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// recomputeRatings пересчитывает снимки рейтинга всех владельцев компаний: сохранённую
+// историю и кварталы с начала прошлого года, чтобы в ней не оставалось пропусков.
+func (j *jobs) recomputeRatings(ctx context.Context) error {
+	owners := make(map[uuid.UUID]struct{})
+	err := j.forEachCompany(ctx, func(company *domain.Company) error {
+		owners[company.OwnerID] = struct{}{}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("пересчёт рейтингов: %w", err)
+	}
+
+	year := j.now().Year()
+	period := &domain.Period{StartYear: year - 1, StartQuarter: 1, EndYear: year, EndQuarter: 4}
+	for ownerId := range owners {
+		err = j.ratingSvc.RecomputeHistory(ctx, ownerId)
+		if err != nil {
+			return fmt.Errorf("пересчёт рейтингов: %w", err)
+		}
+
+		_, err = j.ratingSvc.Recompute(ctx, ownerId, period)
+		if err != nil {
+			return fmt.Errorf("пересчёт рейтингов: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// remindMissingReports публикует FinancialReportMissing для каждой компании без отчёта
+// за последний завершившийся квартал. Напоминание повторяется при каждом запуске, пока отчёт не подан.
+func (j *jobs) remindMissingReports(ctx context.Context) error {
+	year, quarter := domain.LastClosedQuarter(j.now())
+	period := &domain.Period{StartYear: year, StartQuarter: quarter, EndYear: year, EndQuarter: quarter}
+
+	err := j.forEachCompany(ctx, func(company *domain.Company) error {
+		report, err := j.finSvc.GetByCompany(ctx, company.ID, period)
+		if err != nil {
+			return err
+		}
+		if len(report.Reports) != 0 {
+			return nil
+		}
+
+		return j.bus.Publish(ctx, domain.FinancialReportMissing{
+			CompanyID: company.ID,
+			OwnerID:   company.OwnerID,
+			Year:      year,
+			Quarter:   quarter,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("напоминания о неподанных отчётах: %w", err)
+	}
+
+	return nil
+}
+
+// cleanup удаляет журналы старше retention. Токены доступа — подписанные JWT с ограниченным
+// сроком действия и в БД не хранятся, поэтому истекшие токены чистить не нужно.
+func (j *jobs) cleanup(ctx context.Context) error {
+	before := j.now().Add(-retention)
+
+	numDeliveries, err := j.webhookRepo.DeleteFinishedDeliveriesBefore(ctx, before)
+	if err != nil {
+		return fmt.Errorf("очистка журналов: %w", err)
+	}
+
+	// события удаляются после доставок, которые на них ссылаются
+	numEvents, err := j.outboxRepo.DeleteProcessedBefore(ctx, before)
+	if err != nil {
+		return fmt.Errorf("очистка журналов: %w", err)
+	}
+
+	numRuns, err := j.jobRepo.DeleteRunsBefore(ctx, before)
+	if err != nil {
+		return fmt.Errorf("очистка журналов: %w", err)
+	}
+
+	log.Printf("очистка журналов: удалено доставок %d, событий %d, запусков задач %d\n",
+		numDeliveries, numEvents, numRuns)
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"ppo/domain"
+	"ppo/internal/scheduler"
+	"ppo/mocks"
+	"testing"
+	"time"
+)
+
+func TestRegisterJobs(t *testing.T) {
+	require.Nil(t, registerJobs(scheduler.NewScheduler(nil), &jobs{}))
+}
+
+func TestJobs_RemindMissingReports(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	bus := mocks.NewMockIEventBus(ctrl)
+	compSvc := mocks.NewMockICompanyService(ctrl)
+	finSvc := mocks.NewMockIFinancialReportService(ctrl)
+	j := &jobs{
+		bus:     bus,
+		compSvc: compSvc,
+		finSvc:  finSvc,
+		now:     func() time.Time { return time.Date(2024, 2, 5, 9, 0, 0, 0, time.UTC) },
+	}
+
+	ownerId := uuid.UUID{1}
+	filed := &domain.Company{ID: uuid.UUID{2}, OwnerID: ownerId}
+	missing := &domain.Company{ID: uuid.UUID{3}, OwnerID: ownerId}
+	period := &domain.Period{StartYear: 2023, StartQuarter: 4, EndYear: 2023, EndQuarter: 4}
+
+	testCases := []struct {
+		name       string
+		beforeTest func()
+		wantErr    bool
+	}{
+		{
+			name: "напоминание только компаниям без отчёта",
+			beforeTest: func() {
+				compSvc.EXPECT().GetAll(gomock.Any(), 1).Return([]*domain.Company{filed, missing}, nil)
+				compSvc.EXPECT().GetAll(gomock.Any(), 2).Return([]*domain.Company{}, nil)
+				finSvc.EXPECT().
+					GetByCompany(gomock.Any(), filed.ID, period).
+					Return(&domain.FinancialReportByPeriod{Reports: []domain.FinancialReport{{Year: 2023, Quarter: 4}}}, nil)
+				finSvc.EXPECT().
+					GetByCompany(gomock.Any(), missing.ID, period).
+					Return(&domain.FinancialReportByPeriod{}, nil)
+				bus.EXPECT().
+					Publish(gomock.Any(), domain.FinancialReportMissing{
+						CompanyID: missing.ID,
+						OwnerID:   ownerId,
+						Year:      2023,
+						Quarter:   4,
+					}).
+					Return(nil)
+			},
+		},
+		{
+			name: "ошибка получения списка компаний",
+			beforeTest: func() {
+				compSvc.EXPECT().GetAll(gomock.Any(), 1).Return(nil, fmt.Errorf("sql error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.beforeTest()
+
+			err := j.remindMissingReports(context.Background())
+
+			if tc.wantErr {
+				require.NotNil(t, err)
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestJobs_Cleanup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2024, 2, 5, 4, 30, 0, 0, time.UTC)
+	before := now.Add(-retention)
+
+	jobRepo := mocks.NewMockIJobRepository(ctrl)
+	outboxRepo := mocks.NewMockIOutboxRepository(ctrl)
+	webhookRepo := mocks.NewMockIWebhookRepository(ctrl)
+	j := &jobs{
+		jobRepo:     jobRepo,
+		outboxRepo:  outboxRepo,
+		webhookRepo: webhookRepo,
+		now:         func() time.Time { return now },
+	}
+
+	gomock.InOrder(
+		webhookRepo.EXPECT().DeleteFinishedDeliveriesBefore(gomock.Any(), before).Return(3, nil),
+		outboxRepo.EXPECT().DeleteProcessedBefore(gomock.Any(), before).Return(2, nil),
+		jobRepo.EXPECT().DeleteRunsBefore(gomock.Any(), before).Return(1, nil),
+	)
+
+	require.Nil(t, j.cleanup(context.Background()))
+}
@@ -0,0 +1,111 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"ppo/domain"
+	"ppo/internal/services/user"
+	"ppo/internal/services/webhook"
+
+	"github.com/google/uuid"
+)
+
+// subscribe регистрирует внутренних подписчиков доменных событий.
+// Новые реакции на события (кэши, уведомления) добавляются здесь, без изменения сервисов.
+func subscribe(
+	bus domain.IEventBus,
+	compSvc domain.ICompanyService,
+	ratingSvc domain.IRatingService,
+	users *user.CachedService,
+	dispatcher *webhook.Dispatcher,
+) {
+	for _, eventType := range domain.EventTypes {
+		bus.Subscribe(eventType, dispatcher.Enqueue)
+	}
+
+	ratings := &ratingSubscriber{bus: bus, compSvc: compSvc, ratingSvc: ratingSvc}
+	bus.Subscribe(domain.EventCompanyUpdated, ratings.handle)
+	bus.Subscribe(domain.EventCompanyDeleted, ratings.handle)
+	bus.Subscribe(domain.EventFinReportFiled, ratings.handle)
+	bus.Subscribe(domain.EventFinReportDeleted, ratings.handle)
+	// при расчёте только по подтверждённым отчётам решение проверки меняет рейтинг
+	bus.Subscribe(domain.EventFinReportReviewed, ratings.handle)
+
+	// роль назначается в обход сервиса пользователей, поэтому его кэш сбрасывается по событию
+	bus.Subscribe(domain.EventUserRoleChanged, func(_ context.Context, event *domain.OutboxEvent) error {
+		if e, ok := event.Event.(domain.UserRoleChanged); ok {
+			users.Invalidate(e.UserID)
+		}
+		return nil
+	})
+}
+
+// ratingSubscriber пересчитывает снимки рейтинга и публикует RatingChanged,
+// когда меняются компании или отчёты, от которых зависит рейтинг предпринимателя.
+type ratingSubscriber struct {
+	bus       domain.IEventBus
+	compSvc   domain.ICompanyService
+	ratingSvc domain.IRatingService
+}
+
+func (s *ratingSubscriber) handle(ctx context.Context, event *domain.OutboxEvent) (err error) {
+	var ownerId uuid.UUID
+	switch e := event.Event.(type) {
+	case domain.CompanyUpdated:
+		ownerId = e.OwnerID
+		err = s.ratingSvc.RecomputeHistory(ctx, ownerId)
+	case domain.CompanyDeleted:
+		ownerId = e.OwnerID
+		err = s.ratingSvc.RecomputeHistory(ctx, ownerId)
+	case domain.FinancialReportFiled:
+		ownerId, err = s.recomputeAfterReport(ctx, e.CompanyID, e.Year, e.Quarter)
+	case domain.FinancialReportDeleted:
+		ownerId, err = s.recomputeAfterReport(ctx, e.CompanyID, e.Year, e.Quarter)
+	case domain.FinancialReportReviewed:
+		ownerId, err = s.recomputeAfterReport(ctx, e.CompanyID, e.Year, e.Quarter)
+	default:
+		return nil
+	}
+	// отчёт может относиться к несуществующей компании: рейтинг тогда не меняется
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("пересчёт рейтинга: %w", err)
+	}
+
+	err = s.bus.Publish(ctx, domain.RatingChanged{UserID: ownerId})
+	if err != nil {
+		return fmt.Errorf("пересчёт рейтинга: %w", err)
+	}
+
+	return nil
+}
+
+// recomputeAfterReport пересчитывает снимки за квартал отчёта и три следующих:
+// отчёт входит в окно рейтинга каждого из них.
+func (s *ratingSubscriber) recomputeAfterReport(ctx context.Context, companyId uuid.UUID, year, quarter int) (ownerId uuid.UUID, err error) {
+	company, err := s.compSvc.GetById(ctx, companyId)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	endYear, endQuarter := year, quarter+3
+	if endQuarter > 4 {
+		endYear++
+		endQuarter -= 4
+	}
+
+	_, err = s.ratingSvc.Recompute(ctx, company.OwnerID, &domain.Period{
+		StartYear:    year,
+		StartQuarter: quarter,
+		EndYear:      endYear,
+		EndQuarter:   endQuarter,
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return company.OwnerID, nil
+}
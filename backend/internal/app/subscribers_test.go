@@ -0,0 +1,91 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"ppo/domain"
+	"ppo/mocks"
+	"testing"
+)
+
+func TestRatingSubscriber_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	bus := mocks.NewMockIEventBus(ctrl)
+	compSvc := mocks.NewMockICompanyService(ctrl)
+	ratingSvc := mocks.NewMockIRatingService(ctrl)
+	s := &ratingSubscriber{bus: bus, compSvc: compSvc, ratingSvc: ratingSvc}
+
+	ownerId := uuid.UUID{1}
+	companyId := uuid.UUID{2}
+
+	testCases := []struct {
+		name       string
+		event      domain.Event
+		beforeTest func()
+		wantErr    bool
+	}{
+		{
+			name:  "изменение компании",
+			event: domain.CompanyUpdated{ID: companyId, OwnerID: ownerId},
+			beforeTest: func() {
+				ratingSvc.EXPECT().RecomputeHistory(gomock.Any(), ownerId).Return(nil)
+				bus.EXPECT().Publish(gomock.Any(), domain.RatingChanged{UserID: ownerId}).Return(nil)
+			},
+		},
+		{
+			name:  "новый отчет",
+			event: domain.FinancialReportFiled{CompanyID: companyId, Year: 2023, Quarter: 3},
+			beforeTest: func() {
+				compSvc.EXPECT().GetById(gomock.Any(), companyId).Return(&domain.Company{ID: companyId, OwnerID: ownerId}, nil)
+				ratingSvc.EXPECT().
+					Recompute(gomock.Any(), ownerId, &domain.Period{StartYear: 2023, StartQuarter: 3, EndYear: 2024, EndQuarter: 2}).
+					Return(nil, nil)
+				bus.EXPECT().Publish(gomock.Any(), domain.RatingChanged{UserID: ownerId}).Return(nil)
+			},
+		},
+		{
+			name:  "проверка отчета",
+			event: domain.FinancialReportReviewed{CompanyID: companyId, Year: 2024, Quarter: 1, Status: domain.VerificationVerified},
+			beforeTest: func() {
+				compSvc.EXPECT().GetById(gomock.Any(), companyId).Return(&domain.Company{ID: companyId, OwnerID: ownerId}, nil)
+				ratingSvc.EXPECT().
+					Recompute(gomock.Any(), ownerId, &domain.Period{StartYear: 2024, StartQuarter: 1, EndYear: 2024, EndQuarter: 4}).
+					Return(nil, nil)
+				bus.EXPECT().Publish(gomock.Any(), domain.RatingChanged{UserID: ownerId}).Return(nil)
+			},
+		},
+		{
+			name:  "отчет несуществующей компании",
+			event: domain.FinancialReportDeleted{CompanyID: companyId},
+			beforeTest: func() {
+				compSvc.EXPECT().GetById(gomock.Any(), companyId).Return(nil, fmt.Errorf("поиск: %w", domain.ErrNotFound))
+			},
+		},
+		{
+			name:  "ошибка получения компании",
+			event: domain.FinancialReportFiled{CompanyID: companyId},
+			beforeTest: func() {
+				compSvc.EXPECT().GetById(gomock.Any(), companyId).Return(nil, fmt.Errorf("sql error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.beforeTest()
+
+			err := s.handle(context.Background(), &domain.OutboxEvent{Type: tc.event.EventType(), Event: tc.event})
+
+			if tc.wantErr {
+				require.NotNil(t, err)
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
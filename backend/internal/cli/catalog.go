@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"ppo/domain"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+type skillOutput struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+func (c *CLI) renderSkill(skill *domain.Skill) error {
+	out := skillOutput{ID: skill.ID.String(), Name: skill.Name, Description: skill.Description}
+
+	return c.render([]skillOutput{out}, []string{"id", "name", "description"},
+		[][]string{{out.ID, out.Name, out.Description}})
+}
+
+type activityFieldOutput struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Cost        float32 `json:"cost"`
+}
+
+func (c *CLI) renderActivityField(field *domain.ActivityField) error {
+	out := activityFieldOutput{
+		ID:          field.ID.String(),
+		Name:        field.Name,
+		Description: field.Description,
+		Cost:        field.Cost,
+	}
+
+	return c.render([]activityFieldOutput{out}, []string{"id", "name", "description", "cost"},
+		[][]string{{out.ID, out.Name, out.Description, formatFloat(out.Cost)}})
+}
+
+func (c *CLI) skillsAdd(ctx context.Context, args []string) (err error) {
+	fs := flag.NewFlagSet("skills add", flag.ContinueOnError)
+	name := fs.String("name", "", "название")
+	description := fs.String("description", "", "описание")
+	_, err = c.parseFlags(fs, args, 0)
+	if err != nil {
+		return err
+	}
+
+	skill := &domain.Skill{Name: *name, Description: *description}
+	err = c.app.SkillSvc.Create(ctx, skill)
+	if err != nil {
+		return err
+	}
+
+	return c.renderSkill(skill)
+}
+
+// skillsRm удаляет навык и печатает удалённую запись.
+func (c *CLI) skillsRm(ctx context.Context, args []string) (err error) {
+	fs := flag.NewFlagSet("skills rm", flag.ContinueOnError)
+	positional, err := c.parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	id, err := parseID("skills rm", positional[0])
+	if err != nil {
+		return err
+	}
+
+	skill, err := c.app.SkillSvc.GetById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = c.app.SkillSvc.DeleteById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return c.renderSkill(skill)
+}
+
+func (c *CLI) activityFieldsAdd(ctx context.Context, args []string) (err error) {
+	fs := flag.NewFlagSet("activity-fields add", flag.ContinueOnError)
+	name := fs.String("name", "", "название")
+	description := fs.String("description", "", "описание")
+	cost := fs.Float64("cost", 0, "вес сферы деятельности в рейтинге")
+	_, err = c.parseFlags(fs, args, 0)
+	if err != nil {
+		return err
+	}
+
+	field := &domain.ActivityField{Name: *name, Description: *description, Cost: float32(*cost)}
+	err = c.app.ActFieldSvc.Create(ctx, field)
+	if err != nil {
+		return err
+	}
+
+	return c.renderActivityField(field)
+}
+
+// activityFieldsUpdate меняет поля сферы деятельности, заданные флагами; остальные сохраняются.
+func (c *CLI) activityFieldsUpdate(ctx context.Context, args []string) (err error) {
+	fs := flag.NewFlagSet("activity-fields update", flag.ContinueOnError)
+	name := fs.String("name", "", "название")
+	description := fs.String("description", "", "описание")
+	cost := fs.Float64("cost", 0, "вес сферы деятельности в рейтинге")
+	positional, err := c.parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	id, err := parseID("activity-fields update", positional[0])
+	if err != nil {
+		return err
+	}
+
+	field, err := c.app.ActFieldSvc.GetById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	updated := *field
+	set := setFlags(fs)
+	if set["name"] {
+		updated.Name = *name
+	}
+	if set["description"] {
+		updated.Description = *description
+	}
+	if set["cost"] {
+		updated.Cost = float32(*cost)
+	}
+
+	err = c.app.ActFieldSvc.Update(ctx, &updated)
+	if err != nil {
+		return err
+	}
+
+	return c.renderActivityField(&updated)
+}
+
+func parseID(cmd, s string) (uuid.UUID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return uuid.Nil, usagef("%s: %q не является UUID", cmd, s)
+	}
+
+	return id, nil
+}
+
+func formatFloat(f float32) string {
+	return strconv.FormatFloat(float64(f), 'f', -1, 32)
+}
@@ -0,0 +1,308 @@
+// Package cli реализует ppoctl — неинтерактивную консольную утилиту администратора.
+// Команды работают с app.App напрямую, минуя HTTP API, и пригодны для сценариев:
+// результат печатается таблицей, JSON или CSV (-o), а код возврата описывает исход.
+//
+// Коды возврата:
+//
+//	0 — успешно
+//	1 — прочая ошибка (БД, конфигурация)
+//	2 — неверный вызов: неизвестная команда, флаг или аргумент
+//	3 — ошибка валидации данных
+//	4 — объект не найден
+//	5 — конфликт данных, например дубликат
+package cli
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"ppo/domain"
+	"ppo/internal/app"
+	"ppo/internal/config"
+	"ppo/internal/storage/postgres"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	ExitOK         = 0
+	ExitError      = 1
+	ExitUsage      = 2
+	ExitValidation = 3
+	ExitNotFound   = 4
+	ExitConflict   = 5
+)
+
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+	FormatCSV   = "csv"
+)
+
+// usageError — ошибка в аргументах командной строки.
+type usageError struct {
+	msg string
+}
+
+func (e *usageError) Error() string {
+	return e.msg
+}
+
+func usagef(format string, args ...any) error {
+	return &usageError{msg: fmt.Sprintf(format, args...)}
+}
+
+// batchError сообщает о записях, которые не удалось обработать; сами ошибки
+// уже напечатаны по одной и доступны через errors.Is для выбора кода возврата.
+type batchError struct {
+	op     string
+	failed int
+	total  int
+	errs   []error
+}
+
+func (e *batchError) Error() string {
+	return fmt.Sprintf("%s: не обработано записей: %d из %d", e.op, e.failed, e.total)
+}
+
+func (e *batchError) Unwrap() []error {
+	return e.errs
+}
+
+// ExitCode возвращает код возврата для ошибки err.
+func ExitCode(err error) int {
+	var usageErr *usageError
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+		return ExitOK
+	case errors.As(err, &usageErr):
+		return ExitUsage
+	case errors.Is(err, domain.ErrValidation):
+		return ExitValidation
+	case errors.Is(err, domain.ErrNotFound):
+		return ExitNotFound
+	case errors.Is(err, domain.ErrConflict):
+		return ExitConflict
+	default:
+		return ExitError
+	}
+}
+
+type command struct {
+	usage string
+	run   func(c *CLI, ctx context.Context, args []string) error
+}
+
+// commands — группы команд и команды в них.
+var commands = map[string]map[string]command{
+	"users": {
+		"list":     {"[--page N]", (*CLI).usersList},
+		"update":   {"<username> [--full-name S] [--gender m|w] [--birthday YYYY-MM-DD] [--city S]", (*CLI).usersUpdate},
+		"set-role": {"<username> <role>", (*CLI).usersSetRole},
+	},
+	"skills": {
+		"add": {"--name S [--description S]", (*CLI).skillsAdd},
+		"rm":  {"<id>", (*CLI).skillsRm},
+	},
+	"activity-fields": {
+		"add":    {"--name S --cost N [--description S]", (*CLI).activityFieldsAdd},
+		"update": {"<id> [--name S] [--description S] [--cost N]", (*CLI).activityFieldsUpdate},
+	},
+	"reports": {
+		"import": {"[--file PATH]", (*CLI).reportsImport},
+		"export": {"--company ID [--from YYYYQN] [--to YYYYQN]", (*CLI).reportsExport},
+	},
+	"ratings": {
+		"recompute": {"[--user ID] [--from YYYYQN --to YYYYQN [--as-of RFC3339]]", (*CLI).ratingsRecompute},
+	},
+}
+
+type CLI struct {
+	app    *app.App
+	in     io.Reader
+	out    io.Writer
+	errOut io.Writer
+	format string
+}
+
+func NewCLI(a *app.App, in io.Reader, out, errOut io.Writer, format string) *CLI {
+	return &CLI{app: a, in: in, out: out, errOut: errOut, format: format}
+}
+
+// Main разбирает глобальные флаги (параметры конфигурации и -o), подключается к БД
+// и выполняет команду. Возвращает код возврата процесса.
+func Main(args []string, in io.Reader, out, errOut io.Writer) int {
+	fs := flag.NewFlagSet("ppoctl", flag.ContinueOnError)
+	fs.SetOutput(errOut)
+	format := fs.String("o", FormatTable, "формат вывода: table, json или csv")
+	// flag вызывает Usage при ошибке разбора; так она отличается от ошибки конфигурации
+	var badFlags bool
+	fs.Usage = func() {
+		badFlags = true
+		fmt.Fprintln(errOut, "использование: ppoctl [флаги] <группа> <команда> [аргументы]")
+		fmt.Fprintln(errOut)
+		fmt.Fprint(errOut, usage())
+		fmt.Fprintln(errOut)
+		fmt.Fprintln(errOut, "флаги:")
+		fs.PrintDefaults()
+	}
+
+	cfg, err := config.Load(fs, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitOK
+		}
+
+		if badFlags {
+			return ExitUsage
+		}
+
+		fmt.Fprintln(errOut, err)
+		return ExitError
+	}
+
+	// ошибки вызова выявляются до подключения к БД
+	cmd, err := lookup(fs.Args())
+	if err != nil {
+		fmt.Fprintln(errOut, err)
+		fs.Usage()
+		return ExitCode(err)
+	}
+
+	err = checkFormat(*format)
+	if err != nil {
+		fmt.Fprintln(errOut, err)
+		return ExitCode(err)
+	}
+
+	ctx := context.Background()
+	pool, err := postgres.Connect(ctx, &cfg.DBConfig)
+	if err != nil {
+		fmt.Fprintln(errOut, err)
+		return ExitError
+	}
+	defer pool.Close()
+
+	c := NewCLI(app.NewApp(pool, cfg), in, out, errOut, *format)
+	err = cmd.run(c, ctx, fs.Args()[2:])
+	if err != nil && !errors.Is(err, flag.ErrHelp) {
+		fmt.Fprintln(errOut, err)
+	}
+
+	return ExitCode(err)
+}
+
+// Run выполняет команду args: группу, команду и её аргументы.
+func (c *CLI) Run(ctx context.Context, args []string) error {
+	err := checkFormat(c.format)
+	if err != nil {
+		return err
+	}
+
+	cmd, err := lookup(args)
+	if err != nil {
+		return err
+	}
+
+	return cmd.run(c, ctx, args[2:])
+}
+
+func checkFormat(format string) error {
+	switch format {
+	case FormatTable, FormatJSON, FormatCSV:
+		return nil
+	default:
+		return usagef("неизвестный формат вывода %q, ожидается table, json или csv", format)
+	}
+}
+
+func lookup(args []string) (cmd command, err error) {
+	if len(args) == 0 {
+		return command{}, usagef("не указана группа команд")
+	}
+
+	group, ok := commands[args[0]]
+	if !ok {
+		return command{}, usagef("неизвестная группа команд %q", args[0])
+	}
+
+	if len(args) < 2 {
+		return command{}, usagef("не указана команда группы %s", args[0])
+	}
+
+	cmd, ok = group[args[1]]
+	if !ok {
+		return command{}, usagef("неизвестная команда %s %s", args[0], args[1])
+	}
+
+	return cmd, nil
+}
+
+func usage() string {
+	var lines []string
+	for groupName, group := range commands {
+		for name, cmd := range group {
+			lines = append(lines, fmt.Sprintf("  %s %s %s", groupName, name, cmd.usage))
+		}
+	}
+	sort.Strings(lines)
+
+	return "команды:\n" + strings.Join(lines, "\n") + "\n"
+}
+
+// parseFlags разбирает флаги команды вперемешку с позиционными аргументами
+// и проверяет число последних.
+func (c *CLI) parseFlags(fs *flag.FlagSet, args []string, numArgs int) (positional []string, err error) {
+	fs.SetOutput(c.errOut)
+	for {
+		err = fs.Parse(args)
+		if err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, usagef("%s: %v", fs.Name(), err)
+		}
+
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+
+	if len(positional) != numArgs {
+		return nil, usagef("%s: ожидается аргументов: %d, получено: %d", fs.Name(), numArgs, len(positional))
+	}
+
+	return positional, nil
+}
+
+// render печатает v в формате JSON либо строки rows с заголовком header
+// таблицей или CSV.
+func (c *CLI) render(v any, header []string, rows [][]string) error {
+	switch c.format {
+	case FormatJSON:
+		enc := json.NewEncoder(c.out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case FormatCSV:
+		w := csv.NewWriter(c.out)
+		err := w.Write(header)
+		if err != nil {
+			return err
+		}
+		return w.WriteAll(rows)
+	default:
+		w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, strings.ToUpper(strings.Join(header, "\t")))
+		for _, row := range rows {
+			fmt.Fprintln(w, strings.Join(row, "\t"))
+		}
+		return w.Flush()
+	}
+}
@@ -0,0 +1,379 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"ppo/domain"
+	"ppo/internal/app"
+	"ppo/mocks"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCLI_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userSvc := mocks.NewMockIUserService(ctrl)
+	roleSvc := mocks.NewMockIRoleService(ctrl)
+	skillSvc := mocks.NewMockISkillService(ctrl)
+	actFieldSvc := mocks.NewMockIActivityFieldService(ctrl)
+	finSvc := mocks.NewMockIFinancialReportService(ctrl)
+	compSvc := mocks.NewMockICompanyService(ctrl)
+	ratingSvc := mocks.NewMockIRatingService(ctrl)
+	a := &app.App{
+		UserSvc:     userSvc,
+		RoleSvc:     roleSvc,
+		SkillSvc:    skillSvc,
+		ActFieldSvc: actFieldSvc,
+		FinSvc:      finSvc,
+		CompSvc:     compSvc,
+		RatingSvc:   ratingSvc,
+	}
+
+	userId := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	ownerId := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	skillId := uuid.MustParse("00000000-0000-0000-0000-000000000003")
+	fieldId := uuid.MustParse("00000000-0000-0000-0000-000000000004")
+	companyId := uuid.MustParse("00000000-0000-0000-0000-000000000005")
+	reportId := uuid.MustParse("00000000-0000-0000-0000-000000000006")
+	birthday := time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC)
+	user := &domain.User{
+		ID:       userId,
+		Username: "ivan",
+		FullName: "Иванов Иван Иванович",
+		Gender:   "m",
+		Birthday: birthday,
+		City:     "Москва",
+		Role:     "user",
+	}
+
+	testCases := []struct {
+		name       string
+		args       []string
+		format     string
+		in         string
+		beforeTest func()
+		wantCode   int
+		wantOut    string
+		wantInOut  string
+		wantErrOut string
+	}{
+		{
+			name:   "список пользователей со всех страниц в JSON",
+			args:   []string{"users", "list"},
+			format: FormatJSON,
+			beforeTest: func() {
+				userSvc.EXPECT().GetAll(gomock.Any(), 1).Return([]*domain.User{user}, 2, nil)
+				userSvc.EXPECT().GetAll(gomock.Any(), 2).Return([]*domain.User{{ID: ownerId, Username: "petr"}}, 2, nil)
+			},
+			wantCode: ExitOK,
+			wantOut: `[
+  {
+    "id": "00000000-0000-0000-0000-000000000001",
+    "username": "ivan",
+    "full_name": "Иванов Иван Иванович",
+    "gender": "m",
+    "birthday": "1990-05-17",
+    "city": "Москва",
+    "role": "user"
+  },
+  {
+    "id": "00000000-0000-0000-0000-000000000002",
+    "username": "petr",
+    "full_name": "",
+    "gender": "",
+    "birthday": "",
+    "city": "",
+    "role": ""
+  }
+]
+`,
+		},
+		{
+			name: "одна страница пользователей таблицей",
+			args: []string{"users", "list", "--page", "2"},
+			beforeTest: func() {
+				userSvc.EXPECT().GetAll(gomock.Any(), 2).Return([]*domain.User{user}, 2, nil)
+			},
+			wantCode: ExitOK,
+			wantOut: "ID                                    USERNAME  FULL_NAME             GENDER  BIRTHDAY    CITY    ROLE\n" +
+				"00000000-0000-0000-0000-000000000001  ivan      Иванов Иван Иванович  m       1990-05-17  Москва  user\n",
+		},
+		{
+			name: "обновление карточки меняет только заданные поля",
+			args: []string{"users", "update", "ivan", "--city", "Казань"},
+			beforeTest: func() {
+				userSvc.EXPECT().GetByUsername(gomock.Any(), "ivan").Return(user, nil)
+				updated := *user
+				updated.City = "Казань"
+				userSvc.EXPECT().Update(gomock.Any(), &updated).Return(nil)
+			},
+			wantCode: ExitOK,
+		},
+		{
+			name: "обновление карточки с некорректной датой",
+			args: []string{"users", "update", "--birthday", "17.05.1990", "ivan"},
+			beforeTest: func() {
+				userSvc.EXPECT().GetByUsername(gomock.Any(), "ivan").Return(user, nil)
+			},
+			wantCode:   ExitUsage,
+			wantErrOut: "не в формате YYYY-MM-DD",
+		},
+		{
+			name: "отклонённое сервисом обновление карточки",
+			args: []string{"users", "update", "ivan", "--gender", "x"},
+			beforeTest: func() {
+				userSvc.EXPECT().GetByUsername(gomock.Any(), "ivan").Return(user, nil)
+				userSvc.EXPECT().Update(gomock.Any(), gomock.Any()).
+					Return(domain.NewValidationError("gender", "user.gender_unknown"))
+			},
+			wantCode: ExitValidation,
+		},
+		{
+			name: "назначение роли",
+			args: []string{"users", "set-role", "ivan", "admin"},
+			beforeTest: func() {
+				userSvc.EXPECT().GetByUsername(gomock.Any(), "ivan").Return(user, nil)
+				roleSvc.EXPECT().AssignToUser(gomock.Any(), userId, "admin").Return(nil)
+			},
+			wantCode:  ExitOK,
+			wantInOut: "admin",
+		},
+		{
+			name: "назначение роли несуществующему пользователю",
+			args: []string{"users", "set-role", "nobody", "admin"},
+			beforeTest: func() {
+				userSvc.EXPECT().GetByUsername(gomock.Any(), "nobody").
+					Return(nil, domain.NewNotFoundError(errors.New("пользователь не найден")))
+			},
+			wantCode: ExitNotFound,
+		},
+		{
+			name:     "назначение роли без аргумента",
+			args:     []string{"users", "set-role", "ivan"},
+			wantCode: ExitUsage,
+		},
+		{
+			name:   "добавление навыка в CSV",
+			args:   []string{"skills", "add", "--name", "Go", "--description", "язык"},
+			format: FormatCSV,
+			beforeTest: func() {
+				skillSvc.EXPECT().Create(gomock.Any(), &domain.Skill{Name: "Go", Description: "язык"}).
+					DoAndReturn(func(_ context.Context, skill *domain.Skill) error {
+						skill.ID = skillId
+						return nil
+					})
+			},
+			wantCode: ExitOK,
+			wantOut:  "id,name,description\n" + skillId.String() + ",Go,язык\n",
+		},
+		{
+			name: "удаление несуществующего навыка",
+			args: []string{"skills", "rm", skillId.String()},
+			beforeTest: func() {
+				skillSvc.EXPECT().GetById(gomock.Any(), skillId).
+					Return(nil, domain.NewNotFoundError(errors.New("навык не найден")))
+			},
+			wantCode: ExitNotFound,
+		},
+		{
+			name:     "удаление навыка с некорректным id",
+			args:     []string{"skills", "rm", "42"},
+			wantCode: ExitUsage,
+		},
+		{
+			name: "добавление сферы деятельности с дубликатом имени",
+			args: []string{"activity-fields", "add", "--name", "IT", "--description", "ИТ", "--cost", "1.5"},
+			beforeTest: func() {
+				actFieldSvc.EXPECT().
+					Create(gomock.Any(), &domain.ActivityField{Name: "IT", Description: "ИТ", Cost: 1.5}).
+					Return(domain.NewConflictError(errors.New("сфера деятельности уже существует")))
+			},
+			wantCode: ExitConflict,
+		},
+		{
+			name: "обновление веса сферы деятельности",
+			args: []string{"activity-fields", "update", fieldId.String(), "--cost", "2"},
+			beforeTest: func() {
+				actFieldSvc.EXPECT().GetById(gomock.Any(), fieldId).
+					Return(&domain.ActivityField{ID: fieldId, Name: "IT", Description: "ИТ", Cost: 1.5}, nil)
+				actFieldSvc.EXPECT().
+					Update(gomock.Any(), &domain.ActivityField{ID: fieldId, Name: "IT", Description: "ИТ", Cost: 2}).
+					Return(nil)
+			},
+			wantCode: ExitOK,
+		},
+		{
+			name:   "выгрузка отчётов за период в CSV",
+			args:   []string{"reports", "export", "--company", companyId.String(), "--from", "2023Q1", "--to", "2023q2"},
+			format: FormatCSV,
+			beforeTest: func() {
+				finSvc.EXPECT().
+					GetByCompany(gomock.Any(), companyId, &domain.Period{StartYear: 2023, StartQuarter: 1, EndYear: 2023, EndQuarter: 2}).
+					Return(&domain.FinancialReportByPeriod{Reports: []domain.FinancialReport{
+						{ID: reportId, CompanyID: companyId, Year: 2023, Quarter: 1, Revenue: 1000.5, Costs: 300},
+					}}, nil)
+			},
+			wantCode: ExitOK,
+			wantOut: "id,company_id,year,quarter,revenue,costs\n" +
+				reportId.String() + "," + companyId.String() + ",2023,1,1000.5,300\n",
+		},
+		{
+			name:     "выгрузка отчётов с некорректным кварталом",
+			args:     []string{"reports", "export", "--company", companyId.String(), "--from", "2023Q5"},
+			wantCode: ExitUsage,
+		},
+		{
+			name:     "выгрузка отчётов без компании",
+			args:     []string{"reports", "export"},
+			wantCode: ExitUsage,
+		},
+		{
+			name: "импорт отчётов из CSV продолжается после ошибки",
+			args: []string{"reports", "import"},
+			in: "company_id,year,quarter,revenue,costs\n" +
+				companyId.String() + ",2023,1,1000,300\n" +
+				companyId.String() + ",2023,x,1000,300\n" +
+				companyId.String() + ",2023,2,2000,500\n",
+			beforeTest: func() {
+				finSvc.EXPECT().
+					Create(gomock.Any(), &domain.FinancialReport{CompanyID: companyId, Year: 2023, Quarter: 1, Revenue: 1000, Costs: 300}).
+					Return(nil)
+				finSvc.EXPECT().
+					Create(gomock.Any(), &domain.FinancialReport{CompanyID: companyId, Year: 2023, Quarter: 2, Revenue: 2000, Costs: 500}).
+					Return(nil)
+			},
+			wantCode:   ExitValidation,
+			wantErrOut: "запись 2: параметр quarter должен быть целым числом",
+		},
+		{
+			name: "импорт отчётов из JSON",
+			args: []string{"reports", "import"},
+			in:   `[{"company_id": "` + companyId.String() + `", "year": 2023, "quarter": 3, "revenue": 10, "costs": 5}]`,
+			beforeTest: func() {
+				finSvc.EXPECT().
+					Create(gomock.Any(), &domain.FinancialReport{CompanyID: companyId, Year: 2023, Quarter: 3, Revenue: 10, Costs: 5}).
+					Return(domain.NewConflictError(errors.New("отчёт уже существует")))
+			},
+			wantCode:   ExitConflict,
+			wantErrOut: "не обработано записей: 1 из 1",
+		},
+		{
+			name:     "импорт отчётов из CSV без обязательного столбца",
+			args:     []string{"reports", "import"},
+			in:       "company_id,year,quarter,revenue\n",
+			wantCode: ExitValidation,
+		},
+		{
+			name: "пересчёт рейтингов всех владельцев компаний",
+			args: []string{"ratings", "recompute", "--from", "2023Q1", "--to", "2023Q1"},
+			beforeTest: func() {
+				period := &domain.Period{StartYear: 2023, StartQuarter: 1, EndYear: 2023, EndQuarter: 1}
+				compSvc.EXPECT().GetAll(gomock.Any(), 1).Return([]*domain.Company{
+					{ID: uuid.New(), OwnerID: ownerId},
+					{ID: uuid.New(), OwnerID: userId},
+					{ID: uuid.New(), OwnerID: ownerId},
+				}, nil)
+				compSvc.EXPECT().GetAll(gomock.Any(), 2).Return([]*domain.Company{}, nil)
+				gomock.InOrder(
+					ratingSvc.EXPECT().Recompute(gomock.Any(), userId, period).
+						Return([]*domain.RatingSnapshot{{UserID: userId, Year: 2023, Quarter: 1, Rating: 0.5}}, nil),
+					ratingSvc.EXPECT().Recompute(gomock.Any(), ownerId, period).
+						Return(nil, errors.New("нет соединения с БД")),
+				)
+			},
+			wantCode:   ExitError,
+			wantErrOut: "пользователь " + ownerId.String() + ": нет соединения с БД",
+		},
+		{
+			name: "пересчёт рейтингов без владельцев компаний",
+			args: []string{"ratings", "recompute"},
+			beforeTest: func() {
+				compSvc.EXPECT().GetAll(gomock.Any(), 1).Return([]*domain.Company{}, nil)
+			},
+			wantCode:   ExitNotFound,
+			wantErrOut: "владельцы компаний не найдены",
+		},
+		{
+			name: "пересчёт истории рейтинга пользователя",
+			args: []string{"ratings", "recompute", "--user", userId.String()},
+			beforeTest: func() {
+				ratingSvc.EXPECT().RecomputeHistory(gomock.Any(), userId).Return(nil)
+				ratingSvc.EXPECT().GetHistory(gomock.Any(), userId, nil).
+					Return([]*domain.RatingSnapshot{{UserID: userId, Year: 2023, Quarter: 4, Rating: 0.25}}, nil)
+			},
+			wantCode:  ExitOK,
+			wantInOut: "0.25",
+		},
+		{
+			name: "пересчёт рейтинга по версиям отчётов на дату",
+			args: []string{"ratings", "recompute", "--user", userId.String(), "--from", "2023Q2", "--to", "2023Q2",
+				"--as-of", "2023-08-01T00:00:00Z"},
+			beforeTest: func() {
+				period := &domain.Period{
+					StartYear: 2023, StartQuarter: 2, EndYear: 2023, EndQuarter: 2,
+					AsOf: time.Date(2023, time.August, 1, 0, 0, 0, 0, time.UTC),
+				}
+				ratingSvc.EXPECT().Recompute(gomock.Any(), userId, period).
+					Return([]*domain.RatingSnapshot{{UserID: userId, Year: 2023, Quarter: 2, Rating: 0.75}}, nil)
+			},
+			wantCode:  ExitOK,
+			wantInOut: "0.75",
+		},
+		{
+			name:     "дата версий отчётов без периода",
+			args:     []string{"ratings", "recompute", "--as-of", "2023-08-01T00:00:00Z"},
+			wantCode: ExitUsage,
+		},
+		{
+			name:     "неизвестная команда",
+			args:     []string{"skills", "frob"},
+			wantCode: ExitUsage,
+		},
+		{
+			name:     "неизвестный флаг команды",
+			args:     []string{"skills", "add", "--color", "red"},
+			wantCode: ExitUsage,
+		},
+		{
+			name:     "неизвестный формат вывода",
+			args:     []string{"users", "list"},
+			format:   "xml",
+			wantCode: ExitUsage,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.beforeTest != nil {
+				tc.beforeTest()
+			}
+			format := tc.format
+			if format == "" {
+				format = FormatTable
+			}
+
+			var out, errOut bytes.Buffer
+			c := NewCLI(a, strings.NewReader(tc.in), &out, &errOut, format)
+			err := c.Run(context.Background(), tc.args)
+			if err != nil {
+				fmt.Fprintln(&errOut, err)
+			}
+
+			require.Equal(t, tc.wantCode, ExitCode(err), errOut.String())
+			if tc.wantOut != "" {
+				require.Equal(t, tc.wantOut, out.String())
+			}
+			require.Contains(t, out.String(), tc.wantInOut)
+			require.Contains(t, errOut.String(), tc.wantErrOut)
+		})
+	}
+}
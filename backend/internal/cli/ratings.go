@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"ppo/domain"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ratingOutput struct {
+	UserID  string  `json:"user_id"`
+	Year    int     `json:"year"`
+	Quarter int     `json:"quarter"`
+	Rating  float32 `json:"rating"`
+	Revenue float32 `json:"revenue"`
+	Profit  float32 `json:"profit"`
+}
+
+func (c *CLI) renderRatings(snapshots []*domain.RatingSnapshot) error {
+	out := make([]ratingOutput, 0, len(snapshots))
+	rows := make([][]string, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		r := ratingOutput{
+			UserID:  snapshot.UserID.String(),
+			Year:    snapshot.Year,
+			Quarter: snapshot.Quarter,
+			Rating:  snapshot.Rating,
+			Revenue: snapshot.Revenue,
+			Profit:  snapshot.Profit,
+		}
+		out = append(out, r)
+		rows = append(rows, []string{
+			r.UserID, strconv.Itoa(r.Year), strconv.Itoa(r.Quarter),
+			formatFloat(r.Rating), formatFloat(r.Revenue), formatFloat(r.Profit),
+		})
+	}
+
+	return c.render(out, []string{"user_id", "year", "quarter", "rating", "revenue", "profit"}, rows)
+}
+
+// ratingsRecompute пересчитывает снимки рейтинга пользователя --user или, без него,
+// всех владельцев компаний; если компаний нет, команда завершается ошибкой.
+// С периодом пересчитываются кварталы периода, без него — ранее сохранённая история.
+// С --as-of рейтинг периода считается по версиям отчётов, известным на эту дату,
+// и не сохраняется. Печатаются пересчитанные снимки.
+func (c *CLI) ratingsRecompute(ctx context.Context, args []string) (err error) {
+	fs := flag.NewFlagSet("ratings recompute", flag.ContinueOnError)
+	user := fs.String("user", "", "id пользователя; без флага — все владельцы компаний")
+	from := fs.String("from", "", "первый квартал периода, например 2023Q1")
+	to := fs.String("to", "", "последний квартал периода, например 2024Q4")
+	asOf := fs.String("as-of", "", "момент в формате RFC3339, версии отчетов на который используются")
+	_, err = c.parseFlags(fs, args, 0)
+	if err != nil {
+		return err
+	}
+
+	period, err := parsePeriod("ratings recompute", *from, *to, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if *asOf != "" {
+		if period == nil {
+			return usagef("ratings recompute: --as-of задан без --from")
+		}
+
+		period.AsOf, err = time.Parse(time.RFC3339, *asOf)
+		if err != nil {
+			return usagef("ratings recompute: момент %q не в формате RFC3339", *asOf)
+		}
+	}
+
+	var userIds []uuid.UUID
+	if *user != "" {
+		id, err := parseID("ratings recompute", *user)
+		if err != nil {
+			return err
+		}
+		userIds = append(userIds, id)
+	} else {
+		userIds, err = c.companyOwners(ctx)
+		if err != nil {
+			return err
+		}
+		if len(userIds) == 0 {
+			return fmt.Errorf("ratings recompute: владельцы компаний не найдены: %w", domain.ErrNotFound)
+		}
+	}
+
+	snapshots := make([]*domain.RatingSnapshot, 0)
+	var errs []error
+	for _, userId := range userIds {
+		recomputed, err := c.recomputeRating(ctx, userId, period)
+		if err != nil {
+			fmt.Fprintf(c.errOut, "пользователь %s: %v\n", userId, err)
+			errs = append(errs, err)
+			continue
+		}
+
+		snapshots = append(snapshots, recomputed...)
+	}
+
+	err = c.renderRatings(snapshots)
+	if err != nil {
+		return err
+	}
+
+	if len(errs) != 0 {
+		return &batchError{op: "пересчёт рейтингов", failed: len(errs), total: len(userIds), errs: errs}
+	}
+
+	return nil
+}
+
+func (c *CLI) recomputeRating(ctx context.Context, userId uuid.UUID, period *domain.Period) (
+	snapshots []*domain.RatingSnapshot, err error) {
+	if period != nil {
+		return c.app.RatingSvc.Recompute(ctx, userId, period)
+	}
+
+	err = c.app.RatingSvc.RecomputeHistory(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.app.RatingSvc.GetHistory(ctx, userId, nil)
+}
+
+// companyOwners возвращает владельцев всех компаний в стабильном порядке.
+func (c *CLI) companyOwners(ctx context.Context) (owners []uuid.UUID, err error) {
+	seen := make(map[uuid.UUID]bool)
+	for page := 1; ; page++ {
+		companies, err := c.app.CompSvc.GetAll(ctx, page)
+		if err != nil {
+			return nil, fmt.Errorf("получение владельцев компаний: %w", err)
+		}
+		if len(companies) == 0 {
+			break
+		}
+
+		for _, company := range companies {
+			if !seen[company.OwnerID] {
+				seen[company.OwnerID] = true
+				owners = append(owners, company.OwnerID)
+			}
+		}
+	}
+
+	sort.Slice(owners, func(i, j int) bool {
+		return owners[i].String() < owners[j].String()
+	})
+
+	return owners, nil
+}
@@ -0,0 +1,308 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"ppo/domain"
+	"ppo/pkg/i18n"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// reportOutput — строка выгрузки отчётов. Тот же формат принимает импорт, поэтому
+// выгрузку в JSON или CSV можно загрузить обратно; id при импорте не учитывается.
+type reportOutput struct {
+	ID        string  `json:"id,omitempty"`
+	CompanyID string  `json:"company_id"`
+	Year      int     `json:"year"`
+	Quarter   int     `json:"quarter"`
+	Revenue   float32 `json:"revenue"`
+	Costs     float32 `json:"costs"`
+}
+
+var reportHeader = []string{"id", "company_id", "year", "quarter", "revenue", "costs"}
+
+func (c *CLI) renderReports(reports []domain.FinancialReport) error {
+	out := make([]reportOutput, 0, len(reports))
+	rows := make([][]string, 0, len(reports))
+	for _, rep := range reports {
+		r := reportOutput{
+			ID:        rep.ID.String(),
+			CompanyID: rep.CompanyID.String(),
+			Year:      rep.Year,
+			Quarter:   rep.Quarter,
+			Revenue:   rep.Revenue,
+			Costs:     rep.Costs,
+		}
+		out = append(out, r)
+		rows = append(rows, []string{
+			r.ID, r.CompanyID, strconv.Itoa(r.Year), strconv.Itoa(r.Quarter),
+			formatFloat(r.Revenue), formatFloat(r.Costs),
+		})
+	}
+
+	return c.render(out, reportHeader, rows)
+}
+
+// reportsExport выгружает отчёты компании за период. Без --from выгружаются отчёты
+// с самого начала, без --to — по последний завершившийся квартал.
+func (c *CLI) reportsExport(ctx context.Context, args []string) (err error) {
+	fs := flag.NewFlagSet("reports export", flag.ContinueOnError)
+	company := fs.String("company", "", "id компании")
+	from := fs.String("from", "", "первый квартал периода, например 2023Q1")
+	to := fs.String("to", "", "последний квартал периода, например 2024Q4")
+	_, err = c.parseFlags(fs, args, 0)
+	if err != nil {
+		return err
+	}
+
+	if *company == "" {
+		return usagef("reports export: не указан --company")
+	}
+	companyId, err := parseID("reports export", *company)
+	if err != nil {
+		return err
+	}
+
+	period, err := parsePeriod("reports export", *from, *to, time.Now())
+	if err != nil {
+		return err
+	}
+	if period == nil {
+		period = &domain.Period{StartYear: 1, StartQuarter: 1}
+		period.EndYear, period.EndQuarter = domain.LastClosedQuarter(time.Now())
+	}
+
+	reports, err := c.app.FinSvc.GetByCompany(ctx, companyId, period)
+	if err != nil {
+		return err
+	}
+
+	return c.renderReports(reports.Reports)
+}
+
+// reportsImport загружает отчёты из CSV с заголовком или JSON-массива; формат
+// определяется по первому символу. Строки загружаются независимо: ошибка в одной
+// не мешает остальным, но делает код возврата ненулевым.
+func (c *CLI) reportsImport(ctx context.Context, args []string) (err error) {
+	fs := flag.NewFlagSet("reports import", flag.ContinueOnError)
+	file := fs.String("file", "-", "файл CSV или JSON; - — стандартный ввод")
+	_, err = c.parseFlags(fs, args, 0)
+	if err != nil {
+		return err
+	}
+
+	in := c.in
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			return fmt.Errorf("импорт отчётов: %w", err)
+		}
+		defer f.Close()
+		in = f
+	}
+
+	rows, err := readReports(in)
+	if err != nil {
+		return err
+	}
+
+	imported := make([]domain.FinancialReport, 0, len(rows))
+	var errs []error
+	for i, row := range rows {
+		report, err := row.report()
+		if err == nil {
+			err = c.app.FinSvc.Create(ctx, report)
+		}
+		if err != nil {
+			fmt.Fprintf(c.errOut, "запись %d: %v\n", i+1, err)
+			errs = append(errs, err)
+			continue
+		}
+
+		imported = append(imported, *report)
+	}
+
+	err = c.renderReports(imported)
+	if err != nil {
+		return err
+	}
+
+	if len(errs) != 0 {
+		return &batchError{op: "импорт отчётов", failed: len(errs), total: len(rows), errs: errs}
+	}
+
+	return nil
+}
+
+// reportRow — строка импорта до разбора значений.
+type reportRow struct {
+	CompanyID string
+	Year      string
+	Quarter   string
+	Revenue   string
+	Costs     string
+}
+
+func (r *reportRow) report() (report *domain.FinancialReport, err error) {
+	report = new(domain.FinancialReport)
+	report.CompanyID, err = uuid.Parse(r.CompanyID)
+	if err != nil {
+		return nil, domain.NewValidationError("company_id", i18n.MsgParamInvalidUUID, "company_id")
+	}
+
+	report.Year, err = strconv.Atoi(r.Year)
+	if err != nil {
+		return nil, domain.NewValidationError("year", i18n.MsgParamInvalidNumber, "year")
+	}
+
+	report.Quarter, err = strconv.Atoi(r.Quarter)
+	if err != nil {
+		return nil, domain.NewValidationError("quarter", i18n.MsgParamInvalidNumber, "quarter")
+	}
+
+	revenue, err := strconv.ParseFloat(r.Revenue, 32)
+	if err != nil {
+		return nil, domain.NewValidationError("revenue", i18n.MsgRequestFieldInvalid, "revenue")
+	}
+	report.Revenue = float32(revenue)
+
+	costs, err := strconv.ParseFloat(r.Costs, 32)
+	if err != nil {
+		return nil, domain.NewValidationError("costs", i18n.MsgRequestFieldInvalid, "costs")
+	}
+	report.Costs = float32(costs)
+
+	return report, nil
+}
+
+func readReports(in io.Reader) (rows []reportRow, err error) {
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return nil, fmt.Errorf("импорт отчётов: %w", err)
+	}
+
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) != 0 && trimmed[0] == '[' {
+		return readReportsJSON(trimmed)
+	}
+
+	return readReportsCSV(data)
+}
+
+func readReportsJSON(data []byte) (rows []reportRow, err error) {
+	var reports []struct {
+		CompanyID string      `json:"company_id"`
+		Year      json.Number `json:"year"`
+		Quarter   json.Number `json:"quarter"`
+		Revenue   json.Number `json:"revenue"`
+		Costs     json.Number `json:"costs"`
+	}
+	err = json.Unmarshal(data, &reports)
+	if err != nil {
+		return nil, domain.NewValidationError("file", i18n.MsgRequestFieldInvalid, err)
+	}
+
+	rows = make([]reportRow, 0, len(reports))
+	for _, rep := range reports {
+		rows = append(rows, reportRow{
+			CompanyID: rep.CompanyID,
+			Year:      rep.Year.String(),
+			Quarter:   rep.Quarter.String(),
+			Revenue:   rep.Revenue.String(),
+			Costs:     rep.Costs.String(),
+		})
+	}
+
+	return rows, nil
+}
+
+// readReportsCSV читает CSV с заголовком; порядок столбцов произвольный, лишние пропускаются.
+func readReportsCSV(data []byte) (rows []reportRow, err error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.TrimLeadingSpace = true
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, domain.NewValidationError("file", i18n.MsgRequestFieldInvalid, err)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		columns[strings.TrimSpace(name)] = i
+	}
+
+	for _, name := range reportHeader[1:] {
+		if _, ok := columns[name]; !ok {
+			return nil, domain.NewValidationError(name, i18n.MsgParamRequired, name)
+		}
+	}
+
+	rows = make([]reportRow, 0, len(records)-1)
+	for _, record := range records[1:] {
+		rows = append(rows, reportRow{
+			CompanyID: record[columns["company_id"]],
+			Year:      record[columns["year"]],
+			Quarter:   record[columns["quarter"]],
+			Revenue:   record[columns["revenue"]],
+			Costs:     record[columns["costs"]],
+		})
+	}
+
+	return rows, nil
+}
+
+// parsePeriod разбирает границы периода вида 2023Q1. Если не задана ни одна,
+// возвращает nil; если только from — период продолжается по последний завершившийся квартал.
+func parsePeriod(cmd, from, to string, now time.Time) (period *domain.Period, err error) {
+	if from == "" && to == "" {
+		return nil, nil
+	}
+	if from == "" {
+		return nil, usagef("%s: --to задан без --from", cmd)
+	}
+
+	period = new(domain.Period)
+	period.StartYear, period.StartQuarter, err = parseQuarter(cmd, from)
+	if err != nil {
+		return nil, err
+	}
+
+	if to == "" {
+		period.EndYear, period.EndQuarter = domain.LastClosedQuarter(now)
+		return period, nil
+	}
+
+	period.EndYear, period.EndQuarter, err = parseQuarter(cmd, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return period, nil
+}
+
+func parseQuarter(cmd, s string) (year, quarter int, err error) {
+	yearStr, quarterStr, ok := strings.Cut(strings.ToUpper(s), "Q")
+	if ok {
+		year, err = strconv.Atoi(yearStr)
+	}
+	if ok && err == nil {
+		quarter, err = strconv.Atoi(quarterStr)
+	}
+	if !ok || err != nil || quarter < 1 || quarter > 4 {
+		return 0, 0, usagef("%s: квартал %q не в формате YYYYQN, например 2024Q1", cmd, s)
+	}
+
+	return year, quarter, nil
+}
@@ -0,0 +1,165 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"ppo/domain"
+	"time"
+)
+
+type userOutput struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+	FullName string `json:"full_name"`
+	Gender   string `json:"gender"`
+	Birthday string `json:"birthday"`
+	City     string `json:"city"`
+	Role     string `json:"role"`
+}
+
+func toUserOutput(user *domain.User) userOutput {
+	out := userOutput{
+		ID:       user.ID.String(),
+		Username: user.Username,
+		FullName: user.FullName,
+		Gender:   user.Gender,
+		City:     user.City,
+		Role:     user.Role,
+	}
+	if !user.Birthday.IsZero() {
+		out.Birthday = user.Birthday.Format(time.DateOnly)
+	}
+
+	return out
+}
+
+func (c *CLI) renderUsers(users []*domain.User) error {
+	out := make([]userOutput, 0, len(users))
+	rows := make([][]string, 0, len(users))
+	for _, user := range users {
+		u := toUserOutput(user)
+		out = append(out, u)
+		rows = append(rows, []string{u.ID, u.Username, u.FullName, u.Gender, u.Birthday, u.City, u.Role})
+	}
+
+	return c.render(out, []string{"id", "username", "full_name", "gender", "birthday", "city", "role"}, rows)
+}
+
+// usersList печатает страницу пользователей или, без --page, всех.
+func (c *CLI) usersList(ctx context.Context, args []string) (err error) {
+	fs := flag.NewFlagSet("users list", flag.ContinueOnError)
+	page := fs.Int("page", 0, "номер страницы; 0 — все страницы")
+	_, err = c.parseFlags(fs, args, 0)
+	if err != nil {
+		return err
+	}
+	if *page < 0 {
+		return usagef("users list: номер страницы должен быть неотрицательным")
+	}
+
+	var users []*domain.User
+	if *page != 0 {
+		users, _, err = c.app.UserSvc.GetAll(ctx, *page)
+	} else {
+		users, err = collect(func(page int) ([]*domain.User, int, error) {
+			return c.app.UserSvc.GetAll(ctx, page)
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	return c.renderUsers(users)
+}
+
+// usersUpdate меняет поля карточки пользователя, заданные флагами; остальные сохраняются.
+func (c *CLI) usersUpdate(ctx context.Context, args []string) (err error) {
+	fs := flag.NewFlagSet("users update", flag.ContinueOnError)
+	fullName := fs.String("full-name", "", "ФИО")
+	gender := fs.String("gender", "", "пол: m или w")
+	birthday := fs.String("birthday", "", "дата рождения YYYY-MM-DD")
+	city := fs.String("city", "", "город")
+	positional, err := c.parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	user, err := c.app.UserSvc.GetByUsername(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+
+	updated := *user
+	set := setFlags(fs)
+	if set["full-name"] {
+		updated.FullName = *fullName
+	}
+	if set["gender"] {
+		updated.Gender = *gender
+	}
+	if set["birthday"] {
+		updated.Birthday, err = time.Parse(time.DateOnly, *birthday)
+		if err != nil {
+			return usagef("users update: дата рождения %q не в формате YYYY-MM-DD", *birthday)
+		}
+	}
+	if set["city"] {
+		updated.City = *city
+	}
+
+	err = c.app.UserSvc.Update(ctx, &updated)
+	if err != nil {
+		return err
+	}
+
+	return c.renderUsers([]*domain.User{&updated})
+}
+
+func (c *CLI) usersSetRole(ctx context.Context, args []string) (err error) {
+	fs := flag.NewFlagSet("users set-role", flag.ContinueOnError)
+	positional, err := c.parseFlags(fs, args, 2)
+	if err != nil {
+		return err
+	}
+
+	user, err := c.app.UserSvc.GetByUsername(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+
+	err = c.app.RoleSvc.AssignToUser(ctx, user.ID, positional[1])
+	if err != nil {
+		return fmt.Errorf("изменение роли пользователя: %w", err)
+	}
+
+	updated := *user
+	updated.Role = positional[1]
+
+	return c.renderUsers([]*domain.User{&updated})
+}
+
+// collect обходит все страницы списка, начиная с первой.
+func collect[T any](load func(page int) ([]*T, int, error)) (items []*T, err error) {
+	for page := 1; ; page++ {
+		batch, numPages, err := load(page)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, batch...)
+		if page >= numPages {
+			return items, nil
+		}
+	}
+}
+
+// setFlags возвращает имена флагов, явно заданных в командной строке.
+func setFlags(fs *flag.FlagSet) map[string]bool {
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+
+	return set
+}
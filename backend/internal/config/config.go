@@ -1,76 +1,163 @@
 package config
 
 import (
-	"fmt"
-	"os"
+	"ppo/pkg/i18n"
+	"time"
 )
 
-const (
-	PageSize    = 3
-	MaxContacts = 5
-)
+// Каждый параметр с тегом env можно задать в файле конфигурации (ключи из тегов yaml/toml),
+// переменной окружения env и флагом командной строки: имя флага — env в нижнем регистре
+// с дефисами, например DB_HOST → --db-host. Источники применяются в порядке:
+// значения по умолчанию, файл, окружение, флаги.
 
 type DBConfig struct {
-	User     string
-	Password string
-	Database string
-	Host     string
-	Port     string
-	Driver   string
+	User     string `yaml:"user" toml:"user" env:"DB_USER"`
+	Password string `yaml:"password" toml:"password" env:"DB_PASSWORD"`
+	Database string `yaml:"name" toml:"name" env:"DB_NAME"`
+	Host     string `yaml:"host" toml:"host" env:"DB_HOST"`
+	Port     string `yaml:"port" toml:"port" env:"DB_PORT"`
+	Driver   string `yaml:"driver" toml:"driver" env:"DB_DRIVER"`
+	// AutoMigrate применяет недостающие миграции при запуске сервера.
+	AutoMigrate bool `yaml:"auto_migrate" toml:"auto_migrate" env:"DB_AUTO_MIGRATE"`
 }
 
-type Config struct {
-	JwtKey string
-	DBConfig
+// ServerConfig — параметры HTTP-сервера. Нулевой таймаут означает отсутствие ограничения.
+type ServerConfig struct {
+	Addr              string        `yaml:"addr" toml:"addr" env:"HTTP_ADDR"`
+	ReadTimeout       time.Duration `yaml:"read_timeout" toml:"read_timeout" env:"HTTP_READ_TIMEOUT"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" toml:"read_header_timeout" env:"HTTP_READ_HEADER_TIMEOUT"`
+	WriteTimeout      time.Duration `yaml:"write_timeout" toml:"write_timeout" env:"HTTP_WRITE_TIMEOUT"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout" toml:"idle_timeout" env:"HTTP_IDLE_TIMEOUT"`
+	// ShutdownTimeout ограничивает ожидание незавершённых запросов при остановке.
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" toml:"shutdown_timeout" env:"HTTP_SHUTDOWN_TIMEOUT"`
+	// MaxBodyBytes — предельный размер тела запроса; 0 — без ограничения.
+	MaxBodyBytes int64 `yaml:"max_body_bytes" toml:"max_body_bytes" env:"HTTP_MAX_BODY_BYTES"`
+	// TLSCertFile и TLSKeyFile задаются вместе; без них сервер работает по HTTP.
+	TLSCertFile string `yaml:"tls_cert_file" toml:"tls_cert_file" env:"TLS_CERT_FILE"`
+	TLSKeyFile  string `yaml:"tls_key_file" toml:"tls_key_file" env:"TLS_KEY_FILE"`
 }
 
-func ReadConfig() (cfg *Config, err error) {
-	jwtKey := os.Getenv("JWT_KEY")
-	if jwtKey == "" {
-		return nil, fmt.Errorf("JWT_KEY должен быть заполнен")
-	}
+func (c *ServerConfig) TLS() bool {
+	return c.TLSCertFile != ""
+}
 
-	dbUser := os.Getenv("DB_USER")
-	if dbUser == "" {
-		return nil, fmt.Errorf("DB_USER должен быть заполнен")
-	}
+type LimitsConfig struct {
+	// PageSize — число записей на странице списков.
+	PageSize int `yaml:"page_size" toml:"page_size" env:"PAGE_SIZE"`
+	// MaxContacts — наибольшее число контактов одного пользователя.
+	MaxContacts int `yaml:"max_contacts" toml:"max_contacts" env:"MAX_CONTACTS"`
+}
 
-	dbPassword := os.Getenv("DB_PASSWORD")
-	if dbPassword == "" {
-		return nil, fmt.Errorf("DB_PASSWORD должен быть заполнен")
-	}
+type AuthConfig struct {
+	// TokenTTL — срок действия выдаваемых JWT.
+	TokenTTL time.Duration `yaml:"token_ttl" toml:"token_ttl" env:"TOKEN_TTL"`
+}
 
-	dbName := os.Getenv("DB_NAME")
-	if dbName == "" {
-		return nil, fmt.Errorf("DB_NAME должен быть заполнен")
-	}
+type CacheConfig struct {
+	// Size — число записей в каждом кэше сервисов.
+	Size int `yaml:"size" toml:"size" env:"CACHE_SIZE"`
+	// CatalogTTL — время жизни кэша справочников (сферы деятельности, навыки).
+	CatalogTTL time.Duration `yaml:"catalog_ttl" toml:"catalog_ttl" env:"CACHE_CATALOG_TTL"`
+	// UserTTL — время жизни кэша профилей. Обычно меньше, чем у справочников:
+	// другие экземпляры сервера узнают о смене профиля только по его истечении.
+	UserTTL time.Duration `yaml:"user_ttl" toml:"user_ttl" env:"CACHE_USER_TTL"`
+}
 
-	dbHost := os.Getenv("DB_HOST")
-	if dbHost == "" {
-		return nil, fmt.Errorf("DB_HOST должен быть заполнен")
-	}
+// TaxBracket — ставка Rate (в процентах) для годовой прибыли меньше Below.
+type TaxBracket struct {
+	Below float32 `yaml:"below" toml:"below"`
+	Rate  float32 `yaml:"rate" toml:"rate"`
+}
 
-	dbPort := os.Getenv("DB_PORT")
-	if dbPort == "" {
-		return nil, fmt.Errorf("DB_PORT должен быть заполнен")
-	}
+// TaxConfig — прогрессивная шкала налога на годовую прибыль. Ступени упорядочены
+// по возрастанию Below; прибыль не меньше границы последней ступени облагается по TopRate.
+// Шкала задаётся только в файле конфигурации.
+type TaxConfig struct {
+	Brackets []TaxBracket `yaml:"brackets" toml:"brackets"`
+	TopRate  float32      `yaml:"top_rate" toml:"top_rate" env:"TAX_TOP_RATE"`
+}
 
-	dbDriver := os.Getenv("DB_DRIVER")
-	if dbDriver == "" {
-		return nil, fmt.Errorf("DB_DRIVER должен быть заполнен")
+// Rate возвращает ставку налога в процентах для годовой прибыли profit.
+func (c *TaxConfig) Rate(profit float32) float32 {
+	for _, bracket := range c.Brackets {
+		if profit < bracket.Below {
+			return bracket.Rate
+		}
 	}
 
-	dbCfg := DBConfig{
-		User:     dbUser,
-		Password: dbPassword,
-		Database: dbName,
-		Host:     dbHost,
-		Port:     dbPort,
-		Driver:   dbDriver,
-	}
+	return c.TopRate
+}
+
+// RatingConfig — веса составляющих рейтинга предпринимателя: относительного веса
+// сферы деятельности и рентабельности. Рейтинг — их взвешенное среднее.
+type RatingConfig struct {
+	FieldWeight         float32 `yaml:"field_weight" toml:"field_weight" env:"RATING_FIELD_WEIGHT"`
+	ProfitabilityWeight float32 `yaml:"profitability_weight" toml:"profitability_weight" env:"RATING_PROFITABILITY_WEIGHT"`
+	// VerifiedOnly — считать рейтинг только по подтверждённым отчётам.
+	VerifiedOnly bool `yaml:"verified_only" toml:"verified_only" env:"RATING_VERIFIED_ONLY"`
+}
 
+// StorageConfig — параметры хранилища подтверждающих документов. Размер документа
+// ограничен HTTP_MAX_BODY_BYTES.
+type StorageConfig struct {
+	// Dir — каталог локальной файловой системы, в котором хранятся документы.
+	Dir string `yaml:"dir" toml:"dir" env:"STORAGE_DIR"`
+}
+
+type Config struct {
+	JwtKey   string    `yaml:"jwt_key" toml:"jwt_key" env:"JWT_KEY"`
+	Lang     i18n.Lang `yaml:"lang" toml:"lang" env:"APP_LANG"`
+	DBConfig `yaml:"db" toml:"db"`
+	Server   ServerConfig  `yaml:"server" toml:"server"`
+	Limits   LimitsConfig  `yaml:"limits" toml:"limits"`
+	Auth     AuthConfig    `yaml:"auth" toml:"auth"`
+	Cache    CacheConfig   `yaml:"cache" toml:"cache"`
+	Tax      TaxConfig     `yaml:"tax" toml:"tax"`
+	Rating   RatingConfig  `yaml:"rating" toml:"rating"`
+	Storage  StorageConfig `yaml:"storage" toml:"storage"`
+}
+
+// Default возвращает конфигурацию со значениями по умолчанию. Параметры подключения
+// к БД и ключ JWT значений по умолчанию не имеют и должны быть заданы явно.
+func Default() *Config {
 	return &Config{
-		JwtKey:   jwtKey,
-		DBConfig: dbCfg,
-	}, nil
+		Lang: i18n.Default,
+		Server: ServerConfig{
+			Addr:              ":8081",
+			ReadTimeout:       15 * time.Second,
+			ReadHeaderTimeout: 5 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			IdleTimeout:       time.Minute,
+			ShutdownTimeout:   20 * time.Second,
+			MaxBodyBytes:      1 << 20,
+		},
+		Limits: LimitsConfig{
+			PageSize:    3,
+			MaxContacts: 5,
+		},
+		Auth: AuthConfig{
+			TokenTTL: 24 * time.Hour,
+		},
+		Cache: CacheConfig{
+			Size:       1024,
+			CatalogTTL: 5 * time.Minute,
+			UserTTL:    time.Minute,
+		},
+		Tax: TaxConfig{
+			Brackets: []TaxBracket{
+				{Below: 10000000, Rate: 4},
+				{Below: 50000000, Rate: 7},
+				{Below: 150000000, Rate: 13},
+				{Below: 500000000, Rate: 20},
+			},
+			TopRate: 30,
+		},
+		Rating: RatingConfig{
+			FieldWeight:         1,
+			ProfitabilityWeight: 1,
+		},
+		Storage: StorageConfig{
+			Dir: "data/documents",
+		},
+	}
 }
@@ -0,0 +1,224 @@
+package config
+
+import (
+	"flag"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"ppo/pkg/i18n"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// requiredEnv — параметры без значений по умолчанию.
+var requiredEnv = map[string]string{
+	"JWT_KEY":     "secret",
+	"DB_USER":     "postgres",
+	"DB_PASSWORD": "postgres",
+	"DB_NAME":     "postgres",
+	"DB_HOST":     "localhost",
+	"DB_PORT":     "5432",
+	"DB_DRIVER":   "postgres",
+}
+
+// setEnv очищает все переменные конфигурации и задаёт env.
+func setEnv(t *testing.T, env map[string]string) {
+	t.Setenv("APP_CONFIG", "")
+	fields(Default(), func(_ reflect.Value, name string) {
+		t.Setenv(name, env[name])
+	})
+}
+
+func writeFile(t *testing.T, name, data string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.Nil(t, os.WriteFile(path, []byte(data), 0o600))
+
+	return path
+}
+
+func load(args ...string) (*Config, error) {
+	return Load(flag.NewFlagSet("test", flag.ContinueOnError), args)
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	setEnv(t, requiredEnv)
+
+	cfg, err := load()
+	require.Nil(t, err)
+
+	expected := Default()
+	expected.JwtKey = "secret"
+	expected.DBConfig = DBConfig{
+		User:     "postgres",
+		Password: "postgres",
+		Database: "postgres",
+		Host:     "localhost",
+		Port:     "5432",
+		Driver:   "postgres",
+	}
+	require.Equal(t, expected, cfg)
+}
+
+func TestLoad_Sources(t *testing.T) {
+	yamlFile := `
+jwt_key: from-file
+lang: en
+db:
+  host: db.local
+limits:
+  page_size: 10
+  max_contacts: 7
+auth:
+  token_ttl: 2h
+tax:
+  brackets:
+    - below: 1000
+      rate: 5
+  top_rate: 10
+rating:
+  field_weight: 3
+`
+	tomlFile := `
+jwt_key = "from-file"
+lang = "en"
+
+[db]
+host = "db.local"
+
+[limits]
+page_size = 10
+max_contacts = 7
+
+[auth]
+token_ttl = "2h"
+
+[tax]
+top_rate = 10
+
+[[tax.brackets]]
+below = 1000
+rate = 5
+
+[rating]
+field_weight = 3
+`
+	for _, file := range []struct{ name, data string }{{"ppo.yaml", yamlFile}, {"ppo.toml", tomlFile}} {
+		t.Run(file.name, func(t *testing.T) {
+			env := make(map[string]string)
+			for k, v := range requiredEnv {
+				env[k] = v
+			}
+			env["JWT_KEY"] = ""
+			env["DB_HOST"] = "env.local"
+			env["PAGE_SIZE"] = "20"
+			env["DB_AUTO_MIGRATE"] = "true"
+			env["RATING_VERIFIED_ONLY"] = "true"
+			setEnv(t, env)
+
+			cfg, err := load("--config", writeFile(t, file.name, file.data), "--page-size", "30")
+			require.Nil(t, err)
+
+			require.Equal(t, "from-file", cfg.JwtKey)
+			require.Equal(t, i18n.En, cfg.Lang)
+			// окружение переопределяет файл, флаг — окружение
+			require.Equal(t, "env.local", cfg.DBConfig.Host)
+			require.True(t, cfg.DBConfig.AutoMigrate)
+			require.Equal(t, 30, cfg.Limits.PageSize)
+			require.Equal(t, 7, cfg.Limits.MaxContacts)
+			require.Equal(t, 2*time.Hour, cfg.Auth.TokenTTL)
+			require.Equal(t, TaxConfig{Brackets: []TaxBracket{{Below: 1000, Rate: 5}}, TopRate: 10}, cfg.Tax)
+			require.Equal(t, RatingConfig{FieldWeight: 3, ProfitabilityWeight: 1, VerifiedOnly: true}, cfg.Rating)
+			// параметры, не заданные в файле, сохраняют значения по умолчанию
+			require.Equal(t, Default().Server, cfg.Server)
+		})
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	testCases := []struct {
+		name   string
+		env    map[string]string
+		args   func(t *testing.T) []string
+		errStr string
+	}{
+		{
+			name:   "не заданы обязательные параметры",
+			env:    map[string]string{"DB_USER": ""},
+			errStr: "DB_USER должен быть заполнен",
+		},
+		{
+			name:   "некорректная длительность в окружении",
+			env:    map[string]string{"HTTP_READ_TIMEOUT": "15"},
+			errStr: `HTTP_READ_TIMEOUT: "15" не является длительностью, например 30s или 24h`,
+		},
+		{
+			name:   "некорректное число во флаге",
+			args:   func(*testing.T) []string { return []string{"--page-size", "many"} },
+			errStr: `--page-size: "many" не является целым числом`,
+		},
+		{
+			name:   "некорректное логическое значение в окружении",
+			env:    map[string]string{"DB_AUTO_MIGRATE": "yes"},
+			errStr: `DB_AUTO_MIGRATE: "yes" не является логическим значением, например true или false`,
+		},
+		{
+			name:   "сертификат без ключа",
+			env:    map[string]string{"TLS_CERT_FILE": "cert.pem"},
+			errStr: "TLS_CERT_FILE и TLS_KEY_FILE должны быть заданы вместе",
+		},
+		{
+			name:   "неизвестный язык",
+			env:    map[string]string{"APP_LANG": "de"},
+			errStr: "APP_LANG должен быть одним из: ru, en",
+		},
+		{
+			name: "неизвестный параметр в файле",
+			args: func(t *testing.T) []string {
+				return []string{"--config", writeFile(t, "ppo.yaml", "limits:\n  page_sise: 10\n")}
+			},
+			errStr: "field page_sise not found",
+		},
+		{
+			name: "шкала налога не по возрастанию",
+			args: func(t *testing.T) []string {
+				return []string{"--config", writeFile(t, "ppo.toml", "[[tax.brackets]]\nbelow = 10\n[[tax.brackets]]\nbelow = 5\n")}
+			},
+			errStr: "tax.brackets[1].below должна быть больше границы предыдущей ступени",
+		},
+		{
+			name:   "нулевые веса рейтинга",
+			env:    map[string]string{"RATING_FIELD_WEIGHT": "0", "RATING_PROFITABILITY_WEIGHT": "0"},
+			errStr: "хотя бы один из RATING_FIELD_WEIGHT и RATING_PROFITABILITY_WEIGHT должен быть положительным",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			env := make(map[string]string)
+			for k, v := range requiredEnv {
+				env[k] = v
+			}
+			for k, v := range tc.env {
+				env[k] = v
+			}
+			setEnv(t, env)
+
+			var args []string
+			if tc.args != nil {
+				args = tc.args(t)
+			}
+
+			_, err := load(args...)
+			require.NotNil(t, err)
+			require.Contains(t, err.Error(), tc.errStr)
+		})
+	}
+}
+
+func TestTaxConfig_Rate(t *testing.T) {
+	tax := Default().Tax
+
+	require.Equal(t, float32(4), tax.Rate(-100))
+	require.Equal(t, float32(7), tax.Rate(10000000))
+	require.Equal(t, float32(30), tax.Rate(500000000))
+}
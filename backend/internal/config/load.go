@@ -0,0 +1,175 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Load собирает конфигурацию из значений по умолчанию, файла, переменных окружения
+// и флагов args и проверяет её. Путь к файлу задаётся флагом --config или APP_CONFIG;
+// формат определяется расширением: .yaml, .yml или .toml.
+// Флаги параметров регистрируются в fs, поэтому вызывающий может добавить в fs свои.
+func Load(fs *flag.FlagSet, args []string) (cfg *Config, err error) {
+	cfg = Default()
+
+	path := fs.String("config", os.Getenv("APP_CONFIG"), "путь к файлу конфигурации YAML или TOML")
+	flagEnvs := make(map[string]string)
+	fields(cfg, func(_ reflect.Value, env string) {
+		name := strings.ToLower(strings.ReplaceAll(env, "_", "-"))
+		flagEnvs[name] = env
+		fs.String(name, "", "переопределяет "+env)
+	})
+
+	err = fs.Parse(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if *path != "" {
+		err = readFile(*path, cfg)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = applyEnv(cfg, os.LookupEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	var flagErrs []error
+	fs.Visit(func(f *flag.Flag) {
+		env, ok := flagEnvs[f.Name]
+		if !ok {
+			return
+		}
+
+		fields(cfg, func(field reflect.Value, fieldEnv string) {
+			if fieldEnv == env {
+				flagErrs = append(flagErrs, setField(field, "--"+f.Name, f.Value.String()))
+			}
+		})
+	})
+	err = errors.Join(flagErrs...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+func readFile(path string, cfg *Config) (err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("чтение файла конфигурации: %w", err)
+	}
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err = dec.Decode(cfg)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("разбор файла конфигурации %s: %w", path, err)
+		}
+	case ".toml":
+		meta, err := toml.Decode(string(data), cfg)
+		if err != nil {
+			return fmt.Errorf("разбор файла конфигурации %s: %w", path, err)
+		}
+
+		if undecoded := meta.Undecoded(); len(undecoded) != 0 {
+			return fmt.Errorf("разбор файла конфигурации %s: неизвестный параметр %s", path, undecoded[0])
+		}
+	default:
+		return fmt.Errorf("файл конфигурации %s: неизвестный формат %q, ожидается .yaml, .yml или .toml", path, ext)
+	}
+
+	return nil
+}
+
+func applyEnv(cfg *Config, lookup func(string) (string, bool)) error {
+	var errs []error
+	fields(cfg, func(field reflect.Value, env string) {
+		if value, ok := lookup(env); ok && value != "" {
+			errs = append(errs, setField(field, env, value))
+		}
+	})
+
+	return errors.Join(errs...)
+}
+
+// fields вызывает fn для каждого поля cfg с тегом env, обходя вложенные структуры.
+func fields(cfg *Config, fn func(field reflect.Value, env string)) {
+	var walk func(v reflect.Value)
+	walk = func(v reflect.Value) {
+		for i := 0; i < v.NumField(); i++ {
+			field, structField := v.Field(i), v.Type().Field(i)
+			if env, ok := structField.Tag.Lookup("env"); ok {
+				fn(field, env)
+				continue
+			}
+
+			if field.Kind() == reflect.Struct {
+				walk(field)
+			}
+		}
+	}
+
+	walk(reflect.ValueOf(cfg).Elem())
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// setField записывает в field значение value из источника source (переменной окружения или флага).
+func setField(field reflect.Value, source, value string) error {
+	switch {
+	case field.Type() == durationType:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("%s: %q не является длительностью, например 30s или 24h", source, value)
+		}
+		field.SetInt(int64(d))
+	case field.Kind() == reflect.String:
+		field.SetString(value)
+	case field.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%s: %q не является логическим значением, например true или false", source, value)
+		}
+		field.SetBool(b)
+	case field.Kind() == reflect.Int || field.Kind() == reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%s: %q не является целым числом", source, value)
+		}
+		field.SetInt(n)
+	case field.Kind() == reflect.Float32 || field.Kind() == reflect.Float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("%s: %q не является числом", source, value)
+		}
+		field.SetFloat(f)
+	default:
+		return fmt.Errorf("%s: неподдерживаемый тип параметра %s", source, field.Type())
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"ppo/pkg/i18n"
+)
+
+// Validate проверяет конфигурацию и возвращает все найденные ошибки сразу.
+// Параметры в сообщениях названы так же, как переменные окружения.
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(c.JwtKey != "", "JWT_KEY должен быть заполнен")
+	for _, param := range []struct {
+		env   string
+		value string
+	}{
+		{"DB_USER", c.DBConfig.User},
+		{"DB_PASSWORD", c.DBConfig.Password},
+		{"DB_NAME", c.DBConfig.Database},
+		{"DB_HOST", c.DBConfig.Host},
+		{"DB_PORT", c.DBConfig.Port},
+		{"DB_DRIVER", c.DBConfig.Driver},
+	} {
+		check(param.value != "", "%s должен быть заполнен", param.env)
+	}
+
+	lang, ok := i18n.Parse(string(c.Lang))
+	check(ok, "APP_LANG должен быть одним из: ru, en")
+	if ok {
+		c.Lang = lang
+	}
+
+	check(c.Server.Addr != "", "HTTP_ADDR должен быть заполнен")
+	for _, param := range []struct {
+		env   string
+		value int64
+	}{
+		{"HTTP_READ_TIMEOUT", int64(c.Server.ReadTimeout)},
+		{"HTTP_READ_HEADER_TIMEOUT", int64(c.Server.ReadHeaderTimeout)},
+		{"HTTP_WRITE_TIMEOUT", int64(c.Server.WriteTimeout)},
+		{"HTTP_IDLE_TIMEOUT", int64(c.Server.IdleTimeout)},
+		{"HTTP_SHUTDOWN_TIMEOUT", int64(c.Server.ShutdownTimeout)},
+		{"HTTP_MAX_BODY_BYTES", c.Server.MaxBodyBytes},
+	} {
+		check(param.value >= 0, "%s не может быть отрицательным", param.env)
+	}
+	check((c.Server.TLSCertFile == "") == (c.Server.TLSKeyFile == ""),
+		"TLS_CERT_FILE и TLS_KEY_FILE должны быть заданы вместе")
+
+	check(c.Limits.PageSize > 0, "PAGE_SIZE должен быть положительным")
+	check(c.Limits.MaxContacts > 0, "MAX_CONTACTS должен быть положительным")
+	check(c.Auth.TokenTTL > 0, "TOKEN_TTL должен быть положительным")
+
+	check(c.Cache.Size > 0, "CACHE_SIZE должен быть положительным")
+	check(c.Cache.CatalogTTL > 0, "CACHE_CATALOG_TTL должен быть положительным")
+	check(c.Cache.UserTTL > 0, "CACHE_USER_TTL должен быть положительным")
+
+	for i, bracket := range c.Tax.Brackets {
+		check(bracket.Rate >= 0 && bracket.Rate <= 100, "tax.brackets[%d].rate должна быть от 0 до 100", i)
+		if i > 0 {
+			check(bracket.Below > c.Tax.Brackets[i-1].Below,
+				"tax.brackets[%d].below должна быть больше границы предыдущей ступени", i)
+		}
+	}
+	check(c.Tax.TopRate >= 0 && c.Tax.TopRate <= 100, "TAX_TOP_RATE должна быть от 0 до 100")
+
+	check(c.Rating.FieldWeight >= 0, "RATING_FIELD_WEIGHT не может быть отрицательным")
+	check(c.Rating.ProfitabilityWeight >= 0, "RATING_PROFITABILITY_WEIGHT не может быть отрицательным")
+	check(c.Rating.FieldWeight+c.Rating.ProfitabilityWeight > 0,
+		"хотя бы один из RATING_FIELD_WEIGHT и RATING_PROFITABILITY_WEIGHT должен быть положительным")
+
+	check(c.Storage.Dir != "", "STORAGE_DIR должен быть заполнен")
+
+	err := errors.Join(errs...)
+	if err != nil {
+		return fmt.Errorf("некорректная конфигурация:\n%w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"ppo/domain"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultInterval = time.Second
+	// MaxAttempts — число попыток обработать событие, после которого
+	// оно отмечается обработанным с последней ошибкой и больше не повторяется.
+	MaxAttempts = 10
+	batchSize   = 100
+)
+
+// Backoff возвращает паузу перед повторной обработкой события после attempt неудачных:
+// 5 секунд, удваиваясь с каждой попыткой, но не более часа.
+func Backoff(attempt int) time.Duration {
+	delay := 5 * time.Second
+	for i := 1; i < attempt && delay < time.Hour; i++ {
+		delay *= 2
+	}
+
+	return min(delay, time.Hour)
+}
+
+// Bus — шина доменных событий поверх transactional outbox. Publish сохраняет событие
+// в outbox в транзакции вызывающего, а Run доставляет сохранённые события подписчикам.
+// Подписчик вызывается в транзакции, в которой событие отмечается обработанным,
+// поэтому его записи в БД фиксируются ровно один раз; внешние действия подписчика
+// при сбое могут повториться и должны быть идемпотентными.
+type Bus struct {
+	outboxRepo domain.IOutboxRepository
+	transactor domain.ITransactor
+	now        func() time.Time
+
+	mu       sync.RWMutex
+	handlers map[domain.EventType][]domain.EventHandler
+}
+
+func NewBus(outboxRepo domain.IOutboxRepository, transactor domain.ITransactor) *Bus {
+	return &Bus{
+		outboxRepo: outboxRepo,
+		transactor: transactor,
+		now:        time.Now,
+		handlers:   make(map[domain.EventType][]domain.EventHandler),
+	}
+}
+
+func (b *Bus) Publish(ctx context.Context, event domain.Event) (err error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("сериализация события %s: %w", event.EventType(), err)
+	}
+
+	err = b.outboxRepo.Add(ctx, &domain.OutboxEvent{
+		Type:    event.EventType(),
+		Payload: payload,
+		Event:   event,
+	})
+	if err != nil {
+		return fmt.Errorf("публикация события: %w", err)
+	}
+
+	return nil
+}
+
+func (b *Bus) Subscribe(eventType domain.EventType, handler domain.EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+}
+
+// Run вызывает RunOnce каждые interval, пока не будет отменён ctx.
+func (b *Bus) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		_, err := b.RunOnce(ctx)
+		if err != nil {
+			log.Println(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce обрабатывает до batchSize событий, время обработки которых наступило,
+// и возвращает их количество.
+func (b *Bus) RunOnce(ctx context.Context) (numEvents int, err error) {
+	for numEvents < batchSize {
+		var claimed bool
+		err = b.transactor.WithinTx(ctx, func(ctx context.Context) error {
+			event, err := b.outboxRepo.ClaimNext(ctx, b.now())
+			if err != nil || event == nil {
+				return err
+			}
+
+			claimed = true
+			return b.process(ctx, event)
+		})
+		if err != nil {
+			return numEvents, fmt.Errorf("обработка событий: %w", err)
+		}
+
+		if !claimed {
+			return numEvents, nil
+		}
+
+		numEvents++
+	}
+
+	return numEvents, nil
+}
+
+// process передаёт событие подписчикам. Записи подписчиков выполняются во вложенной
+// транзакции: при ошибке они откатываются, а в outbox сохраняется ошибка и время повтора.
+func (b *Bus) process(ctx context.Context, event *domain.OutboxEvent) (err error) {
+	handleErr := b.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		return b.dispatch(ctx, event)
+	})
+	if handleErr == nil {
+		return b.outboxRepo.MarkProcessed(ctx, event.ID)
+	}
+
+	log.Println(fmt.Errorf("обработка события %s %s: %w", event.Type, event.ID, handleErr))
+
+	attempts := event.Attempts + 1
+	err = b.outboxRepo.MarkFailed(ctx, event.ID, handleErr.Error(), b.now().Add(Backoff(attempts)))
+	if err != nil {
+		return err
+	}
+
+	if attempts >= MaxAttempts {
+		return b.outboxRepo.MarkProcessed(ctx, event.ID)
+	}
+
+	return nil
+}
+
+func (b *Bus) dispatch(ctx context.Context, event *domain.OutboxEvent) (err error) {
+	if event.Event == nil {
+		event.Event, err = domain.DecodeEvent(event.Type, event.Payload)
+		if err != nil {
+			return err
+		}
+	}
+
+	b.mu.RLock()
+	handlers := b.handlers[event.Type]
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		err = handler(ctx, event)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,166 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"ppo/domain"
+	"ppo/mocks"
+	"testing"
+	"time"
+)
+
+func newTransactor(ctrl *gomock.Controller) *mocks.MockITransactor {
+	transactor := mocks.NewMockITransactor(ctrl)
+	transactor.EXPECT().
+		WithinTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).
+		AnyTimes()
+
+	return transactor
+}
+
+func TestBus_Publish(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	outboxRepo := mocks.NewMockIOutboxRepository(ctrl)
+	bus := NewBus(outboxRepo, newTransactor(ctrl))
+
+	userId := uuid.UUID{1}
+
+	testCases := []struct {
+		name       string
+		beforeTest func(outboxRepo mocks.MockIOutboxRepository)
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name: "успешная публикация",
+			beforeTest: func(outboxRepo mocks.MockIOutboxRepository) {
+				outboxRepo.EXPECT().
+					Add(context.Background(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, event *domain.OutboxEvent) error {
+						require.Equal(t, domain.EventRatingChanged, event.Type)
+						require.JSONEq(t, `{"user_id":"`+userId.String()+`"}`, string(event.Payload))
+						return nil
+					})
+			},
+			wantErr: false,
+		},
+		{
+			name: "ошибка выполнения запроса в репозитории",
+			beforeTest: func(outboxRepo mocks.MockIOutboxRepository) {
+				outboxRepo.EXPECT().
+					Add(context.Background(), gomock.Any()).
+					Return(fmt.Errorf("sql error"))
+			},
+			wantErr: true,
+			errStr:  errors.New("публикация события: sql error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.beforeTest != nil {
+				tc.beforeTest(*outboxRepo)
+			}
+
+			err := bus.Publish(context.Background(), domain.RatingChanged{UserID: userId})
+
+			if tc.wantErr {
+				require.Equal(t, tc.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestBus_RunOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	companyId := uuid.UUID{1}
+	payload := []byte(`{"id":"` + companyId.String() + `","name":"company"}`)
+
+	testCases := []struct {
+		name          string
+		attempts      int
+		handlerErr    error
+		beforeTest    func(outboxRepo *mocks.MockIOutboxRepository, event *domain.OutboxEvent)
+		wantNumEvents int
+	}{
+		{
+			name: "событие передаётся подписчику и отмечается обработанным",
+			beforeTest: func(outboxRepo *mocks.MockIOutboxRepository, event *domain.OutboxEvent) {
+				outboxRepo.EXPECT().MarkProcessed(gomock.Any(), event.ID).Return(nil)
+			},
+			wantNumEvents: 1,
+		},
+		{
+			name:       "ошибка подписчика откладывает повтор",
+			attempts:   2,
+			handlerErr: fmt.Errorf("handler error"),
+			beforeTest: func(outboxRepo *mocks.MockIOutboxRepository, event *domain.OutboxEvent) {
+				outboxRepo.EXPECT().
+					MarkFailed(gomock.Any(), event.ID, "handler error", now.Add(20*time.Second)).
+					Return(nil)
+			},
+			wantNumEvents: 1,
+		},
+		{
+			name:       "последняя попытка завершает обработку",
+			attempts:   MaxAttempts - 1,
+			handlerErr: fmt.Errorf("handler error"),
+			beforeTest: func(outboxRepo *mocks.MockIOutboxRepository, event *domain.OutboxEvent) {
+				outboxRepo.EXPECT().
+					MarkFailed(gomock.Any(), event.ID, "handler error", gomock.Any()).
+					Return(nil)
+				outboxRepo.EXPECT().MarkProcessed(gomock.Any(), event.ID).Return(nil)
+			},
+			wantNumEvents: 1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			outboxRepo := mocks.NewMockIOutboxRepository(ctrl)
+			bus := NewBus(outboxRepo, newTransactor(ctrl))
+			bus.now = func() time.Time { return now }
+
+			var received domain.Event
+			bus.Subscribe(domain.EventCompanyCreated, func(_ context.Context, event *domain.OutboxEvent) error {
+				received = event.Event
+				return tc.handlerErr
+			})
+
+			event := &domain.OutboxEvent{
+				ID:       uuid.New(),
+				Type:     domain.EventCompanyCreated,
+				Payload:  payload,
+				Attempts: tc.attempts,
+			}
+
+			gomock.InOrder(
+				outboxRepo.EXPECT().ClaimNext(gomock.Any(), now).Return(event, nil),
+				outboxRepo.EXPECT().ClaimNext(gomock.Any(), now).Return(nil, nil),
+			)
+			tc.beforeTest(outboxRepo, event)
+
+			numEvents, err := bus.RunOnce(context.Background())
+			require.Nil(t, err)
+			require.Equal(t, tc.wantNumEvents, numEvents)
+
+			require.Equal(t, domain.CompanyCreated{ID: companyId, Name: "company"}, received)
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	require.Equal(t, 5*time.Second, Backoff(1))
+	require.Equal(t, 20*time.Second, Backoff(3))
+	require.Equal(t, time.Hour, Backoff(100))
+}
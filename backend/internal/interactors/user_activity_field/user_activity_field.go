@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math"
 	"ppo/domain"
+	"ppo/internal/config"
 	"time"
 
 	"github.com/google/uuid"
@@ -21,6 +22,8 @@ type Interactor struct {
 	actFieldService domain.IActivityFieldService
 	compService     domain.ICompanyService
 	finService      domain.IFinancialReportService
+	tax             config.TaxConfig
+	rating          config.RatingConfig
 }
 
 func NewInteractor(
@@ -28,12 +31,16 @@ func NewInteractor(
 	actFieldSvc domain.IActivityFieldService,
 	compSvc domain.ICompanyService,
 	finSvc domain.IFinancialReportService,
+	tax config.TaxConfig,
+	rating config.RatingConfig,
 ) *Interactor {
 	return &Interactor{
 		userService:     userSvc,
 		actFieldService: actFieldSvc,
 		compService:     compSvc,
 		finService:      finSvc,
+		tax:             tax,
+		rating:          rating,
 	}
 }
 
@@ -42,27 +49,13 @@ type taxesData struct {
 	revenue float32
 }
 
-func calculateTaxes(reports map[int]*domain.FinancialReportByPeriod) (taxes *taxesData) {
+func calculateTaxes(reports map[int]*domain.FinancialReportByPeriod, tax *config.TaxConfig) (taxes *taxesData) {
 	taxes = new(taxesData)
 
 	for _, v := range reports {
 		if len(v.Reports) == quartersInYear {
 			totalProfit := v.Profit()
-			var taxFare int
-			switch true {
-			case totalProfit < 10000000:
-				taxFare = 4
-			case totalProfit < 50000000:
-				taxFare = 7
-			case totalProfit < 150000000:
-				taxFare = 13
-			case totalProfit < 500000000:
-				taxFare = 20
-			default:
-				taxFare = 30
-			}
-
-			v.Taxes = totalProfit * (float32(taxFare) / 100)
+			v.Taxes = totalProfit * (tax.Rate(totalProfit) / 100)
 
 			taxes.taxes += v.Taxes
 			taxes.revenue += v.Revenue()
@@ -115,8 +108,10 @@ func findFullYearReports(rep *domain.FinancialReportByPeriod, period *domain.Per
 	return fullYearReports
 }
 
-func calcRating(profit, revenue, cost, maxCost float32) float32 {
-	return (cost/maxCost + profit/revenue) / 2.0
+// calcRating — взвешенное среднее относительного веса сферы деятельности и рентабельности.
+func calcRating(profit, revenue, cost, maxCost float32, weights *config.RatingConfig) float32 {
+	return (weights.FieldWeight*cost/maxCost + weights.ProfitabilityWeight*profit/revenue) /
+		(weights.FieldWeight + weights.ProfitabilityWeight)
 }
 
 func (i *Interactor) GetMostProfitableCompany(ctx context.Context, period *domain.Period, companies []*domain.Company) (company *domain.Company, err error) {
@@ -138,11 +133,6 @@ func (i *Interactor) GetMostProfitableCompany(ctx context.Context, period *domai
 }
 
 func (i *Interactor) CalculateUserRating(ctx context.Context, id uuid.UUID) (rating float32, err error) {
-	companies, _, err := i.compService.GetByOwnerId(ctx, id, 0, false)
-	if err != nil {
-		return 0, fmt.Errorf("получение списка компаний: %w", err)
-	}
-
 	prevYear := time.Now().AddDate(-1, 0, 0).Year()
 	period := &domain.Period{
 		StartYear:    prevYear,
@@ -151,67 +141,254 @@ func (i *Interactor) CalculateUserRating(ctx context.Context, id uuid.UUID) (rat
 		EndQuarter:   lastQuarter,
 	}
 
+	snapshot, err := i.calculateRating(ctx, id, period)
+	if err != nil {
+		return 0, err
+	}
+
+	return snapshot.Rating, nil
+}
+
+// CalculateRatingSnapshot рассчитывает рейтинг на конец квартала quarter года year
+// по отчётам за четыре квартала, заканчивающиеся этим кварталом. Если asOf не
+// нулевой, используются версии отчётов, известные на этот момент.
+func (i *Interactor) CalculateRatingSnapshot(ctx context.Context, id uuid.UUID, year, quarter int, asOf time.Time) (snapshot *domain.RatingSnapshot, err error) {
+	startYear, startQuarter := year, quarter-quartersInYear+1
+	if startQuarter < firstQuarter {
+		startYear--
+		startQuarter += quartersInYear
+	}
+
+	period := &domain.Period{
+		StartYear:    startYear,
+		EndYear:      year,
+		StartQuarter: startQuarter,
+		EndQuarter:   quarter,
+		AsOf:         asOf,
+	}
+
+	snapshot, err = i.calculateRating(ctx, id, period)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot.UserID = id
+	snapshot.Year = year
+	snapshot.Quarter = quarter
+
+	return snapshot, nil
+}
+
+func (i *Interactor) calculateRating(ctx context.Context, id uuid.UUID, period *domain.Period) (snapshot *domain.RatingSnapshot, err error) {
+	period.VerifiedOnly = i.rating.VerifiedOnly
+
+	companies, _, err := i.compService.GetByOwnerId(ctx, id, 0, false)
+	if err != nil {
+		return nil, fmt.Errorf("получение списка компаний: %w", err)
+	}
+
 	report, err := i.GetUserFinancialReport(ctx, id, period)
 	if err != nil {
-		return 0, fmt.Errorf("получение финансового отчета пользователя: %w", err)
+		return nil, fmt.Errorf("получение финансового отчета пользователя: %w", err)
+	}
+
+	snapshot = &domain.RatingSnapshot{
+		Revenue: report.Revenue(),
+		Profit:  report.Profit(),
 	}
 
 	mostProfitableCompany, err := i.GetMostProfitableCompany(ctx, period, companies)
 	if err != nil {
-		return 0, fmt.Errorf("поиск наиболее прибыльной компании: %w", err)
+		return nil, fmt.Errorf("поиск наиболее прибыльной компании: %w", err)
 	}
 	if mostProfitableCompany == nil {
-		return 0.0, nil
+		return snapshot, nil
 	}
 
 	maxCost, err := i.actFieldService.GetMaxCost(ctx)
 	if err != nil {
-		return 0, fmt.Errorf("поиск максимального веса: %w", err)
+		return nil, fmt.Errorf("поиск максимального веса: %w", err)
 	}
 
 	cost, err := i.actFieldService.GetCostByCompanyId(ctx, mostProfitableCompany.ID)
 	if err != nil {
-		return 0, fmt.Errorf("получение веса сферы деятельности компании: %w", err)
+		return nil, fmt.Errorf("получение веса сферы деятельности компании: %w", err)
 	}
 
-	var totalRevenue, totalProfit float32
-	totalRevenue = report.Revenue()
-	totalProfit = report.Profit()
+	snapshot.CompanyID = mostProfitableCompany.ID
+	snapshot.FieldCost = cost
+	snapshot.MaxCost = maxCost
+	snapshot.Rating = calcRating(snapshot.Profit, snapshot.Revenue, cost, maxCost, &i.rating)
 
-	rating = calcRating(totalProfit, totalRevenue, cost, maxCost)
-
-	return rating, nil
+	return snapshot, nil
 }
 
 func (i *Interactor) GetUserFinancialReport(ctx context.Context, id uuid.UUID, period *domain.Period) (report *domain.FinancialReportByPeriod, err error) {
-	report = new(domain.FinancialReportByPeriod)
-
 	companies, _, err := i.compService.GetByOwnerId(ctx, id, 0, false)
 	if err != nil {
 		return nil, fmt.Errorf("получение списка компаний: %w", err)
 	}
 
-	var revenueForTaxLoad float32
-	report.Reports = make([]domain.FinancialReport, 0)
+	reports := make(map[uuid.UUID]*domain.FinancialReportByPeriod, len(companies))
 	for _, comp := range companies {
-		rep, err := i.finService.GetByCompany(ctx, comp.ID, period)
+		reports[comp.ID], err = i.finService.GetByCompany(ctx, comp.ID, period)
 		if err != nil {
 			return nil, fmt.Errorf("получение отчета компании: %w", err)
 		}
+	}
+
+	return i.summarize(companies, reports, period), nil
+}
+
+// GetUserFinancialReports собирает отчёты нескольких пользователей так же, как
+// GetUserFinancialReport, но загружает компании и их отчёты двумя запросами.
+func (i *Interactor) GetUserFinancialReports(ctx context.Context, ids []uuid.UUID, period *domain.Period) (
+	reports map[uuid.UUID]*domain.FinancialReportByPeriod, err error) {
+	byOwner, companyReports, err := i.loadOwnerReports(ctx, ids, period)
+	if err != nil {
+		return nil, err
+	}
+
+	reports = make(map[uuid.UUID]*domain.FinancialReportByPeriod, len(ids))
+	for _, id := range ids {
+		reports[id] = i.summarize(byOwner[id], companyReports, period)
+	}
+
+	return reports, nil
+}
+
+// CalculateUserRatings рассчитывает рейтинги так же, как CalculateUserRating.
+func (i *Interactor) CalculateUserRatings(ctx context.Context, ids []uuid.UUID) (ratings map[uuid.UUID]float32, err error) {
+	prevYear := time.Now().AddDate(-1, 0, 0).Year()
+	period := &domain.Period{
+		StartYear:    prevYear,
+		EndYear:      prevYear,
+		StartQuarter: firstQuarter,
+		EndQuarter:   lastQuarter,
+		VerifiedOnly: i.rating.VerifiedOnly,
+	}
 
+	byOwner, reports, err := i.loadOwnerReports(ctx, ids, period)
+	if err != nil {
+		return nil, err
+	}
+
+	ratings = make(map[uuid.UUID]float32, len(ids))
+	best := make(map[uuid.UUID]*domain.Company, len(ids))
+	fieldIds := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		ratings[id] = 0
+		if company := mostProfitable(byOwner[id], reports); company != nil {
+			best[id] = company
+			fieldIds = append(fieldIds, company.ActivityFieldId)
+		}
+	}
+
+	if len(best) == 0 {
+		return ratings, nil
+	}
+
+	maxCost, err := i.actFieldService.GetMaxCost(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("поиск максимального веса: %w", err)
+	}
+
+	fields, err := i.actFieldService.GetByIds(ctx, fieldIds)
+	if err != nil {
+		return nil, fmt.Errorf("получение сфер деятельности компаний: %w", err)
+	}
+
+	costs := make(map[uuid.UUID]float32, len(fields))
+	for _, field := range fields {
+		costs[field.ID] = field.Cost
+	}
+
+	for id, company := range best {
+		cost, ok := costs[company.ActivityFieldId]
+		if !ok {
+			return nil, fmt.Errorf("получение веса сферы деятельности компании: %w", domain.ErrNotFound)
+		}
+
+		var revenue, profit float32
+		for _, comp := range byOwner[id] {
+			revenue += reports[comp.ID].Revenue()
+			profit += reports[comp.ID].Profit()
+		}
+
+		ratings[id] = calcRating(profit, revenue, cost, maxCost, &i.rating)
+	}
+
+	return ratings, nil
+}
+
+// loadOwnerReports загружает компании пользователей ids, сгруппированные по
+// владельцу, и отчёты этих компаний за период.
+func (i *Interactor) loadOwnerReports(ctx context.Context, ids []uuid.UUID, period *domain.Period) (
+	byOwner map[uuid.UUID][]*domain.Company, reports map[uuid.UUID]*domain.FinancialReportByPeriod, err error) {
+	companies, err := i.compService.GetByOwnerIds(ctx, ids)
+	if err != nil {
+		return nil, nil, fmt.Errorf("получение списка компаний: %w", err)
+	}
+
+	byOwner = make(map[uuid.UUID][]*domain.Company, len(ids))
+	companyIds := make([]uuid.UUID, len(companies))
+	for j, comp := range companies {
+		byOwner[comp.OwnerID] = append(byOwner[comp.OwnerID], comp)
+		companyIds[j] = comp.ID
+	}
+
+	reports = make(map[uuid.UUID]*domain.FinancialReportByPeriod)
+	if len(companyIds) > 0 {
+		reports, err = i.finService.GetByCompanies(ctx, companyIds, period)
+		if err != nil {
+			return nil, nil, fmt.Errorf("получение отчетов компаний: %w", err)
+		}
+	}
+
+	for _, comp := range companies {
+		if reports[comp.ID] == nil {
+			reports[comp.ID] = &domain.FinancialReportByPeriod{Period: period}
+		}
+	}
+
+	return byOwner, reports, nil
+}
+
+// summarize объединяет отчёты компаний пользователя в один отчёт за период
+// и считает налоги по полным годам каждой компании.
+func (i *Interactor) summarize(companies []*domain.Company, reports map[uuid.UUID]*domain.FinancialReportByPeriod,
+	period *domain.Period) (report *domain.FinancialReportByPeriod) {
+	report = &domain.FinancialReportByPeriod{Reports: make([]domain.FinancialReport, 0), Period: period}
+
+	var revenueForTaxLoad float32
+	for _, comp := range companies {
+		rep := reports[comp.ID]
 		fullYears := findFullYearReports(rep, period)
 
-		tax := calculateTaxes(fullYears)
+		tax := calculateTaxes(fullYears, &i.tax)
 		report.Taxes += tax.taxes
 		revenueForTaxLoad += tax.revenue
 
 		report.Reports = append(report.Reports, rep.Reports...)
 	}
 
-	report.Period = period
 	if math.Abs(float64(revenueForTaxLoad)) >= 1e-6 {
 		report.TaxLoad = report.Taxes / revenueForTaxLoad * 100
 	}
 
-	return report, nil
+	return report
+}
+
+// mostProfitable возвращает компанию с наибольшей положительной прибылью за период
+// или nil, если прибыльных компаний нет.
+func mostProfitable(companies []*domain.Company, reports map[uuid.UUID]*domain.FinancialReportByPeriod) (company *domain.Company) {
+	var maxProfit float32
+	for _, comp := range companies {
+		if profit := reports[comp.ID].Profit(); profit > maxProfit {
+			company = comp
+			maxProfit = profit
+		}
+	}
+
+	return company
 }
@@ -2,16 +2,20 @@ package user_activity_field
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"ppo/domain"
+	"ppo/internal/config"
 	"ppo/internal/services/activity_field"
 	"ppo/internal/services/company"
 	"ppo/internal/services/fin_report"
 	"ppo/internal/services/user"
 	"ppo/mocks"
 	"testing"
+	"time"
 )
 
 const eps = 1e-7
@@ -25,12 +29,14 @@ func TestInteractor_CalculateUserRating(t *testing.T) {
 	compRepo := mocks.NewMockICompanyRepository(ctrl)
 	actFieldRepo := mocks.NewMockIActivityFieldRepository(ctrl)
 
-	userSvc := user.NewService(userRepo, compRepo, actFieldRepo)
+	userSvc := user.NewService(userRepo, compRepo, actFieldRepo, nil)
 	actFieldSvc := activity_field.NewService(actFieldRepo, compRepo)
-	compSvc := company.NewService(compRepo)
-	finSvc := fin_report.NewService(finRepo)
+	compSvc := company.NewService(compRepo, actFieldRepo, nil, nil)
+	finSvc := fin_report.NewService(finRepo, nil, nil, nil)
 
-	interactor := NewInteractor(userSvc, actFieldSvc, compSvc, finSvc)
+	interactor := NewInteractor(userSvc, actFieldSvc, compSvc, finSvc, config.Default().Tax, config.Default().Rating)
+
+	prevYear := time.Now().AddDate(-1, 0, 0).Year()
 
 	testCases := []struct {
 		name       string
@@ -65,7 +71,7 @@ func TestInteractor_CalculateUserRating(t *testing.T) {
 								Name:    "b",
 								City:    "b",
 							},
-						}, nil).AnyTimes()
+						}, 1, nil).AnyTimes()
 
 				compRepo.EXPECT().
 					GetById(
@@ -100,8 +106,8 @@ func TestInteractor_CalculateUserRating(t *testing.T) {
 						context.Background(),
 						uuid.UUID{1},
 						&domain.Period{
-							StartYear:    2023,
-							EndYear:      2023,
+							StartYear:    prevYear,
+							EndYear:      prevYear,
 							StartQuarter: 1,
 							EndQuarter:   4,
 						},
@@ -110,7 +116,7 @@ func TestInteractor_CalculateUserRating(t *testing.T) {
 						Reports: []domain.FinancialReport{
 							{
 								ID:        uuid.UUID{8},
-								Year:      2023,
+								Year:      prevYear,
 								Quarter:   1,
 								Revenue:   32532513,
 								Costs:     5436438,
@@ -118,7 +124,7 @@ func TestInteractor_CalculateUserRating(t *testing.T) {
 							},
 							{
 								ID:        uuid.UUID{9},
-								Year:      2023,
+								Year:      prevYear,
 								Quarter:   2,
 								Revenue:   6743634,
 								Costs:     9876967,
@@ -126,7 +132,7 @@ func TestInteractor_CalculateUserRating(t *testing.T) {
 							},
 							{
 								ID:        uuid.UUID{10},
-								Year:      2023,
+								Year:      prevYear,
 								Quarter:   3,
 								Revenue:   4675424,
 								Costs:     2436653,
@@ -134,7 +140,7 @@ func TestInteractor_CalculateUserRating(t *testing.T) {
 							},
 							{
 								ID:        uuid.UUID{11},
-								Year:      2023,
+								Year:      prevYear,
 								Quarter:   4,
 								Revenue:   14385253,
 								Costs:     7546424,
@@ -142,8 +148,8 @@ func TestInteractor_CalculateUserRating(t *testing.T) {
 							},
 						},
 						Period: &domain.Period{
-							StartYear:    2023,
-							EndYear:      2023,
+							StartYear:    prevYear,
+							EndYear:      prevYear,
 							StartQuarter: 1,
 							EndQuarter:   4,
 						},
@@ -154,8 +160,8 @@ func TestInteractor_CalculateUserRating(t *testing.T) {
 						context.Background(),
 						uuid.UUID{2},
 						&domain.Period{
-							StartYear:    2023,
-							EndYear:      2023,
+							StartYear:    prevYear,
+							EndYear:      prevYear,
 							StartQuarter: 1,
 							EndQuarter:   4,
 						},
@@ -164,7 +170,7 @@ func TestInteractor_CalculateUserRating(t *testing.T) {
 						Reports: []domain.FinancialReport{
 							{
 								ID:        uuid.UUID{8},
-								Year:      2023,
+								Year:      prevYear,
 								Quarter:   1,
 								Revenue:   3253251,
 								Costs:     543643,
@@ -172,7 +178,7 @@ func TestInteractor_CalculateUserRating(t *testing.T) {
 							},
 							{
 								ID:        uuid.UUID{9},
-								Year:      2023,
+								Year:      prevYear,
 								Quarter:   2,
 								Revenue:   6743634,
 								Costs:     9876967,
@@ -180,7 +186,7 @@ func TestInteractor_CalculateUserRating(t *testing.T) {
 							},
 							{
 								ID:        uuid.UUID{10},
-								Year:      2023,
+								Year:      prevYear,
 								Quarter:   3,
 								Revenue:   4675412,
 								Costs:     2436765,
@@ -188,7 +194,7 @@ func TestInteractor_CalculateUserRating(t *testing.T) {
 							},
 							{
 								ID:        uuid.UUID{11},
-								Year:      2023,
+								Year:      prevYear,
 								Quarter:   4,
 								Revenue:   1438525,
 								Costs:     754642,
@@ -196,8 +202,8 @@ func TestInteractor_CalculateUserRating(t *testing.T) {
 							},
 						},
 						Period: &domain.Period{
-							StartYear:    2023,
-							EndYear:      2023,
+							StartYear:    prevYear,
+							EndYear:      prevYear,
 							StartQuarter: 1,
 							EndQuarter:   4,
 						},
@@ -225,6 +231,157 @@ func TestInteractor_CalculateUserRating(t *testing.T) {
 	}
 }
 
+func TestInteractor_CalculateRatingSnapshot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockIUserRepository(ctrl)
+	finRepo := mocks.NewMockIFinancialReportRepository(ctrl)
+	compRepo := mocks.NewMockICompanyRepository(ctrl)
+	actFieldRepo := mocks.NewMockIActivityFieldRepository(ctrl)
+
+	userSvc := user.NewService(userRepo, compRepo, actFieldRepo, nil)
+	actFieldSvc := activity_field.NewService(actFieldRepo, compRepo)
+	compSvc := company.NewService(compRepo, actFieldRepo, nil, nil)
+	finSvc := fin_report.NewService(finRepo, nil, nil, nil)
+
+	interactor := NewInteractor(userSvc, actFieldSvc, compSvc, finSvc, config.Default().Tax, config.Default().Rating)
+
+	// окно снимка за 2 квартал 2023 года начинается с 3 квартала 2022 года
+	period := &domain.Period{StartYear: 2022, EndYear: 2023, StartQuarter: 3, EndQuarter: 2}
+	company := &domain.Company{ID: uuid.UUID{1}, OwnerID: uuid.UUID{1}, ActivityFieldId: uuid.UUID{1}}
+
+	testCases := []struct {
+		name       string
+		beforeTest func()
+		wantErr    bool
+		expected   *domain.RatingSnapshot
+		errStr     error
+	}{
+		{
+			name: "успешный расчёт снимка",
+			beforeTest: func() {
+				compRepo.EXPECT().
+					GetByOwnerId(context.Background(), uuid.UUID{1}, 0, false).
+					Return([]*domain.Company{company}, 1, nil).
+					Times(2)
+				finRepo.EXPECT().
+					GetByCompany(context.Background(), uuid.UUID{1}, period).
+					Return(&domain.FinancialReportByPeriod{
+						Reports: []domain.FinancialReport{
+							{Year: 2022, Quarter: 3, Revenue: 100, Costs: 40, CompanyID: uuid.UUID{1}},
+							{Year: 2023, Quarter: 2, Revenue: 100, Costs: 60, CompanyID: uuid.UUID{1}},
+						},
+						Period: period,
+					}, nil).
+					Times(2)
+				actFieldRepo.EXPECT().
+					GetMaxCost(context.Background()).
+					Return(float32(10), nil)
+				compRepo.EXPECT().
+					GetById(context.Background(), uuid.UUID{1}).
+					Return(company, nil)
+				actFieldRepo.EXPECT().
+					GetById(context.Background(), uuid.UUID{1}).
+					Return(&domain.ActivityField{ID: uuid.UUID{1}, Cost: 5}, nil)
+			},
+			expected: &domain.RatingSnapshot{
+				UserID:    uuid.UUID{1},
+				Year:      2023,
+				Quarter:   2,
+				Rating:    (5.0/10.0 + 100.0/200.0) / 2.0,
+				Revenue:   200,
+				Profit:    100,
+				CompanyID: uuid.UUID{1},
+				FieldCost: 5,
+				MaxCost:   10,
+			},
+		},
+		{
+			name: "нет прибыльных компаний",
+			beforeTest: func() {
+				compRepo.EXPECT().
+					GetByOwnerId(context.Background(), uuid.UUID{1}, 0, false).
+					Return([]*domain.Company{}, 0, nil).
+					Times(2)
+			},
+			expected: &domain.RatingSnapshot{
+				UserID:  uuid.UUID{1},
+				Year:    2023,
+				Quarter: 2,
+			},
+		},
+		{
+			name: "ошибка получения компаний",
+			beforeTest: func() {
+				compRepo.EXPECT().
+					GetByOwnerId(context.Background(), uuid.UUID{1}, 0, false).
+					Return(nil, 0, fmt.Errorf("sql error"))
+			},
+			wantErr: true,
+			errStr:  errors.New("получение списка компаний: получение списка компаний по id владельца: sql error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.beforeTest()
+
+			snapshot, err := interactor.CalculateRatingSnapshot(context.Background(), uuid.UUID{1}, 2023, 2, time.Time{})
+
+			if tc.wantErr {
+				require.Equal(t, tc.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+				require.Equal(t, tc.expected, snapshot)
+			}
+		})
+	}
+}
+
+func TestInteractor_CalculateRatingSnapshot_VerifiedOnly(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockIUserRepository(ctrl)
+	finRepo := mocks.NewMockIFinancialReportRepository(ctrl)
+	compRepo := mocks.NewMockICompanyRepository(ctrl)
+	actFieldRepo := mocks.NewMockIActivityFieldRepository(ctrl)
+
+	userSvc := user.NewService(userRepo, compRepo, actFieldRepo, nil)
+	actFieldSvc := activity_field.NewService(actFieldRepo, compRepo)
+	compSvc := company.NewService(compRepo, actFieldRepo, nil, nil)
+	finSvc := fin_report.NewService(finRepo, nil, nil, nil)
+
+	rating := config.Default().Rating
+	rating.VerifiedOnly = true
+	interactor := NewInteractor(userSvc, actFieldSvc, compSvc, finSvc, config.Default().Tax, rating)
+
+	period := &domain.Period{StartYear: 2022, EndYear: 2023, StartQuarter: 3, EndQuarter: 2, VerifiedOnly: true}
+	company := &domain.Company{ID: uuid.UUID{1}, OwnerID: uuid.UUID{1}, ActivityFieldId: uuid.UUID{1}}
+
+	compRepo.EXPECT().
+		GetByOwnerId(context.Background(), uuid.UUID{1}, 0, false).
+		Return([]*domain.Company{company}, 1, nil).
+		Times(2)
+	// неподтверждённые отчёты отбрасывает хранилище, поэтому за период
+	// приходит только один квартал и компания не приносит прибыли
+	finRepo.EXPECT().
+		GetByCompany(context.Background(), uuid.UUID{1}, period).
+		Return(&domain.FinancialReportByPeriod{
+			Reports: []domain.FinancialReport{
+				{Year: 2022, Quarter: 3, Revenue: 100, Costs: 100, CompanyID: uuid.UUID{1},
+					Status: domain.VerificationVerified},
+			},
+			Period: period,
+		}, nil).
+		Times(2)
+
+	snapshot, err := interactor.CalculateRatingSnapshot(context.Background(), uuid.UUID{1}, 2023, 2, time.Time{})
+
+	require.Nil(t, err)
+	require.Equal(t, &domain.RatingSnapshot{UserID: uuid.UUID{1}, Year: 2023, Quarter: 2, Revenue: 100}, snapshot)
+}
+
 func TestInteractor_GetMostProfitableCompany(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -234,12 +391,12 @@ func TestInteractor_GetMostProfitableCompany(t *testing.T) {
 	compRepo := mocks.NewMockICompanyRepository(ctrl)
 	actFieldRepo := mocks.NewMockIActivityFieldRepository(ctrl)
 
-	userSvc := user.NewService(userRepo, compRepo, actFieldRepo)
+	userSvc := user.NewService(userRepo, compRepo, actFieldRepo, nil)
 	actFieldSvc := activity_field.NewService(actFieldRepo, compRepo)
-	compSvc := company.NewService(compRepo)
-	finSvc := fin_report.NewService(finRepo)
+	compSvc := company.NewService(compRepo, actFieldRepo, nil, nil)
+	finSvc := fin_report.NewService(finRepo, nil, nil, nil)
 
-	interactor := NewInteractor(userSvc, actFieldSvc, compSvc, finSvc)
+	interactor := NewInteractor(userSvc, actFieldSvc, compSvc, finSvc, config.Default().Tax, config.Default().Rating)
 
 	testCases := []struct {
 		name       string
@@ -397,12 +554,12 @@ func TestInteractor_GetUserFinancialReport(t *testing.T) {
 	compRepo := mocks.NewMockICompanyRepository(ctrl)
 	actFieldRepo := mocks.NewMockIActivityFieldRepository(ctrl)
 
-	userSvc := user.NewService(userRepo, compRepo, actFieldRepo)
+	userSvc := user.NewService(userRepo, compRepo, actFieldRepo, nil)
 	actFieldSvc := activity_field.NewService(actFieldRepo, compRepo)
-	compSvc := company.NewService(compRepo)
-	finSvc := fin_report.NewService(finRepo)
+	compSvc := company.NewService(compRepo, actFieldRepo, nil, nil)
+	finSvc := fin_report.NewService(finRepo, nil, nil, nil)
 
-	interactor := NewInteractor(userSvc, actFieldSvc, compSvc, finSvc)
+	interactor := NewInteractor(userSvc, actFieldSvc, compSvc, finSvc, config.Default().Tax, config.Default().Rating)
 
 	testCases := []struct {
 		name       string
@@ -438,7 +595,7 @@ func TestInteractor_GetUserFinancialReport(t *testing.T) {
 								Name:    "b",
 								City:    "b",
 							},
-						}, nil)
+						}, 1, nil)
 
 				finRepo.EXPECT().
 					GetByCompany(
@@ -687,6 +844,257 @@ func TestInteractor_GetUserFinancialReport(t *testing.T) {
 	}
 }
 
+func TestInteractor_GetUserFinancialReports(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockIUserRepository(ctrl)
+	finRepo := mocks.NewMockIFinancialReportRepository(ctrl)
+	compRepo := mocks.NewMockICompanyRepository(ctrl)
+	actFieldRepo := mocks.NewMockIActivityFieldRepository(ctrl)
+
+	userSvc := user.NewService(userRepo, compRepo, actFieldRepo, nil)
+	actFieldSvc := activity_field.NewService(actFieldRepo, compRepo)
+	compSvc := company.NewService(compRepo, actFieldRepo, nil, nil)
+	finSvc := fin_report.NewService(finRepo, nil, nil, nil)
+
+	interactor := NewInteractor(userSvc, actFieldSvc, compSvc, finSvc, config.Default().Tax, config.Default().Rating)
+
+	period := &domain.Period{
+		StartYear:    2023,
+		EndYear:      2023,
+		StartQuarter: 1,
+		EndQuarter:   4,
+	}
+
+	testCases := []struct {
+		name       string
+		userIds    []uuid.UUID
+		beforeTest func(finRepo mocks.MockIFinancialReportRepository, compRepo mocks.MockICompanyRepository)
+		expected   map[uuid.UUID]*domain.FinancialReportByPeriod
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name:    "успешный тест",
+			userIds: []uuid.UUID{{1}, {2}},
+			beforeTest: func(finRepo mocks.MockIFinancialReportRepository, compRepo mocks.MockICompanyRepository) {
+				compRepo.EXPECT().
+					GetByOwnerIds(context.Background(), []uuid.UUID{{1}, {2}}).
+					Return([]*domain.Company{
+						{ID: uuid.UUID{1}, OwnerID: uuid.UUID{1}},
+						{ID: uuid.UUID{2}, OwnerID: uuid.UUID{1}},
+					}, nil)
+
+				finRepo.EXPECT().
+					GetByCompanies(context.Background(), []uuid.UUID{{1}, {2}}, period).
+					Return(map[uuid.UUID]*domain.FinancialReportByPeriod{
+						{1}: {
+							Reports: []domain.FinancialReport{
+								{ID: uuid.UUID{1}, CompanyID: uuid.UUID{1}, Revenue: 100, Costs: 50, Year: 2023, Quarter: 1},
+								{ID: uuid.UUID{2}, CompanyID: uuid.UUID{1}, Revenue: 100, Costs: 50, Year: 2023, Quarter: 2},
+								{ID: uuid.UUID{3}, CompanyID: uuid.UUID{1}, Revenue: 100, Costs: 50, Year: 2023, Quarter: 3},
+								{ID: uuid.UUID{4}, CompanyID: uuid.UUID{1}, Revenue: 100, Costs: 50, Year: 2023, Quarter: 4},
+							},
+							Period: period,
+						},
+						{2}: {
+							Reports: []domain.FinancialReport{
+								{ID: uuid.UUID{5}, CompanyID: uuid.UUID{2}, Revenue: 75, Costs: 50, Year: 2023, Quarter: 1},
+							},
+							Period: period,
+						},
+					}, nil)
+			},
+			expected: map[uuid.UUID]*domain.FinancialReportByPeriod{
+				{1}: {
+					Reports: []domain.FinancialReport{
+						{ID: uuid.UUID{1}, CompanyID: uuid.UUID{1}, Revenue: 100, Costs: 50, Year: 2023, Quarter: 1},
+						{ID: uuid.UUID{2}, CompanyID: uuid.UUID{1}, Revenue: 100, Costs: 50, Year: 2023, Quarter: 2},
+						{ID: uuid.UUID{3}, CompanyID: uuid.UUID{1}, Revenue: 100, Costs: 50, Year: 2023, Quarter: 3},
+						{ID: uuid.UUID{4}, CompanyID: uuid.UUID{1}, Revenue: 100, Costs: 50, Year: 2023, Quarter: 4},
+						{ID: uuid.UUID{5}, CompanyID: uuid.UUID{2}, Revenue: 75, Costs: 50, Year: 2023, Quarter: 1},
+					},
+					Period:  period,
+					Taxes:   float32((100 - 50) * 4 * 0.04),
+					TaxLoad: float32((100 - 50) * 4 * 0.04 / (100 * 4) * 100),
+				},
+				{2}: {
+					Reports: []domain.FinancialReport{},
+					Period:  period,
+				},
+			},
+		},
+		{
+			name:    "ошибка получения компаний",
+			userIds: []uuid.UUID{{1}},
+			beforeTest: func(finRepo mocks.MockIFinancialReportRepository, compRepo mocks.MockICompanyRepository) {
+				compRepo.EXPECT().
+					GetByOwnerIds(context.Background(), []uuid.UUID{{1}}).
+					Return(nil, fmt.Errorf("sql error"))
+			},
+			wantErr: true,
+			errStr:  errors.New("получение списка компаний: получение компаний по списку id владельцев: sql error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.beforeTest != nil {
+				tc.beforeTest(*finRepo, *compRepo)
+			}
+
+			reports, err := interactor.GetUserFinancialReports(ctx, tc.userIds, period)
+
+			if tc.wantErr {
+				require.Equal(t, tc.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+				require.Len(t, reports, len(tc.expected))
+				for id, expected := range tc.expected {
+					require.Equal(t, expected.Reports, reports[id].Reports)
+					require.Equal(t, expected.Period, reports[id].Period)
+					require.InDelta(t, expected.Taxes, reports[id].Taxes, eps)
+					require.InDelta(t, expected.TaxLoad, reports[id].TaxLoad, eps)
+				}
+			}
+		})
+	}
+}
+
+func TestInteractor_CalculateUserRatings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockIUserRepository(ctrl)
+	finRepo := mocks.NewMockIFinancialReportRepository(ctrl)
+	compRepo := mocks.NewMockICompanyRepository(ctrl)
+	actFieldRepo := mocks.NewMockIActivityFieldRepository(ctrl)
+
+	userSvc := user.NewService(userRepo, compRepo, actFieldRepo, nil)
+	actFieldSvc := activity_field.NewService(actFieldRepo, compRepo)
+	compSvc := company.NewService(compRepo, actFieldRepo, nil, nil)
+	finSvc := fin_report.NewService(finRepo, nil, nil, nil)
+
+	interactor := NewInteractor(userSvc, actFieldSvc, compSvc, finSvc, config.Default().Tax, config.Default().Rating)
+
+	prevYear := time.Now().AddDate(-1, 0, 0).Year()
+	period := &domain.Period{
+		StartYear:    prevYear,
+		EndYear:      prevYear,
+		StartQuarter: 1,
+		EndQuarter:   4,
+	}
+
+	testCases := []struct {
+		name       string
+		userIds    []uuid.UUID
+		beforeTest func(
+			finRepo mocks.MockIFinancialReportRepository,
+			compRepo mocks.MockICompanyRepository,
+			actFieldRepo mocks.MockIActivityFieldRepository,
+		)
+		expected map[uuid.UUID]float32
+		wantErr  bool
+		errStr   error
+	}{
+		{
+			name:    "успешное вычисление рейтингов",
+			userIds: []uuid.UUID{{1}, {2}, {3}},
+			beforeTest: func(finRepo mocks.MockIFinancialReportRepository, compRepo mocks.MockICompanyRepository, actFieldRepo mocks.MockIActivityFieldRepository) {
+				compRepo.EXPECT().
+					GetByOwnerIds(context.Background(), []uuid.UUID{{1}, {2}, {3}}).
+					Return([]*domain.Company{
+						{ID: uuid.UUID{1}, OwnerID: uuid.UUID{1}, ActivityFieldId: uuid.UUID{1}},
+						{ID: uuid.UUID{2}, OwnerID: uuid.UUID{1}, ActivityFieldId: uuid.UUID{2}},
+						{ID: uuid.UUID{3}, OwnerID: uuid.UUID{2}, ActivityFieldId: uuid.UUID{2}},
+					}, nil)
+
+				finRepo.EXPECT().
+					GetByCompanies(context.Background(), []uuid.UUID{{1}, {2}, {3}}, period).
+					Return(map[uuid.UUID]*domain.FinancialReportByPeriod{
+						{1}: {
+							Reports: []domain.FinancialReport{
+								{CompanyID: uuid.UUID{1}, Revenue: 400, Costs: 100, Year: prevYear, Quarter: 1},
+							},
+							Period: period,
+						},
+						{2}: {
+							Reports: []domain.FinancialReport{
+								{CompanyID: uuid.UUID{2}, Revenue: 100, Costs: 200, Year: prevYear, Quarter: 1},
+							},
+							Period: period,
+						},
+						{3}: {
+							Reports: []domain.FinancialReport{
+								{CompanyID: uuid.UUID{3}, Revenue: 100, Costs: 300, Year: prevYear, Quarter: 1},
+							},
+							Period: period,
+						},
+					}, nil)
+
+				actFieldRepo.EXPECT().
+					GetMaxCost(context.Background()).
+					Return(float32(10), nil)
+
+				actFieldRepo.EXPECT().
+					GetByIds(context.Background(), []uuid.UUID{{1}}).
+					Return([]*domain.ActivityField{{ID: uuid.UUID{1}, Cost: 5}}, nil)
+			},
+			expected: map[uuid.UUID]float32{
+				{1}: (5.0/10.0 + float32(400+100-100-200)/float32(400+100)) / 2.0,
+				{2}: 0,
+				{3}: 0,
+			},
+		},
+		{
+			name:    "у пользователей нет компаний",
+			userIds: []uuid.UUID{{1}},
+			beforeTest: func(finRepo mocks.MockIFinancialReportRepository, compRepo mocks.MockICompanyRepository, actFieldRepo mocks.MockIActivityFieldRepository) {
+				compRepo.EXPECT().
+					GetByOwnerIds(context.Background(), []uuid.UUID{{1}}).
+					Return([]*domain.Company{}, nil)
+			},
+			expected: map[uuid.UUID]float32{{1}: 0},
+		},
+		{
+			name:    "ошибка получения отчетов",
+			userIds: []uuid.UUID{{1}},
+			beforeTest: func(finRepo mocks.MockIFinancialReportRepository, compRepo mocks.MockICompanyRepository, actFieldRepo mocks.MockIActivityFieldRepository) {
+				compRepo.EXPECT().
+					GetByOwnerIds(context.Background(), []uuid.UUID{{1}}).
+					Return([]*domain.Company{{ID: uuid.UUID{1}, OwnerID: uuid.UUID{1}}}, nil)
+
+				finRepo.EXPECT().
+					GetByCompanies(context.Background(), []uuid.UUID{{1}}, period).
+					Return(nil, fmt.Errorf("sql error"))
+			},
+			wantErr: true,
+			errStr:  errors.New("получение отчетов компаний: получение финансовых отчетов по списку id компаний: sql error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.beforeTest != nil {
+				tc.beforeTest(*finRepo, *compRepo, *actFieldRepo)
+			}
+
+			ratings, err := interactor.CalculateUserRatings(ctx, tc.userIds)
+
+			if tc.wantErr {
+				require.Equal(t, tc.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+				require.Len(t, ratings, len(tc.expected))
+				for id, expected := range tc.expected {
+					require.InDelta(t, expected, ratings[id], eps)
+				}
+			}
+		})
+	}
+}
+
 func Test_calcRating(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -707,7 +1115,7 @@ func Test_calcRating(t *testing.T) {
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			rating := calcRating(tc.profit, tc.revenue, tc.cost, tc.maxCost)
+			rating := calcRating(tc.profit, tc.revenue, tc.cost, tc.maxCost, &config.Default().Rating)
 
 			require.InEpsilon(t, tc.expected, rating, eps)
 		})
@@ -811,7 +1219,7 @@ func Test_calculateTaxes(t *testing.T) {
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			tax := calculateTaxes(tc.reports)
+			tax := calculateTaxes(tc.reports, &config.Default().Tax)
 
 			require.InEpsilon(t, tc.expected.taxes, tax.taxes, eps)
 			require.InEpsilon(t, tc.expected.revenue, tax.revenue, eps)
@@ -0,0 +1,263 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"ppo/domain"
+	"ppo/pkg/cron"
+	"sync"
+	"time"
+)
+
+const DefaultInterval = 30 * time.Second
+
+// JobFunc — тело фоновой задачи. Ошибка сохраняется в журнале запусков.
+type JobFunc func(context.Context) error
+
+type job struct {
+	name     string
+	spec     string
+	schedule *cron.Schedule
+	fn       JobFunc
+}
+
+// Scheduler запускает зарегистрированные задачи по расписанию cron и хранит журнал запусков.
+// Несколько экземпляров сервера могут работать одновременно: перед запуском задача
+// захватывает блокировку в БД, поэтому её выполняет только один из них.
+type Scheduler struct {
+	jobRepo   domain.IJobRepository
+	now       func() time.Time
+	startedAt time.Time
+
+	mu   sync.RWMutex
+	jobs []*job
+
+	// triggered учитывает задачи, запущенные через Trigger и ещё не завершившиеся
+	triggered sync.WaitGroup
+}
+
+func NewScheduler(jobRepo domain.IJobRepository) *Scheduler {
+	return &Scheduler{
+		jobRepo:   jobRepo,
+		now:       time.Now,
+		startedAt: time.Now(),
+	}
+}
+
+// Register добавляет задачу name с расписанием spec. Задача, которая ещё ни разу
+// не запускалась, впервые выполняется в ближайшее по расписанию время после старта.
+func (s *Scheduler) Register(name, spec string, fn JobFunc) error {
+	schedule, err := cron.Parse(spec)
+	if err != nil {
+		return fmt.Errorf("регистрация задачи %s: %w", name, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.find(name) != nil {
+		return fmt.Errorf("регистрация задачи %s: задача уже зарегистрирована", name)
+	}
+
+	s.jobs = append(s.jobs, &job{name: name, spec: spec, schedule: schedule, fn: fn})
+
+	return nil
+}
+
+func (s *Scheduler) find(name string) *job {
+	for _, j := range s.jobs {
+		if j.name == name {
+			return j
+		}
+	}
+
+	return nil
+}
+
+func (s *Scheduler) lookup(name string) (*job, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	j := s.find(name)
+	if j == nil {
+		return nil, domain.NewNotFoundError(fmt.Errorf("задача %s не зарегистрирована", name))
+	}
+
+	return j, nil
+}
+
+func (s *Scheduler) registered() []*job {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]*job(nil), s.jobs...)
+}
+
+// nextRun возвращает время очередного запуска задачи после последнего запуска last.
+func (s *Scheduler) nextRun(j *job, last *domain.JobRun) time.Time {
+	since := s.startedAt
+	if last != nil {
+		since = last.StartedAt
+	}
+
+	return j.schedule.Next(since)
+}
+
+// Run вызывает RunOnce каждые interval, пока не будет отменён ctx.
+func (s *Scheduler) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		_, err := s.RunOnce(ctx)
+		if err != nil {
+			log.Println(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce по очереди выполняет задачи, время запуска которых наступило,
+// и возвращает число выполненных. Ошибки самих задач сохраняются в журнале
+// и не прерывают обход; возвращаются только ошибки хранилища.
+func (s *Scheduler) RunOnce(ctx context.Context) (numRuns int, err error) {
+	for _, j := range s.registered() {
+		ran, err := s.runIfDue(ctx, j)
+		if err != nil {
+			return numRuns, fmt.Errorf("запуск задач по расписанию: %w", err)
+		}
+
+		if ran {
+			numRuns++
+		}
+	}
+
+	return numRuns, nil
+}
+
+func (s *Scheduler) runIfDue(ctx context.Context, j *job) (ran bool, err error) {
+	release, ok, err := s.jobRepo.TryLock(ctx, j.name)
+	if err != nil || !ok {
+		return false, err
+	}
+	defer release()
+
+	// последний запуск читается под блокировкой: задачу мог только что выполнить другой экземпляр
+	lastRuns, err := s.jobRepo.GetLastRuns(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	next := s.nextRun(j, lastRuns[j.name])
+	if next.IsZero() || next.After(s.now()) {
+		return false, nil
+	}
+
+	run := &domain.JobRun{Job: j.name, Trigger: domain.JobTriggerSchedule, Status: domain.JobRunning}
+	err = s.jobRepo.StartRun(ctx, run)
+	if err != nil {
+		return false, err
+	}
+
+	return true, s.execute(ctx, j, run)
+}
+
+func (s *Scheduler) execute(ctx context.Context, j *job, run *domain.JobRun) error {
+	jobErr := j.fn(ctx)
+	run.Status = domain.JobSucceeded
+	if jobErr != nil {
+		log.Println(fmt.Errorf("задача %s: %w", j.name, jobErr))
+		run.Status = domain.JobFailed
+		run.Error = jobErr.Error()
+	}
+
+	return s.jobRepo.FinishRun(ctx, run)
+}
+
+func (s *Scheduler) GetJobs(ctx context.Context) (jobs []*domain.Job, err error) {
+	lastRuns, err := s.jobRepo.GetLastRuns(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("получение списка задач: %w", err)
+	}
+
+	registered := s.registered()
+	jobs = make([]*domain.Job, 0, len(registered))
+	for _, j := range registered {
+		jobs = append(jobs, &domain.Job{
+			Name:      j.name,
+			Schedule:  j.spec,
+			NextRunAt: s.nextRun(j, lastRuns[j.name]),
+			LastRun:   lastRuns[j.name],
+		})
+	}
+
+	return jobs, nil
+}
+
+func (s *Scheduler) GetRuns(ctx context.Context, name string, page int) (runs []*domain.JobRun, numPages int, err error) {
+	_, err = s.lookup(name)
+	if err != nil {
+		return nil, 0, fmt.Errorf("получение журнала запусков задачи: %w", err)
+	}
+
+	runs, numPages, err = s.jobRepo.GetRuns(ctx, name, page)
+	if err != nil {
+		return nil, 0, fmt.Errorf("получение журнала запусков задачи: %w", err)
+	}
+
+	return runs, numPages, nil
+}
+
+// Trigger запускает задачу вне расписания и сразу возвращает запись о запуске,
+// не дожидаясь завершения. Если задача уже выполняется, возвращается ErrConflict.
+func (s *Scheduler) Trigger(ctx context.Context, name string) (*domain.JobRun, error) {
+	j, err := s.lookup(name)
+	if err != nil {
+		return nil, fmt.Errorf("запуск задачи: %w", err)
+	}
+
+	release, ok, err := s.jobRepo.TryLock(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("запуск задачи: %w", err)
+	}
+	if !ok {
+		return nil, fmt.Errorf("запуск задачи: %w",
+			domain.NewConflictError(errors.New("задача уже выполняется")))
+	}
+
+	run := &domain.JobRun{Job: name, Trigger: domain.JobTriggerManual, Status: domain.JobRunning}
+	err = s.jobRepo.StartRun(ctx, run)
+	if err != nil {
+		release()
+		return nil, fmt.Errorf("запуск задачи: %w", err)
+	}
+
+	// запись о запуске копируется: исходную меняет горутина задачи
+	started := *run
+	s.triggered.Add(1)
+	go func() {
+		defer s.triggered.Done()
+		defer release()
+
+		// задача не должна прерываться вместе с HTTP-запросом, который её запустил
+		err := s.execute(context.Background(), j, run)
+		if err != nil {
+			log.Println(fmt.Errorf("запуск задачи %s: %w", name, err))
+		}
+	}()
+
+	return &started, nil
+}
+
+// Wait дожидается завершения задач, запущенных через Trigger.
+// Задачи по расписанию завершаются вместе с Run.
+func (s *Scheduler) Wait() {
+	s.triggered.Wait()
+}
@@ -0,0 +1,243 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"ppo/domain"
+	"ppo/mocks"
+	"testing"
+	"time"
+)
+
+func newScheduler(jobRepo domain.IJobRepository, now time.Time) *Scheduler {
+	s := NewScheduler(jobRepo)
+	s.startedAt = now.Add(-time.Hour)
+	s.now = func() time.Time { return now }
+
+	return s
+}
+
+func TestScheduler_Register(t *testing.T) {
+	s := NewScheduler(nil)
+
+	require.Nil(t, s.Register("cleanup", "30 4 * * *", func(context.Context) error { return nil }))
+	require.Equal(t,
+		"регистрация задачи cleanup: задача уже зарегистрирована",
+		s.Register("cleanup", "0 * * * *", func(context.Context) error { return nil }).Error(),
+	)
+	require.NotNil(t, s.Register("broken", "0 25 * * *", func(context.Context) error { return nil }))
+}
+
+func TestScheduler_RunOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2024, 1, 2, 3, 0, 30, 0, time.UTC)
+
+	testCases := []struct {
+		name        string
+		jobErr      error
+		beforeTest  func(jobRepo *mocks.MockIJobRepository)
+		wantNumRuns int
+		wantErr     bool
+		errStr      error
+	}{
+		{
+			name: "задача выполняется, когда наступило время по расписанию",
+			beforeTest: func(jobRepo *mocks.MockIJobRepository) {
+				jobRepo.EXPECT().TryLock(gomock.Any(), "nightly").Return(func() {}, true, nil)
+				jobRepo.EXPECT().
+					GetLastRuns(gomock.Any()).
+					Return(map[string]*domain.JobRun{
+						"nightly": {StartedAt: now.Add(-24 * time.Hour)},
+					}, nil)
+				jobRepo.EXPECT().
+					StartRun(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, run *domain.JobRun) error {
+						require.Equal(t, domain.JobTriggerSchedule, run.Trigger)
+						return nil
+					})
+				jobRepo.EXPECT().
+					FinishRun(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, run *domain.JobRun) error {
+						require.Equal(t, domain.JobSucceeded, run.Status)
+						return nil
+					})
+			},
+			wantNumRuns: 1,
+		},
+		{
+			name:   "ошибка задачи сохраняется в журнале",
+			jobErr: fmt.Errorf("job error"),
+			beforeTest: func(jobRepo *mocks.MockIJobRepository) {
+				jobRepo.EXPECT().TryLock(gomock.Any(), "nightly").Return(func() {}, true, nil)
+				jobRepo.EXPECT().GetLastRuns(gomock.Any()).Return(map[string]*domain.JobRun{}, nil)
+				jobRepo.EXPECT().StartRun(gomock.Any(), gomock.Any()).Return(nil)
+				jobRepo.EXPECT().
+					FinishRun(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, run *domain.JobRun) error {
+						require.Equal(t, domain.JobFailed, run.Status)
+						require.Equal(t, "job error", run.Error)
+						return nil
+					})
+			},
+			wantNumRuns: 1,
+		},
+		{
+			name: "задача уже выполнена другим экземпляром",
+			beforeTest: func(jobRepo *mocks.MockIJobRepository) {
+				jobRepo.EXPECT().TryLock(gomock.Any(), "nightly").Return(func() {}, true, nil)
+				jobRepo.EXPECT().
+					GetLastRuns(gomock.Any()).
+					Return(map[string]*domain.JobRun{
+						"nightly": {StartedAt: now.Add(-time.Second)},
+					}, nil)
+			},
+			wantNumRuns: 0,
+		},
+		{
+			name: "блокировка занята другим экземпляром",
+			beforeTest: func(jobRepo *mocks.MockIJobRepository) {
+				jobRepo.EXPECT().TryLock(gomock.Any(), "nightly").Return(nil, false, nil)
+			},
+			wantNumRuns: 0,
+		},
+		{
+			name: "ошибка выполнения запроса в репозитории",
+			beforeTest: func(jobRepo *mocks.MockIJobRepository) {
+				jobRepo.EXPECT().TryLock(gomock.Any(), "nightly").Return(nil, false, fmt.Errorf("sql error"))
+			},
+			wantErr: true,
+			errStr:  errors.New("запуск задач по расписанию: sql error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			jobRepo := mocks.NewMockIJobRepository(ctrl)
+			s := newScheduler(jobRepo, now)
+			require.Nil(t, s.Register("nightly", "0 3 * * *", func(context.Context) error { return tc.jobErr }))
+
+			tc.beforeTest(jobRepo)
+
+			numRuns, err := s.RunOnce(context.Background())
+
+			if tc.wantErr {
+				require.Equal(t, tc.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+				require.Equal(t, tc.wantNumRuns, numRuns)
+			}
+		})
+	}
+}
+
+func TestScheduler_GetJobs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)
+	jobRepo := mocks.NewMockIJobRepository(ctrl)
+	s := newScheduler(jobRepo, now)
+	require.Nil(t, s.Register("nightly", "0 3 * * *", func(context.Context) error { return nil }))
+	require.Nil(t, s.Register("hourly", "0 * * * *", func(context.Context) error { return nil }))
+
+	lastRun := &domain.JobRun{ID: uuid.New(), Job: "nightly", StartedAt: now.Add(-9 * time.Hour)}
+	jobRepo.EXPECT().GetLastRuns(gomock.Any()).Return(map[string]*domain.JobRun{"nightly": lastRun}, nil)
+
+	jobs, err := s.GetJobs(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, []*domain.Job{
+		{
+			Name:      "nightly",
+			Schedule:  "0 3 * * *",
+			NextRunAt: time.Date(2024, 1, 3, 3, 0, 0, 0, time.UTC),
+			LastRun:   lastRun,
+		},
+		{
+			Name:      "hourly",
+			Schedule:  "0 * * * *",
+			NextRunAt: time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC),
+		},
+	}, jobs)
+}
+
+func TestScheduler_Trigger(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)
+	runId := uuid.New()
+
+	testCases := []struct {
+		name       string
+		job        string
+		beforeTest func(jobRepo *mocks.MockIJobRepository, done chan struct{})
+		wantErr    bool
+		errIs      error
+	}{
+		{
+			name: "успешный запуск",
+			job:  "nightly",
+			beforeTest: func(jobRepo *mocks.MockIJobRepository, done chan struct{}) {
+				jobRepo.EXPECT().
+					TryLock(gomock.Any(), "nightly").
+					Return(func() { close(done) }, true, nil)
+				jobRepo.EXPECT().
+					StartRun(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, run *domain.JobRun) error {
+						require.Equal(t, domain.JobTriggerManual, run.Trigger)
+						run.ID = runId
+						return nil
+					})
+				jobRepo.EXPECT().FinishRun(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:    "задача не зарегистрирована",
+			job:     "unknown",
+			wantErr: true,
+			errIs:   domain.ErrNotFound,
+		},
+		{
+			name: "задача уже выполняется",
+			job:  "nightly",
+			beforeTest: func(jobRepo *mocks.MockIJobRepository, done chan struct{}) {
+				jobRepo.EXPECT().TryLock(gomock.Any(), "nightly").Return(nil, false, nil)
+			},
+			wantErr: true,
+			errIs:   domain.ErrConflict,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			jobRepo := mocks.NewMockIJobRepository(ctrl)
+			s := newScheduler(jobRepo, now)
+			require.Nil(t, s.Register("nightly", "0 3 * * *", func(context.Context) error { return nil }))
+
+			done := make(chan struct{})
+			if tc.beforeTest != nil {
+				tc.beforeTest(jobRepo, done)
+			}
+
+			run, err := s.Trigger(context.Background(), tc.job)
+
+			if tc.wantErr {
+				require.ErrorIs(t, err, tc.errIs)
+			} else {
+				require.Nil(t, err)
+				require.Equal(t, runId, run.ID)
+				require.Equal(t, domain.JobRunning, run.Status)
+
+				select {
+				case <-done:
+				case <-time.After(time.Second):
+					t.Fatal("задача не завершилась")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package seed
+
+var activityFields = []struct {
+	name        string
+	description string
+	cost        float32
+}{
+	{"Информационные технологии", "Разработка программного обеспечения и ИТ-услуги", 1.4},
+	{"Розничная торговля", "Продажа товаров конечным потребителям", 0.8},
+	{"Общественное питание", "Кафе, рестораны и доставка еды", 0.9},
+	{"Строительство", "Жилищное и коммерческое строительство, ремонт", 1.1},
+	{"Логистика", "Грузоперевозки, складское хранение и экспедирование", 1.0},
+	{"Производство", "Выпуск промышленной и потребительской продукции", 1.3},
+	{"Образование", "Курсы, репетиторство и учебные центры", 0.7},
+	{"Здравоохранение", "Частные клиники и медицинские услуги", 1.2},
+	{"Сельское хозяйство", "Растениеводство, животноводство и переработка", 0.6},
+	{"Консалтинг", "Юридические, бухгалтерские и управленческие услуги", 1.0},
+}
+
+var skills = []struct {
+	name        string
+	description string
+}{
+	{"Управление проектами", "Планирование и контроль сроков, бюджета и качества"},
+	{"Продажи", "Поиск клиентов и заключение сделок"},
+	{"Маркетинг", "Продвижение продуктов и исследование рынка"},
+	{"Финансовый анализ", "Бюджетирование и анализ отчётности"},
+	{"Переговоры", "Ведение переговоров с партнёрами и поставщиками"},
+	{"Управление персоналом", "Подбор, обучение и мотивация сотрудников"},
+	{"Программирование", "Разработка программного обеспечения"},
+	{"Логистика", "Организация цепочек поставок"},
+	{"Бухгалтерский учёт", "Ведение учёта и налоговой отчётности"},
+	{"Публичные выступления", "Презентации и выступления перед аудиторией"},
+	{"Иностранные языки", "Деловое общение на иностранных языках"},
+	{"Стратегическое планирование", "Постановка долгосрочных целей развития бизнеса"},
+}
+
+var cities = []string{
+	"Москва", "Санкт-Петербург", "Казань", "Новосибирск",
+	"Екатеринбург", "Нижний Новгород", "Самара", "Краснодар",
+}
+
+// фамилии в мужском роде; женская форма получается добавлением «а»
+var surnames = []string{
+	"Иванов", "Смирнов", "Кузнецов", "Попов", "Васильев", "Петров", "Соколов", "Михайлов",
+	"Новиков", "Фёдоров", "Морозов", "Волков", "Алексеев", "Лебедев", "Семёнов", "Егоров",
+	"Павлов", "Козлов", "Степанов", "Николаев", "Орлов", "Андреев", "Макаров", "Никитин",
+}
+
+var maleNames = []string{
+	"Александр", "Дмитрий", "Максим", "Сергей", "Андрей", "Алексей", "Артём", "Илья",
+	"Кирилл", "Михаил", "Никита", "Матвей", "Роман", "Егор", "Иван", "Павел",
+}
+
+var femaleNames = []string{
+	"Анастасия", "Мария", "Анна", "Виктория", "Екатерина", "Наталья", "Марина", "Полина",
+	"Дарья", "Алина", "Ксения", "Елена", "Ольга", "Татьяна", "Ирина", "Юлия",
+}
+
+// отчества в мужском роде; женская форма получается заменой «ич» на «на»
+var patronymics = []string{
+	"Александрович", "Дмитриевич", "Сергеевич", "Андреевич", "Алексеевич", "Михайлович",
+	"Иванович", "Павлович", "Николаевич", "Владимирович", "Петрович", "Викторович",
+}
+
+var legalForms = []string{"ООО", "ИП", "АО"}
+
+var companyAdjectives = []string{
+	"Северный", "Новый", "Первый", "Главный", "Быстрый", "Надёжный",
+	"Городской", "Открытый", "Точный", "Зелёный", "Сибирский", "Волжский",
+}
+
+var companyNouns = []string{
+	"Ветер", "Путь", "Стандарт", "Проект", "Альянс", "Мост",
+	"Маяк", "Вектор", "Ресурс", "Двор", "Горизонт", "Капитал",
+}
+
+var reviewPros = []string{
+	"Соблюдает сроки", "Честный партнёр", "Гибкие условия", "Быстро отвечает",
+	"Высокое качество работы", "Прозрачная отчётность",
+}
+
+var reviewCons = []string{
+	"Нет", "Иногда задерживает ответы", "Высокие цены", "Сложно договориться об изменениях",
+	"Мало опыта в отрасли",
+}
+
+var reviewDescriptions = []string{
+	"Работали вместе над поставками, остались довольны.",
+	"Сотрудничаем больше года, рекомендую.",
+	"Был один совместный проект, в целом всё прошло хорошо.",
+	"Встречались на отраслевой конференции, обсуждали партнёрство.",
+}
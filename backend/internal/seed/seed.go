@@ -0,0 +1,300 @@
+// Package seed генерирует согласованный синтетический набор данных: предпринимателей
+// с компаниями, квартальными отчётами, навыками, контактами и отзывами. Набор полностью
+// определяется Options: одинаковые параметры дают одинаковые данные, включая id,
+// поэтому его можно использовать для демонстраций, нагрузочных тестов и экспериментов
+// с рейтингом.
+package seed
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"ppo/domain"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Options struct {
+	// Seed — зерно генератора случайных чисел.
+	Seed int64
+	// Entrepreneurs — число предпринимателей.
+	Entrepreneurs int
+	// Quarters — глубина истории отчётов в кварталах; история заканчивается
+	// кварталом EndQuarter года EndYear включительно.
+	Quarters   int
+	EndYear    int
+	EndQuarter int
+	// MaxContacts — наибольшее число контактов одного предпринимателя.
+	MaxContacts int
+}
+
+type Dataset struct {
+	ActivityFields []*domain.ActivityField
+	Skills         []*domain.Skill
+	Users          []*domain.User
+	Companies      []*domain.Company
+	Reports        []*domain.FinancialReport
+	UserSkills     []*domain.UserSkill
+	Contacts       []*domain.Contact
+	Reviews        []*domain.Review
+}
+
+func (o *Options) validate() error {
+	var errs []error
+	if o.Entrepreneurs < 1 {
+		errs = append(errs, errors.New("число предпринимателей должно быть положительным"))
+	}
+	if o.Quarters < 1 {
+		errs = append(errs, errors.New("глубина истории отчётов должна быть положительной"))
+	}
+	if o.EndYear < 1 || o.EndQuarter < 1 || o.EndQuarter > 4 {
+		errs = append(errs, fmt.Errorf("некорректный последний квартал истории: %d Q%d", o.EndYear, o.EndQuarter))
+	} else if o.EndYear*4+o.EndQuarter-o.Quarters < 4 {
+		errs = append(errs, errors.New("история отчётов начинается раньше первого года"))
+	}
+	if o.MaxContacts < 1 {
+		errs = append(errs, errors.New("наибольшее число контактов должно быть положительным"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// generator хранит состояние генерации; все случайные значения, включая id,
+// берутся из rng, поэтому порядок вызовов определяет результат.
+type generator struct {
+	opts Options
+	rng  *rand.Rand
+	data *Dataset
+}
+
+// Generate строит набор данных по opts.
+func Generate(opts Options) (*Dataset, error) {
+	err := opts.validate()
+	if err != nil {
+		return nil, fmt.Errorf("генерация данных: %w", err)
+	}
+
+	g := &generator{
+		opts: opts,
+		rng:  rand.New(rand.NewSource(opts.Seed)),
+		data: new(Dataset),
+	}
+
+	g.catalog()
+	for i := 0; i < opts.Entrepreneurs; i++ {
+		g.entrepreneur(i)
+	}
+	for _, user := range g.data.Users {
+		g.reviews(user)
+	}
+
+	return g.data, nil
+}
+
+func (g *generator) id() uuid.UUID {
+	id, err := uuid.NewRandomFromReader(g.rng)
+	if err != nil {
+		// rand.Rand.Read не возвращает ошибок
+		panic(err)
+	}
+
+	return id
+}
+
+func (g *generator) pick(items []string) string {
+	return items[g.rng.Intn(len(items))]
+}
+
+func (g *generator) catalog() {
+	for _, field := range activityFields {
+		g.data.ActivityFields = append(g.data.ActivityFields, &domain.ActivityField{
+			ID:          g.id(),
+			Name:        field.name,
+			Description: field.description,
+			Cost:        field.cost,
+		})
+	}
+
+	for _, skill := range skills {
+		g.data.Skills = append(g.data.Skills, &domain.Skill{
+			ID:          g.id(),
+			Name:        skill.name,
+			Description: skill.description,
+		})
+	}
+}
+
+func (g *generator) entrepreneur(i int) {
+	user := &domain.User{
+		ID:       g.id(),
+		Username: fmt.Sprintf("seed%d_%d", g.opts.Seed, i+1),
+		Birthday: time.Date(1960, time.January, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, g.rng.Intn(40*365)),
+		City:     g.pick(cities),
+		Role:     "user",
+	}
+
+	surname, name, patronymic := g.pick(surnames), g.pick(maleNames), g.pick(patronymics)
+	user.Gender = "m"
+	if g.rng.Intn(2) == 0 {
+		user.Gender = "w"
+		surname += "а"
+		name = g.pick(femaleNames)
+		patronymic = strings.TrimSuffix(patronymic, "ич") + "на"
+	}
+	user.FullName = strings.Join([]string{surname, name, patronymic}, " ")
+	g.data.Users = append(g.data.Users, user)
+
+	for n := 1 + g.rng.Intn(3); n > 0; n-- {
+		g.company(user)
+	}
+
+	for _, j := range g.rng.Perm(len(g.data.Skills))[:2+g.rng.Intn(4)] {
+		g.data.UserSkills = append(g.data.UserSkills, &domain.UserSkill{UserId: user.ID, SkillId: g.data.Skills[j].ID})
+	}
+
+	g.contacts(user)
+}
+
+func (g *generator) company(owner *domain.User) {
+	company := &domain.Company{
+		ID:              g.id(),
+		OwnerID:         owner.ID,
+		ActivityFieldId: g.data.ActivityFields[g.rng.Intn(len(g.data.ActivityFields))].ID,
+		Name:            fmt.Sprintf("%s «%s %s»", g.pick(legalForms), g.pick(companyAdjectives), g.pick(companyNouns)),
+		City:            owner.City,
+	}
+	// часть компаний работает не в городе владельца
+	if g.rng.Intn(4) == 0 {
+		company.City = g.pick(cities)
+	}
+	g.data.Companies = append(g.data.Companies, company)
+
+	g.reports(company)
+}
+
+// seasonality — поправка выручки по кварталам: к концу года она выше.
+var seasonality = [4]float64{0.9, 1.0, 1.05, 1.15}
+
+// reports строит историю отчётов компании: выручка растёт от квартала к кварталу
+// с индивидуальным трендом, сезонностью и шумом, расходы — доля выручки с колебаниями.
+// Треть компаний основана позже начала истории и отчитывается не за все кварталы.
+func (g *generator) reports(company *domain.Company) {
+	first := g.opts.EndYear*4 + g.opts.EndQuarter - 1 - (g.opts.Quarters - 1)
+	last := g.opts.EndYear*4 + g.opts.EndQuarter - 1
+	if g.rng.Intn(3) == 0 {
+		first += g.rng.Intn(g.opts.Quarters)
+	}
+
+	revenue := math.Exp(11.5 + 4*g.rng.Float64())
+	trend := 0.01 + 0.04*g.rng.NormFloat64()
+	margin := 0.05 + 0.25*g.rng.Float64()
+	for i := first; i <= last; i++ {
+		year, quarter := i/4, i%4+1
+
+		qRevenue := revenue * seasonality[quarter-1] * (1 + 0.08*g.rng.NormFloat64())
+		qRevenue = math.Max(math.Round(qRevenue), 0)
+		costs := qRevenue * (1 - margin + 0.1*g.rng.NormFloat64())
+		costs = math.Max(math.Round(costs), 0)
+
+		g.data.Reports = append(g.data.Reports, &domain.FinancialReport{
+			ID:        g.id(),
+			CompanyID: company.ID,
+			Revenue:   float32(qRevenue),
+			Costs:     float32(costs),
+			Year:      year,
+			Quarter:   quarter,
+		})
+
+		revenue *= 1 + trend
+	}
+}
+
+func (g *generator) contacts(user *domain.User) {
+	contacts := []*domain.Contact{
+		{Name: "email", Value: user.Username + "@example.com"},
+		{Name: "tg", Value: "@" + user.Username},
+		{Name: "phone", Value: fmt.Sprintf("+7 9%02d %03d-%02d-%02d",
+			g.rng.Intn(100), g.rng.Intn(1000), g.rng.Intn(100), g.rng.Intn(100))},
+	}
+
+	n := 1 + g.rng.Intn(len(contacts))
+	n = min(n, g.opts.MaxContacts)
+	for _, contact := range contacts[:n] {
+		contact.ID = g.id()
+		contact.OwnerID = user.ID
+		g.data.Contacts = append(g.data.Contacts, contact)
+	}
+}
+
+// reviews добавляет предпринимателю до трёх отзывов от разных других предпринимателей.
+func (g *generator) reviews(target *domain.User) {
+	users := g.data.Users
+	if len(users) < 2 {
+		return
+	}
+
+	n := min(g.rng.Intn(4), len(users)-1)
+	reviewers := make(map[int]bool, n)
+	for len(reviewers) < n {
+		j := g.rng.Intn(len(users))
+		if users[j].ID == target.ID || reviewers[j] {
+			continue
+		}
+		reviewers[j] = true
+
+		// оценки смещены к положительным, как в реальных отзывах
+		rating := 5 - int(math.Min(math.Abs(g.rng.NormFloat64())*1.5, 4))
+		g.data.Reviews = append(g.data.Reviews, &domain.Review{
+			ID:          g.id(),
+			Reviewer:    users[j].ID,
+			Target:      target.ID,
+			Pros:        g.pick(reviewPros),
+			Cons:        g.pick(reviewCons),
+			Description: g.pick(reviewDescriptions),
+			Rating:      rating,
+		})
+	}
+}
+
+// ReuseCatalog заменяет сгенерированные сферы деятельности и навыки уже существующими
+// с теми же названиями (fields и skills — id по названию) и исправляет ссылки на них,
+// чтобы повторная загрузка с другим зерном не дублировала справочники.
+// Из набора удаляются записи справочников, которые загружать не нужно.
+func (d *Dataset) ReuseCatalog(fields, skills map[string]uuid.UUID) {
+	fieldIds := make(map[uuid.UUID]uuid.UUID)
+	newFields := d.ActivityFields[:0]
+	for _, field := range d.ActivityFields {
+		if id, ok := fields[field.Name]; ok {
+			fieldIds[field.ID] = id
+			continue
+		}
+		newFields = append(newFields, field)
+	}
+	d.ActivityFields = newFields
+
+	for _, company := range d.Companies {
+		if id, ok := fieldIds[company.ActivityFieldId]; ok {
+			company.ActivityFieldId = id
+		}
+	}
+
+	skillIds := make(map[uuid.UUID]uuid.UUID)
+	newSkills := d.Skills[:0]
+	for _, skill := range d.Skills {
+		if id, ok := skills[skill.Name]; ok {
+			skillIds[skill.ID] = id
+			continue
+		}
+		newSkills = append(newSkills, skill)
+	}
+	d.Skills = newSkills
+
+	for _, userSkill := range d.UserSkills {
+		if id, ok := skillIds[userSkill.SkillId]; ok {
+			userSkill.SkillId = id
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package seed
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+var testOptions = Options{
+	Seed:          42,
+	Entrepreneurs: 50,
+	Quarters:      8,
+	EndYear:       2024,
+	EndQuarter:    2,
+	MaxContacts:   2,
+}
+
+func TestGenerate_Reproducible(t *testing.T) {
+	first, err := Generate(testOptions)
+	require.Nil(t, err)
+
+	second, err := Generate(testOptions)
+	require.Nil(t, err)
+	require.Equal(t, first, second)
+
+	opts := testOptions
+	opts.Seed = 43
+	other, err := Generate(opts)
+	require.Nil(t, err)
+	require.NotEqual(t, first.Users[0].ID, other.Users[0].ID)
+}
+
+func TestGenerate_Coherent(t *testing.T) {
+	data, err := Generate(testOptions)
+	require.Nil(t, err)
+	require.Len(t, data.Users, testOptions.Entrepreneurs)
+
+	ids := make(map[uuid.UUID]bool)
+	add := func(id uuid.UUID) {
+		require.False(t, ids[id], "повторяющийся id %s", id)
+		ids[id] = true
+	}
+
+	fields := make(map[uuid.UUID]bool)
+	for _, field := range data.ActivityFields {
+		add(field.ID)
+		fields[field.ID] = true
+	}
+
+	skills := make(map[uuid.UUID]bool)
+	for _, skill := range data.Skills {
+		add(skill.ID)
+		skills[skill.ID] = true
+	}
+
+	users := make(map[uuid.UUID]bool)
+	usernames := make(map[string]bool)
+	for _, user := range data.Users {
+		add(user.ID)
+		users[user.ID] = true
+		require.False(t, usernames[user.Username])
+		usernames[user.Username] = true
+		require.Len(t, strings.Split(user.FullName, " "), 3)
+		require.Contains(t, []string{"m", "w"}, user.Gender)
+		require.NotEmpty(t, user.City)
+	}
+
+	companies := make(map[uuid.UUID]bool)
+	for _, company := range data.Companies {
+		add(company.ID)
+		companies[company.ID] = true
+		require.True(t, users[company.OwnerID])
+		require.True(t, fields[company.ActivityFieldId])
+	}
+
+	type quarter struct {
+		company       uuid.UUID
+		year, quarter int
+	}
+	reported := make(map[quarter]bool)
+	for _, rep := range data.Reports {
+		add(rep.ID)
+		require.True(t, companies[rep.CompanyID])
+		require.GreaterOrEqual(t, rep.Revenue, float32(0))
+		require.GreaterOrEqual(t, rep.Costs, float32(0))
+
+		// история отчётов — с 2022 Q3 по 2024 Q2
+		index := rep.Year*4 + rep.Quarter - 1
+		require.GreaterOrEqual(t, index, 2022*4+2)
+		require.LessOrEqual(t, index, 2024*4+1)
+
+		key := quarter{rep.CompanyID, rep.Year, rep.Quarter}
+		require.False(t, reported[key], "повторный отчёт за квартал")
+		reported[key] = true
+	}
+
+	type pair struct{ a, b uuid.UUID }
+	userSkills := make(map[pair]bool)
+	for _, us := range data.UserSkills {
+		require.True(t, users[us.UserId])
+		require.True(t, skills[us.SkillId])
+		require.False(t, userSkills[pair{us.UserId, us.SkillId}])
+		userSkills[pair{us.UserId, us.SkillId}] = true
+	}
+
+	contacts := make(map[uuid.UUID]int)
+	for _, contact := range data.Contacts {
+		add(contact.ID)
+		require.True(t, users[contact.OwnerID])
+		contacts[contact.OwnerID]++
+		require.LessOrEqual(t, contacts[contact.OwnerID], testOptions.MaxContacts)
+	}
+
+	reviews := make(map[pair]bool)
+	for _, review := range data.Reviews {
+		add(review.ID)
+		require.True(t, users[review.Target])
+		require.True(t, users[review.Reviewer])
+		require.NotEqual(t, review.Target, review.Reviewer)
+		require.False(t, reviews[pair{review.Reviewer, review.Target}])
+		reviews[pair{review.Reviewer, review.Target}] = true
+		require.GreaterOrEqual(t, review.Rating, 1)
+		require.LessOrEqual(t, review.Rating, 5)
+	}
+}
+
+func TestGenerate_InvalidOptions(t *testing.T) {
+	testCases := []struct {
+		name   string
+		modify func(*Options)
+		errStr string
+	}{
+		{
+			name:   "нет предпринимателей",
+			modify: func(o *Options) { o.Entrepreneurs = 0 },
+			errStr: "число предпринимателей должно быть положительным",
+		},
+		{
+			name:   "некорректный квартал",
+			modify: func(o *Options) { o.EndQuarter = 5 },
+			errStr: "некорректный последний квартал истории: 2024 Q5",
+		},
+		{
+			name:   "история до первого года",
+			modify: func(o *Options) { o.EndYear, o.Quarters = 1, 3 },
+			errStr: "история отчётов начинается раньше первого года",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := testOptions
+			tc.modify(&opts)
+
+			_, err := Generate(opts)
+			require.ErrorContains(t, err, tc.errStr)
+		})
+	}
+}
+
+func TestDataset_ReuseCatalog(t *testing.T) {
+	data, err := Generate(testOptions)
+	require.Nil(t, err)
+
+	existingField, existingSkill := uuid.New(), uuid.New()
+	field, skill := data.ActivityFields[0], data.Skills[0]
+	data.ReuseCatalog(
+		map[string]uuid.UUID{field.Name: existingField},
+		map[string]uuid.UUID{skill.Name: existingSkill},
+	)
+
+	require.NotContains(t, data.ActivityFields, field)
+	require.Len(t, data.ActivityFields, len(activityFields)-1)
+	require.NotContains(t, data.Skills, skill)
+
+	var reusedField, reusedSkill bool
+	for _, company := range data.Companies {
+		require.NotEqual(t, field.ID, company.ActivityFieldId)
+		reusedField = reusedField || company.ActivityFieldId == existingField
+	}
+	for _, us := range data.UserSkills {
+		require.NotEqual(t, skill.ID, us.SkillId)
+		reusedSkill = reusedSkill || us.SkillId == existingSkill
+	}
+	require.True(t, reusedField)
+	require.True(t, reusedSkill)
+}
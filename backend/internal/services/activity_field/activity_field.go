@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math"
 	"ppo/domain"
+	"ppo/pkg/i18n"
 
 	"github.com/google/uuid"
 )
@@ -26,15 +27,15 @@ func NewService(
 
 func (s *Service) Create(ctx context.Context, data *domain.ActivityField) (err error) {
 	if data.Name == "" {
-		return fmt.Errorf("должно быть указано название сферы деятельности")
+		return domain.NewValidationError("name", i18n.MsgActFieldNameRequired)
 	}
 
 	if data.Description == "" {
-		return fmt.Errorf("должно быть указано описание сферы деятельности")
+		return domain.NewValidationError("description", i18n.MsgActFieldDescriptionRequired)
 	}
 
 	if math.Abs(float64(data.Cost)) < 1e-7 {
-		return fmt.Errorf("вес сферы деятельности не может быть равен 0")
+		return domain.NewValidationError("cost", i18n.MsgActFieldCostZero)
 	}
 
 	err = s.actFieldRepo.Create(ctx, data)
@@ -73,6 +74,15 @@ func (s *Service) GetById(ctx context.Context, id uuid.UUID) (data *domain.Activ
 	return data, nil
 }
 
+func (s *Service) GetByIds(ctx context.Context, ids []uuid.UUID) (data []*domain.ActivityField, err error) {
+	data, err = s.actFieldRepo.GetByIds(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("получение сфер деятельности по списку id: %w", err)
+	}
+
+	return data, nil
+}
+
 func (s *Service) GetCostByCompanyId(ctx context.Context, companyId uuid.UUID) (cost float32, err error) {
 	company, err := s.compRepo.GetById(ctx, companyId)
 	if err != nil {
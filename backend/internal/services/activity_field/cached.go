@@ -0,0 +1,99 @@
+package activity_field
+
+import (
+	"context"
+	"ppo/domain"
+	"ppo/pkg/cache"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type pageKey struct {
+	page        int
+	isPaginated bool
+}
+
+type page struct {
+	fields   []domain.ActivityField
+	numPages int
+}
+
+// CachedService кэширует список сфер деятельности и максимальный вес поверх svc.
+// Изменения через сервис сбрасывают кэш; изменения в обход него становятся видны по истечении TTL.
+type CachedService struct {
+	domain.IActivityFieldService
+
+	pages   cache.Cache[pageKey, page]
+	maxCost cache.Cache[struct{}, float32]
+}
+
+// NewCachedService создаёт CachedService с LRU-кэшем на size страниц и временем жизни записей ttl.
+func NewCachedService(svc domain.IActivityFieldService, size int, ttl time.Duration) *CachedService {
+	return &CachedService{
+		IActivityFieldService: svc,
+		pages:                 cache.NewLRU[pageKey, page](size, ttl),
+		maxCost:               cache.NewLRU[struct{}, float32](1, ttl),
+	}
+}
+
+func (s *CachedService) Invalidate() {
+	s.pages.Purge()
+	s.maxCost.Purge()
+}
+
+func (s *CachedService) Create(ctx context.Context, data *domain.ActivityField) error {
+	defer s.Invalidate()
+	return s.IActivityFieldService.Create(ctx, data)
+}
+
+func (s *CachedService) Update(ctx context.Context, data *domain.ActivityField) error {
+	defer s.Invalidate()
+	return s.IActivityFieldService.Update(ctx, data)
+}
+
+func (s *CachedService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	defer s.Invalidate()
+	return s.IActivityFieldService.DeleteById(ctx, id)
+}
+
+func (s *CachedService) GetMaxCost(ctx context.Context) (maxCost float32, err error) {
+	if maxCost, ok := s.maxCost.Get(struct{}{}); ok {
+		return maxCost, nil
+	}
+
+	maxCost, err = s.IActivityFieldService.GetMaxCost(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	s.maxCost.Set(struct{}{}, maxCost)
+
+	return maxCost, nil
+}
+
+// GetAll возвращает копии закэшированных сфер деятельности: вызывающий может их изменять.
+func (s *CachedService) GetAll(ctx context.Context, pageNum int, isPaginated bool) (fields []*domain.ActivityField, numPages int, err error) {
+	key := pageKey{page: pageNum, isPaginated: isPaginated}
+	cached, ok := s.pages.Get(key)
+	if !ok {
+		fields, numPages, err = s.IActivityFieldService.GetAll(ctx, pageNum, isPaginated)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		cached = page{fields: make([]domain.ActivityField, len(fields)), numPages: numPages}
+		for i, field := range fields {
+			cached.fields[i] = *field
+		}
+		s.pages.Set(key, cached)
+	}
+
+	fields = make([]*domain.ActivityField, len(cached.fields))
+	for i := range cached.fields {
+		field := cached.fields[i]
+		fields[i] = &field
+	}
+
+	return fields, cached.numPages, nil
+}
@@ -0,0 +1,68 @@
+package activity_field
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"ppo/domain"
+	"ppo/mocks"
+	"testing"
+	"time"
+)
+
+func TestCachedService_GetAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := mocks.NewMockIActivityFieldService(ctrl)
+	cached := NewCachedService(svc, 10, time.Minute)
+
+	fields := []*domain.ActivityField{{ID: uuid.UUID{1}, Name: "aaa", Cost: 1.5}}
+
+	// второй запрос той же страницы обслуживается из кэша
+	svc.EXPECT().GetAll(gomock.Any(), 1, true).Return(fields, 2, nil).Times(1)
+
+	for i := 0; i < 2; i++ {
+		got, numPages, err := cached.GetAll(context.Background(), 1, true)
+		require.Nil(t, err)
+		require.Equal(t, fields, got)
+		require.Equal(t, 2, numPages)
+
+		// изменение полученного значения не портит кэш
+		got[0].Name = "changed"
+	}
+
+	// запись через сервис сбрасывает кэш
+	svc.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+	require.Nil(t, cached.Update(context.Background(), &domain.ActivityField{ID: uuid.UUID{1}}))
+
+	svc.EXPECT().GetAll(gomock.Any(), 1, true).Return(nil, 0, fmt.Errorf("sql error"))
+	_, _, err := cached.GetAll(context.Background(), 1, true)
+	require.NotNil(t, err)
+}
+
+func TestCachedService_GetMaxCost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := mocks.NewMockIActivityFieldService(ctrl)
+	cached := NewCachedService(svc, 10, time.Minute)
+
+	svc.EXPECT().GetMaxCost(gomock.Any()).Return(float32(2.5), nil).Times(1)
+
+	for i := 0; i < 2; i++ {
+		maxCost, err := cached.GetMaxCost(context.Background())
+		require.Nil(t, err)
+		require.Equal(t, float32(2.5), maxCost)
+	}
+
+	svc.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	require.Nil(t, cached.Create(context.Background(), &domain.ActivityField{Name: "bbb", Cost: 3}))
+
+	svc.EXPECT().GetMaxCost(gomock.Any()).Return(float32(3), nil)
+	maxCost, err := cached.GetMaxCost(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, float32(3), maxCost)
+}
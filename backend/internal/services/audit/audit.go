@@ -0,0 +1,171 @@
+// Package audit ведёт журнал изменений. Декораторы сервисов из этого пакета
+// выполняют каждое изменяющее обращение к сервису в транзакции вместе с записью
+// в журнал: кто изменил сущность, что сделал и как она выглядела до и после.
+// Журнал пополняется только при успешном изменении; производные данные
+// (снимки рейтинга, запуски задач) в него не попадают.
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"ppo/domain"
+	"ppo/pkg/i18n"
+	"strings"
+	"unicode"
+)
+
+type Service struct {
+	auditRepo domain.IAuditRepository
+}
+
+func NewService(auditRepo domain.IAuditRepository) domain.IAuditService {
+	return &Service{
+		auditRepo: auditRepo,
+	}
+}
+
+func (s *Service) Find(ctx context.Context, filter *domain.AuditFilter, page int) (
+	entries []*domain.AuditEntry, numPages int, err error) {
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return nil, 0, domain.NewValidationError("to", i18n.MsgAuditPeriodOrder)
+	}
+
+	entries, numPages, err = s.auditRepo.Find(ctx, filter, page)
+	if err != nil {
+		return nil, 0, fmt.Errorf("получение журнала аудита: %w", err)
+	}
+
+	return entries, numPages, nil
+}
+
+// Recorder выполняет изменения и записывает их в журнал в одной транзакции:
+// если запись не удалась, изменение откатывается.
+type Recorder struct {
+	auditRepo  domain.IAuditRepository
+	transactor domain.ITransactor
+}
+
+func NewRecorder(auditRepo domain.IAuditRepository, transactor domain.ITransactor) *Recorder {
+	return &Recorder{
+		auditRepo:  auditRepo,
+		transactor: transactor,
+	}
+}
+
+// change описывает одно изменение сущности. before и after возвращают снимки
+// сущности до и после изменения и могут быть nil, если снимка нет; id вызывается
+// после apply, поэтому видит id, присвоенный при создании.
+type change struct {
+	action domain.AuditAction
+	entity domain.AuditEntity
+	id     func() string
+	before func(context.Context) (any, error)
+	apply  func(context.Context) error
+	after  func(context.Context) (any, error)
+}
+
+func (r *Recorder) track(ctx context.Context, c change) error {
+	return r.transactor.WithinTx(ctx, func(ctx context.Context) (err error) {
+		var before, after any
+		if c.before != nil {
+			before, err = c.before(ctx)
+			// изменять нечего: ошибку, если она нужна, вернёт сам сервис
+			if errors.Is(err, domain.ErrNotFound) {
+				return c.apply(ctx)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		err = c.apply(ctx)
+		if err != nil {
+			return err
+		}
+
+		if c.after != nil {
+			after, err = c.after(ctx)
+			if err != nil {
+				return err
+			}
+		}
+
+		return r.record(ctx, c.action, c.entity, c.id(), before, after)
+	})
+}
+
+// record добавляет запись в журнал от имени пользователя из контекста.
+func (r *Recorder) record(ctx context.Context, action domain.AuditAction, entity domain.AuditEntity,
+	id string, before, after any) (err error) {
+	entry := &domain.AuditEntry{
+		Action:     action,
+		EntityType: entity,
+		EntityID:   id,
+	}
+
+	if actor := domain.ActorFromContext(ctx); actor != nil {
+		entry.ActorID = actor.ID
+		entry.ActorRole = actor.Role
+	}
+
+	entry.Before, err = snapshot(before)
+	if err != nil {
+		return err
+	}
+
+	entry.After, err = snapshot(after)
+	if err != nil {
+		return err
+	}
+
+	return r.auditRepo.Append(ctx, entry)
+}
+
+// snapshot сериализует сущность в JSON. У доменных структур нет json-тегов,
+// поэтому имена полей верхнего уровня приводятся к snake_case, как в API.
+func snapshot(v any) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("снимок сущности для журнала аудита: %w", err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var fields map[string]any
+	if dec.Decode(&fields) != nil {
+		// не объект: сохраняется как есть
+		return data, nil
+	}
+
+	snake := make(map[string]any, len(fields))
+	for name, value := range fields {
+		snake[snakeCase(name)] = value
+	}
+
+	return json.Marshal(snake)
+}
+
+// snakeCase переводит имя поля Go в snake_case, сохраняя аббревиатуры целыми:
+// CompanyID → company_id, URL → url, ActivityFieldId → activity_field_id.
+func snakeCase(name string) string {
+	runes := []rune(name)
+
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 &&
+			(unicode.IsLower(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+
+	return b.String()
+}
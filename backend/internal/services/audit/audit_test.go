@@ -0,0 +1,330 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"ppo/domain"
+	"ppo/mocks"
+	"testing"
+	"time"
+)
+
+func newTestRecorder(ctrl *gomock.Controller) (*Recorder, *mocks.MockIAuditRepository) {
+	auditRepo := mocks.NewMockIAuditRepository(ctrl)
+	transactor := mocks.NewMockITransactor(ctrl)
+	transactor.EXPECT().
+		WithinTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).
+		AnyTimes()
+
+	return NewRecorder(auditRepo, transactor), auditRepo
+}
+
+func TestAuditService_Find(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	auditRepo := mocks.NewMockIAuditRepository(ctrl)
+	svc := NewService(auditRepo)
+
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 3, 0)
+
+	testCases := []struct {
+		name       string
+		filter     *domain.AuditFilter
+		beforeTest func()
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name:   "успешное получение",
+			filter: &domain.AuditFilter{EntityType: domain.AuditEntityFinReport, From: from, To: to},
+			beforeTest: func() {
+				auditRepo.EXPECT().
+					Find(gomock.Any(), &domain.AuditFilter{EntityType: domain.AuditEntityFinReport, From: from, To: to}, 1).
+					Return([]*domain.AuditEntry{{ID: uuid.UUID{1}}}, 1, nil)
+			},
+		},
+		{
+			name:    "конец периода раньше начала",
+			filter:  &domain.AuditFilter{From: to, To: from},
+			wantErr: true,
+			errStr:  errors.New("конец периода должен быть позже его начала"),
+		},
+		{
+			name:   "ошибка выполнения запроса в репозитории",
+			filter: &domain.AuditFilter{},
+			beforeTest: func() {
+				auditRepo.EXPECT().
+					Find(gomock.Any(), &domain.AuditFilter{}, 1).
+					Return(nil, 0, fmt.Errorf("sql error"))
+			},
+			wantErr: true,
+			errStr:  errors.New("получение журнала аудита: sql error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.beforeTest != nil {
+				tc.beforeTest()
+			}
+
+			_, _, err := svc.Find(context.Background(), tc.filter, 1)
+
+			if tc.wantErr {
+				require.Equal(t, tc.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestFinReportService_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rec, auditRepo := newTestRecorder(ctrl)
+	finSvc := mocks.NewMockIFinancialReportService(ctrl)
+	svc := NewFinReportService(finSvc, rec)
+
+	actor := &domain.Actor{ID: uuid.UUID{9}, Role: "admin"}
+	before := &domain.FinancialReport{ID: uuid.UUID{1}, CompanyID: uuid.UUID{2}, Revenue: 100, Costs: 50, Year: 2024, Quarter: 1,
+		Status: domain.VerificationUnverified}
+	after := &domain.FinancialReport{ID: uuid.UUID{1}, CompanyID: uuid.UUID{2}, Revenue: 200, Costs: 50, Year: 2024, Quarter: 1,
+		Status: domain.VerificationUnverified}
+
+	testCases := []struct {
+		name       string
+		beforeTest func()
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name: "изменение записывается со снимками до и после",
+			beforeTest: func() {
+				gomock.InOrder(
+					finSvc.EXPECT().GetById(gomock.Any(), uuid.UUID{1}).Return(before, nil),
+					finSvc.EXPECT().Update(gomock.Any(), after).Return(nil),
+					finSvc.EXPECT().GetById(gomock.Any(), uuid.UUID{1}).Return(after, nil),
+				)
+
+				auditRepo.EXPECT().
+					Append(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, entry *domain.AuditEntry) error {
+						require.Equal(t, uuid.UUID{9}, entry.ActorID)
+						require.Equal(t, "admin", entry.ActorRole)
+						require.Equal(t, domain.AuditUpdate, entry.Action)
+						require.Equal(t, domain.AuditEntityFinReport, entry.EntityType)
+						require.Equal(t, uuid.UUID{1}.String(), entry.EntityID)
+						require.JSONEq(t, `{"id": "01000000-0000-0000-0000-000000000000",
+							"company_id": "02000000-0000-0000-0000-000000000000",
+							"revenue": 100, "costs": 50, "year": 2024, "quarter": 1,
+							"ebitda": null, "net_profit": null, "assets": null, "liabilities": null,
+							"equity": null, "headcount": null, "exports_share": null,
+							"status": "unverified", "review_comment": "",
+							"reviewer_id": "00000000-0000-0000-0000-000000000000",
+							"reviewed_at": "0001-01-01T00:00:00Z"}`, string(entry.Before))
+						require.JSONEq(t, `{"id": "01000000-0000-0000-0000-000000000000",
+							"company_id": "02000000-0000-0000-0000-000000000000",
+							"revenue": 200, "costs": 50, "year": 2024, "quarter": 1,
+							"ebitda": null, "net_profit": null, "assets": null, "liabilities": null,
+							"equity": null, "headcount": null, "exports_share": null,
+							"status": "unverified", "review_comment": "",
+							"reviewer_id": "00000000-0000-0000-0000-000000000000",
+							"reviewed_at": "0001-01-01T00:00:00Z"}`, string(entry.After))
+						return nil
+					})
+			},
+		},
+		{
+			name: "неудачное изменение не записывается",
+			beforeTest: func() {
+				finSvc.EXPECT().GetById(gomock.Any(), uuid.UUID{1}).Return(before, nil)
+				finSvc.EXPECT().Update(gomock.Any(), after).Return(fmt.Errorf("обновление отчета: sql error"))
+			},
+			wantErr: true,
+			errStr:  errors.New("обновление отчета: sql error"),
+		},
+		{
+			name: "ошибка записи в журнал",
+			beforeTest: func() {
+				finSvc.EXPECT().GetById(gomock.Any(), uuid.UUID{1}).Return(before, nil)
+				finSvc.EXPECT().Update(gomock.Any(), after).Return(nil)
+				finSvc.EXPECT().GetById(gomock.Any(), uuid.UUID{1}).Return(after, nil)
+				auditRepo.EXPECT().
+					Append(gomock.Any(), gomock.Any()).
+					Return(fmt.Errorf("запись в журнал аудита: sql error"))
+			},
+			wantErr: true,
+			errStr:  errors.New("запись в журнал аудита: sql error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.beforeTest()
+
+			err := svc.Update(domain.WithActor(context.Background(), actor), after)
+
+			if tc.wantErr {
+				require.Equal(t, tc.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestRoleService_AssignToUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rec, auditRepo := newTestRecorder(ctrl)
+	roleSvc := mocks.NewMockIRoleService(ctrl)
+	userRepo := mocks.NewMockIUserRepository(ctrl)
+	svc := NewRoleService(roleSvc, rec, userRepo)
+
+	userRepo.EXPECT().GetById(gomock.Any(), uuid.UUID{1}).Return(&domain.User{ID: uuid.UUID{1}, Role: "user"}, nil)
+	roleSvc.EXPECT().AssignToUser(gomock.Any(), uuid.UUID{1}, "admin").Return(nil)
+	auditRepo.EXPECT().
+		Append(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, entry *domain.AuditEntry) error {
+			require.Equal(t, uuid.Nil, entry.ActorID)
+			require.Equal(t, domain.AuditAssignRole, entry.Action)
+			require.Equal(t, domain.AuditEntityUser, entry.EntityType)
+			require.Equal(t, uuid.UUID{1}.String(), entry.EntityID)
+			require.JSONEq(t, `{"role": "user"}`, string(entry.Before))
+			require.JSONEq(t, `{"role": "admin"}`, string(entry.After))
+			return nil
+		})
+
+	err := svc.AssignToUser(context.Background(), uuid.UUID{1}, "admin")
+	require.Nil(t, err)
+}
+
+func TestReviewService_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rec, auditRepo := newTestRecorder(ctrl)
+	revSvc := mocks.NewMockIReviewService(ctrl)
+	svc := NewReviewService(revSvc, rec)
+
+	testCases := []struct {
+		name       string
+		beforeTest func()
+	}{
+		{
+			name: "удаление записывается со снимком до изменения",
+			beforeTest: func() {
+				revSvc.EXPECT().Get(gomock.Any(), uuid.UUID{1}).Return(&domain.Review{ID: uuid.UUID{1}, Rating: 1}, nil)
+				revSvc.EXPECT().Delete(gomock.Any(), uuid.UUID{1}).Return(nil)
+				auditRepo.EXPECT().
+					Append(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, entry *domain.AuditEntry) error {
+						require.Equal(t, domain.AuditDelete, entry.Action)
+						require.NotNil(t, entry.Before)
+						require.Nil(t, entry.After)
+						return nil
+					})
+			},
+		},
+		{
+			name: "удаление несуществующего отзыва не записывается",
+			beforeTest: func() {
+				revSvc.EXPECT().
+					Get(gomock.Any(), uuid.UUID{1}).
+					Return(nil, domain.NewNotFoundError(errors.New("получение отзыва по id: объект не найден")))
+				revSvc.EXPECT().Delete(gomock.Any(), uuid.UUID{1}).Return(nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.beforeTest()
+
+			err := svc.Delete(context.Background(), uuid.UUID{1})
+			require.Nil(t, err)
+		})
+	}
+}
+
+func TestUserSkillService_DeleteSkillsForUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rec, auditRepo := newTestRecorder(ctrl)
+	userSkillSvc := mocks.NewMockIUserSkillService(ctrl)
+	svc := NewUserSkillService(userSkillSvc, rec)
+
+	userSkillSvc.EXPECT().
+		GetSkillsForUser(gomock.Any(), uuid.UUID{1}, 0, false).
+		Return([]*domain.Skill{{ID: uuid.UUID{2}}, {ID: uuid.UUID{3}}}, 0, nil)
+	userSkillSvc.EXPECT().DeleteSkillsForUser(gomock.Any(), uuid.UUID{1}).Return(nil)
+
+	var ids []string
+	auditRepo.EXPECT().
+		Append(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, entry *domain.AuditEntry) error {
+			ids = append(ids, entry.EntityID)
+			return nil
+		}).
+		Times(2)
+
+	err := svc.DeleteSkillsForUser(context.Background(), uuid.UUID{1})
+	require.Nil(t, err)
+	require.Equal(t, []string{
+		uuid.UUID{1}.String() + "/" + uuid.UUID{2}.String(),
+		uuid.UUID{1}.String() + "/" + uuid.UUID{3}.String(),
+	}, ids)
+}
+
+func TestWebhookService_Subscribe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rec, auditRepo := newTestRecorder(ctrl)
+	webhookSvc := mocks.NewMockIWebhookService(ctrl)
+	svc := NewWebhookService(webhookSvc, rec)
+
+	sub := &domain.WebhookSubscription{URL: "https://example.com", Secret: "0123456789abcdef"}
+	webhookSvc.EXPECT().Subscribe(gomock.Any(), sub).Return(nil)
+	auditRepo.EXPECT().
+		Append(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, entry *domain.AuditEntry) error {
+			require.NotContains(t, string(entry.After), "0123456789abcdef")
+			return nil
+		})
+
+	err := svc.Subscribe(context.Background(), sub)
+	require.Nil(t, err)
+	require.Equal(t, "0123456789abcdef", sub.Secret)
+}
+
+func TestSnakeCase(t *testing.T) {
+	testCases := []struct {
+		name string
+		want string
+	}{
+		{name: "ID", want: "id"},
+		{name: "URL", want: "url"},
+		{name: "CompanyID", want: "company_id"},
+		{name: "ActivityFieldId", want: "activity_field_id"},
+		{name: "EventTypes", want: "event_types"},
+		{name: "HTTPStatus", want: "http_status"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, snakeCase(tc.name))
+		})
+	}
+}
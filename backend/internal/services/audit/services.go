@@ -0,0 +1,574 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"ppo/domain"
+
+	"github.com/google/uuid"
+)
+
+// Декораторы ниже записывают в журнал изменяющие методы сервисов,
+// остальные методы передаются сервису без изменений. Снимок после изменения
+// перечитывается из хранилища, если сервис мог дополнить сущность сам.
+
+type AuthService struct {
+	domain.IAuthService
+	rec *Recorder
+}
+
+func NewAuthService(svc domain.IAuthService, rec *Recorder) domain.IAuthService {
+	return &AuthService{IAuthService: svc, rec: rec}
+}
+
+// Register записывает в журнал создание пользователя; пароль в снимок не попадает.
+func (s *AuthService) Register(ctx context.Context, authInfo *domain.UserAuth) error {
+	return s.rec.track(ctx, change{
+		action: domain.AuditCreate,
+		entity: domain.AuditEntityUser,
+		id:     func() string { return authInfo.ID.String() },
+		apply:  func(ctx context.Context) error { return s.IAuthService.Register(ctx, authInfo) },
+		after: func(context.Context) (any, error) {
+			return map[string]any{"id": authInfo.ID, "username": authInfo.Username}, nil
+		},
+	})
+}
+
+type UserService struct {
+	domain.IUserService
+	rec *Recorder
+}
+
+func NewUserService(svc domain.IUserService, rec *Recorder) domain.IUserService {
+	return &UserService{IUserService: svc, rec: rec}
+}
+
+func (s *UserService) get(id uuid.UUID) func(context.Context) (any, error) {
+	return func(ctx context.Context) (any, error) { return s.IUserService.GetById(ctx, id) }
+}
+
+func (s *UserService) Create(ctx context.Context, user *domain.User) error {
+	return s.rec.track(ctx, change{
+		action: domain.AuditCreate,
+		entity: domain.AuditEntityUser,
+		id:     func() string { return user.ID.String() },
+		apply:  func(ctx context.Context) error { return s.IUserService.Create(ctx, user) },
+		after:  func(ctx context.Context) (any, error) { return s.IUserService.GetById(ctx, user.ID) },
+	})
+}
+
+func (s *UserService) Update(ctx context.Context, user *domain.User) error {
+	return s.rec.track(ctx, change{
+		action: domain.AuditUpdate,
+		entity: domain.AuditEntityUser,
+		id:     user.ID.String,
+		before: s.get(user.ID),
+		apply:  func(ctx context.Context) error { return s.IUserService.Update(ctx, user) },
+		after:  s.get(user.ID),
+	})
+}
+
+func (s *UserService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	return s.rec.track(ctx, change{
+		action: domain.AuditDelete,
+		entity: domain.AuditEntityUser,
+		id:     id.String,
+		before: s.get(id),
+		apply:  func(ctx context.Context) error { return s.IUserService.DeleteById(ctx, id) },
+	})
+}
+
+type RoleService struct {
+	domain.IRoleService
+	rec      *Recorder
+	userRepo domain.IUserRepository
+}
+
+// NewRoleService создаёт декоратор сервиса ролей; userRepo нужен,
+// чтобы записать прежнюю роль пользователя при назначении новой.
+func NewRoleService(svc domain.IRoleService, rec *Recorder, userRepo domain.IUserRepository) domain.IRoleService {
+	return &RoleService{IRoleService: svc, rec: rec, userRepo: userRepo}
+}
+
+func (s *RoleService) get(name string) func(context.Context) (any, error) {
+	return func(ctx context.Context) (any, error) { return s.IRoleService.GetByName(ctx, name) }
+}
+
+func (s *RoleService) Create(ctx context.Context, role *domain.Role) error {
+	return s.rec.track(ctx, change{
+		action: domain.AuditCreate,
+		entity: domain.AuditEntityRole,
+		id:     func() string { return role.Name },
+		apply:  func(ctx context.Context) error { return s.IRoleService.Create(ctx, role) },
+		after:  s.get(role.Name),
+	})
+}
+
+func (s *RoleService) Update(ctx context.Context, role *domain.Role) error {
+	return s.rec.track(ctx, change{
+		action: domain.AuditUpdate,
+		entity: domain.AuditEntityRole,
+		id:     func() string { return role.Name },
+		before: s.get(role.Name),
+		apply:  func(ctx context.Context) error { return s.IRoleService.Update(ctx, role) },
+		after:  s.get(role.Name),
+	})
+}
+
+func (s *RoleService) DeleteByName(ctx context.Context, name string) error {
+	return s.rec.track(ctx, change{
+		action: domain.AuditDelete,
+		entity: domain.AuditEntityRole,
+		id:     func() string { return name },
+		before: s.get(name),
+		apply:  func(ctx context.Context) error { return s.IRoleService.DeleteByName(ctx, name) },
+	})
+}
+
+// AssignToUser записывает смену роли как изменение пользователя.
+func (s *RoleService) AssignToUser(ctx context.Context, userId uuid.UUID, name string) error {
+	return s.rec.track(ctx, change{
+		action: domain.AuditAssignRole,
+		entity: domain.AuditEntityUser,
+		id:     userId.String,
+		before: func(ctx context.Context) (any, error) {
+			user, err := s.userRepo.GetById(ctx, userId)
+			if err != nil {
+				return nil, err
+			}
+
+			return map[string]any{"role": user.Role}, nil
+		},
+		apply: func(ctx context.Context) error { return s.IRoleService.AssignToUser(ctx, userId, name) },
+		after: func(context.Context) (any, error) { return map[string]any{"role": name}, nil },
+	})
+}
+
+type FinReportService struct {
+	domain.IFinancialReportService
+	rec *Recorder
+}
+
+func NewFinReportService(svc domain.IFinancialReportService, rec *Recorder) domain.IFinancialReportService {
+	return &FinReportService{IFinancialReportService: svc, rec: rec}
+}
+
+func (s *FinReportService) get(id uuid.UUID) func(context.Context) (any, error) {
+	return func(ctx context.Context) (any, error) { return s.IFinancialReportService.GetById(ctx, id) }
+}
+
+func (s *FinReportService) Create(ctx context.Context, report *domain.FinancialReport) error {
+	return s.rec.track(ctx, change{
+		action: domain.AuditCreate,
+		entity: domain.AuditEntityFinReport,
+		id:     func() string { return report.ID.String() },
+		apply:  func(ctx context.Context) error { return s.IFinancialReportService.Create(ctx, report) },
+		after:  func(ctx context.Context) (any, error) { return s.IFinancialReportService.GetById(ctx, report.ID) },
+	})
+}
+
+// CreateByPeriod добавляет отчёты по одному через Create, чтобы каждый получил
+// свою запись в журнале; отчёты периода добавляются атомарно.
+func (s *FinReportService) CreateByPeriod(ctx context.Context, byPeriod *domain.FinancialReportByPeriod) error {
+	err := s.rec.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		for i := range byPeriod.Reports {
+			err := s.Create(ctx, &byPeriod.Reports[i])
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("добавление отчетов за период: %w", err)
+	}
+
+	return nil
+}
+
+func (s *FinReportService) Update(ctx context.Context, report *domain.FinancialReport) error {
+	return s.rec.track(ctx, change{
+		action: domain.AuditUpdate,
+		entity: domain.AuditEntityFinReport,
+		id:     report.ID.String,
+		before: s.get(report.ID),
+		apply:  func(ctx context.Context) error { return s.IFinancialReportService.Update(ctx, report) },
+		after:  s.get(report.ID),
+	})
+}
+
+func (s *FinReportService) Amend(ctx context.Context, amendment *domain.FinancialReportRevision) error {
+	return s.rec.track(ctx, change{
+		action: domain.AuditAmend,
+		entity: domain.AuditEntityFinReport,
+		id:     amendment.ReportID.String,
+		before: s.get(amendment.ReportID),
+		apply:  func(ctx context.Context) error { return s.IFinancialReportService.Amend(ctx, amendment) },
+		after:  s.get(amendment.ReportID),
+	})
+}
+
+func (s *FinReportService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	return s.rec.track(ctx, change{
+		action: domain.AuditDelete,
+		entity: domain.AuditEntityFinReport,
+		id:     id.String,
+		before: s.get(id),
+		apply:  func(ctx context.Context) error { return s.IFinancialReportService.DeleteById(ctx, id) },
+	})
+}
+
+func (s *FinReportService) Submit(ctx context.Context, id uuid.UUID) error {
+	return s.rec.track(ctx, change{
+		action: domain.AuditSubmit,
+		entity: domain.AuditEntityFinReport,
+		id:     id.String,
+		before: s.get(id),
+		apply:  func(ctx context.Context) error { return s.IFinancialReportService.Submit(ctx, id) },
+		after:  s.get(id),
+	})
+}
+
+func (s *FinReportService) Review(ctx context.Context, id uuid.UUID, status domain.VerificationStatus, comment string) error {
+	return s.rec.track(ctx, change{
+		action: domain.AuditReview,
+		entity: domain.AuditEntityFinReport,
+		id:     id.String,
+		before: s.get(id),
+		apply: func(ctx context.Context) error {
+			return s.IFinancialReportService.Review(ctx, id, status, comment)
+		},
+		after: s.get(id),
+	})
+}
+
+// AttachDocument записывает в журнал только сведения о документе, без содержимого.
+func (s *FinReportService) AttachDocument(ctx context.Context, doc *domain.FinancialReportDocument, content io.Reader) error {
+	return s.rec.track(ctx, change{
+		action: domain.AuditCreate,
+		entity: domain.AuditEntityFinDocument,
+		id:     func() string { return doc.ID.String() },
+		apply: func(ctx context.Context) error {
+			return s.IFinancialReportService.AttachDocument(ctx, doc, content)
+		},
+		after: func(context.Context) (any, error) { return doc, nil },
+	})
+}
+
+func (s *FinReportService) DeleteDocument(ctx context.Context, id uuid.UUID) error {
+	return s.rec.track(ctx, change{
+		action: domain.AuditDelete,
+		entity: domain.AuditEntityFinDocument,
+		id:     id.String,
+		apply:  func(ctx context.Context) error { return s.IFinancialReportService.DeleteDocument(ctx, id) },
+	})
+}
+
+type CompanyService struct {
+	domain.ICompanyService
+	rec *Recorder
+}
+
+func NewCompanyService(svc domain.ICompanyService, rec *Recorder) domain.ICompanyService {
+	return &CompanyService{ICompanyService: svc, rec: rec}
+}
+
+func (s *CompanyService) get(id uuid.UUID) func(context.Context) (any, error) {
+	return func(ctx context.Context) (any, error) { return s.ICompanyService.GetById(ctx, id) }
+}
+
+func (s *CompanyService) Create(ctx context.Context, company *domain.Company) error {
+	return s.rec.track(ctx, change{
+		action: domain.AuditCreate,
+		entity: domain.AuditEntityCompany,
+		id:     func() string { return company.ID.String() },
+		apply:  func(ctx context.Context) error { return s.ICompanyService.Create(ctx, company) },
+		after:  func(ctx context.Context) (any, error) { return s.ICompanyService.GetById(ctx, company.ID) },
+	})
+}
+
+func (s *CompanyService) Update(ctx context.Context, company *domain.Company) error {
+	return s.rec.track(ctx, change{
+		action: domain.AuditUpdate,
+		entity: domain.AuditEntityCompany,
+		id:     company.ID.String,
+		before: s.get(company.ID),
+		apply:  func(ctx context.Context) error { return s.ICompanyService.Update(ctx, company) },
+		after:  s.get(company.ID),
+	})
+}
+
+func (s *CompanyService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	return s.rec.track(ctx, change{
+		action: domain.AuditDelete,
+		entity: domain.AuditEntityCompany,
+		id:     id.String,
+		before: s.get(id),
+		apply:  func(ctx context.Context) error { return s.ICompanyService.DeleteById(ctx, id) },
+	})
+}
+
+type ContactsService struct {
+	domain.IContactsService
+	rec *Recorder
+}
+
+func NewContactsService(svc domain.IContactsService, rec *Recorder) domain.IContactsService {
+	return &ContactsService{IContactsService: svc, rec: rec}
+}
+
+func (s *ContactsService) get(id uuid.UUID) func(context.Context) (any, error) {
+	return func(ctx context.Context) (any, error) { return s.IContactsService.GetById(ctx, id) }
+}
+
+func (s *ContactsService) Create(ctx context.Context, contact *domain.Contact) error {
+	return s.rec.track(ctx, change{
+		action: domain.AuditCreate,
+		entity: domain.AuditEntityContact,
+		id:     func() string { return contact.ID.String() },
+		apply:  func(ctx context.Context) error { return s.IContactsService.Create(ctx, contact) },
+		after:  func(ctx context.Context) (any, error) { return s.IContactsService.GetById(ctx, contact.ID) },
+	})
+}
+
+func (s *ContactsService) Update(ctx context.Context, contact *domain.Contact) error {
+	return s.rec.track(ctx, change{
+		action: domain.AuditUpdate,
+		entity: domain.AuditEntityContact,
+		id:     contact.ID.String,
+		before: s.get(contact.ID),
+		apply:  func(ctx context.Context) error { return s.IContactsService.Update(ctx, contact) },
+		after:  s.get(contact.ID),
+	})
+}
+
+func (s *ContactsService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	return s.rec.track(ctx, change{
+		action: domain.AuditDelete,
+		entity: domain.AuditEntityContact,
+		id:     id.String,
+		before: s.get(id),
+		apply:  func(ctx context.Context) error { return s.IContactsService.DeleteById(ctx, id) },
+	})
+}
+
+type SkillService struct {
+	domain.ISkillService
+	rec *Recorder
+}
+
+func NewSkillService(svc domain.ISkillService, rec *Recorder) domain.ISkillService {
+	return &SkillService{ISkillService: svc, rec: rec}
+}
+
+func (s *SkillService) get(id uuid.UUID) func(context.Context) (any, error) {
+	return func(ctx context.Context) (any, error) { return s.ISkillService.GetById(ctx, id) }
+}
+
+func (s *SkillService) Create(ctx context.Context, skill *domain.Skill) error {
+	return s.rec.track(ctx, change{
+		action: domain.AuditCreate,
+		entity: domain.AuditEntitySkill,
+		id:     func() string { return skill.ID.String() },
+		apply:  func(ctx context.Context) error { return s.ISkillService.Create(ctx, skill) },
+		after:  func(ctx context.Context) (any, error) { return s.ISkillService.GetById(ctx, skill.ID) },
+	})
+}
+
+func (s *SkillService) Update(ctx context.Context, skill *domain.Skill) error {
+	return s.rec.track(ctx, change{
+		action: domain.AuditUpdate,
+		entity: domain.AuditEntitySkill,
+		id:     skill.ID.String,
+		before: s.get(skill.ID),
+		apply:  func(ctx context.Context) error { return s.ISkillService.Update(ctx, skill) },
+		after:  s.get(skill.ID),
+	})
+}
+
+func (s *SkillService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	return s.rec.track(ctx, change{
+		action: domain.AuditDelete,
+		entity: domain.AuditEntitySkill,
+		id:     id.String,
+		before: s.get(id),
+		apply:  func(ctx context.Context) error { return s.ISkillService.DeleteById(ctx, id) },
+	})
+}
+
+type UserSkillService struct {
+	domain.IUserSkillService
+	rec *Recorder
+}
+
+func NewUserSkillService(svc domain.IUserSkillService, rec *Recorder) domain.IUserSkillService {
+	return &UserSkillService{IUserSkillService: svc, rec: rec}
+}
+
+// userSkillID — id связки пользователь-навык в журнале: id пользователя и навыка через «/».
+func userSkillID(pair *domain.UserSkill) string {
+	return pair.UserId.String() + "/" + pair.SkillId.String()
+}
+
+func (s *UserSkillService) Create(ctx context.Context, pair *domain.UserSkill) error {
+	return s.rec.track(ctx, change{
+		action: domain.AuditCreate,
+		entity: domain.AuditEntityUserSkill,
+		id:     func() string { return userSkillID(pair) },
+		apply:  func(ctx context.Context) error { return s.IUserSkillService.Create(ctx, pair) },
+		after:  func(context.Context) (any, error) { return pair, nil },
+	})
+}
+
+func (s *UserSkillService) Delete(ctx context.Context, pair *domain.UserSkill) error {
+	return s.rec.track(ctx, change{
+		action: domain.AuditDelete,
+		entity: domain.AuditEntityUserSkill,
+		id:     func() string { return userSkillID(pair) },
+		before: func(context.Context) (any, error) { return pair, nil },
+		apply:  func(ctx context.Context) error { return s.IUserSkillService.Delete(ctx, pair) },
+	})
+}
+
+// DeleteSkillsForUser записывает удаление каждой связки пользователя отдельно.
+func (s *UserSkillService) DeleteSkillsForUser(ctx context.Context, userId uuid.UUID) error {
+	return s.rec.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		skills, _, err := s.IUserSkillService.GetSkillsForUser(ctx, userId, 0, false)
+		if err != nil {
+			return err
+		}
+
+		err = s.IUserSkillService.DeleteSkillsForUser(ctx, userId)
+		if err != nil {
+			return err
+		}
+
+		for _, skill := range skills {
+			pair := &domain.UserSkill{UserId: userId, SkillId: skill.ID}
+			err = s.rec.record(ctx, domain.AuditDelete, domain.AuditEntityUserSkill, userSkillID(pair), pair, nil)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+type ActivityFieldService struct {
+	domain.IActivityFieldService
+	rec *Recorder
+}
+
+func NewActivityFieldService(svc domain.IActivityFieldService, rec *Recorder) domain.IActivityFieldService {
+	return &ActivityFieldService{IActivityFieldService: svc, rec: rec}
+}
+
+func (s *ActivityFieldService) get(id uuid.UUID) func(context.Context) (any, error) {
+	return func(ctx context.Context) (any, error) { return s.IActivityFieldService.GetById(ctx, id) }
+}
+
+func (s *ActivityFieldService) Create(ctx context.Context, field *domain.ActivityField) error {
+	return s.rec.track(ctx, change{
+		action: domain.AuditCreate,
+		entity: domain.AuditEntityActivityField,
+		id:     func() string { return field.ID.String() },
+		apply:  func(ctx context.Context) error { return s.IActivityFieldService.Create(ctx, field) },
+		after:  func(ctx context.Context) (any, error) { return s.IActivityFieldService.GetById(ctx, field.ID) },
+	})
+}
+
+func (s *ActivityFieldService) Update(ctx context.Context, field *domain.ActivityField) error {
+	return s.rec.track(ctx, change{
+		action: domain.AuditUpdate,
+		entity: domain.AuditEntityActivityField,
+		id:     field.ID.String,
+		before: s.get(field.ID),
+		apply:  func(ctx context.Context) error { return s.IActivityFieldService.Update(ctx, field) },
+		after:  s.get(field.ID),
+	})
+}
+
+func (s *ActivityFieldService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	return s.rec.track(ctx, change{
+		action: domain.AuditDelete,
+		entity: domain.AuditEntityActivityField,
+		id:     id.String,
+		before: s.get(id),
+		apply:  func(ctx context.Context) error { return s.IActivityFieldService.DeleteById(ctx, id) },
+	})
+}
+
+type ReviewService struct {
+	domain.IReviewService
+	rec *Recorder
+}
+
+func NewReviewService(svc domain.IReviewService, rec *Recorder) domain.IReviewService {
+	return &ReviewService{IReviewService: svc, rec: rec}
+}
+
+func (s *ReviewService) Create(ctx context.Context, rev *domain.Review) error {
+	return s.rec.track(ctx, change{
+		action: domain.AuditCreate,
+		entity: domain.AuditEntityReview,
+		id:     func() string { return rev.ID.String() },
+		apply:  func(ctx context.Context) error { return s.IReviewService.Create(ctx, rev) },
+		after:  func(ctx context.Context) (any, error) { return s.IReviewService.Get(ctx, rev.ID) },
+	})
+}
+
+func (s *ReviewService) Delete(ctx context.Context, id uuid.UUID) error {
+	return s.rec.track(ctx, change{
+		action: domain.AuditDelete,
+		entity: domain.AuditEntityReview,
+		id:     id.String,
+		before: func(ctx context.Context) (any, error) { return s.IReviewService.Get(ctx, id) },
+		apply:  func(ctx context.Context) error { return s.IReviewService.Delete(ctx, id) },
+	})
+}
+
+type WebhookService struct {
+	domain.IWebhookService
+	rec *Recorder
+}
+
+func NewWebhookService(svc domain.IWebhookService, rec *Recorder) domain.IWebhookService {
+	return &WebhookService{IWebhookService: svc, rec: rec}
+}
+
+// withoutSecret — снимок подписки для журнала: секрет в журнал не попадает.
+func withoutSecret(sub *domain.WebhookSubscription) *domain.WebhookSubscription {
+	redacted := *sub
+	redacted.Secret = ""
+	return &redacted
+}
+
+func (s *WebhookService) Subscribe(ctx context.Context, sub *domain.WebhookSubscription) error {
+	return s.rec.track(ctx, change{
+		action: domain.AuditCreate,
+		entity: domain.AuditEntityWebhook,
+		id:     func() string { return sub.ID.String() },
+		apply:  func(ctx context.Context) error { return s.IWebhookService.Subscribe(ctx, sub) },
+		after:  func(context.Context) (any, error) { return withoutSecret(sub), nil },
+	})
+}
+
+func (s *WebhookService) Unsubscribe(ctx context.Context, id uuid.UUID) error {
+	return s.rec.track(ctx, change{
+		action: domain.AuditDelete,
+		entity: domain.AuditEntityWebhook,
+		id:     id.String,
+		before: func(ctx context.Context) (any, error) {
+			sub, err := s.IWebhookService.GetSubscription(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+
+			return withoutSecret(sub), nil
+		},
+		apply: func(ctx context.Context) error { return s.IWebhookService.Unsubscribe(ctx, id) },
+	})
+}
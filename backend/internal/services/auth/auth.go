@@ -2,32 +2,37 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"ppo/domain"
 	"ppo/pkg/base"
+	"ppo/pkg/i18n"
+	"time"
 )
 
 type Service struct {
 	authRepo domain.IAuthRepository
 	crypto   base.IHashCrypto
 	jwtKey   string
+	tokenTTL time.Duration
 }
 
-func NewService(repo domain.IAuthRepository, crypto base.IHashCrypto, jwtKey string) domain.IAuthService {
+func NewService(repo domain.IAuthRepository, crypto base.IHashCrypto, jwtKey string, tokenTTL time.Duration) domain.IAuthService {
 	return &Service{
 		authRepo: repo,
 		crypto:   crypto,
 		jwtKey:   jwtKey,
+		tokenTTL: tokenTTL,
 	}
 }
 
 func (s *Service) Register(ctx context.Context, authInfo *domain.UserAuth) (err error) {
 	if authInfo.Username == "" {
-		return fmt.Errorf("должно быть указано имя пользователя")
+		return domain.NewValidationError("username", i18n.MsgUsernameRequired)
 	}
 
 	if authInfo.Password == "" {
-		return fmt.Errorf("должен быть указан пароль")
+		return domain.NewValidationError("password", i18n.MsgPasswordRequired)
 	}
 
 	hashedPass, err := s.crypto.GenerateHashPass(authInfo.Password)
@@ -47,23 +52,26 @@ func (s *Service) Register(ctx context.Context, authInfo *domain.UserAuth) (err
 
 func (s *Service) Login(ctx context.Context, authInfo *domain.UserAuth) (token string, err error) {
 	if authInfo.Username == "" {
-		return "", fmt.Errorf("должно быть указано имя пользователя")
+		return "", domain.NewValidationError("username", i18n.MsgUsernameRequired)
 	}
 
 	if authInfo.Password == "" {
-		return "", fmt.Errorf("должен быть указан пароль")
+		return "", domain.NewValidationError("password", i18n.MsgPasswordRequired)
 	}
 
 	userAuth, err := s.authRepo.GetByUsername(ctx, authInfo.Username)
+	if errors.Is(err, domain.ErrNotFound) {
+		return "", domain.ErrInvalidCredentials
+	}
 	if err != nil {
 		return "", fmt.Errorf("получение пользователя по username: %w", err)
 	}
 
 	if !s.crypto.CheckPasswordHash(authInfo.Password, userAuth.HashedPass) {
-		return "", fmt.Errorf("неверный пароль")
+		return "", domain.ErrInvalidCredentials
 	}
 
-	token, err = base.GenerateAuthToken(userAuth.ID.String(), s.jwtKey, userAuth.Role)
+	token, err = base.GenerateAuthToken(userAuth.ID.String(), s.jwtKey, userAuth.Role, s.tokenTTL)
 	if err != nil {
 		return "", fmt.Errorf("генерация токена: %w", err)
 	}
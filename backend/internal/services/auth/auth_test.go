@@ -10,6 +10,7 @@ import (
 	"ppo/mocks"
 	"ppo/pkg/base"
 	"testing"
+	"time"
 )
 
 func TestAuthService_Login(t *testing.T) {
@@ -19,7 +20,7 @@ func TestAuthService_Login(t *testing.T) {
 	jwtKey := "abcdefgh123"
 	repo := mocks.NewMockIAuthRepository(ctrl)
 	crypto := mocks.NewMockIHashCrypto(ctrl)
-	svc := NewService(repo, crypto, jwtKey)
+	svc := NewService(repo, crypto, jwtKey, time.Hour)
 
 	testCases := []struct {
 		name       string
@@ -101,7 +102,24 @@ func TestAuthService_Login(t *testing.T) {
 					Return(false)
 			},
 			wantErr: true,
-			errStr:  errors.New("неверный пароль"),
+			errStr:  domain.ErrInvalidCredentials,
+		},
+		{
+			name: "неизвестный пользователь",
+			authInfo: &domain.UserAuth{
+				Username: "test123",
+				Password: "pass123",
+			},
+			beforeTest: func(authRepo mocks.MockIAuthRepository, crypto mocks.MockIHashCrypto) {
+				authRepo.EXPECT().
+					GetByUsername(
+						context.Background(),
+						"test123",
+					).
+					Return(nil, fmt.Errorf("получение пользователя: %w", domain.ErrNotFound))
+			},
+			wantErr: true,
+			errStr:  domain.ErrInvalidCredentials,
 		},
 	}
 	for _, tc := range testCases {
@@ -130,7 +148,7 @@ func TestAuthService_Register(t *testing.T) {
 
 	repo := mocks.NewMockIAuthRepository(ctrl)
 	crypto := mocks.NewMockIHashCrypto(ctrl)
-	svc := NewService(repo, crypto, "abcdefgh123")
+	svc := NewService(repo, crypto, "abcdefgh123", time.Hour)
 
 	testCases := []struct {
 		name       string
@@ -5,30 +5,37 @@ import (
 	"fmt"
 	"github.com/google/uuid"
 	"ppo/domain"
+	"ppo/pkg/i18n"
 )
 
 type Service struct {
 	actFieldRepo domain.IActivityFieldRepository
 	companyRepo  domain.ICompanyRepository
+	transactor   domain.ITransactor
+	bus          domain.IEventBus
 }
 
 func NewService(
 	companyRepo domain.ICompanyRepository,
 	actFieldRepo domain.IActivityFieldRepository,
+	transactor domain.ITransactor,
+	bus domain.IEventBus,
 ) domain.ICompanyService {
 	return &Service{
 		companyRepo:  companyRepo,
 		actFieldRepo: actFieldRepo,
+		transactor:   transactor,
+		bus:          bus,
 	}
 }
 
 func (s *Service) Create(ctx context.Context, company *domain.Company) (err error) {
 	if company.Name == "" {
-		return fmt.Errorf("должно быть указано название компании")
+		return domain.NewValidationError("name", i18n.MsgCompanyNameRequired)
 	}
 
 	if company.City == "" {
-		return fmt.Errorf("должно быть указано название города")
+		return domain.NewValidationError("city", i18n.MsgCityRequired)
 	}
 
 	_, err = s.actFieldRepo.GetById(ctx, company.ActivityFieldId)
@@ -36,7 +43,20 @@ func (s *Service) Create(ctx context.Context, company *domain.Company) (err erro
 		return fmt.Errorf("добавление компании (поиск сферы деятельности): %w", err)
 	}
 
-	err = s.companyRepo.Create(ctx, company)
+	err = s.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		err := s.companyRepo.Create(ctx, company)
+		if err != nil {
+			return err
+		}
+
+		return s.bus.Publish(ctx, domain.CompanyCreated{
+			ID:              company.ID,
+			OwnerID:         company.OwnerID,
+			ActivityFieldID: company.ActivityFieldId,
+			Name:            company.Name,
+			City:            company.City,
+		})
+	})
 	if err != nil {
 		return fmt.Errorf("добавление компании: %w", err)
 	}
@@ -62,6 +82,15 @@ func (s *Service) GetByOwnerId(ctx context.Context, id uuid.UUID, page int, isPa
 	return companies, numPages, nil
 }
 
+func (s *Service) GetByOwnerIds(ctx context.Context, ids []uuid.UUID) (companies []*domain.Company, err error) {
+	companies, err = s.companyRepo.GetByOwnerIds(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("получение компаний по списку id владельцев: %w", err)
+	}
+
+	return companies, nil
+}
+
 func (s *Service) GetAll(ctx context.Context, page int) (companies []*domain.Company, err error) {
 	companies, err = s.companyRepo.GetAll(ctx, page)
 	if err != nil {
@@ -77,7 +106,20 @@ func (s *Service) Update(ctx context.Context, company *domain.Company) (err erro
 		return fmt.Errorf("обновление информации о компании (поиск сферы деятельности): %w", err)
 	}
 
-	err = s.companyRepo.Update(ctx, company)
+	err = s.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		err := s.companyRepo.Update(ctx, company)
+		if err != nil {
+			return err
+		}
+
+		return s.bus.Publish(ctx, domain.CompanyUpdated{
+			ID:              company.ID,
+			OwnerID:         company.OwnerID,
+			ActivityFieldID: company.ActivityFieldId,
+			Name:            company.Name,
+			City:            company.City,
+		})
+	})
 	if err != nil {
 		return fmt.Errorf("обновление информации о компании: %w", err)
 	}
@@ -86,7 +128,22 @@ func (s *Service) Update(ctx context.Context, company *domain.Company) (err erro
 }
 
 func (s *Service) DeleteById(ctx context.Context, id uuid.UUID) (err error) {
-	err = s.companyRepo.DeleteById(ctx, id)
+	company, err := s.companyRepo.GetById(ctx, id)
+	if err != nil {
+		return fmt.Errorf("удаление компании по id (поиск компании): %w", err)
+	}
+
+	err = s.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		err := s.companyRepo.DeleteById(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return s.bus.Publish(ctx, domain.CompanyDeleted{
+			ID:      company.ID,
+			OwnerID: company.OwnerID,
+		})
+	})
 	if err != nil {
 		return fmt.Errorf("удаление компании по id: %w", err)
 	}
@@ -17,7 +17,14 @@ func TestCompanyService_Create(t *testing.T) {
 	defer ctrl.Finish()
 
 	compRepo := mocks.NewMockICompanyRepository(ctrl)
-	svc := NewService(compRepo)
+	actFieldRepo := mocks.NewMockIActivityFieldRepository(ctrl)
+	transactor := mocks.NewMockITransactor(ctrl)
+	transactor.EXPECT().
+		WithinTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).
+		AnyTimes()
+	bus := mocks.NewMockIEventBus(ctrl)
+	svc := NewService(compRepo, actFieldRepo, transactor, bus)
 
 	testCases := []struct {
 		name       string
@@ -33,6 +40,10 @@ func TestCompanyService_Create(t *testing.T) {
 				City: "ccc",
 			},
 			beforeTest: func(compRepo mocks.MockICompanyRepository) {
+				actFieldRepo.EXPECT().
+					GetById(context.Background(), uuid.UUID{}).
+					Return(&domain.ActivityField{}, nil)
+
 				compRepo.EXPECT().
 					Create(
 						context.Background(),
@@ -41,6 +52,10 @@ func TestCompanyService_Create(t *testing.T) {
 							City: "ccc",
 						},
 					).Return(nil)
+
+				bus.EXPECT().
+					Publish(context.Background(), domain.CompanyCreated{Name: "aaa", City: "ccc"}).
+					Return(nil)
 			},
 			wantErr: false,
 		},
@@ -91,6 +106,10 @@ func TestCompanyService_Create(t *testing.T) {
 				City: "ccc",
 			},
 			beforeTest: func(compRepo mocks.MockICompanyRepository) {
+				actFieldRepo.EXPECT().
+					GetById(context.Background(), uuid.UUID{}).
+					Return(&domain.ActivityField{}, nil)
+
 				compRepo.EXPECT().
 					Create(
 						context.Background(),
@@ -128,9 +147,17 @@ func TestCompanyService_DeleteById(t *testing.T) {
 	defer ctrl.Finish()
 
 	compRepo := mocks.NewMockICompanyRepository(ctrl)
-	svc := NewService(compRepo)
+	actFieldRepo := mocks.NewMockIActivityFieldRepository(ctrl)
+	transactor := mocks.NewMockITransactor(ctrl)
+	transactor.EXPECT().
+		WithinTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).
+		AnyTimes()
+	bus := mocks.NewMockIEventBus(ctrl)
+	svc := NewService(compRepo, actFieldRepo, transactor, bus)
 
 	curUuid := uuid.New()
+	ownerId := uuid.New()
 
 	testCases := []struct {
 		name       string
@@ -143,16 +170,39 @@ func TestCompanyService_DeleteById(t *testing.T) {
 			name: "успешное удаление",
 			id:   curUuid,
 			beforeTest: func(compRepo mocks.MockICompanyRepository) {
+				compRepo.EXPECT().
+					GetById(context.Background(), curUuid).
+					Return(&domain.Company{ID: curUuid, OwnerID: ownerId}, nil)
+
 				compRepo.EXPECT().
 					DeleteById(context.Background(), curUuid).
 					Return(nil)
+
+				bus.EXPECT().
+					Publish(context.Background(), domain.CompanyDeleted{ID: curUuid, OwnerID: ownerId}).
+					Return(nil)
 			},
 			wantErr: false,
 		},
+		{
+			name: "компания не найдена",
+			id:   curUuid,
+			beforeTest: func(compRepo mocks.MockICompanyRepository) {
+				compRepo.EXPECT().
+					GetById(context.Background(), curUuid).
+					Return(nil, fmt.Errorf("sql error"))
+			},
+			wantErr: true,
+			errStr:  errors.New("удаление компании по id (поиск компании): sql error"),
+		},
 		{
 			name: "ошибка выполнения запроса в репозитории",
 			id:   curUuid,
 			beforeTest: func(compRepo mocks.MockICompanyRepository) {
+				compRepo.EXPECT().
+					GetById(context.Background(), curUuid).
+					Return(&domain.Company{ID: curUuid, OwnerID: ownerId}, nil)
+
 				compRepo.EXPECT().
 					DeleteById(context.Background(), curUuid).
 					Return(fmt.Errorf("sql error"))
@@ -184,7 +234,8 @@ func TestCompanyService_GetAll(t *testing.T) {
 	defer ctrl.Finish()
 
 	compRepo := mocks.NewMockICompanyRepository(ctrl)
-	svc := NewService(compRepo)
+	actFieldRepo := mocks.NewMockIActivityFieldRepository(ctrl)
+	svc := NewService(compRepo, actFieldRepo, nil, nil)
 
 	testCases := []struct {
 		name       string
@@ -270,7 +321,8 @@ func TestCompanyService_GetById(t *testing.T) {
 	defer ctrl.Finish()
 
 	compRepo := mocks.NewMockICompanyRepository(ctrl)
-	svc := NewService(compRepo)
+	actFieldRepo := mocks.NewMockIActivityFieldRepository(ctrl)
+	svc := NewService(compRepo, actFieldRepo, nil, nil)
 
 	testCases := []struct {
 		name       string
@@ -341,7 +393,8 @@ func TestCompanyService_GetByOwnerId(t *testing.T) {
 	defer ctrl.Finish()
 
 	compRepo := mocks.NewMockICompanyRepository(ctrl)
-	svc := NewService(compRepo)
+	actFieldRepo := mocks.NewMockIActivityFieldRepository(ctrl)
+	svc := NewService(compRepo, actFieldRepo, nil, nil)
 
 	testCases := []struct {
 		name       string
@@ -381,7 +434,7 @@ func TestCompanyService_GetByOwnerId(t *testing.T) {
 							Name:    "c",
 							City:    "c",
 						},
-					}, nil)
+					}, 1, nil)
 			},
 			expected: []*domain.Company{
 				{
@@ -416,7 +469,7 @@ func TestCompanyService_GetByOwnerId(t *testing.T) {
 						1,
 						true,
 					).
-					Return(nil, fmt.Errorf("sql error"))
+					Return(nil, 0, fmt.Errorf("sql error"))
 			},
 			wantErr: true,
 			errStr:  errors.New("получение списка компаний по id владельца: sql error"),
@@ -429,7 +482,7 @@ func TestCompanyService_GetByOwnerId(t *testing.T) {
 				tc.beforeTest(*compRepo)
 			}
 
-			companies, err := svc.GetByOwnerId(ctx, tc.id, 1, true)
+			companies, _, err := svc.GetByOwnerId(ctx, tc.id, 1, true)
 
 			if tc.wantErr {
 				require.Equal(t, tc.errStr.Error(), err.Error())
@@ -441,12 +494,88 @@ func TestCompanyService_GetByOwnerId(t *testing.T) {
 	}
 }
 
+func TestCompanyService_GetByOwnerIds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	compRepo := mocks.NewMockICompanyRepository(ctrl)
+	actFieldRepo := mocks.NewMockIActivityFieldRepository(ctrl)
+	svc := NewService(compRepo, actFieldRepo, nil, nil)
+
+	testCases := []struct {
+		name       string
+		ids        []uuid.UUID
+		beforeTest func(compRepo mocks.MockICompanyRepository)
+		expected   []*domain.Company
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name: "успешное получение компаний нескольких владельцев",
+			ids:  []uuid.UUID{{1}, {2}},
+			beforeTest: func(compRepo mocks.MockICompanyRepository) {
+				compRepo.EXPECT().
+					GetByOwnerIds(
+						context.Background(),
+						[]uuid.UUID{{1}, {2}},
+					).
+					Return([]*domain.Company{
+						{ID: uuid.UUID{11}, OwnerID: uuid.UUID{1}, Name: "a"},
+						{ID: uuid.UUID{12}, OwnerID: uuid.UUID{2}, Name: "b"},
+					}, nil)
+			},
+			expected: []*domain.Company{
+				{ID: uuid.UUID{11}, OwnerID: uuid.UUID{1}, Name: "a"},
+				{ID: uuid.UUID{12}, OwnerID: uuid.UUID{2}, Name: "b"},
+			},
+		},
+		{
+			name: "ошибка получения данных в репозитории",
+			ids:  []uuid.UUID{{1}},
+			beforeTest: func(compRepo mocks.MockICompanyRepository) {
+				compRepo.EXPECT().
+					GetByOwnerIds(
+						context.Background(),
+						[]uuid.UUID{{1}},
+					).
+					Return(nil, fmt.Errorf("sql error"))
+			},
+			wantErr: true,
+			errStr:  errors.New("получение компаний по списку id владельцев: sql error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.beforeTest != nil {
+				tc.beforeTest(*compRepo)
+			}
+
+			companies, err := svc.GetByOwnerIds(context.Background(), tc.ids)
+
+			if tc.wantErr {
+				require.Equal(t, tc.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+				require.Equal(t, tc.expected, companies)
+			}
+		})
+	}
+}
+
 func TestCompanyService_Update(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	compRepo := mocks.NewMockICompanyRepository(ctrl)
-	svc := NewService(compRepo)
+	actFieldRepo := mocks.NewMockIActivityFieldRepository(ctrl)
+	transactor := mocks.NewMockITransactor(ctrl)
+	transactor.EXPECT().
+		WithinTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).
+		AnyTimes()
+	bus := mocks.NewMockIEventBus(ctrl)
+	svc := NewService(compRepo, actFieldRepo, transactor, bus)
 
 	testCases := []struct {
 		name       string
@@ -462,6 +591,10 @@ func TestCompanyService_Update(t *testing.T) {
 				Name: "aaa",
 			},
 			beforeTest: func(compRepo mocks.MockICompanyRepository) {
+				actFieldRepo.EXPECT().
+					GetById(context.Background(), uuid.UUID{}).
+					Return(&domain.ActivityField{}, nil)
+
 				compRepo.EXPECT().
 					Update(
 						context.Background(),
@@ -470,6 +603,10 @@ func TestCompanyService_Update(t *testing.T) {
 							Name: "aaa",
 						},
 					).Return(nil)
+
+				bus.EXPECT().
+					Publish(context.Background(), domain.CompanyUpdated{ID: uuid.UUID{1}, Name: "aaa"}).
+					Return(nil)
 			},
 			wantErr: false,
 		},
@@ -480,6 +617,10 @@ func TestCompanyService_Update(t *testing.T) {
 				Name: "aaa",
 			},
 			beforeTest: func(compRepo mocks.MockICompanyRepository) {
+				actFieldRepo.EXPECT().
+					GetById(context.Background(), uuid.UUID{}).
+					Return(&domain.ActivityField{}, nil)
+
 				compRepo.EXPECT().
 					Update(
 						context.Background(),
@@ -5,26 +5,28 @@ import (
 	"fmt"
 	"github.com/google/uuid"
 	"ppo/domain"
-	"ppo/internal/config"
+	"ppo/pkg/i18n"
 )
 
 type Service struct {
 	contactRepo domain.IContactsRepository
+	maxContacts int
 }
 
-func NewService(conRepo domain.IContactsRepository) domain.IContactsService {
+func NewService(conRepo domain.IContactsRepository, maxContacts int) domain.IContactsService {
 	return &Service{
 		contactRepo: conRepo,
+		maxContacts: maxContacts,
 	}
 }
 
 func (s *Service) Create(ctx context.Context, contact *domain.Contact) (err error) {
 	if contact.Name == "" {
-		return fmt.Errorf("должно быть указано название средства связи")
+		return domain.NewValidationError("name", i18n.MsgContactNameRequired)
 	}
 
 	if contact.Value == "" {
-		return fmt.Errorf("должно быть указано значение средства связи")
+		return domain.NewValidationError("value", i18n.MsgContactValueRequired)
 	}
 
 	contacts, err := s.contactRepo.GetByOwnerId(ctx, contact.OwnerID)
@@ -32,8 +34,8 @@ func (s *Service) Create(ctx context.Context, contact *domain.Contact) (err erro
 		return fmt.Errorf("добавление средства связи: %w", err)
 	}
 
-	if len(contacts) >= config.MaxContacts {
-		return fmt.Errorf("добавление средства связи: количество не должно быть более %d", config.MaxContacts)
+	if len(contacts) >= s.maxContacts {
+		return domain.NewValidationError("", i18n.MsgContactLimit, s.maxContacts)
 	}
 
 	err = s.contactRepo.Create(ctx, contact)
@@ -62,6 +64,15 @@ func (s *Service) GetByOwnerId(ctx context.Context, id uuid.UUID) (contacts []*d
 	return contacts, nil
 }
 
+func (s *Service) GetByOwnerIds(ctx context.Context, ids []uuid.UUID) (contacts []*domain.Contact, err error) {
+	contacts, err = s.contactRepo.GetByOwnerIds(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("получение средств связи по списку id владельцев: %w", err)
+	}
+
+	return contacts, nil
+}
+
 func (s *Service) Update(ctx context.Context, contact *domain.Contact) (err error) {
 	err = s.contactRepo.Update(ctx, contact)
 	if err != nil {
@@ -17,7 +17,7 @@ func TestContactService_Create(t *testing.T) {
 	defer ctrl.Finish()
 
 	conRepo := mocks.NewMockIContactsRepository(ctrl)
-	svc := NewService(conRepo)
+	svc := NewService(conRepo, 5)
 
 	testCases := []struct {
 		name       string
@@ -33,6 +33,10 @@ func TestContactService_Create(t *testing.T) {
 				Value: "bbb",
 			},
 			beforeTest: func(conRepo mocks.MockIContactsRepository) {
+				conRepo.EXPECT().
+					GetByOwnerId(context.Background(), uuid.UUID{}).
+					Return([]*domain.Contact{}, nil)
+
 				conRepo.EXPECT().
 					Create(
 						context.Background(),
@@ -91,6 +95,10 @@ func TestContactService_Create(t *testing.T) {
 				Value: "bbb",
 			},
 			beforeTest: func(conRepo mocks.MockIContactsRepository) {
+				conRepo.EXPECT().
+					GetByOwnerId(context.Background(), uuid.UUID{}).
+					Return([]*domain.Contact{}, nil)
+
 				conRepo.EXPECT().
 					Create(
 						context.Background(),
@@ -128,7 +136,7 @@ func TestContactService_DeleteById(t *testing.T) {
 	defer ctrl.Finish()
 
 	conRepo := mocks.NewMockIContactsRepository(ctrl)
-	svc := NewService(conRepo)
+	svc := NewService(conRepo, 5)
 
 	curUuid := uuid.New()
 
@@ -184,7 +192,7 @@ func TestContactService_GetByOwnerId(t *testing.T) {
 	defer ctrl.Finish()
 
 	conRepo := mocks.NewMockIContactsRepository(ctrl)
-	svc := NewService(conRepo)
+	svc := NewService(conRepo, 5)
 
 	testCases := []struct {
 		name       string
@@ -202,8 +210,6 @@ func TestContactService_GetByOwnerId(t *testing.T) {
 					GetByOwnerId(
 						context.Background(),
 						uuid.UUID{1},
-						1,
-						true,
 					).
 					Return([]*domain.Contact{
 						{
@@ -256,8 +262,6 @@ func TestContactService_GetByOwnerId(t *testing.T) {
 					GetByOwnerId(
 						context.Background(),
 						uuid.UUID{1},
-						1,
-						true,
 					).
 					Return(nil, fmt.Errorf("sql error"))
 			},
@@ -272,7 +276,7 @@ func TestContactService_GetByOwnerId(t *testing.T) {
 				tc.beforeTest(*conRepo)
 			}
 
-			companies, err := svc.GetByOwnerId(ctx, tc.id, 1, true)
+			companies, err := svc.GetByOwnerId(ctx, tc.id)
 
 			if tc.wantErr {
 				require.Equal(t, tc.errStr.Error(), err.Error())
@@ -284,12 +288,101 @@ func TestContactService_GetByOwnerId(t *testing.T) {
 	}
 }
 
+func TestContactService_GetByOwnerIds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	conRepo := mocks.NewMockIContactsRepository(ctrl)
+	svc := NewService(conRepo, 5)
+
+	testCases := []struct {
+		name       string
+		ids        []uuid.UUID
+		beforeTest func(conRepo mocks.MockIContactsRepository)
+		expected   []*domain.Contact
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name: "успешное получение средств связи по списку id владельцев",
+			ids:  []uuid.UUID{{1}, {2}},
+			beforeTest: func(conRepo mocks.MockIContactsRepository) {
+				conRepo.EXPECT().
+					GetByOwnerIds(
+						context.Background(),
+						[]uuid.UUID{{1}, {2}},
+					).
+					Return([]*domain.Contact{
+						{
+							ID:      uuid.UUID{1},
+							OwnerID: uuid.UUID{1},
+							Name:    "a",
+							Value:   "a",
+						},
+						{
+							ID:      uuid.UUID{2},
+							OwnerID: uuid.UUID{2},
+							Name:    "b",
+							Value:   "b",
+						},
+					}, nil)
+			},
+			expected: []*domain.Contact{
+				{
+					ID:      uuid.UUID{1},
+					OwnerID: uuid.UUID{1},
+					Name:    "a",
+					Value:   "a",
+				},
+				{
+					ID:      uuid.UUID{2},
+					OwnerID: uuid.UUID{2},
+					Name:    "b",
+					Value:   "b",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ошибка получения данных в репозитории",
+			ids:  []uuid.UUID{{1}},
+			beforeTest: func(conRepo mocks.MockIContactsRepository) {
+				conRepo.EXPECT().
+					GetByOwnerIds(
+						context.Background(),
+						[]uuid.UUID{{1}},
+					).
+					Return(nil, fmt.Errorf("sql error"))
+			},
+			wantErr: true,
+			errStr:  errors.New("получение средств связи по списку id владельцев: sql error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.beforeTest != nil {
+				tc.beforeTest(*conRepo)
+			}
+
+			contacts, err := svc.GetByOwnerIds(ctx, tc.ids)
+
+			if tc.wantErr {
+				require.Equal(t, tc.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+				require.Equal(t, contacts, tc.expected)
+			}
+		})
+	}
+}
+
 func TestContactService_GetById(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	conRepo := mocks.NewMockIContactsRepository(ctrl)
-	svc := NewService(conRepo)
+	svc := NewService(conRepo, 5)
 
 	testCases := []struct {
 		name       string
@@ -360,7 +453,7 @@ func TestContactService_Update(t *testing.T) {
 	defer ctrl.Finish()
 
 	conRepo := mocks.NewMockIContactsRepository(ctrl)
-	svc := NewService(conRepo)
+	svc := NewService(conRepo, 5)
 
 	testCases := []struct {
 		name       string
@@ -4,43 +4,151 @@ import (
 	"context"
 	"fmt"
 	"github.com/google/uuid"
+	"io"
+	"math"
 	"ppo/domain"
+	"ppo/pkg/i18n"
+	"strings"
 	"time"
 )
 
 type Service struct {
-	finRepo domain.IFinancialReportRepository
+	finRepo    domain.IFinancialReportRepository
+	blobs      domain.IBlobStorage
+	transactor domain.ITransactor
+	bus        domain.IEventBus
 }
 
-func NewService(finRepo domain.IFinancialReportRepository) domain.IFinancialReportService {
+func NewService(
+	finRepo domain.IFinancialReportRepository,
+	blobs domain.IBlobStorage,
+	transactor domain.ITransactor,
+	bus domain.IEventBus,
+) domain.IFinancialReportService {
 	return &Service{
-		finRepo: finRepo,
+		finRepo:    finRepo,
+		blobs:      blobs,
+		transactor: transactor,
+		bus:        bus,
 	}
 }
 
+func filedEvent(finReport *domain.FinancialReport, amended bool) domain.FinancialReportFiled {
+	return domain.FinancialReportFiled{
+		ID:        finReport.ID,
+		CompanyID: finReport.CompanyID,
+		Year:      finReport.Year,
+		Quarter:   finReport.Quarter,
+		Revenue:   finReport.Revenue,
+		Costs:     finReport.Costs,
+		Amended:   amended,
+	}
+}
+
+// addRevision сохраняет текущие значения отчёта новой версией от имени пользователя из контекста.
+func (s *Service) addRevision(ctx context.Context, finReport *domain.FinancialReport, reason string) (
+	rev *domain.FinancialReportRevision, err error) {
+	rev = &domain.FinancialReportRevision{
+		ReportID: finReport.ID,
+		Revenue:  finReport.Revenue,
+		Costs:    finReport.Costs,
+		Reason:   reason,
+		Current:  true,
+	}
+	if actor := domain.ActorFromContext(ctx); actor != nil {
+		rev.AuthorID = actor.ID
+	}
+
+	err = s.finRepo.AddRevision(ctx, rev)
+	if err != nil {
+		return nil, err
+	}
+
+	return rev, nil
+}
+
+// resetVerification возвращает изменённый отчёт в неподтверждённые:
+// прежнее решение проверки относилось к другим значениям.
+func resetVerification(finReport *domain.FinancialReport) {
+	finReport.Status = domain.VerificationUnverified
+	finReport.ReviewComment = ""
+	finReport.ReviewerID = uuid.Nil
+	finReport.ReviewedAt = time.Time{}
+}
+
+// balanceTolerance — допустимое относительное расхождение активов с суммой
+// обязательств и капитала: показатели в отчётах округляются.
+const balanceTolerance = 0.01
+
+// validateMetrics проверяет расширенные показатели отчёта. Баланс сверяется,
+// только если указаны активы, обязательства и капитал.
+func validateMetrics(finReport *domain.FinancialReport) error {
+	if finReport.Assets != nil && *finReport.Assets < 0 {
+		return domain.NewValidationError("assets", i18n.MsgFinAssetsNegative)
+	}
+
+	if finReport.Liabilities != nil && *finReport.Liabilities < 0 {
+		return domain.NewValidationError("liabilities", i18n.MsgFinLiabilitiesNegative)
+	}
+
+	if finReport.Headcount != nil && *finReport.Headcount < 0 {
+		return domain.NewValidationError("headcount", i18n.MsgFinHeadcountNegative)
+	}
+
+	if finReport.ExportsShare != nil && (*finReport.ExportsShare < 0 || *finReport.ExportsShare > 100) {
+		return domain.NewValidationError("exports_share", i18n.MsgFinExportsShareRange)
+	}
+
+	if finReport.Assets != nil && finReport.Liabilities != nil && finReport.Equity != nil {
+		diff := math.Abs(float64(*finReport.Assets - *finReport.Liabilities - *finReport.Equity))
+		if diff > balanceTolerance*math.Max(float64(*finReport.Assets), 1) {
+			return domain.NewValidationError("equity", i18n.MsgFinBalanceMismatch)
+		}
+	}
+
+	return nil
+}
+
 func (s *Service) Create(ctx context.Context, finReport *domain.FinancialReport) (err error) {
 	if finReport.Revenue < 0 {
-		return fmt.Errorf("выручка не может быть отрицательной")
+		return domain.NewValidationError("revenue", i18n.MsgFinRevenueNegative)
 	}
 
 	if finReport.Costs < 0 {
-		return fmt.Errorf("расходы не могут быть отрицательными")
+		return domain.NewValidationError("costs", i18n.MsgFinCostsNegative)
 	}
 
 	if finReport.Quarter > 4 || finReport.Quarter < 1 {
-		return fmt.Errorf("значение квартала должно находиться в отрезке от 1 до 4")
+		return domain.NewValidationError("quarter", i18n.MsgFinQuarterRange)
 	}
 
 	now := time.Now()
 	if finReport.Year > now.Year() {
-		return fmt.Errorf("значение года не может быть больше текущего года")
+		return domain.NewValidationError("year", i18n.MsgFinYearInFuture)
 	}
 
 	if finReport.Year == now.Year() && finReport.Quarter > (int(now.Month()-1)/3) {
-		return fmt.Errorf("нельзя добавить отчет за квартал, который еще не закончился")
+		return domain.NewValidationError("quarter", i18n.MsgFinQuarterUnfinished)
 	}
 
-	err = s.finRepo.Create(ctx, finReport)
+	err = validateMetrics(finReport)
+	if err != nil {
+		return err
+	}
+
+	err = s.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		err := s.finRepo.Create(ctx, finReport)
+		if err != nil {
+			return err
+		}
+
+		_, err = s.addRevision(ctx, finReport, "")
+		if err != nil {
+			return err
+		}
+
+		return s.bus.Publish(ctx, filedEvent(finReport, false))
+	})
 	if err != nil {
 		return fmt.Errorf("добавление финансового отчета: %w", err)
 	}
@@ -72,7 +180,7 @@ func (s *Service) GetByCompany(ctx context.Context, companyId uuid.UUID, period
 	finReport *domain.FinancialReportByPeriod, err error) {
 	if period.StartYear > period.EndYear ||
 		(period.StartYear == period.EndYear && period.StartQuarter > period.EndQuarter) {
-		return nil, fmt.Errorf("дата конца периода должна быть позже даты начала")
+		return nil, domain.NewValidationError("period", i18n.MsgFinPeriodOrder)
 	}
 
 	finReport, err = s.finRepo.GetByCompany(ctx, companyId, period)
@@ -83,8 +191,42 @@ func (s *Service) GetByCompany(ctx context.Context, companyId uuid.UUID, period
 	return finReport, nil
 }
 
+func (s *Service) GetByCompanies(ctx context.Context, companyIds []uuid.UUID, period *domain.Period) (
+	reports map[uuid.UUID]*domain.FinancialReportByPeriod, err error) {
+	if period.StartYear > period.EndYear ||
+		(period.StartYear == period.EndYear && period.StartQuarter > period.EndQuarter) {
+		return nil, domain.NewValidationError("period", i18n.MsgFinPeriodOrder)
+	}
+
+	reports, err = s.finRepo.GetByCompanies(ctx, companyIds, period)
+	if err != nil {
+		return nil, fmt.Errorf("получение финансовых отчетов по списку id компаний: %w", err)
+	}
+
+	return reports, nil
+}
+
 func (s *Service) Update(ctx context.Context, finReport *domain.FinancialReport) (err error) {
-	err = s.finRepo.Update(ctx, finReport)
+	err = validateMetrics(finReport)
+	if err != nil {
+		return err
+	}
+
+	resetVerification(finReport)
+
+	err = s.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		err := s.finRepo.Update(ctx, finReport)
+		if err != nil {
+			return err
+		}
+
+		_, err = s.addRevision(ctx, finReport, "")
+		if err != nil {
+			return err
+		}
+
+		return s.bus.Publish(ctx, filedEvent(finReport, true))
+	})
 	if err != nil {
 		return fmt.Errorf("обновление отчета: %w", err)
 	}
@@ -92,11 +234,311 @@ func (s *Service) Update(ctx context.Context, finReport *domain.FinancialReport)
 	return nil
 }
 
+// Amend заменяет выручку и расходы отчёта значениями из amendment и сохраняет их
+// новой версией с причиной исправления. В amendment записываются номер версии,
+// автор и время подачи.
+func (s *Service) Amend(ctx context.Context, amendment *domain.FinancialReportRevision) (err error) {
+	if amendment.Revenue < 0 {
+		return domain.NewValidationError("revenue", i18n.MsgFinRevenueNegative)
+	}
+
+	if amendment.Costs < 0 {
+		return domain.NewValidationError("costs", i18n.MsgFinCostsNegative)
+	}
+
+	if strings.TrimSpace(amendment.Reason) == "" {
+		return domain.NewValidationError("reason", i18n.MsgFinAmendReasonRequired)
+	}
+
+	finReport, err := s.finRepo.GetById(ctx, amendment.ReportID)
+	if err != nil {
+		return fmt.Errorf("исправление отчета (поиск отчета): %w", err)
+	}
+
+	finReport.Revenue = amendment.Revenue
+	finReport.Costs = amendment.Costs
+	resetVerification(finReport)
+
+	err = s.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		err := s.finRepo.Update(ctx, finReport)
+		if err != nil {
+			return err
+		}
+
+		rev, err := s.addRevision(ctx, finReport, amendment.Reason)
+		if err != nil {
+			return err
+		}
+		*amendment = *rev
+
+		return s.bus.Publish(ctx, filedEvent(finReport, true))
+	})
+	if err != nil {
+		return fmt.Errorf("исправление отчета: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) GetRevisions(ctx context.Context, id uuid.UUID) (revs []*domain.FinancialReportRevision, err error) {
+	_, err = s.finRepo.GetById(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("получение версий отчета (поиск отчета): %w", err)
+	}
+
+	revs, err = s.finRepo.GetRevisions(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("получение версий отчета: %w", err)
+	}
+
+	return revs, nil
+}
+
+// DeleteById удаляет отчёт вместе с приложенными документами. Содержимое документов
+// удаляется из хранилища после удаления отчёта; ошибки при этом не возвращаются:
+// отчёт уже удалён, а оставшиеся файлы ни на что не ссылаются.
 func (s *Service) DeleteById(ctx context.Context, id uuid.UUID) (err error) {
-	err = s.finRepo.DeleteById(ctx, id)
+	finReport, err := s.finRepo.GetById(ctx, id)
+	if err != nil {
+		return fmt.Errorf("удаление отчета по id (поиск отчета): %w", err)
+	}
+
+	docs, err := s.finRepo.GetDocuments(ctx, id)
+	if err != nil {
+		return fmt.Errorf("удаление отчета по id (поиск документов): %w", err)
+	}
+
+	err = s.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		err := s.finRepo.DeleteById(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return s.bus.Publish(ctx, domain.FinancialReportDeleted{
+			ID:        finReport.ID,
+			CompanyID: finReport.CompanyID,
+			Year:      finReport.Year,
+			Quarter:   finReport.Quarter,
+		})
+	})
 	if err != nil {
 		return fmt.Errorf("удаление отчета по id: %w", err)
 	}
 
+	for _, doc := range docs {
+		_ = s.blobs.Delete(ctx, doc.StorageKey)
+	}
+
+	return nil
+}
+
+func (s *Service) Submit(ctx context.Context, id uuid.UUID) (err error) {
+	finReport, err := s.finRepo.GetById(ctx, id)
+	if err != nil {
+		return fmt.Errorf("отправка отчета на проверку (поиск отчета): %w", err)
+	}
+
+	if finReport.Status != domain.VerificationUnverified && finReport.Status != domain.VerificationRejected {
+		return domain.NewValidationError("status", i18n.MsgFinSubmitStatus)
+	}
+
+	docs, err := s.finRepo.GetDocuments(ctx, id)
+	if err != nil {
+		return fmt.Errorf("отправка отчета на проверку (поиск документов): %w", err)
+	}
+
+	if len(docs) == 0 {
+		return domain.NewValidationError("documents", i18n.MsgFinSubmitNoDocuments)
+	}
+
+	resetVerification(finReport)
+	finReport.Status = domain.VerificationSubmitted
+
+	err = s.finRepo.Update(ctx, finReport)
+	if err != nil {
+		return fmt.Errorf("отправка отчета на проверку: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) Review(ctx context.Context, id uuid.UUID, status domain.VerificationStatus, comment string) (err error) {
+	if status != domain.VerificationVerified && status != domain.VerificationRejected {
+		return domain.NewValidationError("status", i18n.MsgFinReviewDecision)
+	}
+
+	if status == domain.VerificationRejected && strings.TrimSpace(comment) == "" {
+		return domain.NewValidationError("comment", i18n.MsgFinRejectCommentRequired)
+	}
+
+	finReport, err := s.finRepo.GetById(ctx, id)
+	if err != nil {
+		return fmt.Errorf("проверка отчета (поиск отчета): %w", err)
+	}
+
+	if finReport.Status != domain.VerificationSubmitted {
+		return domain.NewValidationError("status", i18n.MsgFinReviewStatus)
+	}
+
+	finReport.Status = status
+	finReport.ReviewComment = comment
+	finReport.ReviewedAt = time.Now().UTC()
+	if actor := domain.ActorFromContext(ctx); actor != nil {
+		finReport.ReviewerID = actor.ID
+	}
+
+	err = s.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		err := s.finRepo.Update(ctx, finReport)
+		if err != nil {
+			return err
+		}
+
+		return s.bus.Publish(ctx, domain.FinancialReportReviewed{
+			ID:        finReport.ID,
+			CompanyID: finReport.CompanyID,
+			Year:      finReport.Year,
+			Quarter:   finReport.Quarter,
+			Status:    finReport.Status,
+			Comment:   finReport.ReviewComment,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("проверка отчета: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) GetByStatus(ctx context.Context, status domain.VerificationStatus, page int) (
+	finReports []*domain.FinancialReport, numPages int, err error) {
+	switch status {
+	case domain.VerificationUnverified, domain.VerificationSubmitted,
+		domain.VerificationVerified, domain.VerificationRejected:
+	default:
+		return nil, 0, domain.NewValidationError("status", i18n.MsgFinStatusUnknown)
+	}
+
+	finReports, numPages, err = s.finRepo.GetByStatus(ctx, status, page)
+	if err != nil {
+		return nil, 0, fmt.Errorf("получение отчетов по статусу проверки: %w", err)
+	}
+
+	return finReports, numPages, nil
+}
+
+// checkDocumentsEditable проверяет, что документы отчёта можно менять:
+// у отправленного на проверку или подтверждённого отчёта они зафиксированы.
+func checkDocumentsEditable(finReport *domain.FinancialReport) error {
+	if finReport.Status == domain.VerificationSubmitted || finReport.Status == domain.VerificationVerified {
+		return domain.NewValidationError("status", i18n.MsgFinDocumentsLocked)
+	}
+
+	return nil
+}
+
+// AttachDocument записывает content в хранилище под новым ключом и сохраняет
+// сведения о документе в doc: id, ключ, размер, автора и время загрузки.
+func (s *Service) AttachDocument(ctx context.Context, doc *domain.FinancialReportDocument, content io.Reader) (err error) {
+	if strings.TrimSpace(doc.Name) == "" {
+		return domain.NewValidationError("name", i18n.MsgFinDocumentNameRequired)
+	}
+
+	if !domain.IsDocumentContentType(doc.ContentType) {
+		return domain.NewValidationError("content_type", i18n.MsgFinDocumentType, strings.Join(domain.DocumentContentTypes, ", "))
+	}
+
+	finReport, err := s.finRepo.GetById(ctx, doc.ReportID)
+	if err != nil {
+		return fmt.Errorf("добавление документа (поиск отчета): %w", err)
+	}
+
+	err = checkDocumentsEditable(finReport)
+	if err != nil {
+		return err
+	}
+
+	doc.StorageKey = "fin_reports/" + doc.ReportID.String() + "/" + uuid.NewString()
+	doc.Size, err = s.blobs.Put(ctx, doc.StorageKey, content)
+	if err != nil {
+		return fmt.Errorf("добавление документа: %w", err)
+	}
+
+	if doc.Size == 0 {
+		_ = s.blobs.Delete(ctx, doc.StorageKey)
+		return domain.NewValidationError("content", i18n.MsgFinDocumentEmpty)
+	}
+
+	if actor := domain.ActorFromContext(ctx); actor != nil {
+		doc.UploadedBy = actor.ID
+	}
+
+	err = s.finRepo.AddDocument(ctx, doc)
+	if err != nil {
+		_ = s.blobs.Delete(ctx, doc.StorageKey)
+		return fmt.Errorf("добавление документа: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) GetDocuments(ctx context.Context, reportId uuid.UUID) (docs []*domain.FinancialReportDocument, err error) {
+	_, err = s.finRepo.GetById(ctx, reportId)
+	if err != nil {
+		return nil, fmt.Errorf("получение документов отчета (поиск отчета): %w", err)
+	}
+
+	docs, err = s.finRepo.GetDocuments(ctx, reportId)
+	if err != nil {
+		return nil, fmt.Errorf("получение документов отчета: %w", err)
+	}
+
+	return docs, nil
+}
+
+func (s *Service) OpenDocument(ctx context.Context, id uuid.UUID) (
+	doc *domain.FinancialReportDocument, content io.ReadCloser, err error) {
+	doc, err = s.finRepo.GetDocument(ctx, id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("получение документа: %w", err)
+	}
+
+	content, err = s.blobs.Open(ctx, doc.StorageKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("получение документа: %w", err)
+	}
+
+	return doc, content, nil
+}
+
+// DeleteDocument удаляет документ и его содержимое в одной транзакции:
+// если содержимое удалить не удалось, документ остаётся приложенным.
+func (s *Service) DeleteDocument(ctx context.Context, id uuid.UUID) (err error) {
+	doc, err := s.finRepo.GetDocument(ctx, id)
+	if err != nil {
+		return fmt.Errorf("удаление документа (поиск документа): %w", err)
+	}
+
+	finReport, err := s.finRepo.GetById(ctx, doc.ReportID)
+	if err != nil {
+		return fmt.Errorf("удаление документа (поиск отчета): %w", err)
+	}
+
+	err = checkDocumentsEditable(finReport)
+	if err != nil {
+		return err
+	}
+
+	err = s.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		err := s.finRepo.DeleteDocument(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return s.blobs.Delete(ctx, doc.StorageKey)
+	})
+	if err != nil {
+		return fmt.Errorf("удаление документа: %w", err)
+	}
+
 	return nil
 }
@@ -7,17 +7,33 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	"io"
 	"ppo/domain"
 	"ppo/mocks"
+	"strings"
 	"testing"
+	"time"
 )
 
+func ptr[T any](v T) *T {
+	return &v
+}
+
 func TestFinReportService_Create(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	finRepo := mocks.NewMockIFinancialReportRepository(ctrl)
-	svc := NewService(finRepo)
+	transactor := mocks.NewMockITransactor(ctrl)
+	transactor.EXPECT().
+		WithinTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).
+		AnyTimes()
+	bus := mocks.NewMockIEventBus(ctrl)
+	svc := NewService(finRepo, nil, transactor, bus)
+
+	now := time.Now()
+	curQuarter := int(now.Month()-1)/3 + 1
 
 	testCases := []struct {
 		name       string
@@ -47,6 +63,21 @@ func TestFinReportService_Create(t *testing.T) {
 							Quarter:   1,
 						},
 					).Return(nil)
+
+				finRepo.EXPECT().
+					AddRevision(
+						context.Background(),
+						&domain.FinancialReportRevision{Revenue: 1, Costs: 1, Current: true},
+					).Return(nil)
+
+				bus.EXPECT().
+					Publish(context.Background(), domain.FinancialReportFiled{
+						CompanyID: uuid.UUID{1},
+						Revenue:   1,
+						Costs:     1,
+						Year:      1,
+						Quarter:   1,
+					}).Return(nil)
 			},
 			wantErr: false,
 		},
@@ -137,7 +168,7 @@ func TestFinReportService_Create(t *testing.T) {
 				CompanyID: uuid.UUID{1},
 				Revenue:   1,
 				Costs:     1,
-				Year:      2025,
+				Year:      now.Year() + 1,
 				Quarter:   1,
 			},
 			beforeTest: func(finRepo mocks.MockIFinancialReportRepository) {
@@ -148,7 +179,7 @@ func TestFinReportService_Create(t *testing.T) {
 							CompanyID: uuid.UUID{1},
 							Revenue:   1,
 							Costs:     1,
-							Year:      2025,
+							Year:      now.Year() + 1,
 							Quarter:   1,
 						},
 					).
@@ -164,8 +195,8 @@ func TestFinReportService_Create(t *testing.T) {
 				CompanyID: uuid.UUID{1},
 				Revenue:   1,
 				Costs:     1,
-				Year:      2024,
-				Quarter:   2,
+				Year:      now.Year(),
+				Quarter:   curQuarter,
 			},
 			beforeTest: func(finRepo mocks.MockIFinancialReportRepository) {
 				finRepo.EXPECT().
@@ -175,8 +206,8 @@ func TestFinReportService_Create(t *testing.T) {
 							CompanyID: uuid.UUID{1},
 							Revenue:   1,
 							Costs:     1,
-							Year:      2024,
-							Quarter:   2,
+							Year:      now.Year(),
+							Quarter:   curQuarter,
 						},
 					).
 					Return(nil).
@@ -212,6 +243,62 @@ func TestFinReportService_Create(t *testing.T) {
 			wantErr: true,
 			errStr:  errors.New("добавление финансового отчета: sql error"),
 		},
+		{
+			name: "доля экспорта больше 100 процентов",
+			data: &domain.FinancialReport{
+				CompanyID:    uuid.UUID{1},
+				Revenue:      1,
+				Year:         2023,
+				Quarter:      1,
+				ExportsShare: ptr[float32](120),
+			},
+			wantErr: true,
+			errStr:  errors.New("доля экспорта должна быть от 0 до 100 процентов"),
+		},
+		{
+			name: "отрицательная численность сотрудников",
+			data: &domain.FinancialReport{
+				CompanyID: uuid.UUID{1},
+				Revenue:   1,
+				Year:      2023,
+				Quarter:   1,
+				Headcount: ptr(-1),
+			},
+			wantErr: true,
+			errStr:  errors.New("численность сотрудников не может быть отрицательной"),
+		},
+		{
+			name: "активы не равны сумме обязательств и капитала",
+			data: &domain.FinancialReport{
+				CompanyID:   uuid.UUID{1},
+				Revenue:     1,
+				Year:        2023,
+				Quarter:     1,
+				Assets:      ptr[float32](1000),
+				Liabilities: ptr[float32](400),
+				Equity:      ptr[float32](500),
+			},
+			wantErr: true,
+			errStr:  errors.New("активы должны быть равны сумме обязательств и капитала"),
+		},
+		{
+			name: "баланс сходится с учётом округления",
+			data: &domain.FinancialReport{
+				CompanyID:   uuid.UUID{1},
+				Revenue:     1,
+				Year:        2023,
+				Quarter:     1,
+				Assets:      ptr[float32](1000),
+				Liabilities: ptr[float32](400),
+				Equity:      ptr[float32](595),
+			},
+			beforeTest: func(finRepo mocks.MockIFinancialReportRepository) {
+				finRepo.EXPECT().Create(context.Background(), gomock.Any()).Return(nil)
+				finRepo.EXPECT().AddRevision(context.Background(), gomock.Any()).Return(nil)
+				bus.EXPECT().Publish(context.Background(), gomock.Any()).Return(nil)
+			},
+			wantErr: false,
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -236,9 +323,21 @@ func TestFinReportService_DeleteById(t *testing.T) {
 	defer ctrl.Finish()
 
 	finRepo := mocks.NewMockIFinancialReportRepository(ctrl)
-	svc := NewService(finRepo)
+	transactor := mocks.NewMockITransactor(ctrl)
+	transactor.EXPECT().
+		WithinTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).
+		AnyTimes()
+	bus := mocks.NewMockIEventBus(ctrl)
+	blobs := mocks.NewMockIBlobStorage(ctrl)
+	svc := NewService(finRepo, blobs, transactor, bus)
 
 	curUuid := uuid.New()
+	report := &domain.FinancialReport{ID: curUuid, CompanyID: uuid.UUID{1}, Year: 2023, Quarter: 2}
+	docs := []*domain.FinancialReportDocument{
+		{ID: uuid.UUID{2}, ReportID: curUuid, StorageKey: "fin_reports/a"},
+		{ID: uuid.UUID{3}, ReportID: curUuid, StorageKey: "fin_reports/b"},
+	}
 
 	testCases := []struct {
 		name       string
@@ -251,16 +350,69 @@ func TestFinReportService_DeleteById(t *testing.T) {
 			name: "успешное удаление",
 			id:   curUuid,
 			beforeTest: func(finRepo mocks.MockIFinancialReportRepository) {
+				finRepo.EXPECT().
+					GetById(context.Background(), curUuid).
+					Return(report, nil)
+
+				finRepo.EXPECT().
+					GetDocuments(context.Background(), curUuid).
+					Return(docs, nil)
+
 				finRepo.EXPECT().
 					DeleteById(context.Background(), curUuid).
 					Return(nil)
+
+				bus.EXPECT().
+					Publish(context.Background(), domain.FinancialReportDeleted{
+						ID:        curUuid,
+						CompanyID: uuid.UUID{1},
+						Year:      2023,
+						Quarter:   2,
+					}).Return(nil)
+
+				blobs.EXPECT().Delete(context.Background(), "fin_reports/a").Return(nil)
+				blobs.EXPECT().Delete(context.Background(), "fin_reports/b").Return(fmt.Errorf("io error"))
 			},
 			wantErr: false,
 		},
+		{
+			name: "отчет не найден",
+			id:   curUuid,
+			beforeTest: func(finRepo mocks.MockIFinancialReportRepository) {
+				finRepo.EXPECT().
+					GetById(context.Background(), curUuid).
+					Return(nil, fmt.Errorf("sql error"))
+			},
+			wantErr: true,
+			errStr:  errors.New("удаление отчета по id (поиск отчета): sql error"),
+		},
+		{
+			name: "ошибка получения документов",
+			id:   curUuid,
+			beforeTest: func(finRepo mocks.MockIFinancialReportRepository) {
+				finRepo.EXPECT().
+					GetById(context.Background(), curUuid).
+					Return(report, nil)
+
+				finRepo.EXPECT().
+					GetDocuments(context.Background(), curUuid).
+					Return(nil, fmt.Errorf("sql error"))
+			},
+			wantErr: true,
+			errStr:  errors.New("удаление отчета по id (поиск документов): sql error"),
+		},
 		{
 			name: "ошибка выполнения запроса в репозитории",
 			id:   curUuid,
 			beforeTest: func(finRepo mocks.MockIFinancialReportRepository) {
+				finRepo.EXPECT().
+					GetById(context.Background(), curUuid).
+					Return(report, nil)
+
+				finRepo.EXPECT().
+					GetDocuments(context.Background(), curUuid).
+					Return(docs, nil)
+
 				finRepo.EXPECT().
 					DeleteById(context.Background(), curUuid).
 					Return(fmt.Errorf("sql error"))
@@ -292,7 +444,7 @@ func TestFinReportService_GetByCompany(t *testing.T) {
 	defer ctrl.Finish()
 
 	finRepo := mocks.NewMockIFinancialReportRepository(ctrl)
-	svc := NewService(finRepo)
+	svc := NewService(finRepo, nil, nil, nil)
 
 	testCases := []struct {
 		name       string
@@ -602,12 +754,140 @@ func TestFinReportService_GetByCompany(t *testing.T) {
 	}
 }
 
+func TestFinReportService_GetByCompanies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	finRepo := mocks.NewMockIFinancialReportRepository(ctrl)
+	svc := NewService(finRepo, nil, nil, nil)
+
+	testCases := []struct {
+		name       string
+		ids        []uuid.UUID
+		period     *domain.Period
+		beforeTest func(finRepo mocks.MockIFinancialReportRepository)
+		expected   map[uuid.UUID]*domain.FinancialReportByPeriod
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name: "успешное получение отчетов компаний по списку id",
+			ids:  []uuid.UUID{{1}, {2}},
+			period: &domain.Period{
+				StartYear:    2023,
+				EndYear:      2023,
+				StartQuarter: 1,
+				EndQuarter:   4,
+			},
+			beforeTest: func(finRepo mocks.MockIFinancialReportRepository) {
+				finRepo.EXPECT().
+					GetByCompanies(
+						context.Background(),
+						[]uuid.UUID{{1}, {2}},
+						&domain.Period{
+							StartYear:    2023,
+							EndYear:      2023,
+							StartQuarter: 1,
+							EndQuarter:   4,
+						},
+					).
+					Return(map[uuid.UUID]*domain.FinancialReportByPeriod{
+						{1}: {
+							Reports: []domain.FinancialReport{
+								{
+									ID:        uuid.UUID{1},
+									CompanyID: uuid.UUID{1},
+									Revenue:   100,
+									Costs:     50,
+									Year:      2023,
+									Quarter:   1,
+								},
+							},
+						},
+						{2}: {},
+					}, nil)
+			},
+			expected: map[uuid.UUID]*domain.FinancialReportByPeriod{
+				{1}: {
+					Reports: []domain.FinancialReport{
+						{
+							ID:        uuid.UUID{1},
+							CompanyID: uuid.UUID{1},
+							Revenue:   100,
+							Costs:     50,
+							Year:      2023,
+							Quarter:   1,
+						},
+					},
+				},
+				{2}: {},
+			},
+			wantErr: false,
+		},
+		{
+			name: "год начала периода больше года конца периода",
+			ids:  []uuid.UUID{{1}},
+			period: &domain.Period{
+				StartYear:    2024,
+				EndYear:      2023,
+				StartQuarter: 1,
+				EndQuarter:   4,
+			},
+			wantErr: true,
+			errStr:  errors.New("дата конца периода должна быть позже даты начала"),
+		},
+		{
+			name: "ошибка получения данных в репозитории",
+			ids:  []uuid.UUID{{1}},
+			period: &domain.Period{
+				StartYear:    2023,
+				EndYear:      2023,
+				StartQuarter: 1,
+				EndQuarter:   4,
+			},
+			beforeTest: func(finRepo mocks.MockIFinancialReportRepository) {
+				finRepo.EXPECT().
+					GetByCompanies(
+						context.Background(),
+						[]uuid.UUID{{1}},
+						&domain.Period{
+							StartYear:    2023,
+							EndYear:      2023,
+							StartQuarter: 1,
+							EndQuarter:   4,
+						},
+					).
+					Return(nil, fmt.Errorf("sql error"))
+			},
+			wantErr: true,
+			errStr:  errors.New("получение финансовых отчетов по списку id компаний: sql error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.beforeTest != nil {
+				tc.beforeTest(*finRepo)
+			}
+
+			reports, err := svc.GetByCompanies(ctx, tc.ids, tc.period)
+
+			if tc.wantErr {
+				require.Equal(t, tc.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+				require.Equal(t, tc.expected, reports)
+			}
+		})
+	}
+}
+
 func TestFinReportService_GetById(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	repo := mocks.NewMockIFinancialReportRepository(ctrl)
-	svc := NewService(repo)
+	svc := NewService(repo, nil, nil, nil)
 
 	testCases := []struct {
 		name       string
@@ -684,7 +964,13 @@ func TestFinReportService_Update(t *testing.T) {
 	defer ctrl.Finish()
 
 	repo := mocks.NewMockIFinancialReportRepository(ctrl)
-	svc := NewService(repo)
+	transactor := mocks.NewMockITransactor(ctrl)
+	transactor.EXPECT().
+		WithinTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).
+		AnyTimes()
+	bus := mocks.NewMockIEventBus(ctrl)
+	svc := NewService(repo, nil, transactor, bus)
 
 	testCases := []struct {
 		name       string
@@ -694,10 +980,14 @@ func TestFinReportService_Update(t *testing.T) {
 		errStr     error
 	}{
 		{
-			name: "успешное обновление",
+			name: "обновление сбрасывает результат проверки",
 			report: &domain.FinancialReport{
-				ID:      uuid.UUID{1},
-				Revenue: 2,
+				ID:            uuid.UUID{1},
+				Revenue:       2,
+				Status:        domain.VerificationVerified,
+				ReviewComment: "сверено",
+				ReviewerID:    uuid.UUID{9},
+				ReviewedAt:    time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC),
 			},
 			beforeTest: func(finRepo mocks.MockIFinancialReportRepository) {
 				finRepo.EXPECT().
@@ -706,8 +996,22 @@ func TestFinReportService_Update(t *testing.T) {
 						&domain.FinancialReport{
 							ID:      uuid.UUID{1},
 							Revenue: 2,
+							Status:  domain.VerificationUnverified,
 						},
 					).Return(nil)
+
+				finRepo.EXPECT().
+					AddRevision(
+						context.Background(),
+						&domain.FinancialReportRevision{ReportID: uuid.UUID{1}, Revenue: 2, Current: true},
+					).Return(nil)
+
+				bus.EXPECT().
+					Publish(context.Background(), domain.FinancialReportFiled{
+						ID:      uuid.UUID{1},
+						Revenue: 2,
+						Amended: true,
+					}).Return(nil)
 			},
 			wantErr: false,
 		},
@@ -724,12 +1028,23 @@ func TestFinReportService_Update(t *testing.T) {
 						&domain.FinancialReport{
 							ID:      uuid.UUID{1},
 							Revenue: 2,
+							Status:  domain.VerificationUnverified,
 						},
 					).Return(fmt.Errorf("sql error"))
 			},
 			wantErr: true,
 			errStr:  errors.New("обновление отчета: sql error"),
 		},
+		{
+			name: "отрицательные обязательства",
+			report: &domain.FinancialReport{
+				ID:          uuid.UUID{1},
+				Revenue:     2,
+				Liabilities: ptr[float32](-1),
+			},
+			wantErr: true,
+			errStr:  errors.New("обязательства не могут быть отрицательными"),
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -748,3 +1063,627 @@ func TestFinReportService_Update(t *testing.T) {
 		})
 	}
 }
+
+func TestFinReportService_Amend(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockIFinancialReportRepository(ctrl)
+	transactor := mocks.NewMockITransactor(ctrl)
+	transactor.EXPECT().
+		WithinTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).
+		AnyTimes()
+	bus := mocks.NewMockIEventBus(ctrl)
+	svc := NewService(repo, nil, transactor, bus)
+
+	actor := &domain.Actor{ID: uuid.UUID{9}, Role: "user"}
+	ctx := domain.WithActor(context.Background(), actor)
+	filedAt := time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name       string
+		amendment  *domain.FinancialReportRevision
+		beforeTest func()
+		want       *domain.FinancialReportRevision
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name:      "успешное исправление",
+			amendment: &domain.FinancialReportRevision{ReportID: uuid.UUID{1}, Revenue: 3, Costs: 2, Reason: "опечатка"},
+			beforeTest: func() {
+				repo.EXPECT().
+					GetById(ctx, uuid.UUID{1}).
+					Return(&domain.FinancialReport{ID: uuid.UUID{1}, CompanyID: uuid.UUID{2}, Revenue: 1, Costs: 1, Year: 2024, Quarter: 1,
+						Status: domain.VerificationRejected, ReviewComment: "нет подписи"}, nil)
+				repo.EXPECT().
+					Update(ctx, &domain.FinancialReport{ID: uuid.UUID{1}, CompanyID: uuid.UUID{2}, Revenue: 3, Costs: 2, Year: 2024, Quarter: 1,
+						Status: domain.VerificationUnverified}).
+					Return(nil)
+				repo.EXPECT().
+					AddRevision(ctx, &domain.FinancialReportRevision{
+						ReportID: uuid.UUID{1},
+						Revenue:  3,
+						Costs:    2,
+						AuthorID: uuid.UUID{9},
+						Reason:   "опечатка",
+						Current:  true,
+					}).
+					DoAndReturn(func(_ context.Context, rev *domain.FinancialReportRevision) error {
+						rev.Version = 2
+						rev.FiledAt = filedAt
+						return nil
+					})
+				bus.EXPECT().
+					Publish(ctx, domain.FinancialReportFiled{
+						ID:        uuid.UUID{1},
+						CompanyID: uuid.UUID{2},
+						Year:      2024,
+						Quarter:   1,
+						Revenue:   3,
+						Costs:     2,
+						Amended:   true,
+					}).Return(nil)
+			},
+			want: &domain.FinancialReportRevision{
+				ReportID: uuid.UUID{1},
+				Version:  2,
+				Revenue:  3,
+				Costs:    2,
+				AuthorID: uuid.UUID{9},
+				Reason:   "опечатка",
+				FiledAt:  filedAt,
+				Current:  true,
+			},
+		},
+		{
+			name:      "без причины",
+			amendment: &domain.FinancialReportRevision{ReportID: uuid.UUID{1}, Revenue: 3, Costs: 2, Reason: " "},
+			wantErr:   true,
+			errStr:    errors.New("должна быть указана причина исправления"),
+		},
+		{
+			name:      "отрицательные расходы",
+			amendment: &domain.FinancialReportRevision{ReportID: uuid.UUID{1}, Revenue: 3, Costs: -2, Reason: "опечатка"},
+			wantErr:   true,
+			errStr:    errors.New("расходы не могут быть отрицательными"),
+		},
+		{
+			name:      "отчёт не найден",
+			amendment: &domain.FinancialReportRevision{ReportID: uuid.UUID{1}, Revenue: 3, Costs: 2, Reason: "опечатка"},
+			beforeTest: func() {
+				repo.EXPECT().
+					GetById(ctx, uuid.UUID{1}).
+					Return(nil, fmt.Errorf("получение отчета по id: %w", domain.ErrNotFound))
+			},
+			wantErr: true,
+			errStr:  errors.New("исправление отчета (поиск отчета): получение отчета по id: объект не найден"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.beforeTest != nil {
+				tc.beforeTest()
+			}
+
+			err := svc.Amend(ctx, tc.amendment)
+
+			if tc.wantErr {
+				require.Equal(t, tc.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+				require.Equal(t, tc.want, tc.amendment)
+			}
+		})
+	}
+}
+
+func TestFinReportService_GetRevisions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockIFinancialReportRepository(ctrl)
+	svc := NewService(repo, nil, nil, nil)
+
+	testCases := []struct {
+		name       string
+		beforeTest func()
+		wantLen    int
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name: "успешное получение",
+			beforeTest: func() {
+				repo.EXPECT().GetById(gomock.Any(), uuid.UUID{1}).Return(&domain.FinancialReport{ID: uuid.UUID{1}}, nil)
+				repo.EXPECT().
+					GetRevisions(gomock.Any(), uuid.UUID{1}).
+					Return([]*domain.FinancialReportRevision{{Version: 1}, {Version: 2, Current: true}}, nil)
+			},
+			wantLen: 2,
+		},
+		{
+			name: "отчёт не найден",
+			beforeTest: func() {
+				repo.EXPECT().
+					GetById(gomock.Any(), uuid.UUID{1}).
+					Return(nil, fmt.Errorf("получение отчета по id: %w", domain.ErrNotFound))
+			},
+			wantErr: true,
+			errStr:  errors.New("получение версий отчета (поиск отчета): получение отчета по id: объект не найден"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.beforeTest()
+
+			revs, err := svc.GetRevisions(context.Background(), uuid.UUID{1})
+
+			if tc.wantErr {
+				require.Equal(t, tc.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+				require.Len(t, revs, tc.wantLen)
+			}
+		})
+	}
+}
+
+func TestFinReportService_Submit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockIFinancialReportRepository(ctrl)
+	svc := NewService(repo, nil, nil, nil)
+
+	docs := []*domain.FinancialReportDocument{{ID: uuid.UUID{2}, ReportID: uuid.UUID{1}}}
+
+	testCases := []struct {
+		name       string
+		beforeTest func()
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name: "отправка отклоненного отчета",
+			beforeTest: func() {
+				repo.EXPECT().
+					GetById(gomock.Any(), uuid.UUID{1}).
+					Return(&domain.FinancialReport{ID: uuid.UUID{1}, Status: domain.VerificationRejected,
+						ReviewComment: "нет подписи", ReviewerID: uuid.UUID{9}}, nil)
+				repo.EXPECT().GetDocuments(gomock.Any(), uuid.UUID{1}).Return(docs, nil)
+				repo.EXPECT().
+					Update(gomock.Any(), &domain.FinancialReport{ID: uuid.UUID{1}, Status: domain.VerificationSubmitted}).
+					Return(nil)
+			},
+		},
+		{
+			name: "отчет уже на проверке",
+			beforeTest: func() {
+				repo.EXPECT().
+					GetById(gomock.Any(), uuid.UUID{1}).
+					Return(&domain.FinancialReport{ID: uuid.UUID{1}, Status: domain.VerificationSubmitted}, nil)
+			},
+			wantErr: true,
+			errStr:  errors.New("отправить на проверку можно только неподтвержденный или отклоненный отчет"),
+		},
+		{
+			name: "без документов",
+			beforeTest: func() {
+				repo.EXPECT().
+					GetById(gomock.Any(), uuid.UUID{1}).
+					Return(&domain.FinancialReport{ID: uuid.UUID{1}, Status: domain.VerificationUnverified}, nil)
+				repo.EXPECT().GetDocuments(gomock.Any(), uuid.UUID{1}).Return(nil, nil)
+			},
+			wantErr: true,
+			errStr:  errors.New("для отправки на проверку к отчету должен быть приложен хотя бы один документ"),
+		},
+		{
+			name: "ошибка выполнения запроса в репозитории",
+			beforeTest: func() {
+				repo.EXPECT().
+					GetById(gomock.Any(), uuid.UUID{1}).
+					Return(&domain.FinancialReport{ID: uuid.UUID{1}, Status: domain.VerificationUnverified}, nil)
+				repo.EXPECT().GetDocuments(gomock.Any(), uuid.UUID{1}).Return(docs, nil)
+				repo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(fmt.Errorf("sql error"))
+			},
+			wantErr: true,
+			errStr:  errors.New("отправка отчета на проверку: sql error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.beforeTest()
+
+			err := svc.Submit(context.Background(), uuid.UUID{1})
+
+			if tc.wantErr {
+				require.Equal(t, tc.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestFinReportService_Review(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockIFinancialReportRepository(ctrl)
+	transactor := mocks.NewMockITransactor(ctrl)
+	transactor.EXPECT().
+		WithinTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).
+		AnyTimes()
+	bus := mocks.NewMockIEventBus(ctrl)
+	svc := NewService(repo, nil, transactor, bus)
+
+	ctx := domain.WithActor(context.Background(), &domain.Actor{ID: uuid.UUID{9}, Role: "admin"})
+	submitted := func() *domain.FinancialReport {
+		return &domain.FinancialReport{ID: uuid.UUID{1}, CompanyID: uuid.UUID{2}, Year: 2024, Quarter: 1,
+			Status: domain.VerificationSubmitted}
+	}
+
+	testCases := []struct {
+		name       string
+		status     domain.VerificationStatus
+		comment    string
+		beforeTest func()
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name:   "подтверждение отчета",
+			status: domain.VerificationVerified,
+			beforeTest: func() {
+				repo.EXPECT().GetById(ctx, uuid.UUID{1}).Return(submitted(), nil)
+				repo.EXPECT().
+					Update(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, report *domain.FinancialReport) error {
+						require.Equal(t, domain.VerificationVerified, report.Status)
+						require.Equal(t, uuid.UUID{9}, report.ReviewerID)
+						require.False(t, report.ReviewedAt.IsZero())
+						return nil
+					})
+				bus.EXPECT().
+					Publish(ctx, domain.FinancialReportReviewed{
+						ID:        uuid.UUID{1},
+						CompanyID: uuid.UUID{2},
+						Year:      2024,
+						Quarter:   1,
+						Status:    domain.VerificationVerified,
+					}).Return(nil)
+			},
+		},
+		{
+			name:    "отклонение без комментария",
+			status:  domain.VerificationRejected,
+			comment: " ",
+			wantErr: true,
+			errStr:  errors.New("при отклонении отчета должен быть указан комментарий"),
+		},
+		{
+			name:    "неизвестное решение",
+			status:  domain.VerificationSubmitted,
+			wantErr: true,
+			errStr:  errors.New("решение проверки должно быть verified или rejected"),
+		},
+		{
+			name:    "отчет не отправлен на проверку",
+			status:  domain.VerificationRejected,
+			comment: "нет подписи",
+			beforeTest: func() {
+				repo.EXPECT().
+					GetById(ctx, uuid.UUID{1}).
+					Return(&domain.FinancialReport{ID: uuid.UUID{1}, Status: domain.VerificationUnverified}, nil)
+			},
+			wantErr: true,
+			errStr:  errors.New("проверить можно только отчет, отправленный на проверку"),
+		},
+		{
+			name:    "ошибка выполнения запроса в репозитории",
+			status:  domain.VerificationRejected,
+			comment: "нет подписи",
+			beforeTest: func() {
+				repo.EXPECT().GetById(ctx, uuid.UUID{1}).Return(submitted(), nil)
+				repo.EXPECT().Update(ctx, gomock.Any()).Return(fmt.Errorf("sql error"))
+			},
+			wantErr: true,
+			errStr:  errors.New("проверка отчета: sql error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.beforeTest != nil {
+				tc.beforeTest()
+			}
+
+			err := svc.Review(ctx, uuid.UUID{1}, tc.status, tc.comment)
+
+			if tc.wantErr {
+				require.Equal(t, tc.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestFinReportService_GetByStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockIFinancialReportRepository(ctrl)
+	svc := NewService(repo, nil, nil, nil)
+
+	testCases := []struct {
+		name       string
+		status     domain.VerificationStatus
+		beforeTest func()
+		wantLen    int
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name:   "успешное получение",
+			status: domain.VerificationSubmitted,
+			beforeTest: func() {
+				repo.EXPECT().
+					GetByStatus(gomock.Any(), domain.VerificationSubmitted, 1).
+					Return([]*domain.FinancialReport{{ID: uuid.UUID{1}}, {ID: uuid.UUID{2}}}, 1, nil)
+			},
+			wantLen: 2,
+		},
+		{
+			name:    "неизвестный статус",
+			status:  "approved",
+			wantErr: true,
+			errStr:  errors.New("статус проверки должен быть одним из: unverified, submitted, verified, rejected"),
+		},
+		{
+			name:   "ошибка выполнения запроса в репозитории",
+			status: domain.VerificationSubmitted,
+			beforeTest: func() {
+				repo.EXPECT().
+					GetByStatus(gomock.Any(), domain.VerificationSubmitted, 1).
+					Return(nil, 0, fmt.Errorf("sql error"))
+			},
+			wantErr: true,
+			errStr:  errors.New("получение отчетов по статусу проверки: sql error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.beforeTest != nil {
+				tc.beforeTest()
+			}
+
+			reports, _, err := svc.GetByStatus(context.Background(), tc.status, 1)
+
+			if tc.wantErr {
+				require.Equal(t, tc.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+				require.Len(t, reports, tc.wantLen)
+			}
+		})
+	}
+}
+
+func TestFinReportService_AttachDocument(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockIFinancialReportRepository(ctrl)
+	blobs := mocks.NewMockIBlobStorage(ctrl)
+	svc := NewService(repo, blobs, nil, nil)
+
+	ctx := domain.WithActor(context.Background(), &domain.Actor{ID: uuid.UUID{9}, Role: "user"})
+	unverified := &domain.FinancialReport{ID: uuid.UUID{1}, Status: domain.VerificationUnverified}
+
+	testCases := []struct {
+		name       string
+		doc        *domain.FinancialReportDocument
+		beforeTest func()
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name: "успешное добавление",
+			doc:  &domain.FinancialReportDocument{ReportID: uuid.UUID{1}, Name: "act.pdf", ContentType: "application/pdf"},
+			beforeTest: func() {
+				repo.EXPECT().GetById(ctx, uuid.UUID{1}).Return(unverified, nil)
+				blobs.EXPECT().Put(ctx, gomock.Any(), gomock.Any()).Return(int64(4), nil)
+				repo.EXPECT().
+					AddDocument(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, doc *domain.FinancialReportDocument) error {
+						require.Equal(t, int64(4), doc.Size)
+						require.Equal(t, uuid.UUID{9}, doc.UploadedBy)
+						require.Contains(t, doc.StorageKey, "fin_reports/"+uuid.UUID{1}.String()+"/")
+						return nil
+					})
+			},
+		},
+		{
+			name:    "недопустимый тип",
+			doc:     &domain.FinancialReportDocument{ReportID: uuid.UUID{1}, Name: "act.exe", ContentType: "application/octet-stream"},
+			wantErr: true,
+			errStr:  errors.New("тип документа должен быть одним из: application/pdf, image/png, image/jpeg"),
+		},
+		{
+			name: "отчет на проверке",
+			doc:  &domain.FinancialReportDocument{ReportID: uuid.UUID{1}, Name: "act.pdf", ContentType: "application/pdf"},
+			beforeTest: func() {
+				repo.EXPECT().
+					GetById(ctx, uuid.UUID{1}).
+					Return(&domain.FinancialReport{ID: uuid.UUID{1}, Status: domain.VerificationSubmitted}, nil)
+			},
+			wantErr: true,
+			errStr:  errors.New("документы нельзя изменять, пока отчет на проверке или подтвержден"),
+		},
+		{
+			name: "пустой документ",
+			doc:  &domain.FinancialReportDocument{ReportID: uuid.UUID{1}, Name: "act.pdf", ContentType: "application/pdf"},
+			beforeTest: func() {
+				repo.EXPECT().GetById(ctx, uuid.UUID{1}).Return(unverified, nil)
+				blobs.EXPECT().Put(ctx, gomock.Any(), gomock.Any()).Return(int64(0), nil)
+				blobs.EXPECT().Delete(ctx, gomock.Any()).Return(nil)
+			},
+			wantErr: true,
+			errStr:  errors.New("документ не может быть пустым"),
+		},
+		{
+			name: "содержимое удаляется при ошибке репозитория",
+			doc:  &domain.FinancialReportDocument{ReportID: uuid.UUID{1}, Name: "act.pdf", ContentType: "application/pdf"},
+			beforeTest: func() {
+				repo.EXPECT().GetById(ctx, uuid.UUID{1}).Return(unverified, nil)
+				blobs.EXPECT().Put(ctx, gomock.Any(), gomock.Any()).Return(int64(4), nil)
+				repo.EXPECT().AddDocument(ctx, gomock.Any()).Return(fmt.Errorf("sql error"))
+				blobs.EXPECT().Delete(ctx, gomock.Any()).Return(nil)
+			},
+			wantErr: true,
+			errStr:  errors.New("добавление документа: sql error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.beforeTest != nil {
+				tc.beforeTest()
+			}
+
+			err := svc.AttachDocument(ctx, tc.doc, strings.NewReader("%PDF"))
+
+			if tc.wantErr {
+				require.Equal(t, tc.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestFinReportService_OpenDocument(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockIFinancialReportRepository(ctrl)
+	blobs := mocks.NewMockIBlobStorage(ctrl)
+	svc := NewService(repo, blobs, nil, nil)
+
+	doc := &domain.FinancialReportDocument{ID: uuid.UUID{2}, ReportID: uuid.UUID{1}, StorageKey: "fin_reports/a"}
+
+	testCases := []struct {
+		name       string
+		beforeTest func()
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name: "успешное получение",
+			beforeTest: func() {
+				repo.EXPECT().GetDocument(gomock.Any(), uuid.UUID{2}).Return(doc, nil)
+				blobs.EXPECT().Open(gomock.Any(), "fin_reports/a").Return(io.NopCloser(strings.NewReader("%PDF")), nil)
+			},
+		},
+		{
+			name: "содержимое не найдено",
+			beforeTest: func() {
+				repo.EXPECT().GetDocument(gomock.Any(), uuid.UUID{2}).Return(doc, nil)
+				blobs.EXPECT().Open(gomock.Any(), "fin_reports/a").Return(nil, domain.ErrNotFound)
+			},
+			wantErr: true,
+			errStr:  errors.New("получение документа: объект не найден"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.beforeTest()
+
+			got, content, err := svc.OpenDocument(context.Background(), uuid.UUID{2})
+
+			if tc.wantErr {
+				require.Equal(t, tc.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+				require.Equal(t, doc, got)
+				data, err := io.ReadAll(content)
+				require.Nil(t, err)
+				require.Equal(t, "%PDF", string(data))
+			}
+		})
+	}
+}
+
+func TestFinReportService_DeleteDocument(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockIFinancialReportRepository(ctrl)
+	blobs := mocks.NewMockIBlobStorage(ctrl)
+	transactor := mocks.NewMockITransactor(ctrl)
+	transactor.EXPECT().
+		WithinTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).
+		AnyTimes()
+	svc := NewService(repo, blobs, transactor, nil)
+
+	doc := &domain.FinancialReportDocument{ID: uuid.UUID{2}, ReportID: uuid.UUID{1}, StorageKey: "fin_reports/a"}
+
+	testCases := []struct {
+		name       string
+		beforeTest func()
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name: "успешное удаление",
+			beforeTest: func() {
+				repo.EXPECT().GetDocument(gomock.Any(), uuid.UUID{2}).Return(doc, nil)
+				repo.EXPECT().
+					GetById(gomock.Any(), uuid.UUID{1}).
+					Return(&domain.FinancialReport{ID: uuid.UUID{1}, Status: domain.VerificationRejected}, nil)
+				repo.EXPECT().DeleteDocument(gomock.Any(), uuid.UUID{2}).Return(nil)
+				blobs.EXPECT().Delete(gomock.Any(), "fin_reports/a").Return(nil)
+			},
+		},
+		{
+			name: "подтвержденный отчет",
+			beforeTest: func() {
+				repo.EXPECT().GetDocument(gomock.Any(), uuid.UUID{2}).Return(doc, nil)
+				repo.EXPECT().
+					GetById(gomock.Any(), uuid.UUID{1}).
+					Return(&domain.FinancialReport{ID: uuid.UUID{1}, Status: domain.VerificationVerified}, nil)
+			},
+			wantErr: true,
+			errStr:  errors.New("документы нельзя изменять, пока отчет на проверке или подтвержден"),
+		},
+		{
+			name: "ошибка удаления содержимого",
+			beforeTest: func() {
+				repo.EXPECT().GetDocument(gomock.Any(), uuid.UUID{2}).Return(doc, nil)
+				repo.EXPECT().
+					GetById(gomock.Any(), uuid.UUID{1}).
+					Return(&domain.FinancialReport{ID: uuid.UUID{1}, Status: domain.VerificationUnverified}, nil)
+				repo.EXPECT().DeleteDocument(gomock.Any(), uuid.UUID{2}).Return(nil)
+				blobs.EXPECT().Delete(gomock.Any(), "fin_reports/a").Return(fmt.Errorf("io error"))
+			},
+			wantErr: true,
+			errStr:  errors.New("удаление документа: io error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.beforeTest()
+
+			err := svc.DeleteDocument(context.Background(), uuid.UUID{2})
+
+			if tc.wantErr {
+				require.Equal(t, tc.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"ppo/domain"
+
+	"github.com/google/uuid"
+)
+
+type Service struct {
+	compRepo domain.ICompanyRepository
+	finRepo  domain.IFinancialReportRepository
+	conRepo  domain.IContactsRepository
+	revRepo  domain.IReviewRepository
+	roleRepo domain.IRoleRepository
+}
+
+func NewService(
+	compRepo domain.ICompanyRepository,
+	finRepo domain.IFinancialReportRepository,
+	conRepo domain.IContactsRepository,
+	revRepo domain.IReviewRepository,
+	roleRepo domain.IRoleRepository,
+) domain.IPolicyService {
+	return &Service{
+		compRepo: compRepo,
+		finRepo:  finRepo,
+		conRepo:  conRepo,
+		revRepo:  revRepo,
+		roleRepo: roleRepo,
+	}
+}
+
+func (s *Service) CanManageCompany(ctx context.Context, actor *domain.Actor, companyId uuid.UUID) (err error) {
+	company, err := s.compRepo.GetById(ctx, companyId)
+	if err != nil {
+		return fmt.Errorf("проверка владельца компании: %w", err)
+	}
+
+	if company.OwnerID != actor.ID {
+		return fmt.Errorf("%w: только владелец может управлять своими компаниями", domain.ErrForbidden)
+	}
+
+	return nil
+}
+
+func (s *Service) CanManageFinReport(ctx context.Context, actor *domain.Actor, reportId uuid.UUID) (err error) {
+	report, err := s.finRepo.GetById(ctx, reportId)
+	if err != nil {
+		return fmt.Errorf("проверка владельца финансового отчета: %w", err)
+	}
+
+	company, err := s.compRepo.GetById(ctx, report.CompanyID)
+	if err != nil {
+		return fmt.Errorf("проверка владельца финансового отчета: %w", err)
+	}
+
+	if company.OwnerID != actor.ID {
+		return fmt.Errorf("%w: только владелец компании может управлять её финансовыми отчетами", domain.ErrForbidden)
+	}
+
+	return nil
+}
+
+func (s *Service) CanReadFinDocuments(ctx context.Context, actor *domain.Actor, reportId uuid.UUID) (err error) {
+	err = s.CanManageFinReport(ctx, actor, reportId)
+	if !errors.Is(err, domain.ErrForbidden) {
+		return err
+	}
+
+	role, err := s.roleRepo.GetByName(ctx, actor.Role)
+	if err != nil {
+		return fmt.Errorf("проверка роли пользователя: %w", err)
+	}
+
+	if !role.HasPermission(domain.PermFinanceVerify) {
+		return fmt.Errorf("%w: документы отчета доступны только владельцу компании и проверяющим", domain.ErrForbidden)
+	}
+
+	return nil
+}
+
+func (s *Service) CanManageContact(ctx context.Context, actor *domain.Actor, contactId uuid.UUID) (err error) {
+	contact, err := s.conRepo.GetById(ctx, contactId)
+	if err != nil {
+		return fmt.Errorf("проверка владельца средства связи: %w", err)
+	}
+
+	if contact.OwnerID != actor.ID {
+		return fmt.Errorf("%w: только владелец может управлять своими средствами связи", domain.ErrForbidden)
+	}
+
+	return nil
+}
+
+func (s *Service) CanDeleteReview(ctx context.Context, actor *domain.Actor, reviewId uuid.UUID) (err error) {
+	review, err := s.revRepo.Get(ctx, reviewId)
+	if err != nil {
+		return fmt.Errorf("проверка автора отзыва: %w", err)
+	}
+
+	if review.Reviewer == actor.ID {
+		return nil
+	}
+
+	role, err := s.roleRepo.GetByName(ctx, actor.Role)
+	if err != nil {
+		return fmt.Errorf("проверка роли пользователя: %w", err)
+	}
+
+	if !role.HasPermission(domain.PermReviewsModerate) {
+		return fmt.Errorf("%w: удалять отзыв может только его автор или модератор", domain.ErrForbidden)
+	}
+
+	return nil
+}
@@ -0,0 +1,316 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"ppo/domain"
+	"ppo/mocks"
+	"testing"
+)
+
+func TestPolicyService_CanManageCompany(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	compRepo := mocks.NewMockICompanyRepository(ctrl)
+	svc := NewService(compRepo, nil, nil, nil, nil)
+
+	testCases := []struct {
+		name       string
+		actor      *domain.Actor
+		companyId  uuid.UUID
+		beforeTest func(compRepo mocks.MockICompanyRepository)
+		wantErr    bool
+		forbidden  bool
+	}{
+		{
+			name:      "владелец компании",
+			actor:     &domain.Actor{ID: uuid.UUID{1}, Role: "user"},
+			companyId: uuid.UUID{2},
+			beforeTest: func(compRepo mocks.MockICompanyRepository) {
+				compRepo.EXPECT().
+					GetById(context.Background(), uuid.UUID{2}).
+					Return(&domain.Company{ID: uuid.UUID{2}, OwnerID: uuid.UUID{1}}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:      "чужая компания",
+			actor:     &domain.Actor{ID: uuid.UUID{3}, Role: "user"},
+			companyId: uuid.UUID{2},
+			beforeTest: func(compRepo mocks.MockICompanyRepository) {
+				compRepo.EXPECT().
+					GetById(context.Background(), uuid.UUID{2}).
+					Return(&domain.Company{ID: uuid.UUID{2}, OwnerID: uuid.UUID{1}}, nil)
+			},
+			wantErr:   true,
+			forbidden: true,
+		},
+		{
+			name:      "ошибка выполнения запроса в репозитории",
+			actor:     &domain.Actor{ID: uuid.UUID{1}, Role: "user"},
+			companyId: uuid.UUID{2},
+			beforeTest: func(compRepo mocks.MockICompanyRepository) {
+				compRepo.EXPECT().
+					GetById(context.Background(), uuid.UUID{2}).
+					Return(nil, fmt.Errorf("sql error"))
+			},
+			wantErr:   true,
+			forbidden: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.beforeTest != nil {
+				tc.beforeTest(*compRepo)
+			}
+
+			err := svc.CanManageCompany(ctx, tc.actor, tc.companyId)
+
+			if tc.wantErr {
+				require.NotNil(t, err)
+				require.Equal(t, tc.forbidden, errors.Is(err, domain.ErrForbidden))
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestPolicyService_CanManageFinReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	compRepo := mocks.NewMockICompanyRepository(ctrl)
+	finRepo := mocks.NewMockIFinancialReportRepository(ctrl)
+	svc := NewService(compRepo, finRepo, nil, nil, nil)
+
+	testCases := []struct {
+		name       string
+		actor      *domain.Actor
+		reportId   uuid.UUID
+		beforeTest func(compRepo mocks.MockICompanyRepository, finRepo mocks.MockIFinancialReportRepository)
+		wantErr    bool
+	}{
+		{
+			name:     "владелец компании",
+			actor:    &domain.Actor{ID: uuid.UUID{1}, Role: "user"},
+			reportId: uuid.UUID{3},
+			beforeTest: func(compRepo mocks.MockICompanyRepository, finRepo mocks.MockIFinancialReportRepository) {
+				finRepo.EXPECT().
+					GetById(context.Background(), uuid.UUID{3}).
+					Return(&domain.FinancialReport{ID: uuid.UUID{3}, CompanyID: uuid.UUID{2}}, nil)
+				compRepo.EXPECT().
+					GetById(context.Background(), uuid.UUID{2}).
+					Return(&domain.Company{ID: uuid.UUID{2}, OwnerID: uuid.UUID{1}}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:     "отчет чужой компании",
+			actor:    &domain.Actor{ID: uuid.UUID{4}, Role: "user"},
+			reportId: uuid.UUID{3},
+			beforeTest: func(compRepo mocks.MockICompanyRepository, finRepo mocks.MockIFinancialReportRepository) {
+				finRepo.EXPECT().
+					GetById(context.Background(), uuid.UUID{3}).
+					Return(&domain.FinancialReport{ID: uuid.UUID{3}, CompanyID: uuid.UUID{2}}, nil)
+				compRepo.EXPECT().
+					GetById(context.Background(), uuid.UUID{2}).
+					Return(&domain.Company{ID: uuid.UUID{2}, OwnerID: uuid.UUID{1}}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:     "бухгалтер не управляет отчетами чужой компании",
+			actor:    &domain.Actor{ID: uuid.UUID{4}, Role: "accountant"},
+			reportId: uuid.UUID{3},
+			beforeTest: func(compRepo mocks.MockICompanyRepository, finRepo mocks.MockIFinancialReportRepository) {
+				finRepo.EXPECT().
+					GetById(context.Background(), uuid.UUID{3}).
+					Return(&domain.FinancialReport{ID: uuid.UUID{3}, CompanyID: uuid.UUID{2}}, nil)
+				compRepo.EXPECT().
+					GetById(context.Background(), uuid.UUID{2}).
+					Return(&domain.Company{ID: uuid.UUID{2}, OwnerID: uuid.UUID{1}}, nil)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.beforeTest != nil {
+				tc.beforeTest(*compRepo, *finRepo)
+			}
+
+			err := svc.CanManageFinReport(ctx, tc.actor, tc.reportId)
+
+			if tc.wantErr {
+				require.True(t, errors.Is(err, domain.ErrForbidden))
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestPolicyService_CanReadFinDocuments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	compRepo := mocks.NewMockICompanyRepository(ctrl)
+	finRepo := mocks.NewMockIFinancialReportRepository(ctrl)
+	roleRepo := mocks.NewMockIRoleRepository(ctrl)
+	svc := NewService(compRepo, finRepo, nil, nil, roleRepo)
+
+	expectReport := func() {
+		finRepo.EXPECT().
+			GetById(context.Background(), uuid.UUID{3}).
+			Return(&domain.FinancialReport{ID: uuid.UUID{3}, CompanyID: uuid.UUID{2}}, nil)
+		compRepo.EXPECT().
+			GetById(context.Background(), uuid.UUID{2}).
+			Return(&domain.Company{ID: uuid.UUID{2}, OwnerID: uuid.UUID{1}}, nil)
+	}
+
+	testCases := []struct {
+		name       string
+		actor      *domain.Actor
+		beforeTest func()
+		wantErr    bool
+	}{
+		{
+			name:       "владелец компании",
+			actor:      &domain.Actor{ID: uuid.UUID{1}, Role: "user"},
+			beforeTest: expectReport,
+			wantErr:    false,
+		},
+		{
+			name:  "проверяющий",
+			actor: &domain.Actor{ID: uuid.UUID{4}, Role: "admin"},
+			beforeTest: func() {
+				expectReport()
+				roleRepo.EXPECT().
+					GetByName(context.Background(), "admin").
+					Return(&domain.Role{
+						Name:        "admin",
+						Permissions: []domain.Permission{domain.PermFinanceVerify},
+					}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:  "чужой отчет без права проверки",
+			actor: &domain.Actor{ID: uuid.UUID{4}, Role: "user"},
+			beforeTest: func() {
+				expectReport()
+				roleRepo.EXPECT().
+					GetByName(context.Background(), "user").
+					Return(&domain.Role{
+						Name:        "user",
+						Permissions: []domain.Permission{domain.PermFinanceWrite},
+					}, nil)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.beforeTest()
+
+			err := svc.CanReadFinDocuments(context.Background(), tc.actor, uuid.UUID{3})
+
+			if tc.wantErr {
+				require.True(t, errors.Is(err, domain.ErrForbidden))
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestPolicyService_CanDeleteReview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	revRepo := mocks.NewMockIReviewRepository(ctrl)
+	roleRepo := mocks.NewMockIRoleRepository(ctrl)
+	svc := NewService(nil, nil, nil, revRepo, roleRepo)
+
+	testCases := []struct {
+		name       string
+		actor      *domain.Actor
+		reviewId   uuid.UUID
+		beforeTest func(revRepo mocks.MockIReviewRepository, roleRepo mocks.MockIRoleRepository)
+		wantErr    bool
+	}{
+		{
+			name:     "автор отзыва",
+			actor:    &domain.Actor{ID: uuid.UUID{1}, Role: "user"},
+			reviewId: uuid.UUID{2},
+			beforeTest: func(revRepo mocks.MockIReviewRepository, roleRepo mocks.MockIRoleRepository) {
+				revRepo.EXPECT().
+					Get(context.Background(), uuid.UUID{2}).
+					Return(&domain.Review{ID: uuid.UUID{2}, Reviewer: uuid.UUID{1}}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:     "модератор",
+			actor:    &domain.Actor{ID: uuid.UUID{3}, Role: "editor"},
+			reviewId: uuid.UUID{2},
+			beforeTest: func(revRepo mocks.MockIReviewRepository, roleRepo mocks.MockIRoleRepository) {
+				revRepo.EXPECT().
+					Get(context.Background(), uuid.UUID{2}).
+					Return(&domain.Review{ID: uuid.UUID{2}, Reviewer: uuid.UUID{1}}, nil)
+				roleRepo.EXPECT().
+					GetByName(context.Background(), "editor").
+					Return(&domain.Role{
+						Name:        "editor",
+						Permissions: []domain.Permission{domain.PermReviewsModerate},
+					}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:     "чужой отзыв без права модерации",
+			actor:    &domain.Actor{ID: uuid.UUID{3}, Role: "user"},
+			reviewId: uuid.UUID{2},
+			beforeTest: func(revRepo mocks.MockIReviewRepository, roleRepo mocks.MockIRoleRepository) {
+				revRepo.EXPECT().
+					Get(context.Background(), uuid.UUID{2}).
+					Return(&domain.Review{ID: uuid.UUID{2}, Reviewer: uuid.UUID{1}}, nil)
+				roleRepo.EXPECT().
+					GetByName(context.Background(), "user").
+					Return(&domain.Role{
+						Name:        "user",
+						Permissions: []domain.Permission{domain.PermReviewsWrite},
+					}, nil)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.beforeTest != nil {
+				tc.beforeTest(*revRepo, *roleRepo)
+			}
+
+			err := svc.CanDeleteReview(ctx, tc.actor, tc.reviewId)
+
+			if tc.wantErr {
+				require.True(t, errors.Is(err, domain.ErrForbidden))
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
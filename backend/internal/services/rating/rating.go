@@ -0,0 +1,117 @@
+package rating
+
+import (
+	"context"
+	"fmt"
+	"ppo/domain"
+	"ppo/pkg/i18n"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Service struct {
+	ratingRepo domain.IRatingRepository
+	interactor domain.IInteractor
+	now        func() time.Time
+}
+
+func NewService(ratingRepo domain.IRatingRepository, interactor domain.IInteractor) domain.IRatingService {
+	return &Service{
+		ratingRepo: ratingRepo,
+		interactor: interactor,
+		now:        time.Now,
+	}
+}
+
+func validatePeriod(period *domain.Period) error {
+	if period.StartQuarter < 1 || period.StartQuarter > 4 || period.EndQuarter < 1 || period.EndQuarter > 4 {
+		return domain.NewValidationError("period", i18n.MsgFinQuarterRange)
+	}
+
+	if period.StartYear > period.EndYear ||
+		(period.StartYear == period.EndYear && period.StartQuarter > period.EndQuarter) {
+		return domain.NewValidationError("period", i18n.MsgFinPeriodOrder)
+	}
+
+	return nil
+}
+
+// Recompute пересчитывает и сохраняет снимки рейтинга за каждый завершившийся квартал периода.
+// При заданном period.AsOf рейтинг считается по отчётам в версиях, известных на эту дату,
+// и только возвращается: сохранённые снимки всегда отражают текущие версии отчётов.
+func (s *Service) Recompute(ctx context.Context, userId uuid.UUID, period *domain.Period) (snapshots []*domain.RatingSnapshot, err error) {
+	err = validatePeriod(period)
+	if err != nil {
+		return nil, err
+	}
+
+	// кварталы нумеруются подряд: year*4 + quarter - 1
+	// снимки за текущий квартал не сохраняются, пока по нему не могут быть поданы отчёты
+	lastYear, lastQuarter := domain.LastClosedQuarter(s.now())
+	end := min(period.EndYear*4+period.EndQuarter, lastYear*4+lastQuarter) - 1
+
+	snapshots = make([]*domain.RatingSnapshot, 0)
+	for i := period.StartYear*4 + period.StartQuarter - 1; i <= end; i++ {
+		snapshot, err := s.recomputeQuarter(ctx, userId, i/4, i%4+1, period.AsOf)
+		if err != nil {
+			return nil, err
+		}
+
+		snapshots = append(snapshots, snapshot)
+	}
+
+	return snapshots, nil
+}
+
+// RecomputeHistory пересчитывает все ранее сохранённые снимки рейтинга пользователя.
+func (s *Service) RecomputeHistory(ctx context.Context, userId uuid.UUID) (err error) {
+	history, err := s.ratingRepo.GetHistory(ctx, userId, nil)
+	if err != nil {
+		return fmt.Errorf("пересчёт истории рейтинга: %w", err)
+	}
+
+	for _, snapshot := range history {
+		_, err = s.recomputeQuarter(ctx, userId, snapshot.Year, snapshot.Quarter, time.Time{})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) recomputeQuarter(ctx context.Context, userId uuid.UUID, year, quarter int, asOf time.Time) (snapshot *domain.RatingSnapshot, err error) {
+	snapshot, err = s.interactor.CalculateRatingSnapshot(ctx, userId, year, quarter, asOf)
+	if err != nil {
+		return nil, fmt.Errorf("расчёт рейтинга за %d квартал %d года: %w", quarter, year, err)
+	}
+
+	if !asOf.IsZero() {
+		return snapshot, nil
+	}
+
+	err = s.ratingRepo.Save(ctx, snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("расчёт рейтинга за %d квартал %d года: %w", quarter, year, err)
+	}
+
+	return snapshot, nil
+}
+
+// GetHistory возвращает сохранённые снимки рейтинга за период; при period == nil — за всё время.
+func (s *Service) GetHistory(ctx context.Context, userId uuid.UUID, period *domain.Period) (snapshots []*domain.RatingSnapshot, err error) {
+	if period != nil {
+		err = validatePeriod(period)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	snapshots, err = s.ratingRepo.GetHistory(ctx, userId, period)
+	if err != nil {
+		return nil, fmt.Errorf("получение истории рейтинга: %w", err)
+	}
+
+	return snapshots, nil
+}
@@ -0,0 +1,217 @@
+package rating
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"ppo/domain"
+	"ppo/mocks"
+	"testing"
+	"time"
+)
+
+func TestRatingService_Recompute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ratingRepo := mocks.NewMockIRatingRepository(ctrl)
+	interactor := mocks.NewMockIInteractor(ctrl)
+	svc := &Service{
+		ratingRepo: ratingRepo,
+		interactor: interactor,
+		now:        func() time.Time { return time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC) },
+	}
+
+	userId := uuid.UUID{1}
+	snapshot := func(year, quarter int) *domain.RatingSnapshot {
+		return &domain.RatingSnapshot{UserID: userId, Year: year, Quarter: quarter, Rating: 0.5}
+	}
+
+	testCases := []struct {
+		name       string
+		period     *domain.Period
+		beforeTest func()
+		wantErr    bool
+		expected   [][2]int
+		errStr     error
+	}{
+		{
+			name:   "пересчёт через границу года",
+			period: &domain.Period{StartYear: 2022, StartQuarter: 4, EndYear: 2023, EndQuarter: 1},
+			beforeTest: func() {
+				for _, q := range [][2]int{{2022, 4}, {2023, 1}} {
+					interactor.EXPECT().
+						CalculateRatingSnapshot(context.Background(), userId, q[0], q[1], time.Time{}).
+						Return(snapshot(q[0], q[1]), nil)
+					ratingRepo.EXPECT().
+						Save(context.Background(), snapshot(q[0], q[1])).
+						Return(nil)
+				}
+			},
+			expected: [][2]int{{2022, 4}, {2023, 1}},
+		},
+		{
+			name:   "незавершённые кварталы пропускаются",
+			period: &domain.Period{StartYear: 2024, StartQuarter: 1, EndYear: 2024, EndQuarter: 4},
+			beforeTest: func() {
+				interactor.EXPECT().
+					CalculateRatingSnapshot(context.Background(), userId, 2024, 1, time.Time{}).
+					Return(snapshot(2024, 1), nil)
+				ratingRepo.EXPECT().
+					Save(context.Background(), snapshot(2024, 1)).
+					Return(nil)
+			},
+			expected: [][2]int{{2024, 1}},
+		},
+		{
+			name: "пересчёт по версиям отчётов на дату не сохраняет снимки",
+			period: &domain.Period{
+				StartYear: 2023, StartQuarter: 2, EndYear: 2023, EndQuarter: 2,
+				AsOf: time.Date(2023, time.August, 1, 0, 0, 0, 0, time.UTC),
+			},
+			beforeTest: func() {
+				interactor.EXPECT().
+					CalculateRatingSnapshot(context.Background(), userId, 2023, 2, time.Date(2023, time.August, 1, 0, 0, 0, 0, time.UTC)).
+					Return(snapshot(2023, 2), nil)
+			},
+			expected: [][2]int{{2023, 2}},
+		},
+		{
+			name:    "конец периода раньше начала",
+			period:  &domain.Period{StartYear: 2023, StartQuarter: 2, EndYear: 2023, EndQuarter: 1},
+			wantErr: true,
+			errStr:  errors.New("дата конца периода должна быть позже даты начала"),
+		},
+		{
+			name:    "некорректный квартал",
+			period:  &domain.Period{StartYear: 2023, StartQuarter: 0, EndYear: 2023, EndQuarter: 1},
+			wantErr: true,
+			errStr:  errors.New("значение квартала должно находиться в отрезке от 1 до 4"),
+		},
+		{
+			name:   "ошибка выполнения запроса в репозитории",
+			period: &domain.Period{StartYear: 2023, StartQuarter: 1, EndYear: 2023, EndQuarter: 1},
+			beforeTest: func() {
+				interactor.EXPECT().
+					CalculateRatingSnapshot(context.Background(), userId, 2023, 1, time.Time{}).
+					Return(snapshot(2023, 1), nil)
+				ratingRepo.EXPECT().
+					Save(context.Background(), snapshot(2023, 1)).
+					Return(fmt.Errorf("sql error"))
+			},
+			wantErr: true,
+			errStr:  errors.New("расчёт рейтинга за 1 квартал 2023 года: sql error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.beforeTest != nil {
+				tc.beforeTest()
+			}
+
+			snapshots, err := svc.Recompute(context.Background(), userId, tc.period)
+
+			if tc.wantErr {
+				require.Equal(t, tc.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+				require.Len(t, snapshots, len(tc.expected))
+				for i, q := range tc.expected {
+					require.Equal(t, q[0], snapshots[i].Year)
+					require.Equal(t, q[1], snapshots[i].Quarter)
+				}
+			}
+		})
+	}
+}
+
+func TestRatingService_RecomputeHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ratingRepo := mocks.NewMockIRatingRepository(ctrl)
+	interactor := mocks.NewMockIInteractor(ctrl)
+	svc := NewService(ratingRepo, interactor)
+
+	userId := uuid.UUID{1}
+	updated := &domain.RatingSnapshot{UserID: userId, Year: 2023, Quarter: 3, Rating: 0.7}
+
+	ratingRepo.EXPECT().
+		GetHistory(context.Background(), userId, nil).
+		Return([]*domain.RatingSnapshot{{UserID: userId, Year: 2023, Quarter: 3, Rating: 0.2}}, nil)
+	interactor.EXPECT().
+		CalculateRatingSnapshot(context.Background(), userId, 2023, 3, time.Time{}).
+		Return(updated, nil)
+	ratingRepo.EXPECT().
+		Save(context.Background(), updated).
+		Return(nil)
+
+	err := svc.RecomputeHistory(context.Background(), userId)
+	require.Nil(t, err)
+}
+
+func TestRatingService_GetHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ratingRepo := mocks.NewMockIRatingRepository(ctrl)
+	svc := NewService(ratingRepo, nil)
+
+	userId := uuid.UUID{1}
+	period := &domain.Period{StartYear: 2023, StartQuarter: 1, EndYear: 2023, EndQuarter: 4}
+
+	testCases := []struct {
+		name       string
+		period     *domain.Period
+		beforeTest func()
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name:   "успешное получение за период",
+			period: period,
+			beforeTest: func() {
+				ratingRepo.EXPECT().
+					GetHistory(context.Background(), userId, period).
+					Return([]*domain.RatingSnapshot{{UserID: userId, Year: 2023, Quarter: 1}}, nil)
+			},
+		},
+		{
+			name: "успешное получение за всё время",
+			beforeTest: func() {
+				ratingRepo.EXPECT().
+					GetHistory(context.Background(), userId, nil).
+					Return([]*domain.RatingSnapshot{}, nil)
+			},
+		},
+		{
+			name:   "ошибка выполнения запроса в репозитории",
+			period: period,
+			beforeTest: func() {
+				ratingRepo.EXPECT().
+					GetHistory(context.Background(), userId, period).
+					Return(nil, fmt.Errorf("sql error"))
+			},
+			wantErr: true,
+			errStr:  errors.New("получение истории рейтинга: sql error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.beforeTest != nil {
+				tc.beforeTest()
+			}
+
+			_, err := svc.GetHistory(context.Background(), userId, tc.period)
+
+			if tc.wantErr {
+				require.Equal(t, tc.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
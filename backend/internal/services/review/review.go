@@ -4,34 +4,55 @@ import (
 	"context"
 	"fmt"
 	"ppo/domain"
+	"ppo/pkg/i18n"
 
 	"github.com/google/uuid"
 )
 
 type Service struct {
-	revRepo domain.IReviewRepository
+	revRepo    domain.IReviewRepository
+	transactor domain.ITransactor
+	bus        domain.IEventBus
 }
 
-func NewService(revRepo domain.IReviewRepository) domain.IReviewService {
+func NewService(
+	revRepo domain.IReviewRepository,
+	transactor domain.ITransactor,
+	bus domain.IEventBus,
+) domain.IReviewService {
 	return &Service{
-		revRepo: revRepo,
+		revRepo:    revRepo,
+		transactor: transactor,
+		bus:        bus,
 	}
 }
 
 func (s *Service) Create(ctx context.Context, rev *domain.Review) (err error) {
 	if rev.Rating <= 0 || rev.Rating > 5 {
-		return fmt.Errorf("оценка должна быть целым числом от 1 до 5")
+		return domain.NewValidationError("rating", i18n.MsgReviewRatingRange)
 	}
 
 	if rev.Pros == "" {
-		return fmt.Errorf("описание преимуществ не должно быть пустым")
+		return domain.NewValidationError("pros", i18n.MsgReviewProsRequired)
 	}
 
 	if rev.Cons == "" {
-		return fmt.Errorf("описание недостатков не должно быть пустым")
+		return domain.NewValidationError("cons", i18n.MsgReviewConsRequired)
 	}
 
-	err = s.revRepo.Create(ctx, rev)
+	err = s.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		err := s.revRepo.Create(ctx, rev)
+		if err != nil {
+			return err
+		}
+
+		return s.bus.Publish(ctx, domain.ReviewPosted{
+			ID:         rev.ID,
+			TargetID:   rev.Target,
+			ReviewerID: rev.Reviewer,
+			Rating:     rev.Rating,
+		})
+	})
 	if err != nil {
 		return fmt.Errorf("создание отзыва: %w", err)
 	}
@@ -66,6 +87,15 @@ func (s *Service) GetAllForTarget(ctx context.Context, id uuid.UUID, page int) (
 	return revs, numPages, nil
 }
 
+func (s *Service) GetAllForTargets(ctx context.Context, ids []uuid.UUID, page int) (pages map[uuid.UUID]*domain.ReviewPage, err error) {
+	pages, err = s.revRepo.GetAllForTargets(ctx, ids, page)
+	if err != nil {
+		return nil, fmt.Errorf("получение отзывов объектов: %w", err)
+	}
+
+	return pages, nil
+}
+
 func (s *Service) Delete(ctx context.Context, id uuid.UUID) (err error) {
 	err = s.revRepo.Delete(ctx, id)
 	if err != nil {
@@ -0,0 +1,139 @@
+package role
+
+import (
+	"context"
+	"fmt"
+	"ppo/domain"
+	"ppo/pkg/i18n"
+
+	"github.com/google/uuid"
+)
+
+type Service struct {
+	roleRepo   domain.IRoleRepository
+	userRepo   domain.IUserRepository
+	transactor domain.ITransactor
+	bus        domain.IEventBus
+}
+
+func NewService(
+	roleRepo domain.IRoleRepository,
+	userRepo domain.IUserRepository,
+	transactor domain.ITransactor,
+	bus domain.IEventBus,
+) domain.IRoleService {
+	return &Service{
+		roleRepo:   roleRepo,
+		userRepo:   userRepo,
+		transactor: transactor,
+		bus:        bus,
+	}
+}
+
+func validatePermissions(perms []domain.Permission) (err error) {
+	for _, perm := range perms {
+		if !domain.IsKnownPermission(perm) {
+			return domain.NewValidationError("permissions", i18n.MsgRoleUnknownPermission, perm)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) Create(ctx context.Context, role *domain.Role) (err error) {
+	if role.Name == "" {
+		return domain.NewValidationError("name", i18n.MsgRoleNameRequired)
+	}
+
+	err = validatePermissions(role.Permissions)
+	if err != nil {
+		return err
+	}
+
+	err = s.roleRepo.Create(ctx, role)
+	if err != nil {
+		return fmt.Errorf("создание роли: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) GetByName(ctx context.Context, name string) (role *domain.Role, err error) {
+	role, err = s.roleRepo.GetByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("получение роли по названию: %w", err)
+	}
+
+	return role, nil
+}
+
+func (s *Service) GetAll(ctx context.Context) (roles []*domain.Role, err error) {
+	roles, err = s.roleRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("получение списка ролей: %w", err)
+	}
+
+	return roles, nil
+}
+
+func (s *Service) Update(ctx context.Context, role *domain.Role) (err error) {
+	err = validatePermissions(role.Permissions)
+	if err != nil {
+		return err
+	}
+
+	err = s.roleRepo.Update(ctx, role)
+	if err != nil {
+		return fmt.Errorf("обновление информации о роли: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) DeleteByName(ctx context.Context, name string) (err error) {
+	err = s.roleRepo.DeleteByName(ctx, name)
+	if err != nil {
+		return fmt.Errorf("удаление роли по названию: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) AssignToUser(ctx context.Context, userId uuid.UUID, name string) (err error) {
+	user, err := s.userRepo.GetById(ctx, userId)
+	if err != nil {
+		return fmt.Errorf("назначение роли (поиск пользователя): %w", err)
+	}
+
+	_, err = s.roleRepo.GetByName(ctx, name)
+	if err != nil {
+		return fmt.Errorf("назначение роли (поиск роли): %w", err)
+	}
+
+	err = s.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		err := s.roleRepo.SetUserRole(ctx, userId, name)
+		if err != nil {
+			return err
+		}
+
+		return s.bus.Publish(ctx, domain.UserRoleChanged{
+			UserID:       userId,
+			Role:         name,
+			PreviousRole: user.Role,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("назначение роли: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) HasPermission(ctx context.Context, name string, perm domain.Permission) (ok bool, err error) {
+	role, err := s.roleRepo.GetByName(ctx, name)
+	if err != nil {
+		return false, fmt.Errorf("проверка разрешения: %w", err)
+	}
+
+	return role.HasPermission(perm), nil
+}
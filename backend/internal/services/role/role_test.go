@@ -0,0 +1,273 @@
+package role
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"ppo/domain"
+	"ppo/mocks"
+	"testing"
+)
+
+func TestRoleService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	roleRepo := mocks.NewMockIRoleRepository(ctrl)
+	userRepo := mocks.NewMockIUserRepository(ctrl)
+	svc := NewService(roleRepo, userRepo, nil, nil)
+
+	testCases := []struct {
+		name       string
+		role       *domain.Role
+		beforeTest func(roleRepo mocks.MockIRoleRepository)
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name: "успешное добавление",
+			role: &domain.Role{
+				Name:        "aaa",
+				Description: "bbb",
+				Permissions: []domain.Permission{domain.PermFinanceRead},
+			},
+			beforeTest: func(roleRepo mocks.MockIRoleRepository) {
+				roleRepo.EXPECT().
+					Create(
+						context.Background(),
+						&domain.Role{
+							Name:        "aaa",
+							Description: "bbb",
+							Permissions: []domain.Permission{domain.PermFinanceRead},
+						},
+					).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "пустое название роли",
+			role: &domain.Role{
+				Name:        "",
+				Description: "bbb",
+			},
+			wantErr: true,
+			errStr:  errors.New("должно быть указано название роли"),
+		},
+		{
+			name: "неизвестное разрешение",
+			role: &domain.Role{
+				Name:        "aaa",
+				Description: "bbb",
+				Permissions: []domain.Permission{"ccc"},
+			},
+			wantErr: true,
+			errStr:  errors.New("неизвестное разрешение: ccc"),
+		},
+		{
+			name: "ошибка выполнения запроса в репозитории",
+			role: &domain.Role{
+				Name:        "aaa",
+				Description: "bbb",
+			},
+			beforeTest: func(roleRepo mocks.MockIRoleRepository) {
+				roleRepo.EXPECT().
+					Create(
+						context.Background(),
+						&domain.Role{
+							Name:        "aaa",
+							Description: "bbb",
+						},
+					).Return(fmt.Errorf("sql error"))
+			},
+			wantErr: true,
+			errStr:  errors.New("создание роли: sql error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.beforeTest != nil {
+				tc.beforeTest(*roleRepo)
+			}
+
+			err := svc.Create(ctx, tc.role)
+
+			if tc.wantErr {
+				require.Equal(t, tc.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestRoleService_AssignToUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	roleRepo := mocks.NewMockIRoleRepository(ctrl)
+	userRepo := mocks.NewMockIUserRepository(ctrl)
+	transactor := mocks.NewMockITransactor(ctrl)
+	transactor.EXPECT().
+		WithinTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).
+		AnyTimes()
+	bus := mocks.NewMockIEventBus(ctrl)
+	svc := NewService(roleRepo, userRepo, transactor, bus)
+
+	testCases := []struct {
+		name       string
+		userId     uuid.UUID
+		role       string
+		beforeTest func(roleRepo mocks.MockIRoleRepository, userRepo mocks.MockIUserRepository)
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name:   "успешное назначение",
+			userId: uuid.UUID{1},
+			role:   "admin",
+			beforeTest: func(roleRepo mocks.MockIRoleRepository, userRepo mocks.MockIUserRepository) {
+				userRepo.EXPECT().
+					GetById(context.Background(), uuid.UUID{1}).
+					Return(&domain.User{ID: uuid.UUID{1}, Role: "user"}, nil)
+				roleRepo.EXPECT().
+					GetByName(context.Background(), "admin").
+					Return(&domain.Role{Name: "admin"}, nil)
+				roleRepo.EXPECT().
+					SetUserRole(context.Background(), uuid.UUID{1}, "admin").
+					Return(nil)
+				bus.EXPECT().
+					Publish(context.Background(), domain.UserRoleChanged{
+						UserID:       uuid.UUID{1},
+						Role:         "admin",
+						PreviousRole: "user",
+					}).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:   "несуществующий пользователь",
+			userId: uuid.UUID{1},
+			role:   "admin",
+			beforeTest: func(roleRepo mocks.MockIRoleRepository, userRepo mocks.MockIUserRepository) {
+				userRepo.EXPECT().
+					GetById(context.Background(), uuid.UUID{1}).
+					Return(nil, fmt.Errorf("sql error"))
+			},
+			wantErr: true,
+			errStr:  errors.New("назначение роли (поиск пользователя): sql error"),
+		},
+		{
+			name:   "несуществующая роль",
+			userId: uuid.UUID{1},
+			role:   "aaa",
+			beforeTest: func(roleRepo mocks.MockIRoleRepository, userRepo mocks.MockIUserRepository) {
+				userRepo.EXPECT().
+					GetById(context.Background(), uuid.UUID{1}).
+					Return(&domain.User{ID: uuid.UUID{1}}, nil)
+				roleRepo.EXPECT().
+					GetByName(context.Background(), "aaa").
+					Return(nil, fmt.Errorf("sql error"))
+			},
+			wantErr: true,
+			errStr:  errors.New("назначение роли (поиск роли): sql error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.beforeTest != nil {
+				tc.beforeTest(*roleRepo, *userRepo)
+			}
+
+			err := svc.AssignToUser(ctx, tc.userId, tc.role)
+
+			if tc.wantErr {
+				require.Equal(t, tc.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestRoleService_HasPermission(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	roleRepo := mocks.NewMockIRoleRepository(ctrl)
+	userRepo := mocks.NewMockIUserRepository(ctrl)
+	svc := NewService(roleRepo, userRepo, nil, nil)
+
+	testCases := []struct {
+		name       string
+		role       string
+		perm       domain.Permission
+		beforeTest func(roleRepo mocks.MockIRoleRepository)
+		want       bool
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name: "разрешение есть",
+			role: "accountant",
+			perm: domain.PermFinanceRead,
+			beforeTest: func(roleRepo mocks.MockIRoleRepository) {
+				roleRepo.EXPECT().
+					GetByName(context.Background(), "accountant").
+					Return(&domain.Role{
+						Name:        "accountant",
+						Permissions: []domain.Permission{domain.PermFinanceRead},
+					}, nil)
+			},
+			want: true,
+		},
+		{
+			name: "разрешения нет",
+			role: "accountant",
+			perm: domain.PermUsersManage,
+			beforeTest: func(roleRepo mocks.MockIRoleRepository) {
+				roleRepo.EXPECT().
+					GetByName(context.Background(), "accountant").
+					Return(&domain.Role{
+						Name:        "accountant",
+						Permissions: []domain.Permission{domain.PermFinanceRead},
+					}, nil)
+			},
+			want: false,
+		},
+		{
+			name: "ошибка выполнения запроса в репозитории",
+			role: "accountant",
+			perm: domain.PermFinanceRead,
+			beforeTest: func(roleRepo mocks.MockIRoleRepository) {
+				roleRepo.EXPECT().
+					GetByName(context.Background(), "accountant").
+					Return(nil, fmt.Errorf("sql error"))
+			},
+			wantErr: true,
+			errStr:  errors.New("проверка разрешения: sql error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.beforeTest != nil {
+				tc.beforeTest(*roleRepo)
+			}
+
+			ok, err := svc.HasPermission(ctx, tc.role, tc.perm)
+
+			if tc.wantErr {
+				require.Equal(t, tc.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+				require.Equal(t, tc.want, ok)
+			}
+		})
+	}
+}
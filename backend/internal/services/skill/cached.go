@@ -0,0 +1,75 @@
+package skill
+
+import (
+	"context"
+	"ppo/domain"
+	"ppo/pkg/cache"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type page struct {
+	skills   []domain.Skill
+	numPages int
+}
+
+// CachedService кэширует страницы списка навыков поверх svc.
+// Изменения через сервис сбрасывают кэш; изменения в обход него становятся видны по истечении TTL.
+type CachedService struct {
+	domain.ISkillService
+
+	pages cache.Cache[int, page]
+}
+
+// NewCachedService создаёт CachedService с LRU-кэшем на size страниц и временем жизни записей ttl.
+func NewCachedService(svc domain.ISkillService, size int, ttl time.Duration) *CachedService {
+	return &CachedService{
+		ISkillService: svc,
+		pages:         cache.NewLRU[int, page](size, ttl),
+	}
+}
+
+func (s *CachedService) Invalidate() {
+	s.pages.Purge()
+}
+
+func (s *CachedService) Create(ctx context.Context, skill *domain.Skill) error {
+	defer s.Invalidate()
+	return s.ISkillService.Create(ctx, skill)
+}
+
+func (s *CachedService) Update(ctx context.Context, skill *domain.Skill) error {
+	defer s.Invalidate()
+	return s.ISkillService.Update(ctx, skill)
+}
+
+func (s *CachedService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	defer s.Invalidate()
+	return s.ISkillService.DeleteById(ctx, id)
+}
+
+// GetAll возвращает копии закэшированных навыков: вызывающий может их изменять.
+func (s *CachedService) GetAll(ctx context.Context, pageNum int) (skills []*domain.Skill, numPages int, err error) {
+	cached, ok := s.pages.Get(pageNum)
+	if !ok {
+		skills, numPages, err = s.ISkillService.GetAll(ctx, pageNum)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		cached = page{skills: make([]domain.Skill, len(skills)), numPages: numPages}
+		for i, skill := range skills {
+			cached.skills[i] = *skill
+		}
+		s.pages.Set(pageNum, cached)
+	}
+
+	skills = make([]*domain.Skill, len(cached.skills))
+	for i := range cached.skills {
+		skill := cached.skills[i]
+		skills[i] = &skill
+	}
+
+	return skills, cached.numPages, nil
+}
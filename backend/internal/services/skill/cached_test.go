@@ -0,0 +1,38 @@
+package skill
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"ppo/domain"
+	"ppo/mocks"
+	"testing"
+	"time"
+)
+
+func TestCachedService_GetAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := mocks.NewMockISkillService(ctrl)
+	cached := NewCachedService(svc, 10, time.Minute)
+
+	skills := []*domain.Skill{{ID: uuid.UUID{1}, Name: "a", Description: "b"}}
+
+	svc.EXPECT().GetAll(gomock.Any(), 1).Return(skills, 1, nil).Times(1)
+	for i := 0; i < 2; i++ {
+		got, numPages, err := cached.GetAll(context.Background(), 1)
+		require.Nil(t, err)
+		require.Equal(t, skills, got)
+		require.Equal(t, 1, numPages)
+	}
+
+	svc.EXPECT().DeleteById(gomock.Any(), uuid.UUID{1}).Return(nil)
+	require.Nil(t, cached.DeleteById(context.Background(), uuid.UUID{1}))
+
+	svc.EXPECT().GetAll(gomock.Any(), 1).Return([]*domain.Skill{}, 0, nil)
+	got, _, err := cached.GetAll(context.Background(), 1)
+	require.Nil(t, err)
+	require.Empty(t, got)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"ppo/domain"
+	"ppo/pkg/i18n"
 
 	"github.com/google/uuid"
 )
@@ -20,11 +21,11 @@ func NewService(skillRepo domain.ISkillRepository) domain.ISkillService {
 
 func (s *Service) Create(ctx context.Context, skill *domain.Skill) (err error) {
 	if skill.Name == "" {
-		return fmt.Errorf("должно быть указано название навыка")
+		return domain.NewValidationError("name", i18n.MsgSkillNameRequired)
 	}
 
 	if skill.Description == "" {
-		return fmt.Errorf("должно быть указано описание навыка")
+		return domain.NewValidationError("description", i18n.MsgSkillDescriptionRequired)
 	}
 
 	err = s.skillRepo.Create(ctx, skill)
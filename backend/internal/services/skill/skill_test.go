@@ -214,7 +214,7 @@ func TestSkillService_GetAll(t *testing.T) {
 							Name:        "c",
 							Description: "c",
 						},
-					}, nil)
+					}, 1, nil)
 			},
 			expected: []*domain.Skill{
 				{
@@ -240,7 +240,7 @@ func TestSkillService_GetAll(t *testing.T) {
 			beforeTest: func(skillRepo mocks.MockISkillRepository) {
 				skillRepo.EXPECT().
 					GetAll(context.Background(), 1).
-					Return(nil, fmt.Errorf("sql error"))
+					Return(nil, 0, fmt.Errorf("sql error"))
 			},
 			wantErr: true,
 			errStr:  errors.New("получение списка всех навыков: sql error"),
@@ -253,7 +253,7 @@ func TestSkillService_GetAll(t *testing.T) {
 				tc.beforeTest(*skillRepo)
 			}
 
-			skills, err := svc.GetAll(ctx, 1)
+			skills, _, err := svc.GetAll(ctx, 1)
 
 			if tc.wantErr {
 				require.Equal(t, tc.errStr.Error(), err.Error())
@@ -0,0 +1,57 @@
+package user
+
+import (
+	"context"
+	"ppo/domain"
+	"ppo/pkg/cache"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CachedService кэширует профили пользователей по id поверх svc.
+// Изменения через сервис сбрасывают запись; роль меняется в обход сервиса,
+// поэтому её смену нужно передавать в Invalidate (см. подписчиков в app).
+type CachedService struct {
+	domain.IUserService
+
+	users cache.Cache[uuid.UUID, domain.User]
+}
+
+// NewCachedService создаёт CachedService с LRU-кэшем на size профилей и временем жизни записей ttl.
+func NewCachedService(svc domain.IUserService, size int, ttl time.Duration) *CachedService {
+	return &CachedService{
+		IUserService: svc,
+		users:        cache.NewLRU[uuid.UUID, domain.User](size, ttl),
+	}
+}
+
+func (s *CachedService) Invalidate(id uuid.UUID) {
+	s.users.Delete(id)
+}
+
+func (s *CachedService) Update(ctx context.Context, user *domain.User) error {
+	defer s.Invalidate(user.ID)
+	return s.IUserService.Update(ctx, user)
+}
+
+func (s *CachedService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	defer s.Invalidate(id)
+	return s.IUserService.DeleteById(ctx, id)
+}
+
+// GetById возвращает копию закэшированного профиля: вызывающий может её изменять.
+func (s *CachedService) GetById(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	user, ok := s.users.Get(id)
+	if !ok {
+		fetched, err := s.IUserService.GetById(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		user = *fetched
+		s.users.Set(id, user)
+	}
+
+	return &user, nil
+}
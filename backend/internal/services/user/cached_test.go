@@ -0,0 +1,49 @@
+package user
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"ppo/domain"
+	"ppo/mocks"
+	"testing"
+	"time"
+)
+
+func TestCachedService_GetById(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := mocks.NewMockIUserService(ctrl)
+	cached := NewCachedService(svc, 10, time.Minute)
+
+	id := uuid.UUID{1}
+	user := &domain.User{ID: id, Username: "test", City: "Москва", Role: "user"}
+
+	svc.EXPECT().GetById(gomock.Any(), id).Return(user, nil).Times(1)
+	for i := 0; i < 2; i++ {
+		got, err := cached.GetById(context.Background(), id)
+		require.Nil(t, err)
+		require.Equal(t, user, got)
+
+		// обработчики изменяют полученный профиль перед Update
+		got.City = "Казань"
+	}
+
+	// смена роли приходит событием и сбрасывает запись
+	cached.Invalidate(id)
+
+	updated := &domain.User{ID: id, Username: "test", City: "Москва", Role: "admin"}
+	svc.EXPECT().GetById(gomock.Any(), id).Return(updated, nil)
+	got, err := cached.GetById(context.Background(), id)
+	require.Nil(t, err)
+	require.Equal(t, "admin", got.Role)
+
+	svc.EXPECT().Update(gomock.Any(), updated).Return(nil)
+	require.Nil(t, cached.Update(context.Background(), updated))
+
+	svc.EXPECT().GetById(gomock.Any(), id).Return(nil, domain.ErrNotFound)
+	_, err = cached.GetById(context.Background(), id)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+}
@@ -2,8 +2,10 @@ package user
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"ppo/domain"
+	"ppo/pkg/i18n"
 	"strings"
 
 	"github.com/google/uuid"
@@ -13,39 +15,42 @@ type Service struct {
 	userRepo     domain.IUserRepository
 	companyRepo  domain.ICompanyRepository
 	actFieldRepo domain.IActivityFieldRepository
+	roleRepo     domain.IRoleRepository
 }
 
 func NewService(
 	userRepo domain.IUserRepository,
 	companyRepo domain.ICompanyRepository,
 	actFieldRepo domain.IActivityFieldRepository,
+	roleRepo domain.IRoleRepository,
 ) domain.IUserService {
 	return &Service{
 		userRepo:     userRepo,
 		companyRepo:  companyRepo,
 		actFieldRepo: actFieldRepo,
+		roleRepo:     roleRepo,
 	}
 }
 
 func (s *Service) Create(ctx context.Context, user *domain.User) (err error) {
 	if user.Gender != "m" && user.Gender != "w" {
-		return fmt.Errorf("неизвестный пол")
+		return domain.NewValidationError("gender", i18n.MsgUserGenderUnknown)
 	}
 
 	if user.City == "" {
-		return fmt.Errorf("должно быть указано название города")
+		return domain.NewValidationError("city", i18n.MsgCityRequired)
 	}
 
 	if user.Birthday.IsZero() {
-		return fmt.Errorf("должна быть указана дата рождения")
+		return domain.NewValidationError("birthday", i18n.MsgUserBirthdayRequired)
 	}
 
 	if user.FullName == "" {
-		return fmt.Errorf("должны быть указаны ФИО")
+		return domain.NewValidationError("full_name", i18n.MsgUserFullNameRequired)
 	}
 
 	if len(strings.Split(user.FullName, " ")) != 3 {
-		return fmt.Errorf("некорректное количество слов (должны быть фамилия, имя и отчество)")
+		return domain.NewValidationError("full_name", i18n.MsgUserFullNameWordCount)
 	}
 
 	err = s.userRepo.Create(ctx, user)
@@ -74,6 +79,15 @@ func (s *Service) GetById(ctx context.Context, userId uuid.UUID) (user *domain.U
 	return user, nil
 }
 
+func (s *Service) GetByIds(ctx context.Context, ids []uuid.UUID) (users []*domain.User, err error) {
+	users, err = s.userRepo.GetByIds(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("получение пользователей по списку id: %w", err)
+	}
+
+	return users, nil
+}
+
 func (s *Service) GetAll(ctx context.Context, page int) (users []*domain.User, numPages int, err error) {
 	users, numPages, err = s.userRepo.GetAll(ctx, page)
 	if err != nil {
@@ -85,27 +99,31 @@ func (s *Service) GetAll(ctx context.Context, page int) (users []*domain.User, n
 
 func (s *Service) Update(ctx context.Context, user *domain.User) (err error) {
 	if user.Gender != "m" && user.Gender != "w" {
-		return fmt.Errorf("неизвестный пол")
+		return domain.NewValidationError("gender", i18n.MsgUserGenderUnknown)
 	}
 
 	if user.City == "" {
-		return fmt.Errorf("должно быть указано название города")
+		return domain.NewValidationError("city", i18n.MsgCityRequired)
 	}
 
 	if user.Birthday.IsZero() {
-		return fmt.Errorf("должна быть указана дата рождения")
+		return domain.NewValidationError("birthday", i18n.MsgUserBirthdayRequired)
 	}
 
 	if user.FullName == "" {
-		return fmt.Errorf("должны быть указаны ФИО")
+		return domain.NewValidationError("full_name", i18n.MsgUserFullNameRequired)
 	}
 
 	if len(strings.Split(user.FullName, " ")) != 3 {
-		return fmt.Errorf("некорректное количество слов (должны быть фамилия, имя и отчество)")
+		return domain.NewValidationError("full_name", i18n.MsgUserFullNameWordCount)
 	}
 
-	if user.Role != "admin" && user.Role != "user" {
-		return fmt.Errorf("невалидная роль")
+	_, err = s.roleRepo.GetByName(ctx, user.Role)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.NewValidationError("role", i18n.MsgUserRoleInvalid, err)
+	}
+	if err != nil {
+		return fmt.Errorf("невалидная роль: %w", err)
 	}
 
 	err = s.userRepo.Update(ctx, user)
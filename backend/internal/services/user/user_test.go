@@ -20,7 +20,8 @@ func TestUserService_DeleteById(t *testing.T) {
 	userRepo := mocks.NewMockIUserRepository(ctrl)
 	compRepo := mocks.NewMockICompanyRepository(ctrl)
 	actFieldRepo := mocks.NewMockIActivityFieldRepository(ctrl)
-	svc := NewService(userRepo, compRepo, actFieldRepo)
+	roleRepo := mocks.NewMockIRoleRepository(ctrl)
+	svc := NewService(userRepo, compRepo, actFieldRepo, roleRepo)
 
 	curUuid := uuid.New()
 
@@ -78,7 +79,8 @@ func TestUserService_GetAll(t *testing.T) {
 	userRepo := mocks.NewMockIUserRepository(ctrl)
 	compRepo := mocks.NewMockICompanyRepository(ctrl)
 	actFieldRepo := mocks.NewMockIActivityFieldRepository(ctrl)
-	svc := NewService(userRepo, compRepo, actFieldRepo)
+	roleRepo := mocks.NewMockIRoleRepository(ctrl)
+	svc := NewService(userRepo, compRepo, actFieldRepo, roleRepo)
 
 	testCases := []struct {
 		name       string
@@ -117,7 +119,7 @@ func TestUserService_GetAll(t *testing.T) {
 							Birthday: time.Date(3, 3, 3, 3, 3, 3, 3, time.Local),
 							City:     "c",
 						},
-					}, nil)
+					}, 1, nil)
 			},
 			expected: []*domain.User{
 				{
@@ -152,7 +154,7 @@ func TestUserService_GetAll(t *testing.T) {
 			beforeTest: func(userRepo mocks.MockIUserRepository) {
 				userRepo.EXPECT().
 					GetAll(context.Background(), 1).
-					Return(nil, fmt.Errorf("sql error"))
+					Return(nil, 0, fmt.Errorf("sql error"))
 			},
 			wantErr: true,
 			errStr:  errors.New("получение списка всех пользователей: sql error"),
@@ -165,7 +167,7 @@ func TestUserService_GetAll(t *testing.T) {
 				tc.beforeTest(*userRepo)
 			}
 
-			users, err := svc.GetAll(ctx, 1)
+			users, _, err := svc.GetAll(ctx, 1)
 
 			if tc.wantErr {
 				require.Equal(t, tc.errStr.Error(), err.Error())
@@ -184,7 +186,8 @@ func TestUserService_Create(t *testing.T) {
 	userRepo := mocks.NewMockIUserRepository(ctrl)
 	compRepo := mocks.NewMockICompanyRepository(ctrl)
 	actFieldRepo := mocks.NewMockIActivityFieldRepository(ctrl)
-	svc := NewService(userRepo, compRepo, actFieldRepo)
+	roleRepo := mocks.NewMockIRoleRepository(ctrl)
+	svc := NewService(userRepo, compRepo, actFieldRepo, roleRepo)
 
 	testCases := []struct {
 		name       string
@@ -385,7 +388,8 @@ func TestUserService_GetById(t *testing.T) {
 	userRepo := mocks.NewMockIUserRepository(ctrl)
 	compRepo := mocks.NewMockICompanyRepository(ctrl)
 	actFieldRepo := mocks.NewMockIActivityFieldRepository(ctrl)
-	svc := NewService(userRepo, compRepo, actFieldRepo)
+	roleRepo := mocks.NewMockIRoleRepository(ctrl)
+	svc := NewService(userRepo, compRepo, actFieldRepo, roleRepo)
 
 	testCases := []struct {
 		name       string
@@ -464,52 +468,63 @@ func TestUserService_Update(t *testing.T) {
 	userRepo := mocks.NewMockIUserRepository(ctrl)
 	compRepo := mocks.NewMockICompanyRepository(ctrl)
 	actFieldRepo := mocks.NewMockIActivityFieldRepository(ctrl)
-	svc := NewService(userRepo, compRepo, actFieldRepo)
+	roleRepo := mocks.NewMockIRoleRepository(ctrl)
+	svc := NewService(userRepo, compRepo, actFieldRepo, roleRepo)
+
+	user := &domain.User{
+		ID:       uuid.UUID{1},
+		FullName: "a b c",
+		Gender:   "m",
+		Birthday: time.Date(1, 1, 1, 1, 1, 1, 1, time.Local),
+		City:     "a",
+		Role:     "admin",
+	}
 
 	testCases := []struct {
 		name       string
 		user       *domain.User
-		beforeTest func(userRepo mocks.MockIUserRepository)
+		beforeTest func(userRepo mocks.MockIUserRepository, roleRepo mocks.MockIRoleRepository)
 		wantErr    bool
 		errStr     error
+		errIs      error
 	}{
 		{
 			name: "успешное обновление",
-			user: &domain.User{
-				ID:   uuid.UUID{1},
-				City: "a",
-				Role: "admin",
-			},
-			beforeTest: func(userRepo mocks.MockIUserRepository) {
+			user: user,
+			beforeTest: func(userRepo mocks.MockIUserRepository, roleRepo mocks.MockIRoleRepository) {
+				roleRepo.EXPECT().
+					GetByName(context.Background(), "admin").
+					Return(&domain.Role{Name: "admin"}, nil)
+
 				userRepo.EXPECT().
-					Update(
-						context.Background(),
-						&domain.User{
-							ID:   uuid.UUID{1},
-							City: "a",
-							Role: "admin",
-						},
-					).Return(nil)
+					Update(context.Background(), user).
+					Return(nil)
 			},
 			wantErr: false,
 		},
 		{
-			name: "ошибка выполнения запроса в репозитории",
-			user: &domain.User{
-				ID:   uuid.UUID{1},
-				City: "a",
-				Role: "admin",
+			name: "несуществующая роль",
+			user: user,
+			beforeTest: func(userRepo mocks.MockIUserRepository, roleRepo mocks.MockIRoleRepository) {
+				roleRepo.EXPECT().
+					GetByName(context.Background(), "admin").
+					Return(nil, domain.NewNotFoundError(fmt.Errorf("no rows in result set")))
 			},
-			beforeTest: func(userRepo mocks.MockIUserRepository) {
+			wantErr: true,
+			errStr:  errors.New("невалидная роль: no rows in result set"),
+			errIs:   domain.ErrValidation,
+		},
+		{
+			name: "ошибка выполнения запроса в репозитории",
+			user: user,
+			beforeTest: func(userRepo mocks.MockIUserRepository, roleRepo mocks.MockIRoleRepository) {
+				roleRepo.EXPECT().
+					GetByName(context.Background(), "admin").
+					Return(&domain.Role{Name: "admin"}, nil)
+
 				userRepo.EXPECT().
-					Update(
-						context.Background(),
-						&domain.User{
-							ID:   uuid.UUID{1},
-							City: "a",
-							Role: "admin",
-						},
-					).Return(fmt.Errorf("sql error"))
+					Update(context.Background(), user).
+					Return(fmt.Errorf("sql error"))
 			},
 			wantErr: true,
 			errStr:  errors.New("обновление информации о пользователе: sql error"),
@@ -519,13 +534,16 @@ func TestUserService_Update(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			ctx := context.Background()
 			if tc.beforeTest != nil {
-				tc.beforeTest(*userRepo)
+				tc.beforeTest(*userRepo, *roleRepo)
 			}
 
 			err := svc.Update(ctx, tc.user)
 
 			if tc.wantErr {
 				require.Equal(t, tc.errStr.Error(), err.Error())
+				if tc.errIs != nil {
+					require.ErrorIs(t, err, tc.errIs)
+				}
 			} else {
 				require.Nil(t, err)
 			}
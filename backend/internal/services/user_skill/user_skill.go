@@ -63,6 +63,37 @@ func (s *Service) GetSkillsForUser(ctx context.Context, userId uuid.UUID, page i
 	return skills, numPages, nil
 }
 
+func (s *Service) GetSkillsForUsers(ctx context.Context, userIds []uuid.UUID) (skills map[uuid.UUID][]*domain.Skill, err error) {
+	userSkills, err := s.userSkillRepo.GetUserSkillsByUserIds(ctx, userIds)
+	if err != nil {
+		return nil, fmt.Errorf("получение связок пользователь-навык по списку userId: %w", err)
+	}
+
+	skillIds := make([]uuid.UUID, 0, len(userSkills))
+	for _, userSkill := range userSkills {
+		skillIds = append(skillIds, userSkill.SkillId)
+	}
+
+	skillList, err := s.skillRepo.GetByIds(ctx, skillIds)
+	if err != nil {
+		return nil, fmt.Errorf("получение навыков по списку skillId: %w", err)
+	}
+
+	byId := make(map[uuid.UUID]*domain.Skill, len(skillList))
+	for _, skill := range skillList {
+		byId[skill.ID] = skill
+	}
+
+	skills = make(map[uuid.UUID][]*domain.Skill, len(userIds))
+	for _, userSkill := range userSkills {
+		if skill, ok := byId[userSkill.SkillId]; ok {
+			skills[userSkill.UserId] = append(skills[userSkill.UserId], skill)
+		}
+	}
+
+	return skills, nil
+}
+
 func (s *Service) GetUsersForSkill(ctx context.Context, skillId uuid.UUID, page int) (users []*domain.User, err error) {
 	userSkills, err := s.userSkillRepo.GetUserSkillsBySkillId(ctx, skillId, page)
 	if err != nil {
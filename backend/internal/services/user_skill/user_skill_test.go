@@ -212,7 +212,7 @@ func TestUserSkillService_GetSkillsForUser(t *testing.T) {
 							UserId:  uuid.UUID{1},
 							SkillId: uuid.UUID{3},
 						},
-					}, nil)
+					}, 1, nil)
 
 				skillRepo.EXPECT().
 					GetById(
@@ -275,7 +275,7 @@ func TestUserSkillService_GetSkillsForUser(t *testing.T) {
 							UserId:  uuid.UUID{1},
 							SkillId: uuid.UUID{1},
 						},
-					}, nil)
+					}, 1, nil)
 
 				skillRepo.EXPECT().
 					GetById(
@@ -303,7 +303,7 @@ func TestUserSkillService_GetSkillsForUser(t *testing.T) {
 						1,
 						true,
 					).
-					Return(nil, fmt.Errorf("sql error"))
+					Return(nil, 0, fmt.Errorf("sql error"))
 			},
 			wantErr: true,
 			errStr:  errors.New("получение связок пользователь-навык по userId: sql error"),
@@ -316,7 +316,144 @@ func TestUserSkillService_GetSkillsForUser(t *testing.T) {
 				tc.beforeTest(*userSkillRepo, *userRepo, *skillRepo)
 			}
 
-			skills, err := svc.GetSkillsForUser(ctx, uuid.UUID{1}, 1, true)
+			skills, _, err := svc.GetSkillsForUser(ctx, uuid.UUID{1}, 1, true)
+
+			if tc.wantErr {
+				require.Equal(t, tc.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+				require.Equal(t, tc.expected, skills)
+			}
+		})
+	}
+}
+
+func TestUserSkillService_GetSkillsForUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userSkillRepo := mocks.NewMockIUserSkillRepository(ctrl)
+	userRepo := mocks.NewMockIUserRepository(ctrl)
+	skillRepo := mocks.NewMockISkillRepository(ctrl)
+	svc := NewService(userSkillRepo, userRepo, skillRepo)
+
+	testCases := []struct {
+		name       string
+		ids        []uuid.UUID
+		beforeTest func(userSkillRepo mocks.MockIUserSkillRepository, skillRepo mocks.MockISkillRepository)
+		expected   map[uuid.UUID][]*domain.Skill
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name: "успешное получение навыков пользователей",
+			ids:  []uuid.UUID{{1}, {2}, {3}},
+			beforeTest: func(userSkillRepo mocks.MockIUserSkillRepository, skillRepo mocks.MockISkillRepository) {
+				userSkillRepo.EXPECT().
+					GetUserSkillsByUserIds(
+						context.Background(),
+						[]uuid.UUID{{1}, {2}, {3}},
+					).
+					Return([]*domain.UserSkill{
+						{
+							UserId:  uuid.UUID{1},
+							SkillId: uuid.UUID{1},
+						},
+						{
+							UserId:  uuid.UUID{1},
+							SkillId: uuid.UUID{2},
+						},
+						{
+							UserId:  uuid.UUID{2},
+							SkillId: uuid.UUID{1},
+						},
+					}, nil)
+
+				skillRepo.EXPECT().
+					GetByIds(
+						context.Background(),
+						[]uuid.UUID{{1}, {2}, {1}},
+					).
+					Return([]*domain.Skill{
+						{
+							ID:   uuid.UUID{1},
+							Name: "a",
+						},
+						{
+							ID:   uuid.UUID{2},
+							Name: "b",
+						},
+					}, nil)
+			},
+			expected: map[uuid.UUID][]*domain.Skill{
+				{1}: {
+					{
+						ID:   uuid.UUID{1},
+						Name: "a",
+					},
+					{
+						ID:   uuid.UUID{2},
+						Name: "b",
+					},
+				},
+				{2}: {
+					{
+						ID:   uuid.UUID{1},
+						Name: "a",
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ошибка получения навыков в репозитории",
+			ids:  []uuid.UUID{{1}},
+			beforeTest: func(userSkillRepo mocks.MockIUserSkillRepository, skillRepo mocks.MockISkillRepository) {
+				userSkillRepo.EXPECT().
+					GetUserSkillsByUserIds(
+						context.Background(),
+						[]uuid.UUID{{1}},
+					).
+					Return([]*domain.UserSkill{
+						{
+							UserId:  uuid.UUID{1},
+							SkillId: uuid.UUID{1},
+						},
+					}, nil)
+
+				skillRepo.EXPECT().
+					GetByIds(
+						context.Background(),
+						[]uuid.UUID{{1}},
+					).
+					Return(nil, fmt.Errorf("sql error"))
+			},
+			wantErr: true,
+			errStr:  errors.New("получение навыков по списку skillId: sql error"),
+		},
+		{
+			name: "ошибка получения связок в репозитории",
+			ids:  []uuid.UUID{{1}},
+			beforeTest: func(userSkillRepo mocks.MockIUserSkillRepository, skillRepo mocks.MockISkillRepository) {
+				userSkillRepo.EXPECT().
+					GetUserSkillsByUserIds(
+						context.Background(),
+						[]uuid.UUID{{1}},
+					).
+					Return(nil, fmt.Errorf("sql error"))
+			},
+			wantErr: true,
+			errStr:  errors.New("получение связок пользователь-навык по списку userId: sql error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.beforeTest != nil {
+				tc.beforeTest(*userSkillRepo, *skillRepo)
+			}
+
+			skills, err := svc.GetSkillsForUsers(ctx, tc.ids)
 
 			if tc.wantErr {
 				require.Equal(t, tc.errStr.Error(), err.Error())
@@ -540,7 +677,7 @@ func TestUserSkillService_DeleteSkillsForUser(t *testing.T) {
 							UserId:  uuid.UUID{1},
 							SkillId: uuid.UUID{2},
 						},
-					}, nil)
+					}, 1, nil)
 
 				userSkillRepo.EXPECT().
 					Delete(
@@ -583,7 +720,7 @@ func TestUserSkillService_DeleteSkillsForUser(t *testing.T) {
 							UserId:  uuid.UUID{1},
 							SkillId: uuid.UUID{2},
 						},
-					}, nil)
+					}, 1, nil)
 
 				userSkillRepo.EXPECT().
 					Delete(
@@ -611,7 +748,7 @@ func TestUserSkillService_DeleteSkillsForUser(t *testing.T) {
 						0,
 						false,
 					).
-					Return(nil, fmt.Errorf("sql error"))
+					Return(nil, 0, fmt.Errorf("sql error"))
 			},
 			wantErr: true,
 			errStr:  errors.New("получение связок пользователь-навык по userId: sql error"),
@@ -0,0 +1,206 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"ppo/domain"
+	"time"
+)
+
+const (
+	DefaultInterval = 5 * time.Second
+	MaxAttempts     = 8
+	requestTimeout  = 10 * time.Second
+	// Доставки забираются по одной, поэтому claimLease покрывает одну попытку
+	// и должен превышать requestTimeout, иначе доставку успеет повторно
+	// забрать другой экземпляр сервера.
+	claimLease = time.Minute
+)
+
+const (
+	HeaderEvent     = "X-PPO-Event"
+	HeaderDelivery  = "X-PPO-Delivery"
+	HeaderSignature = "X-PPO-Signature"
+)
+
+// Envelope — тело запроса, которое получает подписчик.
+type Envelope struct {
+	ID        string           `json:"id"`
+	Type      domain.EventType `json:"type"`
+	CreatedAt time.Time        `json:"created_at"`
+	Data      json.RawMessage  `json:"data"`
+}
+
+// Sign возвращает значение заголовка X-PPO-Signature для тела body:
+// HMAC-SHA256 с секретом подписки в шестнадцатеричном виде.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Backoff возвращает паузу перед следующей попыткой после attempt неудачных:
+// 30 секунд, удваиваясь с каждой попыткой, но не более 6 часов.
+func Backoff(attempt int) time.Duration {
+	delay := 30 * time.Second
+	for i := 1; i < attempt && delay < 6*time.Hour; i++ {
+		delay *= 2
+	}
+
+	return min(delay, 6*time.Hour)
+}
+
+// Dispatcher раскладывает доменные события по подписчикам и отправляет их.
+type Dispatcher struct {
+	webhookRepo domain.IWebhookRepository
+	client      *http.Client
+	timeout     time.Duration
+	now         func() time.Time
+}
+
+func NewDispatcher(webhookRepo domain.IWebhookRepository, client *http.Client) *Dispatcher {
+	if client == nil {
+		client = &http.Client{Timeout: requestTimeout}
+	}
+
+	return &Dispatcher{
+		webhookRepo: webhookRepo,
+		client:      client,
+		timeout:     requestTimeout,
+		now:         time.Now,
+	}
+}
+
+// Run вызывает RunOnce каждые interval, пока не будет отменён ctx.
+func (d *Dispatcher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := d.RunOnce(ctx)
+		if err != nil {
+			log.Println(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Enqueue ставит событие в очередь доставки всем подписчикам на его тип.
+// Подписывается на все типы событий шины в app.NewApp.
+func (d *Dispatcher) Enqueue(ctx context.Context, event *domain.OutboxEvent) error {
+	_, err := d.webhookRepo.EnqueueDeliveries(ctx, event)
+	if err != nil {
+		return fmt.Errorf("постановка вебхуков в очередь: %w", err)
+	}
+
+	return nil
+}
+
+// RunOnce выполняет все доставки, время которых наступило. Каждая доставка
+// забирается непосредственно перед отправкой, чтобы медленные подписчики
+// не задерживали доставки, уже забранные этим экземпляром.
+func (d *Dispatcher) RunOnce(ctx context.Context) (err error) {
+	for {
+		deliveries, err := d.webhookRepo.ClaimDueDeliveries(ctx, d.now(), claimLease, 1)
+		if err != nil {
+			return fmt.Errorf("рассылка вебхуков: %w", err)
+		}
+		if len(deliveries) == 0 {
+			return nil
+		}
+
+		err = d.deliver(ctx, deliveries[0])
+		if err != nil {
+			return fmt.Errorf("рассылка вебхуков: %w", err)
+		}
+	}
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	body, err := json.Marshal(Envelope{
+		ID:        delivery.EventID.String(),
+		Type:      delivery.EventType,
+		CreatedAt: delivery.EventCreatedAt,
+		Data:      delivery.Payload,
+	})
+	if err != nil {
+		return fmt.Errorf("сериализация события: %w", err)
+	}
+
+	start := d.now()
+	statusCode, sendErr := d.send(ctx, delivery, body)
+
+	delivery.Attempts++
+	delivery.LastStatusCode = statusCode
+	delivery.LastError = ""
+	if sendErr != nil {
+		delivery.LastError = sendErr.Error()
+	}
+
+	switch {
+	case sendErr == nil:
+		delivery.Status = domain.DeliveryDelivered
+	case delivery.Attempts >= MaxAttempts:
+		delivery.Status = domain.DeliveryFailed
+	default:
+		delivery.NextAttemptAt = d.now().Add(Backoff(delivery.Attempts))
+	}
+
+	attempt := &domain.WebhookDeliveryAttempt{
+		DeliveryID: delivery.ID,
+		Attempt:    delivery.Attempts,
+		StatusCode: statusCode,
+		Error:      delivery.LastError,
+		Duration:   d.now().Sub(start),
+	}
+
+	err = d.webhookRepo.SaveAttempt(ctx, delivery, attempt)
+	if err != nil {
+		return fmt.Errorf("сохранение результата доставки: %w", err)
+	}
+
+	return nil
+}
+
+// send отправляет тело body подписчику. Успешной считается доставка с ответом 2xx.
+// Попытка ограничена d.timeout независимо от настроек клиента.
+func (d *Dispatcher) send(ctx context.Context, delivery *domain.WebhookDelivery, body []byte) (statusCode int, err error) {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("создание запроса: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, string(delivery.EventType))
+	req.Header.Set(HeaderDelivery, delivery.ID.String())
+	req.Header.Set(HeaderSignature, Sign(delivery.Secret, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("отправка запроса: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("подписчик ответил статусом %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,239 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"ppo/domain"
+	"ppo/mocks"
+	"testing"
+	"time"
+)
+
+// subscriber — локальная замена подписчика: проверяет подпись и отвечает заданным статусом.
+type subscriber struct {
+	secret   string
+	status   int
+	requests []Envelope
+}
+
+func (s *subscriber) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	if r.Header.Get(HeaderSignature) != Sign(s.secret, body) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var env Envelope
+	json.Unmarshal(body, &env)
+	s.requests = append(s.requests, env)
+
+	w.WriteHeader(s.status)
+}
+
+func TestDispatcher_RunOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const secret = "0123456789abcdef"
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		status         int
+		attempts       int
+		secret         string
+		closed         bool
+		wantStatus     domain.DeliveryStatus
+		wantNext       time.Time
+		wantStatusCode int
+		wantError      bool
+		wantRequests   int
+	}{
+		{
+			name:           "успешная доставка",
+			status:         http.StatusOK,
+			secret:         secret,
+			wantStatus:     domain.DeliveryDelivered,
+			wantStatusCode: http.StatusOK,
+			wantRequests:   1,
+		},
+		{
+			name:           "ошибка подписчика откладывает повтор",
+			status:         http.StatusInternalServerError,
+			attempts:       2,
+			secret:         secret,
+			wantStatus:     domain.DeliveryPending,
+			wantNext:       now.Add(2 * time.Minute),
+			wantStatusCode: http.StatusInternalServerError,
+			wantError:      true,
+			wantRequests:   1,
+		},
+		{
+			name:           "последняя попытка завершает доставку ошибкой",
+			status:         http.StatusInternalServerError,
+			attempts:       MaxAttempts - 1,
+			secret:         secret,
+			wantStatus:     domain.DeliveryFailed,
+			wantStatusCode: http.StatusInternalServerError,
+			wantError:      true,
+			wantRequests:   1,
+		},
+		{
+			name:           "подпись другим секретом не принимается",
+			status:         http.StatusOK,
+			secret:         "fedcba9876543210",
+			wantStatus:     domain.DeliveryPending,
+			wantNext:       now.Add(30 * time.Second),
+			wantStatusCode: http.StatusUnauthorized,
+			wantError:      true,
+		},
+		{
+			name:         "подписчик недоступен",
+			secret:       secret,
+			closed:       true,
+			wantStatus:   domain.DeliveryPending,
+			wantNext:     now.Add(30 * time.Second),
+			wantError:    true,
+			wantRequests: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sub := &subscriber{secret: secret, status: tc.status}
+			srv := httptest.NewServer(sub)
+			if tc.closed {
+				srv.Close()
+			} else {
+				defer srv.Close()
+			}
+
+			webhookRepo := mocks.NewMockIWebhookRepository(ctrl)
+			d := NewDispatcher(webhookRepo, srv.Client())
+			d.now = func() time.Time { return now }
+
+			delivery := &domain.WebhookDelivery{
+				ID:             uuid.New(),
+				SubscriptionID: uuid.New(),
+				EventID:        uuid.New(),
+				EventType:      domain.EventCompanyCreated,
+				Payload:        []byte(`{"name":"company"}`),
+				URL:            srv.URL,
+				Secret:         tc.secret,
+				Status:         domain.DeliveryPending,
+				Attempts:       tc.attempts,
+			}
+
+			var saved *domain.WebhookDelivery
+			var attempt *domain.WebhookDeliveryAttempt
+			webhookRepo.EXPECT().
+				ClaimDueDeliveries(gomock.Any(), now, claimLease, 1).
+				Return([]*domain.WebhookDelivery{delivery}, nil)
+			webhookRepo.EXPECT().
+				ClaimDueDeliveries(gomock.Any(), now, claimLease, 1).
+				Return([]*domain.WebhookDelivery{}, nil)
+			webhookRepo.EXPECT().
+				SaveAttempt(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, d *domain.WebhookDelivery, a *domain.WebhookDeliveryAttempt) error {
+					saved, attempt = d, a
+					return nil
+				})
+
+			err := d.RunOnce(context.Background())
+			require.Nil(t, err)
+
+			require.Equal(t, tc.wantStatus, saved.Status)
+			require.Equal(t, tc.attempts+1, saved.Attempts)
+			require.Equal(t, tc.wantStatusCode, saved.LastStatusCode)
+			require.Equal(t, tc.wantError, saved.LastError != "")
+			if !tc.wantNext.IsZero() {
+				require.Equal(t, tc.wantNext, saved.NextAttemptAt)
+			}
+
+			require.Equal(t, delivery.ID, attempt.DeliveryID)
+			require.Equal(t, tc.attempts+1, attempt.Attempt)
+
+			require.Len(t, sub.requests, tc.wantRequests)
+			if tc.wantRequests > 0 {
+				require.Equal(t, delivery.EventID.String(), sub.requests[0].ID)
+				require.Equal(t, domain.EventCompanyCreated, sub.requests[0].Type)
+				require.JSONEq(t, `{"name":"company"}`, string(sub.requests[0].Data))
+			}
+		})
+	}
+}
+
+func TestDispatcher_RunOnce_SlowSubscriber(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	webhookRepo := mocks.NewMockIWebhookRepository(ctrl)
+	d := NewDispatcher(webhookRepo, srv.Client())
+	d.timeout = 50 * time.Millisecond
+
+	newDelivery := func() *domain.WebhookDelivery {
+		return &domain.WebhookDelivery{
+			ID:        uuid.New(),
+			EventID:   uuid.New(),
+			EventType: domain.EventCompanyCreated,
+			Payload:   []byte(`{}`),
+			URL:       srv.URL,
+			Status:    domain.DeliveryPending,
+		}
+	}
+
+	// каждая доставка забирается только после того, как завершилась предыдущая,
+	// и её попытка укладывается в аренду
+	var claimedAt time.Time
+	claim := func(deliveries ...*domain.WebhookDelivery) *gomock.Call {
+		return webhookRepo.EXPECT().
+			ClaimDueDeliveries(gomock.Any(), gomock.Any(), claimLease, 1).
+			DoAndReturn(func(context.Context, time.Time, time.Duration, int) ([]*domain.WebhookDelivery, error) {
+				claimedAt = time.Now()
+				return deliveries, nil
+			})
+	}
+	save := func() *gomock.Call {
+		return webhookRepo.EXPECT().
+			SaveAttempt(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, delivery *domain.WebhookDelivery, _ *domain.WebhookDeliveryAttempt) error {
+				require.Less(t, time.Since(claimedAt), claimLease)
+				require.Equal(t, domain.DeliveryPending, delivery.Status)
+				require.NotEmpty(t, delivery.LastError)
+				return nil
+			})
+	}
+	gomock.InOrder(
+		claim(newDelivery()),
+		save(),
+		claim(newDelivery()),
+		save(),
+		claim(),
+	)
+
+	err := d.RunOnce(context.Background())
+	require.Nil(t, err)
+}
+
+func TestBackoff(t *testing.T) {
+	require.Equal(t, 30*time.Second, Backoff(1))
+	require.Equal(t, time.Minute, Backoff(2))
+	require.Equal(t, 4*time.Minute, Backoff(4))
+	require.Equal(t, 6*time.Hour, Backoff(100))
+}
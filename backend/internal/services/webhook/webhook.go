@@ -0,0 +1,97 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"ppo/domain"
+	"ppo/pkg/i18n"
+
+	"github.com/google/uuid"
+)
+
+// MinSecretLength — минимальная длина секрета, которым подписываются доставки.
+const MinSecretLength = 16
+
+type Service struct {
+	webhookRepo domain.IWebhookRepository
+}
+
+func NewService(webhookRepo domain.IWebhookRepository) domain.IWebhookService {
+	return &Service{
+		webhookRepo: webhookRepo,
+	}
+}
+
+func validateSubscription(sub *domain.WebhookSubscription) error {
+	u, err := url.Parse(sub.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return domain.NewValidationError("url", i18n.MsgWebhookURLInvalid)
+	}
+
+	if len(sub.Secret) < MinSecretLength {
+		return domain.NewValidationError("secret", i18n.MsgWebhookSecretShort, MinSecretLength)
+	}
+
+	if len(sub.EventTypes) == 0 {
+		return domain.NewValidationError("event_types", i18n.MsgWebhookEventsRequired)
+	}
+
+	for _, eventType := range sub.EventTypes {
+		if !domain.IsKnownEventType(eventType) {
+			return domain.NewValidationError("event_types", i18n.MsgWebhookEventUnknown, eventType)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) Subscribe(ctx context.Context, sub *domain.WebhookSubscription) (err error) {
+	err = validateSubscription(sub)
+	if err != nil {
+		return err
+	}
+
+	err = s.webhookRepo.CreateSubscription(ctx, sub)
+	if err != nil {
+		return fmt.Errorf("создание подписки на вебхук: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) GetSubscription(ctx context.Context, id uuid.UUID) (sub *domain.WebhookSubscription, err error) {
+	sub, err = s.webhookRepo.GetSubscriptionById(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("получение подписки на вебхук по id: %w", err)
+	}
+
+	return sub, nil
+}
+
+func (s *Service) GetSubscriptions(ctx context.Context) (subs []*domain.WebhookSubscription, err error) {
+	subs, err = s.webhookRepo.GetSubscriptions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("получение списка подписок на вебхуки: %w", err)
+	}
+
+	return subs, nil
+}
+
+func (s *Service) Unsubscribe(ctx context.Context, id uuid.UUID) (err error) {
+	err = s.webhookRepo.DeleteSubscription(ctx, id)
+	if err != nil {
+		return fmt.Errorf("удаление подписки на вебхук: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) GetDeliveries(ctx context.Context, subscriptionId uuid.UUID, page int) (deliveries []*domain.WebhookDelivery, numPages int, err error) {
+	deliveries, numPages, err = s.webhookRepo.GetDeliveries(ctx, subscriptionId, page)
+	if err != nil {
+		return nil, 0, fmt.Errorf("получение журнала доставок вебхука: %w", err)
+	}
+
+	return deliveries, numPages, nil
+}
@@ -0,0 +1,124 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"ppo/domain"
+	"ppo/mocks"
+	"testing"
+)
+
+func TestWebhookService_Subscribe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	webhookRepo := mocks.NewMockIWebhookRepository(ctrl)
+	svc := NewService(webhookRepo)
+
+	valid := func() *domain.WebhookSubscription {
+		return &domain.WebhookSubscription{
+			URL:        "https://example.com/hooks",
+			Secret:     "0123456789abcdef",
+			EventTypes: []domain.EventType{domain.EventCompanyCreated},
+		}
+	}
+
+	testCases := []struct {
+		name       string
+		sub        func() *domain.WebhookSubscription
+		beforeTest func(webhookRepo mocks.MockIWebhookRepository)
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name: "успешное добавление",
+			sub:  valid,
+			beforeTest: func(webhookRepo mocks.MockIWebhookRepository) {
+				webhookRepo.EXPECT().
+					CreateSubscription(context.Background(), valid()).
+					Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "относительный адрес",
+			sub: func() *domain.WebhookSubscription {
+				sub := valid()
+				sub.URL = "/hooks"
+				return sub
+			},
+			wantErr: true,
+			errStr:  errors.New("адрес вебхука должен быть абсолютным URL со схемой http или https"),
+		},
+		{
+			name: "неподдерживаемая схема",
+			sub: func() *domain.WebhookSubscription {
+				sub := valid()
+				sub.URL = "ftp://example.com"
+				return sub
+			},
+			wantErr: true,
+			errStr:  errors.New("адрес вебхука должен быть абсолютным URL со схемой http или https"),
+		},
+		{
+			name: "короткий секрет",
+			sub: func() *domain.WebhookSubscription {
+				sub := valid()
+				sub.Secret = "secret"
+				return sub
+			},
+			wantErr: true,
+			errStr:  errors.New("секрет должен содержать не менее 16 символов"),
+		},
+		{
+			name: "не указаны типы событий",
+			sub: func() *domain.WebhookSubscription {
+				sub := valid()
+				sub.EventTypes = nil
+				return sub
+			},
+			wantErr: true,
+			errStr:  errors.New("должен быть указан хотя бы один тип события"),
+		},
+		{
+			name: "неизвестный тип события",
+			sub: func() *domain.WebhookSubscription {
+				sub := valid()
+				sub.EventTypes = []domain.EventType{"company.renamed"}
+				return sub
+			},
+			wantErr: true,
+			errStr:  errors.New("неизвестный тип события: company.renamed"),
+		},
+		{
+			name: "ошибка выполнения запроса в репозитории",
+			sub:  valid,
+			beforeTest: func(webhookRepo mocks.MockIWebhookRepository) {
+				webhookRepo.EXPECT().
+					CreateSubscription(context.Background(), valid()).
+					Return(fmt.Errorf("sql error"))
+			},
+			wantErr: true,
+			errStr:  errors.New("создание подписки на вебхук: sql error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.beforeTest != nil {
+				tc.beforeTest(*webhookRepo)
+			}
+
+			err := svc.Subscribe(ctx, tc.sub())
+
+			if tc.wantErr {
+				require.Equal(t, tc.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
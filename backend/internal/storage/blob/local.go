@@ -0,0 +1,128 @@
+// Package blob содержит реализации хранилища файлов domain.IBlobStorage.
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"ppo/domain"
+	"strings"
+)
+
+// LocalStorage хранит файлы в каталоге локальной файловой системы: каждому ключу
+// соответствует файл с тем же относительным путём. Запись атомарна: содержимое
+// пишется во временный файл, который затем переименовывается.
+type LocalStorage struct {
+	dir string
+}
+
+// NewLocalStorage создаёт хранилище в каталоге dir. Каталог создаётся при первой записи.
+func NewLocalStorage(dir string) *LocalStorage {
+	return &LocalStorage{dir: dir}
+}
+
+// path переводит ключ в путь внутри каталога хранилища. Ключи с «..», абсолютные
+// и пустые отклоняются, чтобы запись не вышла за пределы каталога.
+func (s *LocalStorage) path(key string) (string, error) {
+	if key == "" || !filepath.IsLocal(filepath.FromSlash(key)) || strings.Contains(key, "\\") {
+		return "", fmt.Errorf("недопустимый ключ файла %q", key)
+	}
+
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
+
+func (s *LocalStorage) Put(ctx context.Context, key string, r io.Reader) (size int64, err error) {
+	path, err := s.path(key)
+	if err != nil {
+		return 0, err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o750)
+	if err != nil {
+		return 0, fmt.Errorf("сохранение файла: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return 0, fmt.Errorf("сохранение файла: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	size, err = io.Copy(tmp, &ctxReader{ctx: ctx, r: r})
+	if err != nil {
+		return 0, fmt.Errorf("сохранение файла: %w", err)
+	}
+
+	err = tmp.Sync()
+	if err != nil {
+		return 0, fmt.Errorf("сохранение файла: %w", err)
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return 0, fmt.Errorf("сохранение файла: %w", err)
+	}
+
+	err = os.Rename(tmp.Name(), path)
+	if err != nil {
+		return 0, fmt.Errorf("сохранение файла: %w", err)
+	}
+
+	return size, nil
+}
+
+func (s *LocalStorage) Open(_ context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("открытие файла %s: %w", key, domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("открытие файла %s: %w", key, err)
+	}
+
+	return f, nil
+}
+
+func (s *LocalStorage) Delete(_ context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("удаление файла %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// ctxReader прерывает копирование, когда контекст отменён, например клиент
+// прервал загрузку.
+type ctxReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r *ctxReader) Read(p []byte) (int, error) {
+	err := r.ctx.Err()
+	if err != nil {
+		return 0, err
+	}
+
+	return r.r.Read(p)
+}
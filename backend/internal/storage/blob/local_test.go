@@ -0,0 +1,74 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"ppo/domain"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStorage(t *testing.T) {
+	dir := t.TempDir()
+	storage := NewLocalStorage(filepath.Join(dir, "documents"))
+	ctx := context.Background()
+
+	_, err := storage.Open(ctx, "reports/1/doc")
+	require.True(t, errors.Is(err, domain.ErrNotFound))
+
+	size, err := storage.Put(ctx, "reports/1/doc", strings.NewReader("содержимое"))
+	require.Nil(t, err)
+	require.Equal(t, int64(len("содержимое")), size)
+
+	size, err = storage.Put(ctx, "reports/1/doc", strings.NewReader("новое"))
+	require.Nil(t, err)
+	require.Equal(t, int64(len("новое")), size)
+
+	r, err := storage.Open(ctx, "reports/1/doc")
+	require.Nil(t, err)
+	data, err := io.ReadAll(r)
+	require.Nil(t, err)
+	require.Nil(t, r.Close())
+	require.Equal(t, "новое", string(data))
+
+	entries, err := os.ReadDir(filepath.Join(dir, "documents", "reports", "1"))
+	require.Nil(t, err)
+	require.Len(t, entries, 1, "временные файлы должны удаляться")
+
+	require.Nil(t, storage.Delete(ctx, "reports/1/doc"))
+	require.Nil(t, storage.Delete(ctx, "reports/1/doc"))
+
+	_, err = storage.Open(ctx, "reports/1/doc")
+	require.True(t, errors.Is(err, domain.ErrNotFound))
+}
+
+func TestLocalStorage_InvalidKey(t *testing.T) {
+	storage := NewLocalStorage(t.TempDir())
+
+	for _, key := range []string{"", "../outside", "/etc/passwd", "a/../../b", `a\b`} {
+		t.Run(key, func(t *testing.T) {
+			_, err := storage.Put(context.Background(), key, strings.NewReader("x"))
+			require.NotNil(t, err)
+		})
+	}
+}
+
+func TestLocalStorage_CanceledUpload(t *testing.T) {
+	dir := t.TempDir()
+	storage := NewLocalStorage(dir)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := storage.Put(ctx, "doc", strings.NewReader("x"))
+	require.True(t, errors.Is(err, context.Canceled))
+
+	entries, err := os.ReadDir(dir)
+	require.Nil(t, err)
+	require.Empty(t, entries)
+}
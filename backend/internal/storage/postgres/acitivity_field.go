@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"ppo/domain"
-	"ppo/internal/config"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -12,28 +11,31 @@ import (
 )
 
 type ActivityFieldRepository struct {
-	db *pgxpool.Pool
+	db       *pgxpool.Pool
+	pageSize int
 }
 
-func NewActivityFieldRepository(db *pgxpool.Pool) domain.IActivityFieldRepository {
+func NewActivityFieldRepository(db *pgxpool.Pool, pageSize int) domain.IActivityFieldRepository {
 	return &ActivityFieldRepository{
-		db: db,
+		db:       db,
+		pageSize: pageSize,
 	}
 }
 
 func (r *ActivityFieldRepository) Create(ctx context.Context, data *domain.ActivityField) (err error) {
 	query := `insert into ppo.activity_fields(name, description, cost) 
-	values ($1, $2, $3)`
+	values ($1, $2, $3)
+	returning id`
 
-	_, err = r.db.Exec(
+	err = conn(ctx, r.db).QueryRow(
 		ctx,
 		query,
 		data.Name,
 		data.Description,
 		data.Cost,
-	)
+	).Scan(&data.ID)
 	if err != nil {
-		return fmt.Errorf("создание сферы деятельности: %w", err)
+		return fmt.Errorf("создание сферы деятельности: %w", translateError(err))
 	}
 
 	return nil
@@ -42,13 +44,13 @@ func (r *ActivityFieldRepository) Create(ctx context.Context, data *domain.Activ
 func (r *ActivityFieldRepository) DeleteById(ctx context.Context, id uuid.UUID) (err error) {
 	query := `delete from ppo.activity_fields where id = $1`
 
-	_, err = r.db.Exec(
+	_, err = conn(ctx, r.db).Exec(
 		ctx,
 		query,
 		id,
 	)
 	if err != nil {
-		return fmt.Errorf("удаление сферы деятельности по id: %w", err)
+		return fmt.Errorf("удаление сферы деятельности по id: %w", translateError(err))
 	}
 
 	return nil
@@ -63,7 +65,7 @@ func (r *ActivityFieldRepository) Update(ctx context.Context, data *domain.Activ
 			    cost = $3
 			where id = $4`
 
-	_, err = r.db.Exec(
+	_, err = conn(ctx, r.db).Exec(
 		ctx,
 		query,
 		data.Name,
@@ -72,7 +74,7 @@ func (r *ActivityFieldRepository) Update(ctx context.Context, data *domain.Activ
 		data.ID,
 	)
 	if err != nil {
-		return fmt.Errorf("обновление информации о сфере деятельности: %w", err)
+		return fmt.Errorf("обновление информации о сфере деятельности: %w", translateError(err))
 	}
 
 	return nil
@@ -82,7 +84,7 @@ func (r *ActivityFieldRepository) GetById(ctx context.Context, id uuid.UUID) (fi
 	query := `select name, description, cost from ppo.activity_fields where id = $1`
 
 	field = new(domain.ActivityField)
-	err = r.db.QueryRow(
+	err = conn(ctx, r.db).QueryRow(
 		ctx,
 		query,
 		id,
@@ -92,7 +94,7 @@ func (r *ActivityFieldRepository) GetById(ctx context.Context, id uuid.UUID) (fi
 		&field.Cost,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("получение сферы деятельности по id: %w", err)
+		return nil, fmt.Errorf("получение сферы деятельности по id: %w", translateError(err))
 	}
 
 	field.ID = id
@@ -100,17 +102,50 @@ func (r *ActivityFieldRepository) GetById(ctx context.Context, id uuid.UUID) (fi
 	return field, nil
 }
 
+func (r *ActivityFieldRepository) GetByIds(ctx context.Context, ids []uuid.UUID) (fields []*domain.ActivityField, err error) {
+	query := `select id, name, description, cost from ppo.activity_fields where id = any($1)`
+
+	rows, err := conn(ctx, r.db).Query(
+		ctx,
+		query,
+		ids,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("получение сфер деятельности по списку id: %w", translateError(err))
+	}
+	defer rows.Close()
+
+	fields = make([]*domain.ActivityField, 0, len(ids))
+	for rows.Next() {
+		tmp := new(domain.ActivityField)
+
+		err = rows.Scan(
+			&tmp.ID,
+			&tmp.Name,
+			&tmp.Description,
+			&tmp.Cost,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("сканирование полученных строк: %w", translateError(err))
+		}
+
+		fields = append(fields, tmp)
+	}
+
+	return fields, nil
+}
+
 func (r *ActivityFieldRepository) GetMaxCost(ctx context.Context) (cost float32, err error) {
 	query := `select max(cost)
 		from ppo.activity_fields`
 
-	err = r.db.QueryRow(
+	err = conn(ctx, r.db).QueryRow(
 		ctx,
 		query,
 	).Scan(&cost)
 
 	if err != nil {
-		return 0, fmt.Errorf("получение максимального веса сферы деятельности: %w", err)
+		return 0, fmt.Errorf("получение максимального веса сферы деятельности: %w", translateError(err))
 	}
 
 	return cost, nil
@@ -127,20 +162,20 @@ func (r *ActivityFieldRepository) GetAll(ctx context.Context, page int, isPagina
 
 	var rows pgx.Rows
 	if !isPaginated {
-		rows, err = r.db.Query(
+		rows, err = conn(ctx, r.db).Query(
 			ctx,
 			query,
 		)
 	} else {
-		rows, err = r.db.Query(
+		rows, err = conn(ctx, r.db).Query(
 			ctx,
 			query+` offset $1 limit $2`,
-			(page-1)*config.PageSize,
-			config.PageSize,
+			(page-1)*r.pageSize,
+			r.pageSize,
 		)
 	}
 	if err != nil {
-		return nil, 0, fmt.Errorf("получение сфер деятельности: %w", err)
+		return nil, 0, fmt.Errorf("получение сфер деятельности: %w", translateError(err))
 	}
 
 	fields = make([]*domain.ActivityField, 0)
@@ -155,23 +190,23 @@ func (r *ActivityFieldRepository) GetAll(ctx context.Context, page int, isPagina
 		)
 
 		if err != nil {
-			return nil, 0, fmt.Errorf("сканирование полученных строк: %w", err)
+			return nil, 0, fmt.Errorf("сканирование полученных строк: %w", translateError(err))
 		}
 
 		fields = append(fields, tmp)
 	}
 
 	var numRecords int
-	err = r.db.QueryRow(
+	err = conn(ctx, r.db).QueryRow(
 		ctx,
 		`select count(*) from ppo.activity_fields`,
 	).Scan(&numRecords)
 	if err != nil {
-		return nil, 0, fmt.Errorf("получение числа сфер деятельности: %w", err)
+		return nil, 0, fmt.Errorf("получение числа сфер деятельности: %w", translateError(err))
 	}
 
-	numPages = numRecords / config.PageSize
-	if numRecords%config.PageSize != 0 {
+	numPages = numRecords / r.pageSize
+	if numRecords%r.pageSize != 0 {
 		numPages++
 	}
 
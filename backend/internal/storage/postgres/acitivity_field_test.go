@@ -8,7 +8,7 @@ import (
 )
 
 func TestActivityFieldRepository_Create(t *testing.T) {
-	repo := NewActivityFieldRepository(testDbInstance)
+	repo := NewActivityFieldRepository(testDbInstance, testPageSize)
 
 	testCases := []struct {
 		name    string
@@ -39,7 +39,7 @@ func TestActivityFieldRepository_Create(t *testing.T) {
 }
 
 func TestActivityFieldRepository_GetMaxCost(t *testing.T) {
-	repo := NewActivityFieldRepository(testDbInstance)
+	repo := NewActivityFieldRepository(testDbInstance, testPageSize)
 
 	testCases := []struct {
 		name     string
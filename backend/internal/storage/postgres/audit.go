@@ -0,0 +1,167 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"ppo/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AuditRepository struct {
+	db       *pgxpool.Pool
+	pageSize int
+}
+
+func NewAuditRepository(db *pgxpool.Pool, pageSize int) domain.IAuditRepository {
+	return &AuditRepository{
+		db:       db,
+		pageSize: pageSize,
+	}
+}
+
+// Append добавляет запись в журнал. Вызванный внутри Transactor.WithinTx,
+// пишет запись в той же транзакции, что и само изменение.
+func (r *AuditRepository) Append(ctx context.Context, entry *domain.AuditEntry) (err error) {
+	query := `insert into ppo.audit_log(actor_id, actor_role, action, entity_type, entity_id, before, after)
+	values ($1, $2, $3, $4, $5, $6, $7)
+	returning id, created_at`
+
+	err = conn(ctx, r.db).QueryRow(
+		ctx,
+		query,
+		nullUUID(entry.ActorID),
+		entry.ActorRole,
+		entry.Action,
+		entry.EntityType,
+		entry.EntityID,
+		nullJSON(entry.Before),
+		nullJSON(entry.After),
+	).Scan(&entry.ID, &entry.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("запись в журнал аудита: %w", translateError(err))
+	}
+
+	return nil
+}
+
+// auditFilterCond — условие выборки по AuditFilter; пустое поле фильтра передаётся как null.
+const auditFilterCond = `($1::varchar is null or entity_type = $1)
+	and ($2::varchar is null or entity_id = $2)
+	and ($3::uuid is null or actor_id = $3)
+	and ($4::timestamptz is null or created_at >= $4)
+	and ($5::timestamptz is null or created_at < $5)`
+
+func auditFilterArgs(filter *domain.AuditFilter) []any {
+	return []any{
+		nullString(string(filter.EntityType)),
+		nullString(filter.EntityID),
+		nullUUID(filter.ActorID),
+		nullTime(filter.From),
+		nullTime(filter.To),
+	}
+}
+
+// Find возвращает страницу записей, подходящих под filter, от новых к старым.
+func (r *AuditRepository) Find(ctx context.Context, filter *domain.AuditFilter, page int) (
+	entries []*domain.AuditEntry, numPages int, err error) {
+	query := `select
+		id,
+		coalesce(actor_id, '00000000-0000-0000-0000-000000000000'),
+		actor_role,
+		action,
+		entity_type,
+		entity_id,
+		before,
+		after,
+		created_at
+	from ppo.audit_log
+	where ` + auditFilterCond + `
+	order by created_at desc
+	offset $6 limit $7`
+
+	args := auditFilterArgs(filter)
+	rows, err := conn(ctx, r.db).Query(
+		ctx,
+		query,
+		append(args, (page-1)*r.pageSize, r.pageSize)...,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("получение записей журнала аудита: %w", translateError(err))
+	}
+	defer rows.Close()
+
+	entries = make([]*domain.AuditEntry, 0)
+	for rows.Next() {
+		entry := new(domain.AuditEntry)
+		var before, after []byte
+		err = rows.Scan(
+			&entry.ID,
+			&entry.ActorID,
+			&entry.ActorRole,
+			&entry.Action,
+			&entry.EntityType,
+			&entry.EntityID,
+			&before,
+			&after,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("сканирование полученных строк: %w", translateError(err))
+		}
+
+		entry.Before, entry.After = before, after
+		entries = append(entries, entry)
+	}
+
+	var numRecords int
+	err = conn(ctx, r.db).QueryRow(
+		ctx,
+		`select count(*) from ppo.audit_log where `+auditFilterCond,
+		args...,
+	).Scan(&numRecords)
+	if err != nil {
+		return nil, 0, fmt.Errorf("получение количества записей журнала аудита: %w", translateError(err))
+	}
+
+	numPages = numRecords / r.pageSize
+	if numRecords%r.pageSize != 0 {
+		numPages++
+	}
+
+	return entries, numPages, nil
+}
+
+func nullString(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
+
+func nullUUID(id uuid.UUID) *uuid.UUID {
+	if id == uuid.Nil {
+		return nil
+	}
+
+	return &id
+}
+
+func nullTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
+
+func nullJSON(data []byte) []byte {
+	if len(data) == 0 {
+		return nil
+	}
+
+	return data
+}
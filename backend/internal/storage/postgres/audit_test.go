@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"ppo/domain"
+	"testing"
+	"time"
+)
+
+func TestAuditRepository_AppendFind(t *testing.T) {
+	auditRepo := NewAuditRepository(testDbInstance, 10)
+	ctx := context.Background()
+
+	entityId := uuid.NewString()
+	entries := []*domain.AuditEntry{
+		{
+			ActorID:    uuid.UUID{1},
+			ActorRole:  "admin",
+			Action:     domain.AuditCreate,
+			EntityType: domain.AuditEntityFinReport,
+			EntityID:   entityId,
+			After:      []byte(`{"revenue": 100}`),
+		},
+		{
+			Action:     domain.AuditDelete,
+			EntityType: domain.AuditEntityFinReport,
+			EntityID:   entityId,
+			Before:     []byte(`{"revenue": 100}`),
+		},
+	}
+	for _, entry := range entries {
+		require.Nil(t, auditRepo.Append(ctx, entry))
+	}
+
+	testCases := []struct {
+		name      string
+		filter    *domain.AuditFilter
+		wantCount int
+	}{
+		{
+			name:      "по сущности",
+			filter:    &domain.AuditFilter{EntityType: domain.AuditEntityFinReport, EntityID: entityId},
+			wantCount: 2,
+		},
+		{
+			name:      "по пользователю",
+			filter:    &domain.AuditFilter{EntityID: entityId, ActorID: uuid.UUID{1}},
+			wantCount: 1,
+		},
+		{
+			name:      "по периоду",
+			filter:    &domain.AuditFilter{EntityID: entityId, To: entries[0].CreatedAt.Add(-time.Second)},
+			wantCount: 0,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			found, numPages, err := auditRepo.Find(ctx, tc.filter, 1)
+
+			require.Nil(t, err)
+			require.Len(t, found, tc.wantCount)
+			if tc.wantCount > 0 {
+				require.Equal(t, 1, numPages)
+			}
+		})
+	}
+
+	_, err := testDbInstance.Exec(ctx, `delete from ppo.audit_log where entity_id = $1`, entityId)
+	require.NotNil(t, err, "журнал аудита должен быть только для добавления")
+}
@@ -18,16 +18,16 @@ func NewAuthRepository(db *pgxpool.Pool) domain.IAuthRepository {
 }
 
 func (r *AuthRepository) Register(ctx context.Context, authInfo *domain.UserAuth) (err error) {
-	query := `insert into ppo.users (username, password, role) values ($1, $2, 'user')`
+	query := `insert into ppo.users (username, password, role) values ($1, $2, 'user') returning id`
 
-	_, err = r.db.Exec(
+	err = conn(ctx, r.db).QueryRow(
 		ctx,
 		query,
 		authInfo.Username,
 		authInfo.HashedPass,
-	)
+	).Scan(&authInfo.ID)
 	if err != nil {
-		return fmt.Errorf("регистрация пользователя: %w", err)
+		return fmt.Errorf("регистрация пользователя: %w", translateError(err))
 	}
 
 	return nil
@@ -37,7 +37,7 @@ func (r *AuthRepository) GetByUsername(ctx context.Context, username string) (da
 	query := `select id, password, role from ppo.users where username = $1`
 
 	tmp := new(UserAuth)
-	err = r.db.QueryRow(
+	err = conn(ctx, r.db).QueryRow(
 		ctx,
 		query,
 		username,
@@ -47,7 +47,7 @@ func (r *AuthRepository) GetByUsername(ctx context.Context, username string) (da
 		&tmp.Role,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("получение пользователя по username: %w", err)
+		return nil, fmt.Errorf("получение пользователя по username: %w", translateError(err))
 	}
 
 	return UserAuthDbToUserAuth(tmp), nil
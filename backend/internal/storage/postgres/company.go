@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"ppo/domain"
-	"ppo/internal/config"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -12,29 +11,32 @@ import (
 )
 
 type CompanyRepository struct {
-	db *pgxpool.Pool
+	db       *pgxpool.Pool
+	pageSize int
 }
 
-func NewCompanyRepository(db *pgxpool.Pool) domain.ICompanyRepository {
+func NewCompanyRepository(db *pgxpool.Pool, pageSize int) domain.ICompanyRepository {
 	return &CompanyRepository{
-		db: db,
+		db:       db,
+		pageSize: pageSize,
 	}
 }
 
 func (r *CompanyRepository) Create(ctx context.Context, company *domain.Company) (err error) {
 	query := `insert into ppo.companies(owner_id, activity_field_id, name, city) 
-	values ($1, $2, $3, $4)`
+	values ($1, $2, $3, $4)
+	returning id`
 
-	_, err = r.db.Exec(
+	err = conn(ctx, r.db).QueryRow(
 		ctx,
 		query,
 		company.OwnerID,
 		company.ActivityFieldId,
 		company.Name,
 		company.City,
-	)
+	).Scan(&company.ID)
 	if err != nil {
-		return fmt.Errorf("создание компании: %w", err)
+		return fmt.Errorf("создание компании: %w", translateError(err))
 	}
 
 	return nil
@@ -44,7 +46,7 @@ func (r *CompanyRepository) GetById(ctx context.Context, id uuid.UUID) (company
 	query := `select owner_id, activity_field_id, name, city from ppo.companies where id = $1`
 
 	company = new(domain.Company)
-	err = r.db.QueryRow(
+	err = conn(ctx, r.db).QueryRow(
 		ctx,
 		query,
 		id,
@@ -55,7 +57,7 @@ func (r *CompanyRepository) GetById(ctx context.Context, id uuid.UUID) (company
 		&company.City,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("получение компании по id: %w", err)
+		return nil, fmt.Errorf("получение компании по id: %w", translateError(err))
 	}
 	company.ID = id
 
@@ -74,22 +76,22 @@ func (r *CompanyRepository) GetByOwnerId(ctx context.Context, id uuid.UUID, page
 
 	var rows pgx.Rows
 	if !isPaginated {
-		rows, err = r.db.Query(
+		rows, err = conn(ctx, r.db).Query(
 			ctx,
 			query,
 			id,
 		)
 	} else {
-		rows, err = r.db.Query(
+		rows, err = conn(ctx, r.db).Query(
 			ctx,
 			query+` offset $2 limit $3`,
 			id,
-			(page-1)*config.PageSize,
-			config.PageSize,
+			(page-1)*r.pageSize,
+			r.pageSize,
 		)
 	}
 	if err != nil {
-		return nil, 0, fmt.Errorf("получение компаний: %w", err)
+		return nil, 0, fmt.Errorf("получение компаний: %w", translateError(err))
 	}
 
 	companies = make([]*domain.Company, 0)
@@ -105,30 +107,72 @@ func (r *CompanyRepository) GetByOwnerId(ctx context.Context, id uuid.UUID, page
 		tmp.OwnerID = id
 
 		if err != nil {
-			return nil, 0, fmt.Errorf("сканирование полученных строк: %w", err)
+			return nil, 0, fmt.Errorf("сканирование полученных строк: %w", translateError(err))
 		}
 
 		companies = append(companies, tmp)
 	}
 
 	var numRecords int
-	err = r.db.QueryRow(
+	err = conn(ctx, r.db).QueryRow(
 		ctx,
 		`select count(*) from ppo.companies where owner_id = $1`,
 		id,
 	).Scan(&numRecords)
 	if err != nil {
-		return nil, 0, fmt.Errorf("получение списка компаний предпринимателя: %w", err)
+		return nil, 0, fmt.Errorf("получение списка компаний предпринимателя: %w", translateError(err))
 	}
 
-	numPages = numRecords / config.PageSize
-	if numRecords%config.PageSize != 0 {
+	numPages = numRecords / r.pageSize
+	if numRecords%r.pageSize != 0 {
 		numPages++
 	}
 
 	return companies, numPages, nil
 }
 
+func (r *CompanyRepository) GetByOwnerIds(ctx context.Context, ids []uuid.UUID) (companies []*domain.Company, err error) {
+	query :=
+		`select 
+    		id, 
+    		owner_id,
+    		activity_field_id,
+    		name,
+    		city 
+		from ppo.companies 
+		where owner_id = any($1)`
+
+	rows, err := conn(ctx, r.db).Query(
+		ctx,
+		query,
+		ids,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("получение компаний по списку id владельцев: %w", translateError(err))
+	}
+	defer rows.Close()
+
+	companies = make([]*domain.Company, 0)
+	for rows.Next() {
+		tmp := new(domain.Company)
+
+		err = rows.Scan(
+			&tmp.ID,
+			&tmp.OwnerID,
+			&tmp.ActivityFieldId,
+			&tmp.Name,
+			&tmp.City,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("сканирование полученных строк: %w", translateError(err))
+		}
+
+		companies = append(companies, tmp)
+	}
+
+	return companies, nil
+}
+
 func (r *CompanyRepository) Update(ctx context.Context, company *domain.Company) (err error) {
 	query := `
 			update ppo.companies
@@ -139,7 +183,7 @@ func (r *CompanyRepository) Update(ctx context.Context, company *domain.Company)
 			    city = $4
 			where id = $5`
 
-	_, err = r.db.Exec(
+	_, err = conn(ctx, r.db).Exec(
 		ctx,
 		query,
 		company.OwnerID,
@@ -149,14 +193,14 @@ func (r *CompanyRepository) Update(ctx context.Context, company *domain.Company)
 		company.ID,
 	)
 	if err != nil {
-		return fmt.Errorf("обновление информации о компании: %w", err)
+		return fmt.Errorf("обновление информации о компании: %w", translateError(err))
 	}
 
 	return nil
 }
 
 func (r *CompanyRepository) DeleteById(ctx context.Context, id uuid.UUID) (err error) {
-	tx, err := r.db.Begin(ctx)
+	tx, err := conn(ctx, r.db).Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("открытие транзакции: %w", err)
 	}
@@ -176,7 +220,7 @@ func (r *CompanyRepository) DeleteById(ctx context.Context, id uuid.UUID) (err e
 		id,
 	)
 	if err != nil {
-		return fmt.Errorf("удаление компании по id: %w", err)
+		return fmt.Errorf("удаление компании по id: %w", translateError(err))
 	}
 
 	_, err = tx.Exec(
@@ -185,7 +229,7 @@ func (r *CompanyRepository) DeleteById(ctx context.Context, id uuid.UUID) (err e
 		id,
 	)
 	if err != nil {
-		return fmt.Errorf("удаление отчетов, связанных с компанией: %w", err)
+		return fmt.Errorf("удаление отчетов, связанных с компанией: %w", translateError(err))
 	}
 
 	err = tx.Commit(ctx)
@@ -197,16 +241,16 @@ func (r *CompanyRepository) DeleteById(ctx context.Context, id uuid.UUID) (err e
 }
 
 func (r *CompanyRepository) GetAll(ctx context.Context, page int) (companies []*domain.Company, err error) {
-	query := `select id, owner_id, activity_field_id, name, city from ppo.companies offset $1 limit $2`
+	query := `select id, owner_id, activity_field_id, name, city from ppo.companies order by id offset $1 limit $2`
 
-	rows, err := r.db.Query(
+	rows, err := conn(ctx, r.db).Query(
 		ctx,
 		query,
-		(page-1)*config.PageSize,
-		config.PageSize,
+		(page-1)*r.pageSize,
+		r.pageSize,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("получение списка компаний: %w", err)
+		return nil, fmt.Errorf("получение списка компаний: %w", translateError(err))
 	}
 
 	companies = make([]*domain.Company, 0)
@@ -222,8 +266,10 @@ func (r *CompanyRepository) GetAll(ctx context.Context, page int) (companies []*
 		)
 
 		if err != nil {
-			return nil, fmt.Errorf("сканирование полученных строк: %w", err)
+			return nil, fmt.Errorf("сканирование полученных строк: %w", translateError(err))
 		}
+
+		companies = append(companies, tmp)
 	}
 
 	return companies, nil
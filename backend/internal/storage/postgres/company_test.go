@@ -0,0 +1,39 @@
+package postgres
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCompanyRepository_GetAll(t *testing.T) {
+	repo := NewCompanyRepository(testDbInstance, testPageSize)
+	ctx := context.Background()
+
+	seeded := []uuid.UUID{
+		uuid.MustParse("fa406cca-27d6-446e-8cfd-b1a71ed680a0"),
+		uuid.MustParse("c4f2abf1-e80c-4c31-bc77-fe5a8e5fab40"),
+		uuid.MustParse("f8185baf-b552-4028-8a39-b061ac1a650f"),
+	}
+
+	ids := make([]uuid.UUID, 0)
+	for page := 1; ; page++ {
+		companies, err := repo.GetAll(ctx, page)
+		require.Nil(t, err)
+		require.LessOrEqual(t, len(companies), testPageSize)
+		if len(companies) == 0 {
+			break
+		}
+
+		for _, company := range companies {
+			require.NotEmpty(t, company.Name)
+			ids = append(ids, company.ID)
+		}
+	}
+
+	require.Subset(t, ids, seeded)
+	for i := 1; i < len(ids); i++ {
+		require.Less(t, ids[i-1].String(), ids[i].String())
+	}
+}
@@ -20,17 +20,18 @@ func NewContactRepository(db *pgxpool.Pool) domain.IContactsRepository {
 
 func (r *ContactRepository) Create(ctx context.Context, contact *domain.Contact) (err error) {
 	query := `insert into ppo.contacts(owner_id, name, value) 
-	values ($1, $2, $3)`
+	values ($1, $2, $3)
+	returning id`
 
-	_, err = r.db.Exec(
+	err = conn(ctx, r.db).QueryRow(
 		ctx,
 		query,
 		contact.OwnerID,
 		contact.Name,
 		contact.Value,
-	)
+	).Scan(&contact.ID)
 	if err != nil {
-		return fmt.Errorf("создание средства связи: %w", err)
+		return fmt.Errorf("создание средства связи: %w", translateError(err))
 	}
 
 	return nil
@@ -40,7 +41,7 @@ func (r *ContactRepository) GetById(ctx context.Context, id uuid.UUID) (contact
 	query := `select owner_id, name, value from ppo.contacts where id = $1`
 
 	contact = new(domain.Contact)
-	err = r.db.QueryRow(
+	err = conn(ctx, r.db).QueryRow(
 		ctx,
 		query,
 		id,
@@ -50,7 +51,7 @@ func (r *ContactRepository) GetById(ctx context.Context, id uuid.UUID) (contact
 		&contact.Value,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("получение средства связи по id: %w", err)
+		return nil, fmt.Errorf("получение средства связи по id: %w", translateError(err))
 	}
 
 	contact.ID = id
@@ -66,13 +67,13 @@ func (r *ContactRepository) GetByOwnerId(ctx context.Context, id uuid.UUID) (con
 		from ppo.contacts 
 		where owner_id = $1`
 
-	rows, err := r.db.Query(
+	rows, err := conn(ctx, r.db).Query(
 		ctx,
 		query,
 		id,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("получение средств связи: %w", err)
+		return nil, fmt.Errorf("получение средств связи: %w", translateError(err))
 	}
 
 	contacts = make([]*domain.Contact, 0)
@@ -87,8 +88,48 @@ func (r *ContactRepository) GetByOwnerId(ctx context.Context, id uuid.UUID) (con
 		tmp.OwnerID = id
 
 		if err != nil {
-			return nil, fmt.Errorf("сканирование полученных строк: %w", err)
+			return nil, fmt.Errorf("сканирование полученных строк: %w", translateError(err))
+		}
+		contacts = append(contacts, tmp)
+	}
+
+	return contacts, nil
+}
+
+func (r *ContactRepository) GetByOwnerIds(ctx context.Context, ids []uuid.UUID) (contacts []*domain.Contact, err error) {
+	query := `
+		select 
+		    id,
+		    owner_id,
+		    name,
+		    value 
+		from ppo.contacts 
+		where owner_id = any($1)`
+
+	rows, err := conn(ctx, r.db).Query(
+		ctx,
+		query,
+		ids,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("получение средств связи по списку id владельцев: %w", translateError(err))
+	}
+	defer rows.Close()
+
+	contacts = make([]*domain.Contact, 0)
+	for rows.Next() {
+		tmp := new(domain.Contact)
+
+		err = rows.Scan(
+			&tmp.ID,
+			&tmp.OwnerID,
+			&tmp.Name,
+			&tmp.Value,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("сканирование полученных строк: %w", translateError(err))
 		}
+
 		contacts = append(contacts, tmp)
 	}
 
@@ -104,7 +145,7 @@ func (r *ContactRepository) Update(ctx context.Context, contact *domain.Contact)
 			    value = $3
 			where id = $4`
 
-	_, err = r.db.Exec(
+	_, err = conn(ctx, r.db).Exec(
 		ctx,
 		query,
 		contact.OwnerID,
@@ -113,7 +154,7 @@ func (r *ContactRepository) Update(ctx context.Context, contact *domain.Contact)
 		contact.ID,
 	)
 	if err != nil {
-		return fmt.Errorf("обновление информации о средстве связи: %w", err)
+		return fmt.Errorf("обновление информации о средстве связи: %w", translateError(err))
 	}
 
 	return nil
@@ -122,13 +163,13 @@ func (r *ContactRepository) Update(ctx context.Context, contact *domain.Contact)
 func (r *ContactRepository) DeleteById(ctx context.Context, id uuid.UUID) (err error) {
 	query := `delete from ppo.contacts where id = $1`
 
-	_, err = r.db.Exec(
+	_, err = conn(ctx, r.db).Exec(
 		ctx,
 		query,
 		id,
 	)
 	if err != nil {
-		return fmt.Errorf("удаление средства связи по id: %w", err)
+		return fmt.Errorf("удаление средства связи по id: %w", translateError(err))
 	}
 
 	return nil
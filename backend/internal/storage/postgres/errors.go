@@ -0,0 +1,37 @@
+package postgres
+
+import (
+	"errors"
+	"ppo/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	notNullViolation    = "23502"
+	foreignKeyViolation = "23503"
+	uniqueViolation     = "23505"
+	checkViolation      = "23514"
+)
+
+// translateError сопоставляет ошибки pgx с ошибками домена.
+func translateError(err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.NewNotFoundError(err)
+	}
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	switch pgErr.Code {
+	case uniqueViolation, foreignKeyViolation:
+		return domain.NewConflictError(err)
+	case notNullViolation, checkViolation:
+		return &domain.ValidationError{Field: pgErr.ColumnName, Message: err.Error()}
+	}
+
+	return err
+}
@@ -8,23 +8,73 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"ppo/domain"
+	"time"
 )
 
 type FinReportRepository struct {
-	db *pgxpool.Pool
+	db       *pgxpool.Pool
+	pageSize int
 }
 
-func NewFinReportRepository(db *pgxpool.Pool) domain.IFinancialReportRepository {
+func NewFinReportRepository(db *pgxpool.Pool, pageSize int) domain.IFinancialReportRepository {
 	return &FinReportRepository{
-		db: db,
+		db:       db,
+		pageSize: pageSize,
 	}
 }
 
+// finReportColumns — столбцы отчёта в порядке, который ожидает scanFinReport.
+const finReportColumns = `r.id, r.company_id, r.revenue, r.costs, r.year, r.quarter,
+	r.ebitda, r.net_profit, r.assets, r.liabilities, r.equity, r.headcount, r.exports_share,
+	r.status, r.review_comment, r.reviewer_id, r.reviewed_at`
+
+func scanFinReport(row pgx.Row) (report *domain.FinancialReport, err error) {
+	var (
+		reviewerId *uuid.UUID
+		reviewedAt *time.Time
+	)
+
+	report = new(domain.FinancialReport)
+	err = row.Scan(
+		&report.ID,
+		&report.CompanyID,
+		&report.Revenue,
+		&report.Costs,
+		&report.Year,
+		&report.Quarter,
+		&report.EBITDA,
+		&report.NetProfit,
+		&report.Assets,
+		&report.Liabilities,
+		&report.Equity,
+		&report.Headcount,
+		&report.ExportsShare,
+		&report.Status,
+		&report.ReviewComment,
+		&reviewerId,
+		&reviewedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if reviewerId != nil {
+		report.ReviewerID = *reviewerId
+	}
+	if reviewedAt != nil {
+		report.ReviewedAt = *reviewedAt
+	}
+
+	return report, nil
+}
+
 func (r *FinReportRepository) Create(ctx context.Context, finReport *domain.FinancialReport) (err error) {
-	query := `insert into ppo.fin_reports(company_id, revenue, costs, year, quarter) 
-	values ($1, $2, $3, $4, $5)`
+	query := `insert into ppo.fin_reports(company_id, revenue, costs, year, quarter,
+		ebitda, net_profit, assets, liabilities, equity, headcount, exports_share) 
+	values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	returning id, status`
 
-	_, err = r.db.Exec(
+	err = conn(ctx, r.db).QueryRow(
 		ctx,
 		query,
 		finReport.CompanyID,
@@ -32,41 +82,62 @@ func (r *FinReportRepository) Create(ctx context.Context, finReport *domain.Fina
 		finReport.Costs,
 		finReport.Year,
 		finReport.Quarter,
-	)
+		finReport.EBITDA,
+		finReport.NetProfit,
+		finReport.Assets,
+		finReport.Liabilities,
+		finReport.Equity,
+		finReport.Headcount,
+		finReport.ExportsShare,
+	).Scan(&finReport.ID, &finReport.Status)
 	if err != nil {
-		return fmt.Errorf("создание финансового отчета: %w", err)
+		return fmt.Errorf("создание финансового отчета: %w", translateError(err))
 	}
 
 	return nil
 }
 
 func (r *FinReportRepository) GetById(ctx context.Context, id uuid.UUID) (report *domain.FinancialReport, err error) {
-	query := `select company_id, revenue, costs, year, quarter from ppo.fin_reports where id = $1`
+	query := `select ` + finReportColumns + ` from ppo.fin_reports r where r.id = $1`
 
-	report = new(domain.FinancialReport)
-	err = r.db.QueryRow(
-		ctx,
-		query,
-		id,
-	).Scan(
-		&report.CompanyID,
-		&report.Revenue,
-		&report.Costs,
-		&report.Year,
-		&report.Quarter,
-	)
+	report, err = scanFinReport(conn(ctx, r.db).QueryRow(ctx, query, id))
 	if err != nil {
-		return nil, fmt.Errorf("получение отчета по id: %w", err)
+		return nil, fmt.Errorf("получение отчета по id: %w", translateError(err))
 	}
 
-	report.ID = id
 	return report, nil
 }
 
+// asOfSelect выбирает отчёты со значениями последней версии, поданной не позже $4.
+// Расширенные показатели не версионируются и берутся из текущего отчёта.
+const asOfSelect = `select r.id, r.company_id, v.revenue, v.costs, r.year, r.quarter,
+		r.ebitda, r.net_profit, r.assets, r.liabilities, r.equity, r.headcount, r.exports_share,
+		r.status, r.review_comment, r.reviewer_id, r.reviewed_at
+	from ppo.fin_reports r
+	join lateral (
+		select revenue, costs
+		from ppo.fin_report_revisions
+		where report_id = r.id and filed_at <= $4
+		order by version desc
+		limit 1
+	) v on true`
+
+// asOfQuery выбирает отчёт компании $1 за квартал $3 года $2 на момент $4.
+const asOfQuery = asOfSelect + `
+	where r.company_id = $1 and r.year = $2 and r.quarter = $3`
+
 func (r *FinReportRepository) GetByCompany(ctx context.Context, companyId uuid.UUID, period *domain.Period) (report *domain.FinancialReportByPeriod, err error) {
-	query := `select id, company_id, revenue, costs, year, quarter
-	from ppo.fin_reports 
-	where company_id = $1 and year = $2 and quarter = $3`
+	query := `select ` + finReportColumns + `
+	from ppo.fin_reports r
+	where r.company_id = $1 and r.year = $2 and r.quarter = $3`
+	args := []any{companyId, 0, 0}
+	if !period.AsOf.IsZero() {
+		query = asOfQuery
+		args = append(args, period.AsOf)
+	}
+	if period.VerifiedOnly {
+		query += ` and r.status = 'verified'`
+	}
 
 	report = new(domain.FinancialReportByPeriod)
 	report.Reports = make([]domain.FinancialReport, 0)
@@ -83,28 +154,14 @@ func (r *FinReportRepository) GetByCompany(ctx context.Context, companyId uuid.U
 		}
 
 		for quarter := startQtr; quarter <= endQtr; quarter++ {
-			tmp := new(domain.FinancialReport)
-
-			err = r.db.QueryRow(
-				ctx,
-				query,
-				companyId,
-				year,
-				quarter,
-			).Scan(
-				&tmp.ID,
-				&tmp.CompanyID,
-				&tmp.Revenue,
-				&tmp.Costs,
-				&tmp.Year,
-				&tmp.Quarter,
-			)
+			args[1], args[2] = year, quarter
+			tmp, err := scanFinReport(conn(ctx, r.db).QueryRow(ctx, query, args...))
 
 			if err != nil {
 				if errors.Is(err, pgx.ErrNoRows) {
 					continue
 				} else {
-					return nil, fmt.Errorf("сканирование записи: %w", err)
+					return nil, fmt.Errorf("сканирование записи: %w", translateError(err))
 				}
 			}
 
@@ -117,6 +174,46 @@ func (r *FinReportRepository) GetByCompany(ctx context.Context, companyId uuid.U
 	return report, nil
 }
 
+func (r *FinReportRepository) GetByCompanies(ctx context.Context, companyIds []uuid.UUID, period *domain.Period) (
+	reports map[uuid.UUID]*domain.FinancialReportByPeriod, err error) {
+	// кварталы нумеруются подряд: year*4 + quarter - 1
+	query := `select ` + finReportColumns + `
+	from ppo.fin_reports r`
+	args := []any{companyIds, period.StartYear*4 + period.StartQuarter - 1, period.EndYear*4 + period.EndQuarter - 1}
+	if !period.AsOf.IsZero() {
+		query = asOfSelect
+		args = append(args, period.AsOf)
+	}
+	query += `
+	where r.company_id = any($1) and r.year*4 + r.quarter - 1 between $2 and $3`
+	if period.VerifiedOnly {
+		query += ` and r.status = 'verified'`
+	}
+	query += ` order by r.company_id, r.year, r.quarter`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("получение отчетов компаний: %w", translateError(err))
+	}
+	defer rows.Close()
+
+	reports = make(map[uuid.UUID]*domain.FinancialReportByPeriod, len(companyIds))
+	for _, id := range companyIds {
+		reports[id] = &domain.FinancialReportByPeriod{Reports: make([]domain.FinancialReport, 0), Period: period}
+	}
+
+	for rows.Next() {
+		tmp, err := scanFinReport(rows)
+		if err != nil {
+			return nil, fmt.Errorf("сканирование полученных строк: %w", translateError(err))
+		}
+
+		reports[tmp.CompanyID].Reports = append(reports[tmp.CompanyID].Reports, *tmp)
+	}
+
+	return reports, nil
+}
+
 func (r *FinReportRepository) Update(ctx context.Context, finRep *domain.FinancialReport) (err error) {
 	query := `
 			update ppo.fin_reports
@@ -125,10 +222,21 @@ func (r *FinReportRepository) Update(ctx context.Context, finRep *domain.Financi
 			    revenue = $2,
 			    costs = $3,
 			    year = $4,
-			    quarter = $5
-			where id = $6`
+			    quarter = $5,
+			    status = $6,
+			    review_comment = $7,
+			    reviewer_id = $8,
+			    reviewed_at = $9,
+			    ebitda = $10,
+			    net_profit = $11,
+			    assets = $12,
+			    liabilities = $13,
+			    equity = $14,
+			    headcount = $15,
+			    exports_share = $16
+			where id = $17`
 
-	_, err = r.db.Exec(
+	_, err = conn(ctx, r.db).Exec(
 		ctx,
 		query,
 		finRep.CompanyID,
@@ -136,10 +244,21 @@ func (r *FinReportRepository) Update(ctx context.Context, finRep *domain.Financi
 		finRep.Costs,
 		finRep.Year,
 		finRep.Quarter,
+		finRep.Status,
+		finRep.ReviewComment,
+		nullUUID(finRep.ReviewerID),
+		nullTime(finRep.ReviewedAt),
+		finRep.EBITDA,
+		finRep.NetProfit,
+		finRep.Assets,
+		finRep.Liabilities,
+		finRep.Equity,
+		finRep.Headcount,
+		finRep.ExportsShare,
 		finRep.ID,
 	)
 	if err != nil {
-		return fmt.Errorf("обновление информации о финансовом отчете: %w", err)
+		return fmt.Errorf("обновление информации о финансовом отчете: %w", translateError(err))
 	}
 
 	return nil
@@ -148,13 +267,212 @@ func (r *FinReportRepository) Update(ctx context.Context, finRep *domain.Financi
 func (r *FinReportRepository) DeleteById(ctx context.Context, id uuid.UUID) (err error) {
 	query := `delete from ppo.fin_reports where id = $1`
 
-	_, err = r.db.Exec(
+	_, err = conn(ctx, r.db).Exec(
 		ctx,
 		query,
 		id,
 	)
 	if err != nil {
-		return fmt.Errorf("удаление отчета по id: %w", err)
+		return fmt.Errorf("удаление отчета по id: %w", translateError(err))
+	}
+
+	return nil
+}
+
+func (r *FinReportRepository) AddRevision(ctx context.Context, rev *domain.FinancialReportRevision) (err error) {
+	query := `insert into ppo.fin_report_revisions(report_id, version, revenue, costs, author_id, reason)
+	select $1::uuid, coalesce(max(version), 0) + 1, $2, $3, $4, $5
+	from ppo.fin_report_revisions
+	where report_id = $1::uuid
+	returning version, filed_at`
+
+	var authorId *uuid.UUID
+	if rev.AuthorID != uuid.Nil {
+		authorId = &rev.AuthorID
+	}
+
+	err = conn(ctx, r.db).QueryRow(
+		ctx,
+		query,
+		rev.ReportID,
+		rev.Revenue,
+		rev.Costs,
+		authorId,
+		rev.Reason,
+	).Scan(&rev.Version, &rev.FiledAt)
+	if err != nil {
+		return fmt.Errorf("сохранение версии отчета: %w", translateError(err))
+	}
+
+	return nil
+}
+
+// GetRevisions возвращает версии отчёта по возрастанию номера; последняя отмечена как текущая.
+func (r *FinReportRepository) GetRevisions(ctx context.Context, reportId uuid.UUID) (revs []*domain.FinancialReportRevision, err error) {
+	query := `select version, revenue, costs, coalesce(author_id, '00000000-0000-0000-0000-000000000000'), reason, filed_at
+	from ppo.fin_report_revisions
+	where report_id = $1
+	order by version`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, reportId)
+	if err != nil {
+		return nil, fmt.Errorf("получение версий отчета: %w", translateError(err))
+	}
+	defer rows.Close()
+
+	revs = make([]*domain.FinancialReportRevision, 0)
+	for rows.Next() {
+		rev := &domain.FinancialReportRevision{ReportID: reportId}
+		err = rows.Scan(
+			&rev.Version,
+			&rev.Revenue,
+			&rev.Costs,
+			&rev.AuthorID,
+			&rev.Reason,
+			&rev.FiledAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("сканирование полученных строк: %w", translateError(err))
+		}
+
+		revs = append(revs, rev)
+	}
+
+	if len(revs) != 0 {
+		revs[len(revs)-1].Current = true
+	}
+
+	return revs, nil
+}
+
+// GetByStatus возвращает страницу отчётов в статусе проверки status, начиная с самых ранних кварталов.
+func (r *FinReportRepository) GetByStatus(ctx context.Context, status domain.VerificationStatus, page int) (
+	reports []*domain.FinancialReport, numPages int, err error) {
+	query := `select ` + finReportColumns + `
+	from ppo.fin_reports r
+	where r.status = $1
+	order by r.year, r.quarter, r.id
+	offset $2 limit $3`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, status, (page-1)*r.pageSize, r.pageSize)
+	if err != nil {
+		return nil, 0, fmt.Errorf("получение отчетов по статусу проверки: %w", translateError(err))
+	}
+	defer rows.Close()
+
+	reports = make([]*domain.FinancialReport, 0)
+	for rows.Next() {
+		report, err := scanFinReport(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("сканирование полученных строк: %w", translateError(err))
+		}
+
+		reports = append(reports, report)
+	}
+
+	var numRecords int
+	err = conn(ctx, r.db).QueryRow(
+		ctx,
+		`select count(*) from ppo.fin_reports where status = $1`,
+		status,
+	).Scan(&numRecords)
+	if err != nil {
+		return nil, 0, fmt.Errorf("получение отчетов по статусу проверки: %w", translateError(err))
+	}
+
+	numPages = numRecords / r.pageSize
+	if numRecords%r.pageSize != 0 {
+		numPages++
+	}
+
+	return reports, numPages, nil
+}
+
+const finDocumentColumns = `id, report_id, name, content_type, size, storage_key,
+	coalesce(uploaded_by, '00000000-0000-0000-0000-000000000000'), uploaded_at`
+
+func scanFinDocument(row pgx.Row) (doc *domain.FinancialReportDocument, err error) {
+	doc = new(domain.FinancialReportDocument)
+	err = row.Scan(
+		&doc.ID,
+		&doc.ReportID,
+		&doc.Name,
+		&doc.ContentType,
+		&doc.Size,
+		&doc.StorageKey,
+		&doc.UploadedBy,
+		&doc.UploadedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+func (r *FinReportRepository) AddDocument(ctx context.Context, doc *domain.FinancialReportDocument) (err error) {
+	query := `insert into ppo.fin_report_documents(report_id, name, content_type, size, storage_key, uploaded_by)
+	values ($1, $2, $3, $4, $5, $6)
+	returning id, uploaded_at`
+
+	err = conn(ctx, r.db).QueryRow(
+		ctx,
+		query,
+		doc.ReportID,
+		doc.Name,
+		doc.ContentType,
+		doc.Size,
+		doc.StorageKey,
+		nullUUID(doc.UploadedBy),
+	).Scan(&doc.ID, &doc.UploadedAt)
+	if err != nil {
+		return fmt.Errorf("добавление документа к отчету: %w", translateError(err))
+	}
+
+	return nil
+}
+
+func (r *FinReportRepository) GetDocument(ctx context.Context, id uuid.UUID) (doc *domain.FinancialReportDocument, err error) {
+	query := `select ` + finDocumentColumns + ` from ppo.fin_report_documents where id = $1`
+
+	doc, err = scanFinDocument(conn(ctx, r.db).QueryRow(ctx, query, id))
+	if err != nil {
+		return nil, fmt.Errorf("получение документа по id: %w", translateError(err))
+	}
+
+	return doc, nil
+}
+
+// GetDocuments возвращает документы отчёта в порядке загрузки.
+func (r *FinReportRepository) GetDocuments(ctx context.Context, reportId uuid.UUID) (docs []*domain.FinancialReportDocument, err error) {
+	query := `select ` + finDocumentColumns + `
+	from ppo.fin_report_documents
+	where report_id = $1
+	order by uploaded_at, id`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, reportId)
+	if err != nil {
+		return nil, fmt.Errorf("получение документов отчета: %w", translateError(err))
+	}
+	defer rows.Close()
+
+	docs = make([]*domain.FinancialReportDocument, 0)
+	for rows.Next() {
+		doc, err := scanFinDocument(rows)
+		if err != nil {
+			return nil, fmt.Errorf("сканирование полученных строк: %w", translateError(err))
+		}
+
+		docs = append(docs, doc)
+	}
+
+	return docs, nil
+}
+
+func (r *FinReportRepository) DeleteDocument(ctx context.Context, id uuid.UUID) (err error) {
+	_, err = conn(ctx, r.db).Exec(ctx, `delete from ppo.fin_report_documents where id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("удаление документа по id: %w", translateError(err))
 	}
 
 	return nil
@@ -7,10 +7,11 @@ import (
 	"github.com/stretchr/testify/require"
 	"ppo/domain"
 	"testing"
+	"time"
 )
 
 func TestFinReportRepository_Create(t *testing.T) {
-	finRepo := NewFinReportRepository(testDbInstance)
+	finRepo := NewFinReportRepository(testDbInstance, 10)
 
 	testCases := []struct {
 		name    string
@@ -44,7 +45,7 @@ func TestFinReportRepository_Create(t *testing.T) {
 }
 
 func TestFinReportRepository_DeleteById(t *testing.T) {
-	finRepo := NewFinReportRepository(testDbInstance)
+	finRepo := NewFinReportRepository(testDbInstance, 10)
 
 	testCases := []struct {
 		name    string
@@ -78,7 +79,7 @@ func TestFinReportRepository_DeleteById(t *testing.T) {
 }
 
 func TestFinReportRepository_Update(t *testing.T) {
-	finRepo := NewFinReportRepository(testDbInstance)
+	finRepo := NewFinReportRepository(testDbInstance, 10)
 
 	testCases := []struct {
 		name    string
@@ -106,3 +107,145 @@ func TestFinReportRepository_Update(t *testing.T) {
 		})
 	}
 }
+
+func TestFinReportRepository_Revisions(t *testing.T) {
+	finRepo := NewFinReportRepository(testDbInstance, 10)
+	ctx := context.Background()
+
+	report := &domain.FinancialReport{CompanyID: uuid.UUID{1}, Revenue: 10, Costs: 5, Year: 2030, Quarter: 2}
+	require.Nil(t, finRepo.Create(ctx, report))
+
+	first := &domain.FinancialReportRevision{ReportID: report.ID, Revenue: 10, Costs: 5}
+	require.Nil(t, finRepo.AddRevision(ctx, first))
+	require.Equal(t, 1, first.Version)
+
+	report.Revenue = 20
+	require.Nil(t, finRepo.Update(ctx, report))
+
+	second := &domain.FinancialReportRevision{ReportID: report.ID, Revenue: 20, Costs: 5, Reason: "опечатка"}
+	require.Nil(t, finRepo.AddRevision(ctx, second))
+	require.Equal(t, 2, second.Version)
+
+	revs, err := finRepo.GetRevisions(ctx, report.ID)
+	require.Nil(t, err)
+	require.Len(t, revs, 2)
+	require.False(t, revs[0].Current)
+	require.True(t, revs[1].Current)
+	require.Equal(t, "опечатка", revs[1].Reason)
+
+	period := &domain.Period{StartYear: 2030, StartQuarter: 2, EndYear: 2030, EndQuarter: 2}
+
+	current, err := finRepo.GetByCompany(ctx, uuid.UUID{1}, period)
+	require.Nil(t, err)
+	require.Len(t, current.Reports, 1)
+	require.Equal(t, float32(20), current.Reports[0].Revenue)
+
+	period.AsOf = second.FiledAt.Add(-time.Microsecond)
+	known, err := finRepo.GetByCompany(ctx, uuid.UUID{1}, period)
+	require.Nil(t, err)
+	require.Len(t, known.Reports, 1)
+	require.Equal(t, float32(10), known.Reports[0].Revenue)
+}
+
+func TestFinReportRepository_Verification(t *testing.T) {
+	finRepo := NewFinReportRepository(testDbInstance, 10)
+	ctx := context.Background()
+
+	report := &domain.FinancialReport{CompanyID: uuid.UUID{1}, Revenue: 10, Costs: 5, Year: 2031, Quarter: 1}
+	require.Nil(t, finRepo.Create(ctx, report))
+	require.Equal(t, domain.VerificationUnverified, report.Status)
+
+	doc := &domain.FinancialReportDocument{
+		ReportID:    report.ID,
+		Name:        "act.pdf",
+		ContentType: "application/pdf",
+		Size:        4,
+		StorageKey:  "fin_reports/" + report.ID.String() + "/act",
+	}
+	require.Nil(t, finRepo.AddDocument(ctx, doc))
+	require.False(t, doc.UploadedAt.IsZero())
+
+	docs, err := finRepo.GetDocuments(ctx, report.ID)
+	require.Nil(t, err)
+	require.Len(t, docs, 1)
+	require.Equal(t, uuid.Nil, docs[0].UploadedBy)
+
+	period := &domain.Period{StartYear: 2031, StartQuarter: 1, EndYear: 2031, EndQuarter: 1, VerifiedOnly: true}
+	unverified, err := finRepo.GetByCompany(ctx, uuid.UUID{1}, period)
+	require.Nil(t, err)
+	require.Empty(t, unverified.Reports)
+
+	report.Status = domain.VerificationVerified
+	report.ReviewerID = uuid.UUID{1}
+	report.ReviewedAt = time.Now().UTC().Truncate(time.Microsecond)
+	require.Nil(t, finRepo.Update(ctx, report))
+
+	verified, err := finRepo.GetByCompany(ctx, uuid.UUID{1}, period)
+	require.Nil(t, err)
+	require.Len(t, verified.Reports, 1)
+	require.Equal(t, report.ReviewedAt, verified.Reports[0].ReviewedAt.UTC())
+
+	queue, _, err := finRepo.GetByStatus(ctx, domain.VerificationVerified, 1)
+	require.Nil(t, err)
+	require.NotEmpty(t, queue)
+
+	require.Nil(t, finRepo.DeleteDocument(ctx, doc.ID))
+	_, err = finRepo.GetDocument(ctx, doc.ID)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestFinReportRepository_Metrics(t *testing.T) {
+	finRepo := NewFinReportRepository(testDbInstance, 10)
+	ctx := context.Background()
+
+	assets, liabilities, equity, headcount := float32(1000), float32(400), float32(600), 12
+	report := &domain.FinancialReport{
+		CompanyID:   uuid.UUID{1},
+		Revenue:     10,
+		Costs:       5,
+		Year:        2032,
+		Quarter:     1,
+		Assets:      &assets,
+		Liabilities: &liabilities,
+		Equity:      &equity,
+		Headcount:   &headcount,
+	}
+	require.Nil(t, finRepo.Create(ctx, report))
+
+	got, err := finRepo.GetById(ctx, report.ID)
+	require.Nil(t, err)
+	require.Equal(t, report.Assets, got.Assets)
+	require.Equal(t, report.Headcount, got.Headcount)
+	require.Nil(t, got.EBITDA)
+	require.Nil(t, got.ExportsShare)
+
+	share := float32(35)
+	got.ExportsShare = &share
+	got.Headcount = nil
+	require.Nil(t, finRepo.Update(ctx, got))
+
+	updated, err := finRepo.GetById(ctx, report.ID)
+	require.Nil(t, err)
+	require.Equal(t, &share, updated.ExportsShare)
+	require.Nil(t, updated.Headcount)
+}
+
+func TestFinReportRepository_GetByCompanies(t *testing.T) {
+	finRepo := NewFinReportRepository(testDbInstance, 10)
+	ctx := context.Background()
+
+	first := &domain.FinancialReport{CompanyID: uuid.UUID{1}, Revenue: 10, Costs: 5, Year: 2033, Quarter: 2}
+	require.Nil(t, finRepo.Create(ctx, first))
+	outside := &domain.FinancialReport{CompanyID: uuid.UUID{1}, Revenue: 10, Costs: 5, Year: 2034, Quarter: 1}
+	require.Nil(t, finRepo.Create(ctx, outside))
+
+	period := &domain.Period{StartYear: 2033, StartQuarter: 1, EndYear: 2033, EndQuarter: 4}
+	reports, err := finRepo.GetByCompanies(ctx, []uuid.UUID{{1}, {99}}, period)
+	require.Nil(t, err)
+	require.Len(t, reports, 2)
+
+	require.Len(t, reports[uuid.UUID{1}].Reports, 1)
+	require.Equal(t, first.ID, reports[uuid.UUID{1}].Reports[0].ID)
+	require.Equal(t, period, reports[uuid.UUID{1}].Period)
+	require.Empty(t, reports[uuid.UUID{99}].Reports)
+}
@@ -0,0 +1,198 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"ppo/domain"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type JobRepository struct {
+	db       *pgxpool.Pool
+	pageSize int
+}
+
+func NewJobRepository(db *pgxpool.Pool, pageSize int) domain.IJobRepository {
+	return &JobRepository{
+		db:       db,
+		pageSize: pageSize,
+	}
+}
+
+// TryLock захватывает сессионную advisory-блокировку на отдельном соединении пула.
+// Соединение удерживается до вызова release, поэтому блокировка держится всё время
+// выполнения задачи и снимается автоматически, если экземпляр сервера упадёт.
+func (r *JobRepository) TryLock(ctx context.Context, job string) (release func(), ok bool, err error) {
+	c, err := r.db.Acquire(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("получение соединения для блокировки задачи: %w", err)
+	}
+
+	key := "ppo.job." + job
+	err = c.QueryRow(ctx, `select pg_try_advisory_lock(hashtext($1))`, key).Scan(&ok)
+	if err != nil || !ok {
+		c.Release()
+		if err != nil {
+			return nil, false, fmt.Errorf("блокировка задачи: %w", translateError(err))
+		}
+		return nil, false, nil
+	}
+
+	release = func() {
+		_, err := c.Exec(context.Background(), `select pg_advisory_unlock(hashtext($1))`, key)
+		if err != nil {
+			log.Println(fmt.Errorf("снятие блокировки задачи %s: %w", job, err))
+		}
+		c.Release()
+	}
+
+	return release, true, nil
+}
+
+func (r *JobRepository) StartRun(ctx context.Context, run *domain.JobRun) (err error) {
+	query := `insert into ppo.job_runs(job, trigger, status)
+	values ($1, $2, $3)
+	returning id, started_at`
+
+	err = conn(ctx, r.db).QueryRow(
+		ctx,
+		query,
+		run.Job,
+		run.Trigger,
+		run.Status,
+	).Scan(&run.ID, &run.StartedAt)
+	if err != nil {
+		return fmt.Errorf("запись запуска задачи: %w", translateError(err))
+	}
+
+	return nil
+}
+
+func (r *JobRepository) FinishRun(ctx context.Context, run *domain.JobRun) (err error) {
+	query := `update ppo.job_runs
+	set
+		status = $1,
+		error = nullif($2, ''),
+		finished_at = now()
+	where id = $3
+	returning finished_at`
+
+	err = conn(ctx, r.db).QueryRow(
+		ctx,
+		query,
+		run.Status,
+		run.Error,
+		run.ID,
+	).Scan(&run.FinishedAt)
+	if err != nil {
+		return fmt.Errorf("запись результата задачи: %w", translateError(err))
+	}
+
+	return nil
+}
+
+const jobRunColumns = `id, job, trigger, status, coalesce(error, ''), started_at, coalesce(finished_at, '0001-01-01')`
+
+func scanJobRun(row interface{ Scan(...any) error }) (*domain.JobRun, error) {
+	run := new(domain.JobRun)
+	err := row.Scan(
+		&run.ID,
+		&run.Job,
+		&run.Trigger,
+		&run.Status,
+		&run.Error,
+		&run.StartedAt,
+		&run.FinishedAt,
+	)
+
+	return run, err
+}
+
+// GetLastRuns возвращает последний запуск каждой задачи, которая хотя бы раз запускалась.
+func (r *JobRepository) GetLastRuns(ctx context.Context) (runs map[string]*domain.JobRun, err error) {
+	query := `select distinct on (job) ` + jobRunColumns + `
+	from ppo.job_runs
+	order by job, started_at desc`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("получение последних запусков задач: %w", translateError(err))
+	}
+	defer rows.Close()
+
+	runs = make(map[string]*domain.JobRun)
+	for rows.Next() {
+		run, err := scanJobRun(rows)
+		if err != nil {
+			return nil, fmt.Errorf("сканирование полученных строк: %w", translateError(err))
+		}
+
+		runs[run.Job] = run
+	}
+
+	return runs, nil
+}
+
+func (r *JobRepository) GetRuns(ctx context.Context, job string, page int) (runs []*domain.JobRun, numPages int, err error) {
+	query := `select ` + jobRunColumns + `
+	from ppo.job_runs
+	where job = $1
+	order by started_at desc
+	offset $2 limit $3`
+
+	rows, err := conn(ctx, r.db).Query(
+		ctx,
+		query,
+		job,
+		(page-1)*r.pageSize,
+		r.pageSize,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("получение запусков задачи: %w", translateError(err))
+	}
+	defer rows.Close()
+
+	runs = make([]*domain.JobRun, 0)
+	for rows.Next() {
+		run, err := scanJobRun(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("сканирование полученных строк: %w", translateError(err))
+		}
+
+		runs = append(runs, run)
+	}
+
+	var numRecords int
+	err = conn(ctx, r.db).QueryRow(
+		ctx,
+		`select count(*) from ppo.job_runs where job = $1`,
+		job,
+	).Scan(&numRecords)
+	if err != nil {
+		return nil, 0, fmt.Errorf("получение количества запусков задачи: %w", translateError(err))
+	}
+
+	numPages = numRecords / r.pageSize
+	if numRecords%r.pageSize != 0 {
+		numPages++
+	}
+
+	return runs, numPages, nil
+}
+
+// DeleteRunsBefore удаляет завершённые запуски, начатые раньше before.
+func (r *JobRepository) DeleteRunsBefore(ctx context.Context, before time.Time) (numDeleted int, err error) {
+	tag, err := conn(ctx, r.db).Exec(
+		ctx,
+		`delete from ppo.job_runs where started_at < $1 and status <> 'running'`,
+		before,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("удаление старых запусков задач: %w", translateError(err))
+	}
+
+	return int(tag.RowsAffected()), nil
+}
@@ -0,0 +1,143 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"ppo/migrations"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+// MigrationState — миграция из встроенного набора и признак её применения.
+type MigrationState struct {
+	Version uint
+	Name    string
+	Applied bool
+}
+
+// MigrationStatus описывает состояние схемы: Version — последняя применённая миграция
+// (0, если не применено ни одной); Dirty — миграция Version завершилась с ошибкой
+// и схему нужно исправить вручную, после чего выполнить Force.
+type MigrationStatus struct {
+	Version    uint
+	Dirty      bool
+	Migrations []MigrationState
+}
+
+// Migrator применяет и откатывает миграции, встроенные в пакет migrations.
+// Версия схемы хранится в таблице schema_migrations.
+type Migrator struct {
+	src source.Driver
+	m   *migrate.Migrate
+}
+
+// NewMigrator создаёт мигратор поверх пула соединений. Close мигратора пул не закрывает.
+func NewMigrator(pool *pgxpool.Pool) (*Migrator, error) {
+	src, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return nil, fmt.Errorf("чтение миграций: %w", err)
+	}
+
+	db, err := pgx.WithInstance(stdlib.OpenDBFromPool(pool), &pgx.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("подготовка миграций: %w", err)
+	}
+
+	m, err := migrate.NewWithInstance("iofs", src, "pgx5", db)
+	if err != nil {
+		return nil, fmt.Errorf("подготовка миграций: %w", err)
+	}
+
+	return &Migrator{src: src, m: m}, nil
+}
+
+// Up применяет все ещё не применённые миграции.
+func (m *Migrator) Up() error {
+	err := m.m.Up()
+	if err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("применение миграций: %w", err)
+	}
+
+	return nil
+}
+
+// Down откатывает steps последних применённых миграций.
+func (m *Migrator) Down(steps int) error {
+	if steps < 1 {
+		return fmt.Errorf("откат миграций: число шагов должно быть положительным, получено %d", steps)
+	}
+
+	err := m.m.Steps(-steps)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("откат миграций: применено меньше %d миграций", steps)
+	}
+	if err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("откат миграций: %w", err)
+	}
+
+	return nil
+}
+
+// Force записывает версию схемы без выполнения миграций и снимает признак Dirty.
+// Используется после ручного исправления схемы; version == -1 означает «ничего не применено».
+func (m *Migrator) Force(version int) error {
+	err := m.m.Force(version)
+	if err != nil {
+		return fmt.Errorf("установка версии схемы: %w", err)
+	}
+
+	return nil
+}
+
+// Status возвращает версию схемы и список встроенных миграций.
+func (m *Migrator) Status() (status *MigrationStatus, err error) {
+	status = new(MigrationStatus)
+	status.Version, status.Dirty, err = m.m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return nil, fmt.Errorf("получение версии схемы: %w", err)
+	}
+
+	for version, err := m.src.First(); ; version, err = m.src.Next(version) {
+		if errors.Is(err, os.ErrNotExist) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("чтение миграций: %w", err)
+		}
+
+		name, err := m.name(version)
+		if err != nil {
+			return nil, err
+		}
+
+		status.Migrations = append(status.Migrations, MigrationState{
+			Version: version,
+			Name:    name,
+			Applied: version < status.Version || version == status.Version && !status.Dirty,
+		})
+	}
+
+	return status, nil
+}
+
+func (m *Migrator) name(version uint) (string, error) {
+	r, name, err := m.src.ReadUp(version)
+	if err != nil {
+		return "", fmt.Errorf("чтение миграции %d: %w", version, err)
+	}
+
+	return name, r.Close()
+}
+
+// Close освобождает соединение мигратора.
+func (m *Migrator) Close() error {
+	srcErr, dbErr := m.m.Close()
+
+	return errors.Join(srcErr, dbErr)
+}
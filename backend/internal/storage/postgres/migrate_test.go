@@ -0,0 +1,64 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrator_Status(t *testing.T) {
+	m, err := NewMigrator(testDbInstance)
+	require.Nil(t, err)
+	defer m.Close()
+
+	// TestMain уже применил все миграции, повторное применение ничего не меняет
+	require.Nil(t, m.Up())
+
+	status, err := m.Status()
+	require.Nil(t, err)
+	require.False(t, status.Dirty)
+	require.NotEmpty(t, status.Migrations)
+	require.Equal(t, status.Migrations[len(status.Migrations)-1].Version, status.Version)
+	for _, migration := range status.Migrations {
+		require.True(t, migration.Applied, "миграция %d не применена", migration.Version)
+	}
+}
+
+func TestMigrator_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	// отдельная пустая база, чтобы откат не затронул данные остальных тестов
+	_, err := testDbInstance.Exec(ctx, `create database migrate_round_trip`)
+	require.Nil(t, err)
+
+	cfg := testDbInstance.Config()
+	cfg.ConnConfig.Database = "migrate_round_trip"
+	db, err := pgxpool.NewWithConfig(ctx, cfg)
+	require.Nil(t, err)
+	defer db.Close()
+
+	m, err := NewMigrator(db)
+	require.Nil(t, err)
+	defer m.Close()
+
+	require.Nil(t, m.Up())
+	status, err := m.Status()
+	require.Nil(t, err)
+
+	require.Nil(t, m.Down(len(status.Migrations)))
+
+	var schemas int
+	err = db.QueryRow(ctx, `select count(*) from information_schema.schemata where schema_name = 'ppo'`).Scan(&schemas)
+	require.Nil(t, err)
+	require.Zero(t, schemas, "откат всех миграций должен удалить схему ppo")
+
+	require.Nil(t, m.Up())
+	status, err = m.Status()
+	require.Nil(t, err)
+	require.False(t, status.Dirty)
+	for _, migration := range status.Migrations {
+		require.True(t, migration.Applied, "миграция %d не применена", migration.Version)
+	}
+}
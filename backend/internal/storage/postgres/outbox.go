@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"ppo/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type OutboxRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewOutboxRepository(db *pgxpool.Pool) domain.IOutboxRepository {
+	return &OutboxRepository{
+		db: db,
+	}
+}
+
+func (r *OutboxRepository) Add(ctx context.Context, event *domain.OutboxEvent) (err error) {
+	query := `insert into ppo.outbox(event_type, payload)
+	values ($1, $2)
+	returning id, created_at`
+
+	err = conn(ctx, r.db).QueryRow(
+		ctx,
+		query,
+		event.Type,
+		event.Payload,
+	).Scan(&event.ID, &event.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("запись события %s в outbox: %w", event.Type, translateError(err))
+	}
+
+	return nil
+}
+
+// ClaimNext блокирует самое раннее необработанное событие, время попытки которого наступило к now.
+// Блокировка держится до конца транзакции из ctx, другие экземпляры сервера это событие пропускают.
+// Если таких событий нет, возвращает nil.
+func (r *OutboxRepository) ClaimNext(ctx context.Context, now time.Time) (event *domain.OutboxEvent, err error) {
+	query :=
+		`select id, event_type, payload, attempts, created_at
+		from ppo.outbox
+		where processed_at is null and next_attempt_at <= $1
+		order by created_at
+		limit 1
+		for update skip locked`
+
+	event = new(domain.OutboxEvent)
+	err = conn(ctx, r.db).QueryRow(
+		ctx,
+		query,
+		now,
+	).Scan(
+		&event.ID,
+		&event.Type,
+		&event.Payload,
+		&event.Attempts,
+		&event.CreatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("выбор события из outbox: %w", translateError(err))
+	}
+
+	return event, nil
+}
+
+func (r *OutboxRepository) MarkProcessed(ctx context.Context, id uuid.UUID) (err error) {
+	_, err = conn(ctx, r.db).Exec(
+		ctx,
+		`update ppo.outbox set processed_at = now() where id = $1`,
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("отметка события обработанным: %w", translateError(err))
+	}
+
+	return nil
+}
+
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id uuid.UUID, lastError string, nextAttemptAt time.Time) (err error) {
+	_, err = conn(ctx, r.db).Exec(
+		ctx,
+		`update ppo.outbox
+		set
+			attempts = attempts + 1,
+			last_error = $1,
+			next_attempt_at = $2
+		where id = $3`,
+		lastError,
+		nextAttemptAt,
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("сохранение ошибки обработки события: %w", translateError(err))
+	}
+
+	return nil
+}
+
+// DeleteProcessedBefore удаляет обработанные раньше before события,
+// на которые больше не ссылается ни одна доставка вебхука.
+func (r *OutboxRepository) DeleteProcessedBefore(ctx context.Context, before time.Time) (numDeleted int, err error) {
+	tag, err := conn(ctx, r.db).Exec(
+		ctx,
+		`delete from ppo.outbox o
+		where o.processed_at < $1
+			and not exists (select 1 from ppo.webhook_deliveries d where d.event_id = o.id)`,
+		before,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("удаление обработанных событий: %w", translateError(err))
+	}
+
+	return int(tag.RowsAffected()), nil
+}
@@ -0,0 +1,27 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"ppo/internal/config"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Connect открывает пул соединений с БД по параметрам cfg и проверяет подключение.
+func Connect(ctx context.Context, cfg *config.DBConfig) (pool *pgxpool.Pool, err error) {
+	connStr := fmt.Sprintf("%s://%s:%s@%s:%s/%s", cfg.Driver, cfg.User, cfg.Password, cfg.Host, cfg.Port, cfg.Database)
+
+	pool, err = pgxpool.New(ctx, connStr)
+	if err != nil {
+		return nil, fmt.Errorf("подключение к БД: %w", err)
+	}
+
+	err = pool.Ping(ctx)
+	if err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("пинг БД: %w", err)
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,117 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"ppo/domain"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type RatingRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewRatingRepository(db *pgxpool.Pool) domain.IRatingRepository {
+	return &RatingRepository{
+		db: db,
+	}
+}
+
+// Save сохраняет снимок рейтинга, заменяя ранее рассчитанный за тот же квартал.
+func (r *RatingRepository) Save(ctx context.Context, snapshot *domain.RatingSnapshot) (err error) {
+	query := `insert into ppo.rating_snapshots(user_id, year, quarter, rating, revenue, profit, company_id, field_cost, max_cost)
+	values ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	on conflict (user_id, year, quarter) do update
+	set
+		rating = excluded.rating,
+		revenue = excluded.revenue,
+		profit = excluded.profit,
+		company_id = excluded.company_id,
+		field_cost = excluded.field_cost,
+		max_cost = excluded.max_cost,
+		computed_at = now()
+	returning computed_at`
+
+	var companyId *uuid.UUID
+	if snapshot.CompanyID != uuid.Nil {
+		companyId = &snapshot.CompanyID
+	}
+
+	err = conn(ctx, r.db).QueryRow(
+		ctx,
+		query,
+		snapshot.UserID,
+		snapshot.Year,
+		snapshot.Quarter,
+		snapshot.Rating,
+		snapshot.Revenue,
+		snapshot.Profit,
+		companyId,
+		snapshot.FieldCost,
+		snapshot.MaxCost,
+	).Scan(&snapshot.ComputedAt)
+	if err != nil {
+		return fmt.Errorf("сохранение снимка рейтинга: %w", translateError(err))
+	}
+
+	return nil
+}
+
+// GetHistory возвращает снимки рейтинга пользователя за период в хронологическом порядке.
+// Если period равен nil, возвращаются все снимки.
+func (r *RatingRepository) GetHistory(ctx context.Context, userId uuid.UUID, period *domain.Period) (snapshots []*domain.RatingSnapshot, err error) {
+	query :=
+		`select
+			year,
+			quarter,
+			rating,
+			revenue,
+			profit,
+			coalesce(company_id, '00000000-0000-0000-0000-000000000000'),
+			field_cost,
+			max_cost,
+			computed_at
+		from ppo.rating_snapshots
+		where user_id = $1
+			and ($2::int is null or year * 4 + quarter between $2 and $3)
+		order by year, quarter`
+
+	var from, to *int
+	if period != nil {
+		start := period.StartYear*4 + period.StartQuarter
+		end := period.EndYear*4 + period.EndQuarter
+		from, to = &start, &end
+	}
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, userId, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("получение истории рейтинга: %w", translateError(err))
+	}
+	defer rows.Close()
+
+	snapshots = make([]*domain.RatingSnapshot, 0)
+	for rows.Next() {
+		tmp := &domain.RatingSnapshot{UserID: userId}
+
+		err = rows.Scan(
+			&tmp.Year,
+			&tmp.Quarter,
+			&tmp.Rating,
+			&tmp.Revenue,
+			&tmp.Profit,
+			&tmp.CompanyID,
+			&tmp.FieldCost,
+			&tmp.MaxCost,
+			&tmp.ComputedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("сканирование полученных строк: %w", translateError(err))
+		}
+
+		snapshots = append(snapshots, tmp)
+	}
+
+	return snapshots, nil
+}
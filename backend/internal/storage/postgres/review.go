@@ -4,27 +4,29 @@ import (
 	"context"
 	"fmt"
 	"ppo/domain"
-	"ppo/internal/config"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type ReviewRepository struct {
-	db *pgxpool.Pool
+	db       *pgxpool.Pool
+	pageSize int
 }
 
-func NewReviewRepository(db *pgxpool.Pool) domain.IReviewRepository {
+func NewReviewRepository(db *pgxpool.Pool, pageSize int) domain.IReviewRepository {
 	return &ReviewRepository{
-		db: db,
+		db:       db,
+		pageSize: pageSize,
 	}
 }
 
 func (r *ReviewRepository) Create(ctx context.Context, rev *domain.Review) (err error) {
 	query := `insert into ppo.reviews(target_id, reviewer_id, pros, cons, description, rating) 
-	values ($1, $2, $3, $4, $5, $6)`
+	values ($1, $2, $3, $4, $5, $6)
+	returning id`
 
-	_, err = r.db.Exec(
+	err = conn(ctx, r.db).QueryRow(
 		ctx,
 		query,
 		rev.Target,
@@ -33,9 +35,9 @@ func (r *ReviewRepository) Create(ctx context.Context, rev *domain.Review) (err
 		rev.Cons,
 		rev.Description,
 		rev.Rating,
-	)
+	).Scan(&rev.ID)
 	if err != nil {
-		return fmt.Errorf("создание отзыва: %w", err)
+		return fmt.Errorf("создание отзыва: %w", translateError(err))
 	}
 
 	return nil
@@ -53,7 +55,7 @@ func (r *ReviewRepository) Get(ctx context.Context, id uuid.UUID) (rev *domain.R
 	where id = $1`
 
 	rev = new(domain.Review)
-	err = r.db.QueryRow(
+	err = conn(ctx, r.db).QueryRow(
 		ctx,
 		query,
 		id,
@@ -66,7 +68,7 @@ func (r *ReviewRepository) Get(ctx context.Context, id uuid.UUID) (rev *domain.R
 		&rev.Rating,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("получение отзыва по id: %w", err)
+		return nil, fmt.Errorf("получение отзыва по id: %w", translateError(err))
 	}
 
 	rev.ID = id
@@ -86,15 +88,15 @@ func (r *ReviewRepository) GetAllForReviewer(ctx context.Context, id uuid.UUID,
 		where reviewer_id = $1
 		offset $2 limit $3`
 
-	rows, err := r.db.Query(
+	rows, err := conn(ctx, r.db).Query(
 		ctx,
 		query,
 		id,
-		(page-1)*config.PageSize,
-		config.PageSize,
+		(page-1)*r.pageSize,
+		r.pageSize,
 	)
 	if err != nil {
-		return nil, 0, fmt.Errorf("получение отзывов ревьюера: %w", err)
+		return nil, 0, fmt.Errorf("получение отзывов ревьюера: %w", translateError(err))
 	}
 
 	revs = make([]*domain.Review, 0)
@@ -112,24 +114,24 @@ func (r *ReviewRepository) GetAllForReviewer(ctx context.Context, id uuid.UUID,
 		tmp.Reviewer = id
 
 		if err != nil {
-			return nil, 0, fmt.Errorf("сканирование полученных строк: %w", err)
+			return nil, 0, fmt.Errorf("сканирование полученных строк: %w", translateError(err))
 		}
 
 		revs = append(revs, tmp)
 	}
 
 	var numRecords int
-	err = r.db.QueryRow(
+	err = conn(ctx, r.db).QueryRow(
 		ctx,
 		`select count(*) from ppo.reviews where reviewer_id = $1`,
 		id,
 	).Scan(&numRecords)
 	if err != nil {
-		return nil, 0, fmt.Errorf("получение количества отзывов ревьюера: %w", err)
+		return nil, 0, fmt.Errorf("получение количества отзывов ревьюера: %w", translateError(err))
 	}
 
-	numPages = numRecords / config.PageSize
-	if numRecords%config.PageSize != 0 {
+	numPages = numRecords / r.pageSize
+	if numRecords%r.pageSize != 0 {
 		numPages++
 	}
 
@@ -149,15 +151,15 @@ func (r *ReviewRepository) GetAllForTarget(ctx context.Context, id uuid.UUID, pa
 		where target_id = $1
 		offset $2 limit $3`
 
-	rows, err := r.db.Query(
+	rows, err := conn(ctx, r.db).Query(
 		ctx,
 		query,
 		id,
-		(page-1)*config.PageSize,
-		config.PageSize,
+		(page-1)*r.pageSize,
+		r.pageSize,
 	)
 	if err != nil {
-		return nil, 0, fmt.Errorf("получение отзывов ревьюера: %w", err)
+		return nil, 0, fmt.Errorf("получение отзывов ревьюера: %w", translateError(err))
 	}
 
 	revs = make([]*domain.Review, 0)
@@ -175,40 +177,127 @@ func (r *ReviewRepository) GetAllForTarget(ctx context.Context, id uuid.UUID, pa
 		tmp.Target = id
 
 		if err != nil {
-			return nil, 0, fmt.Errorf("сканирование полученных строк: %w", err)
+			return nil, 0, fmt.Errorf("сканирование полученных строк: %w", translateError(err))
 		}
 
 		revs = append(revs, tmp)
 	}
 
 	var numRecords int
-	err = r.db.QueryRow(
+	err = conn(ctx, r.db).QueryRow(
 		ctx,
 		`select count(*) from ppo.reviews where target_id = $1`,
 		id,
 	).Scan(&numRecords)
 	if err != nil {
-		return nil, 0, fmt.Errorf("получение количества отзывов объекта: %w", err)
+		return nil, 0, fmt.Errorf("получение количества отзывов объекта: %w", translateError(err))
 	}
 
-	numPages = numRecords / config.PageSize
-	if numRecords%config.PageSize != 0 {
+	numPages = numRecords / r.pageSize
+	if numRecords%r.pageSize != 0 {
 		numPages++
 	}
 
 	return revs, numPages, nil
 }
 
+// GetAllForTargets выбирает страницу отзывов о каждом из объектов одним запросом.
+// Отзывы внутри объекта упорядочены по id.
+func (r *ReviewRepository) GetAllForTargets(ctx context.Context, ids []uuid.UUID, page int) (pages map[uuid.UUID]*domain.ReviewPage, err error) {
+	query :=
+		`select
+			id,
+			reviewer_id,
+			target_id,
+			pros,
+			cons,
+			description,
+			rating
+		from (
+			select *, row_number() over (partition by target_id order by id) as num
+			from ppo.reviews
+			where target_id = any($1)
+		) r
+		where num > $2 and num <= $3
+		order by target_id, num`
+
+	rows, err := conn(ctx, r.db).Query(
+		ctx,
+		query,
+		ids,
+		(page-1)*r.pageSize,
+		page*r.pageSize,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("получение отзывов объектов: %w", translateError(err))
+	}
+	defer rows.Close()
+
+	pages = make(map[uuid.UUID]*domain.ReviewPage, len(ids))
+	for _, id := range ids {
+		pages[id] = &domain.ReviewPage{Reviews: make([]*domain.Review, 0)}
+	}
+
+	for rows.Next() {
+		tmp := new(domain.Review)
+
+		err = rows.Scan(
+			&tmp.ID,
+			&tmp.Reviewer,
+			&tmp.Target,
+			&tmp.Pros,
+			&tmp.Cons,
+			&tmp.Description,
+			&tmp.Rating,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("сканирование полученных строк: %w", translateError(err))
+		}
+
+		pages[tmp.Target].Reviews = append(pages[tmp.Target].Reviews, tmp)
+	}
+
+	rows, err = conn(ctx, r.db).Query(
+		ctx,
+		`select target_id, count(*) from ppo.reviews where target_id = any($1) group by target_id`,
+		ids,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("получение количества отзывов объектов: %w", translateError(err))
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id         uuid.UUID
+			numRecords int
+		)
+
+		err = rows.Scan(&id, &numRecords)
+		if err != nil {
+			return nil, fmt.Errorf("сканирование полученных строк: %w", translateError(err))
+		}
+
+		numPages := numRecords / r.pageSize
+		if numRecords%r.pageSize != 0 {
+			numPages++
+		}
+		pages[id].NumPages = numPages
+	}
+
+	return pages, nil
+}
+
 func (r *ReviewRepository) Delete(ctx context.Context, id uuid.UUID) (err error) {
 	query := `delete from ppo.reviews where id = $1`
 
-	_, err = r.db.Exec(
+	_, err = conn(ctx, r.db).Exec(
 		ctx,
 		query,
 		id,
 	)
 	if err != nil {
-		return fmt.Errorf("удаление отзыва по id: %w", err)
+		return fmt.Errorf("удаление отзыва по id: %w", translateError(err))
 	}
 
 	return nil
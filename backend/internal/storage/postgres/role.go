@@ -0,0 +1,258 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"ppo/domain"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type RoleRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewRoleRepository(db *pgxpool.Pool) domain.IRoleRepository {
+	return &RoleRepository{
+		db: db,
+	}
+}
+
+func (r *RoleRepository) Create(ctx context.Context, role *domain.Role) (err error) {
+	tx, err := conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("открытие транзакции: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			rollbackErr := tx.Rollback(ctx)
+			if rollbackErr != nil {
+				err = fmt.Errorf("обработанная ошибка: %w\nоткат транзакции: %v", err, rollbackErr)
+			}
+		}
+	}()
+
+	_, err = tx.Exec(
+		ctx,
+		`insert into ppo.roles(name, description) values ($1, $2)`,
+		role.Name,
+		role.Description,
+	)
+	if err != nil {
+		return fmt.Errorf("создание роли: %w", translateError(err))
+	}
+
+	err = insertRolePermissions(ctx, tx, role)
+	if err != nil {
+		return fmt.Errorf("создание роли: %w", translateError(err))
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("закрытие транзакции: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RoleRepository) GetByName(ctx context.Context, name string) (role *domain.Role, err error) {
+	role = new(domain.Role)
+	err = conn(ctx, r.db).QueryRow(
+		ctx,
+		`select name, description from ppo.roles where name = $1`,
+		name,
+	).Scan(
+		&role.Name,
+		&role.Description,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("получение роли по названию: %w", translateError(err))
+	}
+
+	role.Permissions, err = r.getPermissions(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("получение роли по названию: %w", translateError(err))
+	}
+
+	return role, nil
+}
+
+func (r *RoleRepository) GetAll(ctx context.Context) (roles []*domain.Role, err error) {
+	rows, err := conn(ctx, r.db).Query(
+		ctx,
+		`select name, description from ppo.roles order by name`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("получение списка ролей: %w", translateError(err))
+	}
+
+	roles = make([]*domain.Role, 0)
+	for rows.Next() {
+		tmp := new(domain.Role)
+
+		err = rows.Scan(
+			&tmp.Name,
+			&tmp.Description,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("сканирование полученных строк: %w", translateError(err))
+		}
+
+		roles = append(roles, tmp)
+	}
+
+	for _, role := range roles {
+		role.Permissions, err = r.getPermissions(ctx, role.Name)
+		if err != nil {
+			return nil, fmt.Errorf("получение списка ролей: %w", translateError(err))
+		}
+	}
+
+	return roles, nil
+}
+
+func (r *RoleRepository) Update(ctx context.Context, role *domain.Role) (err error) {
+	tx, err := conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("открытие транзакции: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			rollbackErr := tx.Rollback(ctx)
+			if rollbackErr != nil {
+				err = fmt.Errorf("обработанная ошибка: %w\nоткат транзакции: %v", err, rollbackErr)
+			}
+		}
+	}()
+
+	_, err = tx.Exec(
+		ctx,
+		`update ppo.roles set description = $1 where name = $2`,
+		role.Description,
+		role.Name,
+	)
+	if err != nil {
+		return fmt.Errorf("обновление информации о роли: %w", translateError(err))
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`delete from ppo.role_permissions where role_name = $1`,
+		role.Name,
+	)
+	if err != nil {
+		return fmt.Errorf("удаление разрешений роли: %w", translateError(err))
+	}
+
+	err = insertRolePermissions(ctx, tx, role)
+	if err != nil {
+		return fmt.Errorf("обновление информации о роли: %w", translateError(err))
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("закрытие транзакции: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RoleRepository) DeleteByName(ctx context.Context, name string) (err error) {
+	tx, err := conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("открытие транзакции: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			rollbackErr := tx.Rollback(ctx)
+			if rollbackErr != nil {
+				err = fmt.Errorf("обработанная ошибка: %w\nоткат транзакции: %v", err, rollbackErr)
+			}
+		}
+	}()
+
+	_, err = tx.Exec(
+		ctx,
+		`delete from ppo.role_permissions where role_name = $1`,
+		name,
+	)
+	if err != nil {
+		return fmt.Errorf("удаление разрешений роли: %w", translateError(err))
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`delete from ppo.roles where name = $1`,
+		name,
+	)
+	if err != nil {
+		return fmt.Errorf("удаление роли по названию: %w", translateError(err))
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("закрытие транзакции: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RoleRepository) SetUserRole(ctx context.Context, userId uuid.UUID, name string) (err error) {
+	_, err = conn(ctx, r.db).Exec(
+		ctx,
+		`update ppo.users set role = $1 where id = $2`,
+		name,
+		userId,
+	)
+	if err != nil {
+		return fmt.Errorf("назначение роли пользователю: %w", translateError(err))
+	}
+
+	return nil
+}
+
+func (r *RoleRepository) getPermissions(ctx context.Context, name string) (perms []domain.Permission, err error) {
+	rows, err := conn(ctx, r.db).Query(
+		ctx,
+		`select permission from ppo.role_permissions where role_name = $1 order by permission`,
+		name,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("получение разрешений роли: %w", translateError(err))
+	}
+
+	perms = make([]domain.Permission, 0)
+	for rows.Next() {
+		var perm string
+
+		err = rows.Scan(&perm)
+		if err != nil {
+			return nil, fmt.Errorf("сканирование полученных строк: %w", translateError(err))
+		}
+
+		perms = append(perms, domain.Permission(perm))
+	}
+
+	return perms, nil
+}
+
+func insertRolePermissions(ctx context.Context, tx pgx.Tx, role *domain.Role) (err error) {
+	for _, perm := range role.Permissions {
+		_, err = tx.Exec(
+			ctx,
+			`insert into ppo.role_permissions(role_name, permission) values ($1, $2)`,
+			role.Name,
+			string(perm),
+		)
+		if err != nil {
+			return fmt.Errorf("добавление разрешения %s: %w", perm, err)
+		}
+	}
+
+	return nil
+}
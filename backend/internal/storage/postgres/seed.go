@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"ppo/internal/seed"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type SeedRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewSeedRepository(db *pgxpool.Pool) *SeedRepository {
+	return &SeedRepository{
+		db: db,
+	}
+}
+
+// Insert загружает набор данных одной транзакцией через COPY. Справочники с уже
+// существующими названиями не дублируются: на них перенаправляются ссылки набора.
+// Всем пользователям набора назначается хэш пароля passwordHash.
+func (r *SeedRepository) Insert(ctx context.Context, data *seed.Dataset, passwordHash string) (err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("загрузка данных: открытие транзакции: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	fields, err := namedIds(ctx, tx, "select id, name from ppo.activity_fields")
+	if err != nil {
+		return fmt.Errorf("загрузка данных: %w", err)
+	}
+
+	skills, err := namedIds(ctx, tx, "select id, name from ppo.skills")
+	if err != nil {
+		return fmt.Errorf("загрузка данных: %w", err)
+	}
+
+	data.ReuseCatalog(fields, skills)
+
+	tables := []struct {
+		name    string
+		columns []string
+		rows    [][]any
+	}{
+		{name: "activity_fields", columns: []string{"id", "name", "description", "cost"}},
+		{name: "skills", columns: []string{"id", "name", "description"}},
+		{name: "users", columns: []string{"id", "username", "full_name", "birthday", "gender", "city", "password", "role"}},
+		{name: "companies", columns: []string{"id", "owner_id", "activity_field_id", "name", "city"}},
+		{name: "fin_reports", columns: []string{"id", "company_id", "revenue", "costs", "year", "quarter"}},
+		{name: "fin_report_revisions", columns: []string{"report_id", "version", "revenue", "costs"}},
+		{name: "user_skills", columns: []string{"user_id", "skill_id"}},
+		{name: "contacts", columns: []string{"id", "owner_id", "name", "value"}},
+		{name: "reviews", columns: []string{"id", "target_id", "reviewer_id", "pros", "cons", "description", "rating"}},
+	}
+	for _, f := range data.ActivityFields {
+		tables[0].rows = append(tables[0].rows, []any{f.ID, f.Name, f.Description, f.Cost})
+	}
+	for _, s := range data.Skills {
+		tables[1].rows = append(tables[1].rows, []any{s.ID, s.Name, s.Description})
+	}
+	for _, u := range data.Users {
+		tables[2].rows = append(tables[2].rows, []any{u.ID, u.Username, u.FullName, u.Birthday, u.Gender, u.City, passwordHash, u.Role})
+	}
+	for _, c := range data.Companies {
+		tables[3].rows = append(tables[3].rows, []any{c.ID, c.OwnerID, c.ActivityFieldId, c.Name, c.City})
+	}
+	for _, rep := range data.Reports {
+		tables[4].rows = append(tables[4].rows, []any{rep.ID, rep.CompanyID, rep.Revenue, rep.Costs, rep.Year, rep.Quarter})
+		tables[5].rows = append(tables[5].rows, []any{rep.ID, 1, rep.Revenue, rep.Costs})
+	}
+	for _, us := range data.UserSkills {
+		tables[6].rows = append(tables[6].rows, []any{us.UserId, us.SkillId})
+	}
+	for _, c := range data.Contacts {
+		tables[7].rows = append(tables[7].rows, []any{c.ID, c.OwnerID, c.Name, c.Value})
+	}
+	for _, rev := range data.Reviews {
+		tables[8].rows = append(tables[8].rows, []any{rev.ID, rev.Target, rev.Reviewer, rev.Pros, rev.Cons, rev.Description, rev.Rating})
+	}
+
+	for _, table := range tables {
+		_, err = tx.CopyFrom(ctx, pgx.Identifier{"ppo", table.name}, table.columns, pgx.CopyFromRows(table.rows))
+		if err != nil {
+			return fmt.Errorf("загрузка данных в %s: %w", table.name, translateError(err))
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("загрузка данных: закрытие транзакции: %w", err)
+	}
+
+	return nil
+}
+
+func namedIds(ctx context.Context, tx pgx.Tx, query string) (ids map[string]uuid.UUID, err error) {
+	rows, err := tx.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	ids = make(map[string]uuid.UUID)
+	var (
+		id   uuid.UUID
+		name string
+	)
+	_, err = pgx.ForEachRow(rows, []any{&id, &name}, func() error {
+		ids[name] = id
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
@@ -4,34 +4,36 @@ import (
 	"context"
 	"fmt"
 	"ppo/domain"
-	"ppo/internal/config"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type SkillRepository struct {
-	db *pgxpool.Pool
+	db       *pgxpool.Pool
+	pageSize int
 }
 
-func NewSkillRepository(db *pgxpool.Pool) domain.ISkillRepository {
+func NewSkillRepository(db *pgxpool.Pool, pageSize int) domain.ISkillRepository {
 	return &SkillRepository{
-		db: db,
+		db:       db,
+		pageSize: pageSize,
 	}
 }
 
 func (r *SkillRepository) Create(ctx context.Context, skill *domain.Skill) (err error) {
 	query := `insert into ppo.skills(name, description) 
-	values ($1, $2)`
+	values ($1, $2)
+	returning id`
 
-	_, err = r.db.Exec(
+	err = conn(ctx, r.db).QueryRow(
 		ctx,
 		query,
 		skill.Name,
 		skill.Description,
-	)
+	).Scan(&skill.ID)
 	if err != nil {
-		return fmt.Errorf("создание навыка: %w", err)
+		return fmt.Errorf("создание навыка: %w", translateError(err))
 	}
 
 	return nil
@@ -41,7 +43,7 @@ func (r *SkillRepository) GetById(ctx context.Context, id uuid.UUID) (skill *dom
 	query := `select name, description from ppo.skills where id = $1`
 
 	skill = new(domain.Skill)
-	err = r.db.QueryRow(
+	err = conn(ctx, r.db).QueryRow(
 		ctx,
 		query,
 		id,
@@ -50,24 +52,56 @@ func (r *SkillRepository) GetById(ctx context.Context, id uuid.UUID) (skill *dom
 		&skill.Description,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("получение навыка по id: %w", err)
+		return nil, fmt.Errorf("получение навыка по id: %w", translateError(err))
 	}
 
 	skill.ID = id
 	return skill, nil
 }
 
+func (r *SkillRepository) GetByIds(ctx context.Context, ids []uuid.UUID) (skills []*domain.Skill, err error) {
+	query := `select id, name, description from ppo.skills where id = any($1)`
+
+	rows, err := conn(ctx, r.db).Query(
+		ctx,
+		query,
+		ids,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("получение навыков по списку id: %w", translateError(err))
+	}
+	defer rows.Close()
+
+	skills = make([]*domain.Skill, 0)
+	for rows.Next() {
+		tmp := new(domain.Skill)
+
+		err = rows.Scan(
+			&tmp.ID,
+			&tmp.Name,
+			&tmp.Description,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("сканирование полученных строк: %w", translateError(err))
+		}
+
+		skills = append(skills, tmp)
+	}
+
+	return skills, nil
+}
+
 func (r *SkillRepository) GetAll(ctx context.Context, page int) (skills []*domain.Skill, numPages int, err error) {
 	query := `select id, name, description from ppo.skills offset $1 limit $2`
 
-	rows, err := r.db.Query(
+	rows, err := conn(ctx, r.db).Query(
 		ctx,
 		query,
-		(page-1)*config.PageSize,
-		config.PageSize,
+		(page-1)*r.pageSize,
+		r.pageSize,
 	)
 	if err != nil {
-		return nil, 0, fmt.Errorf("получение навыков: %w", err)
+		return nil, 0, fmt.Errorf("получение навыков: %w", translateError(err))
 	}
 
 	skills = make([]*domain.Skill, 0)
@@ -81,22 +115,22 @@ func (r *SkillRepository) GetAll(ctx context.Context, page int) (skills []*domai
 		)
 
 		if err != nil {
-			return nil, 0, fmt.Errorf("сканирование полученных строк: %w", err)
+			return nil, 0, fmt.Errorf("сканирование полученных строк: %w", translateError(err))
 		}
 		skills = append(skills, tmp)
 	}
 
 	var numRecords int
-	err = r.db.QueryRow(
+	err = conn(ctx, r.db).QueryRow(
 		ctx,
 		`select count(*) from ppo.skills`,
 	).Scan(&numRecords)
 	if err != nil {
-		return nil, 0, fmt.Errorf("получение количества навыков предпринимателя: %w", err)
+		return nil, 0, fmt.Errorf("получение количества навыков предпринимателя: %w", translateError(err))
 	}
 
-	numPages = numRecords / config.PageSize
-	if numRecords%config.PageSize != 0 {
+	numPages = numRecords / r.pageSize
+	if numRecords%r.pageSize != 0 {
 		numPages++
 	}
 
@@ -111,7 +145,7 @@ func (r *SkillRepository) Update(ctx context.Context, skill *domain.Skill) (err
 			    description = $2 
 			where id = $3`
 
-	_, err = r.db.Exec(
+	_, err = conn(ctx, r.db).Exec(
 		ctx,
 		query,
 		skill.Name,
@@ -119,14 +153,14 @@ func (r *SkillRepository) Update(ctx context.Context, skill *domain.Skill) (err
 		skill.ID,
 	)
 	if err != nil {
-		return fmt.Errorf("обновление информации о навыке: %w", err)
+		return fmt.Errorf("обновление информации о навыке: %w", translateError(err))
 	}
 
 	return nil
 }
 
 func (r *SkillRepository) DeleteById(ctx context.Context, id uuid.UUID) (err error) {
-	tx, err := r.db.Begin(ctx)
+	tx, err := conn(ctx, r.db).Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("открытие транзакции: %w", err)
 	}
@@ -137,7 +171,7 @@ func (r *SkillRepository) DeleteById(ctx context.Context, id uuid.UUID) (err err
 		id,
 	)
 	if err != nil {
-		return fmt.Errorf("удаление навыка по id: %w", err)
+		return fmt.Errorf("удаление навыка по id: %w", translateError(err))
 	}
 
 	_, err = tx.Exec(
@@ -146,7 +180,7 @@ func (r *SkillRepository) DeleteById(ctx context.Context, id uuid.UUID) (err err
 		id,
 	)
 	if err != nil {
-		return fmt.Errorf("удаление связанных с навыком записей: %w", err)
+		return fmt.Errorf("удаление связанных с навыком записей: %w", translateError(err))
 	}
 
 	err = tx.Commit(ctx)
@@ -2,16 +2,12 @@ package postgres
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
 	"time"
 
-	"github.com/golang-migrate/migrate/v4"
-	_ "github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/jackc/pgx/v5/pgxpool"
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/testcontainers/testcontainers-go"
@@ -22,6 +18,8 @@ const (
 	DbName = "postgres"
 	DbUser = "postgres"
 	DbPass = "postgres"
+
+	testPageSize = 3
 )
 
 type TestDatabase struct {
@@ -37,7 +35,7 @@ func SetupTestDatabase() *TestDatabase {
 		log.Fatal("failed to setup test: ", err)
 	}
 
-	err = migrateDb(dbAddr)
+	err = migrateDb(dbInstance)
 	if err != nil {
 		log.Fatal("failed to perform db migration: ", err)
 	}
@@ -95,28 +93,14 @@ func createContainer(ctx context.Context) (testcontainers.Container, *pgxpool.Po
 	return container, db, dbAddr, nil
 }
 
-func migrateDb(dbAddr string) error {
-	currentDir, err := os.Getwd()
-	if err != nil {
-		return fmt.Errorf("получение текущей директории: %w", err)
-	}
-
-	relativePathToMigrationFiles := "./migrations"
-	pathToMigrationFiles := filepath.Join(currentDir, "..", "..", "..", relativePathToMigrationFiles)
-
-	databaseURL := fmt.Sprintf("postgres://%s:%s@%s/%s?sslmode=disable", DbUser, DbPass, dbAddr, DbName)
-	m, err := migrate.New(fmt.Sprintf("file:%s", pathToMigrationFiles), databaseURL)
+func migrateDb(db *pgxpool.Pool) error {
+	m, err := NewMigrator(db)
 	if err != nil {
 		return err
 	}
 	defer m.Close()
 
-	err = m.Up()
-	if err != nil && !errors.Is(err, migrate.ErrNoChange) {
-		return err
-	}
-
-	return nil
+	return m.Up()
 }
 
 func SeedTestData(db *pgxpool.Pool) error {
@@ -4,19 +4,20 @@ import (
 	"context"
 	"fmt"
 	"ppo/domain"
-	"ppo/internal/config"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type UserRepository struct {
-	db *pgxpool.Pool
+	db       *pgxpool.Pool
+	pageSize int
 }
 
-func NewUserRepository(db *pgxpool.Pool) domain.IUserRepository {
+func NewUserRepository(db *pgxpool.Pool, pageSize int) domain.IUserRepository {
 	return &UserRepository{
-		db: db,
+		db:       db,
+		pageSize: pageSize,
 	}
 }
 
@@ -29,7 +30,7 @@ func (r *UserRepository) Create(ctx context.Context, user *domain.User) (err err
 		    city = $4
 		where id = $5`
 
-	_, err = r.db.Exec(
+	_, err = conn(ctx, r.db).Exec(
 		ctx,
 		query,
 		user.FullName,
@@ -39,7 +40,7 @@ func (r *UserRepository) Create(ctx context.Context, user *domain.User) (err err
 		user.ID,
 	)
 	if err != nil {
-		return fmt.Errorf("создание пользователя: %w", err)
+		return fmt.Errorf("создание пользователя: %w", translateError(err))
 	}
 
 	return nil
@@ -49,7 +50,7 @@ func (r *UserRepository) GetByUsername(ctx context.Context, username string) (us
 	query := `select id, username, full_name, birthday, gender, city, role from ppo.users where username = $1`
 
 	tmp := new(User)
-	err = r.db.QueryRow(
+	err = conn(ctx, r.db).QueryRow(
 		ctx,
 		query,
 		username,
@@ -63,7 +64,7 @@ func (r *UserRepository) GetByUsername(ctx context.Context, username string) (us
 		&tmp.Role,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("получение пользователя по username: %w", err)
+		return nil, fmt.Errorf("получение пользователя по username: %w", translateError(err))
 	}
 
 	return UserDbToUser(tmp), nil
@@ -73,7 +74,7 @@ func (r *UserRepository) GetById(ctx context.Context, userId uuid.UUID) (user *d
 	query := `select username, full_name, birthday, gender, city, role from ppo.users where id = $1`
 
 	tmp := new(User)
-	err = r.db.QueryRow(
+	err = conn(ctx, r.db).QueryRow(
 		ctx,
 		query,
 		userId,
@@ -86,13 +87,49 @@ func (r *UserRepository) GetById(ctx context.Context, userId uuid.UUID) (user *d
 		&tmp.Role,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("получение пользователя по id: %w", err)
+		return nil, fmt.Errorf("получение пользователя по id: %w", translateError(err))
 	}
 
 	tmp.ID = userId
 	return UserDbToUser(tmp), nil
 }
 
+func (r *UserRepository) GetByIds(ctx context.Context, ids []uuid.UUID) (users []*domain.User, err error) {
+	query := `select id, username, full_name, birthday, gender, city, role from ppo.users where id = any($1)`
+
+	rows, err := conn(ctx, r.db).Query(
+		ctx,
+		query,
+		ids,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("получение пользователей по списку id: %w", translateError(err))
+	}
+	defer rows.Close()
+
+	users = make([]*domain.User, 0, len(ids))
+	for rows.Next() {
+		tmp := new(User)
+
+		err = rows.Scan(
+			&tmp.ID,
+			&tmp.Username,
+			&tmp.FullName,
+			&tmp.Birthday,
+			&tmp.Gender,
+			&tmp.City,
+			&tmp.Role,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("сканирование полученных строк: %w", translateError(err))
+		}
+
+		users = append(users, UserDbToUser(tmp))
+	}
+
+	return users, nil
+}
+
 func (r *UserRepository) GetAll(ctx context.Context, page int) (users []*domain.User, numPages int, err error) {
 	query := `select 
     	id,
@@ -106,14 +143,14 @@ func (r *UserRepository) GetAll(ctx context.Context, page int) (users []*domain.
 	offset $1
 	limit $2`
 
-	rows, err := r.db.Query(
+	rows, err := conn(ctx, r.db).Query(
 		ctx,
 		query,
-		(page-1)*config.PageSize,
-		config.PageSize,
+		(page-1)*r.pageSize,
+		r.pageSize,
 	)
 	if err != nil {
-		return nil, 0, fmt.Errorf("получение предпринимателей: %w", err)
+		return nil, 0, fmt.Errorf("получение предпринимателей: %w", translateError(err))
 	}
 
 	users = make([]*domain.User, 0)
@@ -130,22 +167,22 @@ func (r *UserRepository) GetAll(ctx context.Context, page int) (users []*domain.
 		)
 
 		if err != nil {
-			return nil, 0, fmt.Errorf("сканирование полученных строк: %w", err)
+			return nil, 0, fmt.Errorf("сканирование полученных строк: %w", translateError(err))
 		}
 		users = append(users, UserDbToUser(tmp))
 	}
 
 	var numRecords int
-	err = r.db.QueryRow(
+	err = conn(ctx, r.db).QueryRow(
 		ctx,
 		`select count(*) from ppo.users`,
 	).Scan(&numRecords)
 	if err != nil {
-		return nil, 0, fmt.Errorf("получение количества предпринимателей: %w", err)
+		return nil, 0, fmt.Errorf("получение количества предпринимателей: %w", translateError(err))
 	}
 
-	numPages = numRecords / config.PageSize
-	if numRecords%config.PageSize != 0 {
+	numPages = numRecords / r.pageSize
+	if numRecords%r.pageSize != 0 {
 		numPages++
 	}
 
@@ -164,7 +201,7 @@ func (r *UserRepository) Update(ctx context.Context, user *domain.User) (err err
 			    username = $6
 			where id = $7`
 
-	_, err = r.db.Exec(
+	_, err = conn(ctx, r.db).Exec(
 		ctx,
 		query,
 		user.FullName,
@@ -176,7 +213,7 @@ func (r *UserRepository) Update(ctx context.Context, user *domain.User) (err err
 		user.ID,
 	)
 	if err != nil {
-		return fmt.Errorf("обновление информации о пользователе: %w", err)
+		return fmt.Errorf("обновление информации о пользователе: %w", translateError(err))
 	}
 
 	return nil
@@ -185,13 +222,13 @@ func (r *UserRepository) Update(ctx context.Context, user *domain.User) (err err
 func (r *UserRepository) DeleteById(ctx context.Context, id uuid.UUID) (err error) {
 	query := `delete from ppo.users where id = $1`
 
-	_, err = r.db.Exec(
+	_, err = conn(ctx, r.db).Exec(
 		ctx,
 		query,
 		id,
 	)
 	if err != nil {
-		return fmt.Errorf("удаление пользователя по id: %w", err)
+		return fmt.Errorf("удаление пользователя по id: %w", translateError(err))
 	}
 
 	return nil
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"ppo/domain"
-	"ppo/internal/config"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -12,12 +11,14 @@ import (
 )
 
 type UserSkillRepository struct {
-	db *pgxpool.Pool
+	db       *pgxpool.Pool
+	pageSize int
 }
 
-func NewUserSkillRepository(db *pgxpool.Pool) domain.IUserSkillRepository {
+func NewUserSkillRepository(db *pgxpool.Pool, pageSize int) domain.IUserSkillRepository {
 	return &UserSkillRepository{
-		db: db,
+		db:       db,
+		pageSize: pageSize,
 	}
 }
 
@@ -25,14 +26,14 @@ func (r *UserSkillRepository) Create(ctx context.Context, pair *domain.UserSkill
 	query := `insert into ppo.user_skills(user_id, skill_id) 
 	values ($1, $2)`
 
-	_, err = r.db.Exec(
+	_, err = conn(ctx, r.db).Exec(
 		ctx,
 		query,
 		pair.UserId,
 		pair.SkillId,
 	)
 	if err != nil {
-		return fmt.Errorf("создание cвязи пользователь-навык: %w", err)
+		return fmt.Errorf("создание cвязи пользователь-навык: %w", translateError(err))
 	}
 
 	return nil
@@ -41,14 +42,14 @@ func (r *UserSkillRepository) Create(ctx context.Context, pair *domain.UserSkill
 func (r *UserSkillRepository) Delete(ctx context.Context, pair *domain.UserSkill) (err error) {
 	query := `delete from ppo.user_skills where user_id = $1 and skill_id = $2`
 
-	_, err = r.db.Exec(
+	_, err = conn(ctx, r.db).Exec(
 		ctx,
 		query,
 		pair.UserId,
 		pair.SkillId,
 	)
 	if err != nil {
-		return fmt.Errorf("удаление пары пользователь-навык: %w", err)
+		return fmt.Errorf("удаление пары пользователь-навык: %w", translateError(err))
 	}
 
 	return nil
@@ -62,22 +63,22 @@ func (r *UserSkillRepository) GetUserSkillsByUserId(ctx context.Context, userId
 
 	var rows pgx.Rows
 	if !isPaginated {
-		rows, err = r.db.Query(
+		rows, err = conn(ctx, r.db).Query(
 			ctx,
 			query,
 			userId,
 		)
 	} else {
-		rows, err = r.db.Query(
+		rows, err = conn(ctx, r.db).Query(
 			ctx,
 			query+` offset $2 limit $3`,
 			userId,
-			(page-1)*config.PageSize,
-			config.PageSize,
+			(page-1)*r.pageSize,
+			r.pageSize,
 		)
 	}
 	if err != nil {
-		return nil, 0, fmt.Errorf("получение навыков пользователя: %w", err)
+		return nil, 0, fmt.Errorf("получение навыков пользователя: %w", translateError(err))
 	}
 
 	pairs = make([]*domain.UserSkill, 0)
@@ -88,7 +89,7 @@ func (r *UserSkillRepository) GetUserSkillsByUserId(ctx context.Context, userId
 			&tmp.SkillId,
 		)
 		if err != nil {
-			return nil, 0, fmt.Errorf("сканирование строки: %w", err)
+			return nil, 0, fmt.Errorf("сканирование строки: %w", translateError(err))
 		}
 
 		tmp.UserId = userId
@@ -96,23 +97,57 @@ func (r *UserSkillRepository) GetUserSkillsByUserId(ctx context.Context, userId
 	}
 
 	var numRecords int
-	err = r.db.QueryRow(
+	err = conn(ctx, r.db).QueryRow(
 		ctx,
 		`select count(*) from ppo.user_skills where user_id = $1`,
 		userId,
 	).Scan(&numRecords)
 	if err != nil {
-		return nil, 0, fmt.Errorf("получение количества навыков предпринимателя: %w", err)
+		return nil, 0, fmt.Errorf("получение количества навыков предпринимателя: %w", translateError(err))
 	}
 
-	numPages = numRecords / config.PageSize
-	if numRecords%config.PageSize != 0 {
+	numPages = numRecords / r.pageSize
+	if numRecords%r.pageSize != 0 {
 		numPages++
 	}
 
 	return pairs, numPages, nil
 }
 
+func (r *UserSkillRepository) GetUserSkillsByUserIds(ctx context.Context, userIds []uuid.UUID) (pairs []*domain.UserSkill, err error) {
+	query := `
+		select user_id, skill_id 
+		from ppo.user_skills 
+		where user_id = any($1)`
+
+	rows, err := conn(ctx, r.db).Query(
+		ctx,
+		query,
+		userIds,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("получение навыков пользователей: %w", translateError(err))
+	}
+	defer rows.Close()
+
+	pairs = make([]*domain.UserSkill, 0)
+	for rows.Next() {
+		tmp := new(domain.UserSkill)
+
+		err = rows.Scan(
+			&tmp.UserId,
+			&tmp.SkillId,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("сканирование строки: %w", translateError(err))
+		}
+
+		pairs = append(pairs, tmp)
+	}
+
+	return pairs, nil
+}
+
 func (r *UserSkillRepository) GetUserSkillsBySkillId(ctx context.Context, skillId uuid.UUID, page int) (pairs []*domain.UserSkill, err error) {
 	query := `
 		select user_id 
@@ -121,15 +156,15 @@ func (r *UserSkillRepository) GetUserSkillsBySkillId(ctx context.Context, skillI
 		offset $2
 		limit $3`
 
-	rows, err := r.db.Query(
+	rows, err := conn(ctx, r.db).Query(
 		ctx,
 		query,
 		skillId,
-		(page-1)*config.PageSize,
-		config.PageSize,
+		(page-1)*r.pageSize,
+		r.pageSize,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("получение пользователей по навыку: %w", err)
+		return nil, fmt.Errorf("получение пользователей по навыку: %w", translateError(err))
 	}
 
 	pairs = make([]*domain.UserSkill, 0)
@@ -140,7 +175,7 @@ func (r *UserSkillRepository) GetUserSkillsBySkillId(ctx context.Context, skillI
 			&tmp.UserId,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("сканирование строки: %w", err)
+			return nil, fmt.Errorf("сканирование строки: %w", translateError(err))
 		}
 
 		tmp.SkillId = skillId
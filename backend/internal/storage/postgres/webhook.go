@@ -0,0 +1,373 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"ppo/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type WebhookRepository struct {
+	db       *pgxpool.Pool
+	pageSize int
+}
+
+func NewWebhookRepository(db *pgxpool.Pool, pageSize int) domain.IWebhookRepository {
+	return &WebhookRepository{
+		db:       db,
+		pageSize: pageSize,
+	}
+}
+
+func (r *WebhookRepository) CreateSubscription(ctx context.Context, sub *domain.WebhookSubscription) (err error) {
+	query := `insert into ppo.webhook_subscriptions(url, secret, event_types)
+	values ($1, $2, $3)
+	returning id, created_at`
+
+	err = conn(ctx, r.db).QueryRow(
+		ctx,
+		query,
+		sub.URL,
+		sub.Secret,
+		eventTypesToStrings(sub.EventTypes),
+	).Scan(&sub.ID, &sub.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("создание подписки на вебхук: %w", translateError(err))
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) GetSubscriptionById(ctx context.Context, id uuid.UUID) (sub *domain.WebhookSubscription, err error) {
+	query := `select url, secret, event_types, created_at from ppo.webhook_subscriptions where id = $1`
+
+	sub = new(domain.WebhookSubscription)
+	var eventTypes []string
+	err = conn(ctx, r.db).QueryRow(
+		ctx,
+		query,
+		id,
+	).Scan(
+		&sub.URL,
+		&sub.Secret,
+		&eventTypes,
+		&sub.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("получение подписки на вебхук по id: %w", translateError(err))
+	}
+
+	sub.ID = id
+	sub.EventTypes = stringsToEventTypes(eventTypes)
+	return sub, nil
+}
+
+func (r *WebhookRepository) GetSubscriptions(ctx context.Context) (subs []*domain.WebhookSubscription, err error) {
+	query := `select id, url, secret, event_types, created_at from ppo.webhook_subscriptions order by created_at`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("получение списка подписок на вебхуки: %w", translateError(err))
+	}
+	defer rows.Close()
+
+	subs = make([]*domain.WebhookSubscription, 0)
+	for rows.Next() {
+		tmp := new(domain.WebhookSubscription)
+		var eventTypes []string
+
+		err = rows.Scan(
+			&tmp.ID,
+			&tmp.URL,
+			&tmp.Secret,
+			&eventTypes,
+			&tmp.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("сканирование полученных строк: %w", translateError(err))
+		}
+
+		tmp.EventTypes = stringsToEventTypes(eventTypes)
+		subs = append(subs, tmp)
+	}
+
+	return subs, nil
+}
+
+func (r *WebhookRepository) DeleteSubscription(ctx context.Context, id uuid.UUID) (err error) {
+	_, err = conn(ctx, r.db).Exec(
+		ctx,
+		`delete from ppo.webhook_subscriptions where id = $1`,
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("удаление подписки на вебхук: %w", translateError(err))
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) GetDeliveries(ctx context.Context, subscriptionId uuid.UUID, page int) (deliveries []*domain.WebhookDelivery, numPages int, err error) {
+	query :=
+		`select
+			d.id,
+			d.event_id,
+			e.event_type,
+			e.payload,
+			e.created_at,
+			d.status,
+			d.attempts,
+			d.next_attempt_at,
+			coalesce(d.last_status_code, 0),
+			coalesce(d.last_error, ''),
+			d.created_at
+		from ppo.webhook_deliveries d
+		join ppo.outbox e on e.id = d.event_id
+		where d.subscription_id = $1
+		order by d.created_at desc
+		offset $2 limit $3`
+
+	rows, err := conn(ctx, r.db).Query(
+		ctx,
+		query,
+		subscriptionId,
+		(page-1)*r.pageSize,
+		r.pageSize,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("получение журнала доставок вебхука: %w", translateError(err))
+	}
+	defer rows.Close()
+
+	deliveries = make([]*domain.WebhookDelivery, 0)
+	for rows.Next() {
+		tmp := &domain.WebhookDelivery{SubscriptionID: subscriptionId}
+
+		err = rows.Scan(
+			&tmp.ID,
+			&tmp.EventID,
+			&tmp.EventType,
+			&tmp.Payload,
+			&tmp.EventCreatedAt,
+			&tmp.Status,
+			&tmp.Attempts,
+			&tmp.NextAttemptAt,
+			&tmp.LastStatusCode,
+			&tmp.LastError,
+			&tmp.CreatedAt,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("сканирование полученных строк: %w", translateError(err))
+		}
+
+		deliveries = append(deliveries, tmp)
+	}
+
+	var numRecords int
+	err = conn(ctx, r.db).QueryRow(
+		ctx,
+		`select count(*) from ppo.webhook_deliveries where subscription_id = $1`,
+		subscriptionId,
+	).Scan(&numRecords)
+	if err != nil {
+		return nil, 0, fmt.Errorf("получение количества доставок вебхука: %w", translateError(err))
+	}
+
+	numPages = numRecords / r.pageSize
+	if numRecords%r.pageSize != 0 {
+		numPages++
+	}
+
+	return deliveries, numPages, nil
+}
+
+// EnqueueDeliveries создаёт доставки события для всех подписок на его тип
+// и возвращает их количество. Вызывается в транзакции обработки события,
+// поэтому доставки появляются вместе с отметкой об обработке.
+func (r *WebhookRepository) EnqueueDeliveries(ctx context.Context, event *domain.OutboxEvent) (numDeliveries int, err error) {
+	query :=
+		`insert into ppo.webhook_deliveries(subscription_id, event_id)
+		select id, $1
+		from ppo.webhook_subscriptions
+		where $2 = any(event_types)`
+
+	tag, err := conn(ctx, r.db).Exec(ctx, query, event.ID, event.Type)
+	if err != nil {
+		return 0, fmt.Errorf("постановка события в очередь доставки: %w", translateError(err))
+	}
+
+	return int(tag.RowsAffected()), nil
+}
+
+// ClaimDueDeliveries выбирает до limit доставок, время попытки которых наступило к now,
+// и откладывает их следующую попытку на lease, чтобы другие экземпляры не отправили их повторно.
+func (r *WebhookRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) (deliveries []*domain.WebhookDelivery, err error) {
+	query :=
+		`with due as (
+			select id
+			from ppo.webhook_deliveries
+			where status = 'pending' and next_attempt_at <= $1
+			order by next_attempt_at
+			limit $3
+			for update skip locked
+		)
+		update ppo.webhook_deliveries d
+		set next_attempt_at = $2
+		from due, ppo.webhook_subscriptions s, ppo.outbox e
+		where d.id = due.id and s.id = d.subscription_id and e.id = d.event_id
+		returning
+			d.id,
+			d.subscription_id,
+			d.event_id,
+			e.event_type,
+			e.payload,
+			e.created_at,
+			s.url,
+			s.secret,
+			d.status,
+			d.attempts,
+			d.created_at`
+
+	rows, err := conn(ctx, r.db).Query(
+		ctx,
+		query,
+		now,
+		now.Add(lease),
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("выбор доставок для отправки: %w", translateError(err))
+	}
+	defer rows.Close()
+
+	deliveries = make([]*domain.WebhookDelivery, 0)
+	for rows.Next() {
+		tmp := new(domain.WebhookDelivery)
+
+		err = rows.Scan(
+			&tmp.ID,
+			&tmp.SubscriptionID,
+			&tmp.EventID,
+			&tmp.EventType,
+			&tmp.Payload,
+			&tmp.EventCreatedAt,
+			&tmp.URL,
+			&tmp.Secret,
+			&tmp.Status,
+			&tmp.Attempts,
+			&tmp.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("сканирование полученных строк: %w", translateError(err))
+		}
+
+		deliveries = append(deliveries, tmp)
+	}
+
+	return deliveries, nil
+}
+
+// SaveAttempt записывает попытку в журнал и сохраняет новое состояние доставки.
+func (r *WebhookRepository) SaveAttempt(ctx context.Context, delivery *domain.WebhookDelivery, attempt *domain.WebhookDeliveryAttempt) (err error) {
+	tx, err := conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("открытие транзакции: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			rollbackErr := tx.Rollback(ctx)
+			if rollbackErr != nil {
+				err = fmt.Errorf("обработанная ошибка: %w\nоткат транзакции: %v", err, rollbackErr)
+			}
+		}
+	}()
+
+	_, err = tx.Exec(
+		ctx,
+		`insert into ppo.webhook_delivery_attempts(delivery_id, attempt, status_code, error, duration_ms)
+		values ($1, $2, nullif($3, 0), nullif($4, ''), $5)`,
+		attempt.DeliveryID,
+		attempt.Attempt,
+		attempt.StatusCode,
+		attempt.Error,
+		attempt.Duration.Milliseconds(),
+	)
+	if err != nil {
+		return fmt.Errorf("запись попытки доставки в журнал: %w", translateError(err))
+	}
+
+	err = updateDelivery(ctx, tx, delivery)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("закрытие транзакции: %w", err)
+	}
+
+	return nil
+}
+
+func updateDelivery(ctx context.Context, tx pgx.Tx, delivery *domain.WebhookDelivery) error {
+	_, err := tx.Exec(
+		ctx,
+		`update ppo.webhook_deliveries
+		set
+			status = $1,
+			attempts = $2,
+			next_attempt_at = $3,
+			last_status_code = nullif($4, 0),
+			last_error = nullif($5, '')
+		where id = $6`,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.NextAttemptAt,
+		delivery.LastStatusCode,
+		delivery.LastError,
+		delivery.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("обновление состояния доставки: %w", translateError(err))
+	}
+
+	return nil
+}
+
+func eventTypesToStrings(eventTypes []domain.EventType) []string {
+	res := make([]string, len(eventTypes))
+	for i, t := range eventTypes {
+		res[i] = string(t)
+	}
+
+	return res
+}
+
+func stringsToEventTypes(vals []string) []domain.EventType {
+	res := make([]domain.EventType, len(vals))
+	for i, val := range vals {
+		res[i] = domain.EventType(val)
+	}
+
+	return res
+}
+
+// DeleteFinishedDeliveriesBefore удаляет доставленные и окончательно проваленные доставки,
+// созданные раньше before, вместе с историей их попыток.
+func (r *WebhookRepository) DeleteFinishedDeliveriesBefore(ctx context.Context, before time.Time) (numDeleted int, err error) {
+	tag, err := conn(ctx, r.db).Exec(
+		ctx,
+		`delete from ppo.webhook_deliveries where created_at < $1 and status <> 'pending'`,
+		before,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("удаление завершённых доставок: %w", translateError(err))
+	}
+
+	return int(tag.RowsAffected()), nil
+}
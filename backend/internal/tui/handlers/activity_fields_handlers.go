@@ -1,162 +1,78 @@
 package handlers
 
 import (
-	"bufio"
-	"context"
 	"fmt"
-	"github.com/google/uuid"
-	"os"
 	"ppo/domain"
 	"ppo/internal/app"
 	"ppo/internal/tui/utils"
-	"strconv"
-	"strings"
 )
 
-func AddActivityField(a *app.App, args ...any) (err error) {
-	ctx := context.Background()
-	reader := bufio.NewReader(os.Stdin)
+func activityFieldForm(s *utils.Screen, field *domain.ActivityField, save func(*domain.ActivityField) error) *utils.Form {
+	form := utils.NewForm(s).
+		Input("name", "Название", field.Name).
+		Input("description", "Описание", field.Description).
+		Number("cost", "Вес", formatFloat(field.Cost))
 
-	var name, description string
-	var cost float32
-	fmt.Printf("Введите название cферы деятельности: ")
-	_, err = fmt.Scanf("%s", &name)
-	if err != nil {
-		return fmt.Errorf("ошибка ввода названия сферы деятельности: %w", err)
-	}
-
-	fmt.Printf("Введите описание: ")
-	description, err = reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("ошибка ввода описания: %w", err)
-	}
-	description = strings.TrimSpace(description)
-
-	fmt.Printf("Введите вес сферы деятельности: ")
-	_, err = fmt.Scanf("%f", &cost)
-	if err != nil {
-		return fmt.Errorf("ошибка ввода веса сферы деятельности: %w", err)
-	}
-
-	var field domain.ActivityField
-	field.Name = name
-	field.Description = description
-	field.Cost = cost
+	return form.OnSubmit(func() (err error) {
+		cost, err := form.Float("cost")
+		if err != nil {
+			return err
+		}
 
-	err = a.ActFieldSvc.Create(ctx, &field)
-	if err != nil {
-		return fmt.Errorf("ошибка добавления сферы деятельности: %w", err)
-	}
+		updated := *field
+		updated.Name = form.Text("name")
+		updated.Description = form.Text("description")
+		updated.Cost = cost
 
-	return nil
+		return save(&updated)
+	})
 }
 
-func DeleteActivityField(a *app.App, args ...any) (err error) {
-	ctx := context.Background()
-	reader := bufio.NewReader(os.Stdin)
-
-	err = utils.PrintPaginatedCollection("Сферы деятельности", a.ActFieldSvc.GetAll, ctx)
-	if err != nil {
-		return fmt.Errorf("вывод сфер деятельности с пагинацией: %w", err)
-	}
-
-	fmt.Printf("Введите id сферы деятельности: ")
-	activityFieldId, err := reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("ошибка ввода сферы деятельности: %w", err)
-	}
-	activityFieldId = strings.TrimSpace(activityFieldId)
-
-	activityFieldUuid, err := uuid.Parse(activityFieldId)
-	if err != nil {
-		return fmt.Errorf("парсинг uuid из строки: %w", err)
-	}
+func AddActivityField(s *utils.Screen, a *app.App, actor *domain.Actor) (err error) {
+	activityFieldForm(s, &domain.ActivityField{}, func(field *domain.ActivityField) error {
+		err := a.ActFieldSvc.Create(actorContext(actor), field)
+		if err != nil {
+			return fmt.Errorf("ошибка добавления сферы деятельности: %w", err)
+		}
 
-	err = a.ActFieldSvc.DeleteById(ctx, activityFieldUuid)
-	if err != nil {
-		return fmt.Errorf("удаление сферы деятельности: %w", err)
-	}
+		return nil
+	}).Show("Новая сфера деятельности")
 
 	return nil
 }
 
-func UpdateActivityField(a *app.App, args ...any) (err error) {
-	ctx := context.Background()
-	reader := bufio.NewReader(os.Stdin)
+func DeleteActivityField(s *utils.Screen, a *app.App, actor *domain.Actor) (err error) {
+	table := utils.NewTable(s, activityFieldColumns(), allActivityFields(a))
+	table.OnSelect(func(field *domain.ActivityField) {
+		table.Confirm(fmt.Sprintf("Удалить сферу деятельности «%s»?", field.Name), func() (err error) {
+			err = a.ActFieldSvc.DeleteById(actorContext(actor), field.ID)
+			if err != nil {
+				return fmt.Errorf("удаление сферы деятельности: %w", err)
+			}
 
-	err = utils.PrintPaginatedCollection("Сферы деятельности", a.ActFieldSvc.GetAll, ctx)
-	if err != nil {
-		return fmt.Errorf("вывод сфер деятельности с пагинацией: %w", err)
-	}
+			return nil
+		})
+	})
 
-	fmt.Printf("Введите id сферы деятельности: ")
-	activityFieldId, err := reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("ошибка ввода сферы деятельности: %w", err)
-	}
-	activityFieldId = strings.TrimSpace(activityFieldId)
-
-	activityFieldUuid, err := uuid.Parse(activityFieldId)
-	if err != nil {
-		return fmt.Errorf("парсинг uuid из строки: %w", err)
-	}
-
-	field, err := a.ActFieldSvc.GetById(ctx, activityFieldUuid)
-	if err != nil {
-		return fmt.Errorf("получение сферы деятельности по id: %w", err)
-	}
-
-	var name, description, costStr string
-	var cost float64
-	fmt.Printf("Введите название cферы деятельности (%s): ", field.Name)
-	name, err = reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("ошибка ввода названия сферы деятельности: %w", err)
-	}
-	name = strings.TrimSpace(name)
-	if name != "" {
-		field.Name = name
-	}
-
-	fmt.Printf("Введите описание (%s): ", field.Description)
-	description, err = reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("ошибка ввода описания: %w", err)
-	}
-	description = strings.TrimSpace(description)
-	if description != "" {
-		field.Description = description
-	}
-
-	fmt.Printf("Введите вес сферы деятельности (%f): ", field.Cost)
-	costStr, err = reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("ошибка ввода веса сферы деятельности: %w", err)
-	}
-	costStr = strings.TrimSpace(costStr)
-	if costStr != "" {
-		cost, err = strconv.ParseFloat(costStr, 32)
-		if err != nil {
-			return fmt.Errorf("ошибка конвертации во float: %w", err)
-		}
+	return table.Show("Удаление сферы деятельности")
+}
 
-		field.Cost = float32(cost)
-	}
+func UpdateActivityField(s *utils.Screen, a *app.App, actor *domain.Actor) (err error) {
+	table := utils.NewTable(s, activityFieldColumns(), allActivityFields(a))
+	table.OnSelect(func(field *domain.ActivityField) {
+		activityFieldForm(s, field, func(field *domain.ActivityField) error {
+			err := a.ActFieldSvc.Update(actorContext(actor), field)
+			if err != nil {
+				return fmt.Errorf("ошибка обновления сферы деятельности: %w", err)
+			}
 
-	err = a.ActFieldSvc.Update(ctx, field)
-	if err != nil {
-		return fmt.Errorf("ошибка обновления сферы деятельности: %w", err)
-	}
+			return nil
+		}).OnDone(table.Refresh).Show("Сфера деятельности " + field.Name)
+	})
 
-	return nil
+	return table.Show("Обновление сферы деятельности")
 }
 
-func GetActivityFields(a *app.App, args ...any) (err error) {
-	ctx := context.Background()
-	err = utils.PrintPaginatedCollection("Сферы деятельности", a.ActFieldSvc.GetAll, ctx)
-	if err != nil {
-		return fmt.Errorf("вывод сфер деятельности с пагинацией: %w", err)
-	}
-
-	return nil
+func GetActivityFields(s *utils.Screen, a *app.App, actor *domain.Actor) (err error) {
+	return utils.NewTable(s, activityFieldColumns(), allActivityFields(a)).Show("Сферы деятельности")
 }
@@ -1,194 +1,81 @@
 package handlers
 
 import (
-	"bufio"
-	"context"
 	"fmt"
-	"os"
+	"ppo/domain"
 	"ppo/internal/app"
-	"strings"
-	"time"
+	"ppo/internal/tui/utils"
 )
 
-func UpdateUser(a *app.App, args ...any) (err error) {
-	ctx := context.Background()
-	reader := bufio.NewReader(os.Stdin)
+var genders = []string{"m", "w"}
 
-	var username, fullName, gender, birthdayStr, city, role string
-	fmt.Printf("Введите имя пользователя: ")
-	_, err = fmt.Scanf("%s", &username)
-	if err != nil {
-		return fmt.Errorf("ошибка ввода имени пользователя: %w", err)
-	}
-
-	user, err := a.UserSvc.GetByUsername(ctx, username)
-	if err != nil {
-		return fmt.Errorf("пользователь не найден")
-	}
-
-	fmt.Printf("Введите полное имя (%s): ", user.FullName)
-	fullName, _ = reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("ошибка ввода полного имени: %w", err)
-	}
-	fullName = strings.TrimSpace(fullName)
-	if fullName != "" {
-		user.FullName = fullName
-	}
-
-	fmt.Printf("Введите дату рождения в формате ГГГГ-ММ-ДД (%s): ", user.Birthday.Format("2006-01-02"))
-	birthdayStr, _ = reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("ошибка ввода даты рождения")
-	}
-	birthdayStr = strings.TrimSpace(birthdayStr)
-	if birthdayStr != "" {
-		bday, err := time.Parse("2006-01-02", birthdayStr)
-		if err != nil {
-			return fmt.Errorf("ошибка перевода даты рождения в time.Time: %w", err)
-		}
-		user.Birthday = bday
-	}
-
-	fmt.Printf("Введите пол (%s): ", user.Gender)
-	gender, _ = reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("ошибка ввода пола: %w", err)
-	}
-	gender = strings.TrimSpace(gender)
-	if gender != "" {
-		user.Gender = gender
-	}
-
-	fmt.Printf("Введите город (%s): ", user.City)
-	city, _ = reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("ошибка ввода города: %w", err)
-	}
-	city = strings.TrimSpace(city)
-	if city != "" {
-		user.City = city
-	}
-
-	fmt.Printf("Введите роль (%s): ", user.Role)
-	role, _ = reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("ошибка ввода роли: %w", err)
-	}
-	role = strings.TrimSpace(role)
-	if role != "" {
-		user.Role = role
-	}
-
-	err = a.UserSvc.Update(ctx, user)
-	if err != nil {
-		return fmt.Errorf("ошибка обновления карточки предпринимателя: %w", err)
-	}
-
-	return nil
+func UpdateUser(s *utils.Screen, a *app.App, actor *domain.Actor) (err error) {
+	return pickUser(s, a, func(user *domain.User) {
+		userForm(s, a, actor, user, "Карточка предпринимателя "+user.Username)
+	})
 }
 
-func CreateUser(a *app.App, args ...any) (err error) {
-	ctx := context.Background()
-	reader := bufio.NewReader(os.Stdin)
-
-	var username, fullName, gender, birthdayStr, city, role string
-	fmt.Printf("Введите имя пользователя: ")
-	_, err = fmt.Scanf("%s", &username)
-	if err != nil {
-		return fmt.Errorf("ошибка ввода имени пользователя: %w", err)
-	}
+// CreateUser заполняет карточку зарегистрированного пользователя, у которого её ещё нет.
+func CreateUser(s *utils.Screen, a *app.App, actor *domain.Actor) (err error) {
+	return pickUser(s, a, func(user *domain.User) {
+		card := &domain.User{ID: user.ID, Username: user.Username, Role: user.Role}
+		userForm(s, a, actor, card, "Новая карточка предпринимателя "+user.Username)
+	})
+}
 
-	user, err := a.UserSvc.GetByUsername(ctx, username)
-	if err != nil {
-		return fmt.Errorf("пользователь не найден: %w", err)
+func userForm(s *utils.Screen, a *app.App, actor *domain.Actor, user *domain.User, title string) {
+	gender := 0
+	for i, g := range genders {
+		if g == user.Gender {
+			gender = i
+		}
 	}
 
-	fmt.Printf("Введите полное имя: ")
-	fullName, _ = reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("ошибка ввода полного имени: %w", err)
-	}
-	fullName = strings.TrimSpace(fullName)
+	form := utils.NewForm(s).
+		Input("full_name", "ФИО", user.FullName).
+		Input("birthday", "Дата рождения (ГГГГ-ММ-ДД)", utils.FormatDate(user.Birthday)).
+		Select("gender", "Пол", genders, gender).
+		Input("city", "Город", user.City).
+		Picker("role", "Роль", user.Role, user.Role, pickRole(s, a))
 
-	fmt.Printf("Введите дату рождения в формате ГГГГ-ММ-ДД: ")
-	birthdayStr, _ = reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("ошибка ввода даты рождения")
-	}
-	birthdayStr = strings.TrimSpace(birthdayStr)
-	var bday time.Time
-	if birthdayStr != "" {
-		bday, err = time.Parse("2006-01-02", birthdayStr)
+	form.OnSubmit(func() (err error) {
+		birthday, err := form.Date("birthday")
 		if err != nil {
-			return fmt.Errorf("ошибка перевода даты рождения в time.Time: %w", err)
+			return err
 		}
-	}
 
-	fmt.Printf("Введите пол: ")
-	gender, _ = reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("ошибка ввода пола: %w", err)
-	}
-	gender = strings.TrimSpace(gender)
-
-	fmt.Printf("Введите город: ")
-	city, _ = reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("ошибка ввода города: %w", err)
-	}
-	city = strings.TrimSpace(city)
+		updated := *user
+		updated.FullName = form.Text("full_name")
+		updated.Birthday = birthday
+		_, updated.Gender = form.Option("gender")
+		updated.City = form.Text("city")
+		updated.Role, _ = form.Value("role").(string)
 
-	fmt.Printf("Введите роль: ")
-	role, _ = reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("ошибка ввода роли: %w", err)
-	}
-	role = strings.TrimSpace(role)
-
-	user.Gender = gender
-	user.City = city
-	user.Birthday = bday
-	user.FullName = fullName
-	user.Role = role
+		err = a.UserSvc.Update(actorContext(actor), &updated)
+		if err != nil {
+			return fmt.Errorf("ошибка обновления карточки предпринимателя: %w", err)
+		}
 
-	err = a.UserSvc.Update(ctx, user)
-	if err != nil {
-		return fmt.Errorf("ошибка добавления информации в карточку предпринимателя: %w", err)
-	}
+		return nil
+	})
 
-	return nil
+	form.Show(title)
 }
 
-func ChangeUserRole(a *app.App, args ...any) (err error) {
-	ctx := context.Background()
-	reader := bufio.NewReader(os.Stdin)
-
-	var username, role string
-	fmt.Printf("Введите имя пользователя: ")
-	_, err = fmt.Scanf("%s", &username)
-	if err != nil {
-		return fmt.Errorf("ошибка ввода имени пользователя: %w", err)
-	}
-
-	user, err := a.UserSvc.GetByUsername(ctx, username)
-	if err != nil {
-		return fmt.Errorf("пользователь не найден: %w", err)
-	}
-
-	fmt.Printf("Введите роль: ")
-	role, _ = reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("ошибка ввода роли: %w", err)
-	}
-	role = strings.TrimSpace(role)
-
-	user.Role = role
-
-	err = a.UserSvc.Update(ctx, user)
-	if err != nil {
-		return fmt.Errorf("изменение роли пользователя: %w", err)
-	}
+func ChangeUserRole(s *utils.Screen, a *app.App, actor *domain.Actor) (err error) {
+	return pickUser(s, a, func(user *domain.User) {
+		pickRole(s, a)(func(role string, _ any) {
+			err := a.RoleSvc.AssignToUser(actorContext(actor), user.ID, role)
+			if err != nil {
+				s.Error(fmt.Errorf("изменение роли пользователя: %w", err))
+				return
+			}
+
+			s.Info(fmt.Sprintf("Пользователю %s назначена роль %s", user.Username, role))
+		})
+	})
+}
 
-	return nil
+func GetRoles(s *utils.Screen, a *app.App, actor *domain.Actor) (err error) {
+	return utils.NewTable(s, roleColumns(), utils.All(a.RoleSvc.GetAll)).Show("Роли")
 }
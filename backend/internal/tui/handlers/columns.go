@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"ppo/domain"
+	"ppo/internal/app"
+	"ppo/internal/tui/utils"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// actorContext — контекст вызова сервисов от имени вошедшего пользователя:
+// сделанные изменения записываются в журнал аудита с его id.
+func actorContext(actor *domain.Actor) context.Context {
+	return domain.WithActor(context.Background(), actor)
+}
+
+func formatFloat(v float32) string {
+	return strconv.FormatFloat(float64(v), 'f', -1, 32)
+}
+
+func userColumns() []utils.Column[domain.User] {
+	return []utils.Column[domain.User]{
+		{Title: "Логин", Value: func(u *domain.User) string { return u.Username }},
+		{Title: "ФИО", Value: func(u *domain.User) string { return u.FullName }},
+		{Title: "Город", Value: func(u *domain.User) string { return u.City }},
+		{Title: "Пол", Value: func(u *domain.User) string { return u.Gender }},
+		{
+			Title: "Дата рождения",
+			Value: func(u *domain.User) string { return utils.FormatDate(u.Birthday) },
+			Less:  func(a, b *domain.User) bool { return a.Birthday.Before(b.Birthday) },
+		},
+		{Title: "Роль", Value: func(u *domain.User) string { return u.Role }},
+	}
+}
+
+func skillColumns() []utils.Column[domain.Skill] {
+	return []utils.Column[domain.Skill]{
+		{Title: "Название", Value: func(s *domain.Skill) string { return s.Name }},
+		{Title: "Описание", Value: func(s *domain.Skill) string { return s.Description }},
+	}
+}
+
+func activityFieldColumns() []utils.Column[domain.ActivityField] {
+	return []utils.Column[domain.ActivityField]{
+		{Title: "Название", Value: func(f *domain.ActivityField) string { return f.Name }},
+		{Title: "Описание", Value: func(f *domain.ActivityField) string { return f.Description }},
+		{
+			Title: "Вес",
+			Value: func(f *domain.ActivityField) string { return fmt.Sprintf("%.2f", f.Cost) },
+			Less:  func(a, b *domain.ActivityField) bool { return a.Cost < b.Cost },
+		},
+	}
+}
+
+// companyColumns выводит вместо идентификатора сферы деятельности её название.
+func companyColumns(a *app.App) []utils.Column[domain.Company] {
+	return []utils.Column[domain.Company]{
+		{Title: "Название", Value: func(c *domain.Company) string { return c.Name }},
+		{Title: "Город", Value: func(c *domain.Company) string { return c.City }},
+		{Title: "Сфера деятельности", Value: func(c *domain.Company) string { return activityFieldName(a, c.ActivityFieldId) }},
+	}
+}
+
+func contactColumns() []utils.Column[domain.Contact] {
+	return []utils.Column[domain.Contact]{
+		{Title: "Средство связи", Value: func(c *domain.Contact) string { return c.Name }},
+		{Title: "Значение", Value: func(c *domain.Contact) string { return c.Value }},
+	}
+}
+
+func roleColumns() []utils.Column[domain.Role] {
+	return []utils.Column[domain.Role]{
+		{Title: "Название", Value: func(r *domain.Role) string { return r.Name }},
+		{Title: "Описание", Value: func(r *domain.Role) string { return r.Description }},
+		{
+			Title: "Разрешения",
+			Value: func(r *domain.Role) string {
+				perms := make([]string, len(r.Permissions))
+				for i, perm := range r.Permissions {
+					perms[i] = string(perm)
+				}
+
+				return strings.Join(perms, ", ")
+			},
+		},
+	}
+}
+
+func reportColumns() []utils.Column[domain.FinancialReport] {
+	return []utils.Column[domain.FinancialReport]{
+		{
+			Title: "Период",
+			Value: func(r *domain.FinancialReport) string { return fmt.Sprintf("%d Q%d", r.Year, r.Quarter) },
+			Less: func(a, b *domain.FinancialReport) bool {
+				return a.Year < b.Year || a.Year == b.Year && a.Quarter < b.Quarter
+			},
+		},
+		{
+			Title: "Выручка",
+			Value: func(r *domain.FinancialReport) string { return fmt.Sprintf("%.2f", r.Revenue) },
+			Less:  func(a, b *domain.FinancialReport) bool { return a.Revenue < b.Revenue },
+		},
+		{
+			Title: "Расходы",
+			Value: func(r *domain.FinancialReport) string { return fmt.Sprintf("%.2f", r.Costs) },
+			Less:  func(a, b *domain.FinancialReport) bool { return a.Costs < b.Costs },
+		},
+	}
+}
+
+func myCompanies(a *app.App, actor *domain.Actor) utils.PageFunc[domain.Company] {
+	return func(ctx context.Context, page int) ([]*domain.Company, int, error) {
+		return a.CompSvc.GetByOwnerId(ctx, actor.ID, page, true)
+	}
+}
+
+func allActivityFields(a *app.App) utils.PageFunc[domain.ActivityField] {
+	return func(ctx context.Context, page int) ([]*domain.ActivityField, int, error) {
+		return a.ActFieldSvc.GetAll(ctx, page, true)
+	}
+}
+
+// Функции pick* открывают выбор записи для поля выбора формы.
+
+func pickActivityField(s *utils.Screen, a *app.App) func(set func(string, any)) {
+	return func(set func(string, any)) {
+		err := utils.Pick(s, "Выбор сферы деятельности", activityFieldColumns(), allActivityFields(a),
+			func(field *domain.ActivityField) { set(field.Name, field.ID) })
+		if err != nil {
+			s.Error(err)
+		}
+	}
+}
+
+func pickRole(s *utils.Screen, a *app.App) func(set func(string, any)) {
+	return func(set func(string, any)) {
+		err := utils.Pick(s, "Выбор роли", roleColumns(), utils.All(a.RoleSvc.GetAll),
+			func(role *domain.Role) { set(role.Name, role.Name) })
+		if err != nil {
+			s.Error(err)
+		}
+	}
+}
+
+func pickMyCompany(s *utils.Screen, a *app.App, actor *domain.Actor) func(set func(string, any)) {
+	return func(set func(string, any)) {
+		err := utils.Pick(s, "Выбор компании", companyColumns(a), myCompanies(a, actor),
+			func(comp *domain.Company) { set(comp.Name, comp.ID) })
+		if err != nil {
+			s.Error(err)
+		}
+	}
+}
+
+func pickUser(s *utils.Screen, a *app.App, onPick func(*domain.User)) error {
+	return utils.Pick(s, "Выбор предпринимателя", userColumns(), a.UserSvc.GetAll, onPick)
+}
+
+func activityFieldName(a *app.App, id uuid.UUID) string {
+	field, err := a.ActFieldSvc.GetById(context.Background(), id)
+	if err != nil {
+		return ""
+	}
+
+	return field.Name
+}
@@ -1,210 +1,107 @@
 package handlers
 
 import (
-	"bufio"
 	"context"
 	"fmt"
-	"github.com/google/uuid"
-	"os"
 	"ppo/domain"
 	"ppo/internal/app"
 	"ppo/internal/tui/utils"
-	"strings"
 )
 
-func AddCompany(a *app.App, args ...any) (err error) {
-	ctx := context.Background()
-	reader := bufio.NewReader(os.Stdin)
+func AddCompany(s *utils.Screen, a *app.App, actor *domain.Actor) (err error) {
+	form := utils.NewForm(s).
+		Input("name", "Название", "").
+		Input("city", "Город", "").
+		Picker("activity_field_id", "Сфера деятельности", "", nil, pickActivityField(s, a))
 
-	var username string
-	var ok bool
-	if len(args) > 0 {
-		username, ok = args[0].(string)
-		if !ok {
-			return fmt.Errorf("приведение аргумента к string")
+	form.OnSubmit(func() (err error) {
+		fieldID, err := form.UUID("activity_field_id")
+		if err != nil {
+			return err
+		}
+
+		company := &domain.Company{
+			OwnerID:         actor.ID,
+			Name:            form.Text("name"),
+			City:            form.Text("city"),
+			ActivityFieldId: fieldID,
+		}
+
+		err = a.CompSvc.Create(actorContext(actor), company)
+		if err != nil {
+			return fmt.Errorf("ошибка добавления компании: %w", err)
 		}
-	}
-
-	user, err := a.UserSvc.GetByUsername(ctx, username)
-	if err != nil {
-		return fmt.Errorf("пользователь не найден: %w", err)
-	}
-
-	var name, activityFieldId, city string
-	fmt.Printf("Введите название компании: ")
-	_, err = fmt.Scanf("%s", &name)
-	if err != nil {
-		return fmt.Errorf("ошибка ввода названия компании: %w", err)
-	}
-
-	fmt.Printf("Введите город: ")
-	city, err = reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("ошибка ввода города: %w", err)
-	}
-	city = strings.TrimSpace(city)
-
-	err = GetActivityFields(a, args...)
-	if err != nil {
-		return fmt.Errorf("добавление компании: %w", err)
-	}
-
-	fmt.Printf("Введите id сферы деятельности: ")
-	activityFieldId, err = reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("ошибка ввода сферы деятельности: %w", err)
-	}
-	activityFieldId = strings.TrimSpace(activityFieldId)
-
-	activityFieldUuid, err := uuid.Parse(activityFieldId)
-	if err != nil {
-		return fmt.Errorf("парсинг uuid из строки: %w", err)
-	}
-
-	var company domain.Company
-	company.Name = name
-	company.OwnerID = user.ID
-	company.City = city
-	company.ActivityFieldId = activityFieldUuid
-
-	err = a.CompSvc.Create(ctx, &company)
-	if err != nil {
-		return fmt.Errorf("ошибка добавления компании: %w", err)
-	}
 
+		return nil
+	})
+
+	form.Show("Новая компания")
 	return nil
 }
 
-func DeleteCompany(a *app.App, args ...any) (err error) {
-	ctx := context.Background()
-	err = GetMyCompanies(a, args...)
-	if err != nil {
-		return fmt.Errorf("удаление компании: %w", err)
-	}
-
-	reader := bufio.NewReader(os.Stdin)
+func DeleteCompany(s *utils.Screen, a *app.App, actor *domain.Actor) (err error) {
+	table := utils.NewTable(s, companyColumns(a), myCompanies(a, actor))
+	table.OnSelect(func(comp *domain.Company) {
+		table.Confirm(fmt.Sprintf("Удалить компанию «%s»?", comp.Name), func() (err error) {
+			ctx := actorContext(actor)
 
-	fmt.Printf("Введите id компании: ")
-	companyId, err := reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("ошибка ввода id компании: %w", err)
-	}
-	companyId = strings.TrimSpace(companyId)
+			err = a.PolicySvc.CanManageCompany(ctx, actor, comp.ID)
+			if err != nil {
+				return fmt.Errorf("удаление компании: %w", err)
+			}
 
-	companyUuid, err := uuid.Parse(companyId)
-	if err != nil {
-		return fmt.Errorf("парсинг uuid из строки: %w", err)
-	}
+			err = a.CompSvc.DeleteById(ctx, comp.ID)
+			if err != nil {
+				return fmt.Errorf("удаление компании: %w", err)
+			}
 
-	err = a.CompSvc.DeleteById(ctx, companyUuid)
-	if err != nil {
-		return fmt.Errorf("удаление компании: %w", err)
-	}
+			return nil
+		})
+	})
 
-	return nil
+	return table.Show("Удаление компании")
 }
 
-func UpdateCompany(a *app.App, args ...any) (err error) {
-	ctx := context.Background()
-	err = GetMyCompanies(a, args...)
-	if err != nil {
-		return fmt.Errorf("обновление информации о компании: %w", err)
-	}
-
-	reader := bufio.NewReader(os.Stdin)
-
-	fmt.Printf("Введите id компании: ")
-	companyId, err := reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("ошибка ввода id компании: %w", err)
-	}
-	companyId = strings.TrimSpace(companyId)
-
-	companyUuid, err := uuid.Parse(companyId)
-	if err != nil {
-		return fmt.Errorf("парсинг uuid из строки: %w", err)
-	}
-
-	comp, err := a.CompSvc.GetById(ctx, companyUuid)
-	if err != nil {
-		return fmt.Errorf("получение компании по id: %w", err)
-	}
-
-	var name, city, activityFieldId string
-	fmt.Printf("Введите название компании (%s): ", comp.Name)
-	name, err = reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("ошибка ввода названия компании: %w", err)
-	}
-	name = strings.TrimSpace(name)
-	if name != "" {
-		comp.Name = name
-	}
-
-	fmt.Printf("Введите название города (%s): ", comp.City)
-	city, err = reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("ошибка ввода названия города: %w", err)
-	}
-	city = strings.TrimSpace(city)
-	if city != "" {
-		comp.City = city
-	}
-
-	err = GetActivityFields(a, args...)
-	if err != nil {
-		return fmt.Errorf("обновление компании: %w", err)
-	}
-
-	field, err := a.ActFieldSvc.GetById(ctx, comp.ActivityFieldId)
-	if err != nil {
-		return fmt.Errorf("обновление компании: %w", err)
-	}
-
-	fmt.Printf("Введите id сферы деятельности (%s): ", field.Name)
-	activityFieldId, err = reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("ошибка ввода сферы деятельности: %w", err)
-	}
-	activityFieldId = strings.TrimSpace(activityFieldId)
-
-	if activityFieldId != "" {
-		activityFieldUuid, err := uuid.Parse(activityFieldId)
+func UpdateCompany(s *utils.Screen, a *app.App, actor *domain.Actor) (err error) {
+	table := utils.NewTable(s, companyColumns(a), myCompanies(a, actor))
+	table.OnSelect(func(comp *domain.Company) {
+		err := a.PolicySvc.CanManageCompany(context.Background(), actor, comp.ID)
 		if err != nil {
-			return fmt.Errorf("парсинг uuid из строки: %w", err)
+			s.Error(fmt.Errorf("обновление информации о компании: %w", err))
+			return
 		}
 
-		comp.ActivityFieldId = activityFieldUuid
-	}
+		form := utils.NewForm(s).
+			Input("name", "Название", comp.Name).
+			Input("city", "Город", comp.City).
+			Picker("activity_field_id", "Сфера деятельности", activityFieldName(a, comp.ActivityFieldId),
+				comp.ActivityFieldId, pickActivityField(s, a))
 
-	err = a.CompSvc.Update(ctx, comp)
-	if err != nil {
-		return fmt.Errorf("ошибка обновления компании: %w", err)
-	}
+		form.OnSubmit(func() (err error) {
+			fieldID, err := form.UUID("activity_field_id")
+			if err != nil {
+				return err
+			}
 
-	return nil
-}
+			updated := *comp
+			updated.Name = form.Text("name")
+			updated.City = form.Text("city")
+			updated.ActivityFieldId = fieldID
 
-func GetMyCompanies(a *app.App, args ...any) (err error) {
-	ctx := context.Background()
-	var username string
-	var ok bool
-	if len(args) > 0 {
-		username, ok = args[0].(string)
-		if !ok {
-			return fmt.Errorf("приведение аргумента к string")
-		}
-	}
+			err = a.CompSvc.Update(actorContext(actor), &updated)
+			if err != nil {
+				return fmt.Errorf("ошибка обновления компании: %w", err)
+			}
 
-	user, err := a.UserSvc.GetByUsername(ctx, username)
-	if err != nil {
-		return fmt.Errorf("пользователь не найден")
-	}
+			return nil
+		}).OnDone(table.Refresh)
 
-	err = utils.PrintPaginatedCollectionArgs("Компании", a.CompSvc.GetByOwnerId, ctx, user.ID, true)
-	if err != nil {
-		return fmt.Errorf("вывод компаний предпринимателя с пагинацией: %w", err)
-	}
+		form.Show("Компания " + comp.Name)
+	})
 
-	return nil
+	return table.Show("Обновление информации о компании")
+}
+
+func GetMyCompanies(s *utils.Screen, a *app.App, actor *domain.Actor) (err error) {
+	return utils.NewTable(s, companyColumns(a), myCompanies(a, actor)).Show("Мои компании")
 }
@@ -1,166 +1,98 @@
 package handlers
 
 import (
-	"bufio"
 	"context"
 	"fmt"
-	"github.com/google/uuid"
-	"os"
 	"ppo/domain"
 	"ppo/internal/app"
 	"ppo/internal/tui/utils"
-	"strings"
 )
 
-func AddContact(a *app.App, args ...any) (err error) {
-	ctx := context.Background()
-	reader := bufio.NewReader(os.Stdin)
+func myContacts(a *app.App, actor *domain.Actor) utils.PageFunc[domain.Contact] {
+	return utils.All(func(ctx context.Context) ([]*domain.Contact, error) {
+		return a.ConSvc.GetByOwnerId(ctx, actor.ID)
+	})
+}
+
+func AddContact(s *utils.Screen, a *app.App, actor *domain.Actor) (err error) {
+	form := utils.NewForm(s).
+		Input("name", "Средство связи", "").
+		Input("value", "Значение", "")
+
+	form.OnSubmit(func() (err error) {
+		contact := &domain.Contact{
+			OwnerID: actor.ID,
+			Name:    form.Text("name"),
+			Value:   form.Text("value"),
+		}
 
-	var username string
-	var ok bool
-	if len(args) > 0 {
-		username, ok = args[0].(string)
-		if !ok {
-			return fmt.Errorf("приведение аргумента к string")
+		err = a.ConSvc.Create(actorContext(actor), contact)
+		if err != nil {
+			return fmt.Errorf("ошибка добавления средства связи: %w", err)
 		}
-	}
-
-	user, err := a.UserSvc.GetByUsername(ctx, username)
-	if err != nil {
-		return fmt.Errorf("пользователь не найден: %w", err)
-	}
-
-	var name, value string
-	fmt.Printf("Введите название средства связи: ")
-	name, err = reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("ошибка ввода названия средства связи: %w", err)
-	}
-
-	fmt.Printf("Введите значение: ")
-	value, err = reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("ошибка ввода значения: %w", err)
-	}
-	value = strings.TrimSpace(value)
-
-	var contact domain.Contact
-	contact.OwnerID = user.ID
-	contact.Name = name
-	contact.Value = value
-
-	err = a.ConSvc.Create(ctx, &contact)
-	if err != nil {
-		return fmt.Errorf("ошибка добавления средства связи: %w", err)
-	}
 
+		return nil
+	})
+
+	form.Show("Новое средство связи")
 	return nil
 }
 
-func DeleteContact(a *app.App, args ...any) (err error) {
-	ctx := context.Background()
-	err = GetMyContacts(a, args...)
-	if err != nil {
-		return fmt.Errorf("удаление средства связи: %w", err)
-	}
-
-	reader := bufio.NewReader(os.Stdin)
+func DeleteContact(s *utils.Screen, a *app.App, actor *domain.Actor) (err error) {
+	table := utils.NewTable(s, contactColumns(), myContacts(a, actor))
+	table.OnSelect(func(contact *domain.Contact) {
+		table.Confirm(fmt.Sprintf("Удалить средство связи «%s»?", contact.Name), func() (err error) {
+			ctx := actorContext(actor)
 
-	fmt.Printf("Введите id средства связи: ")
-	conId, err := reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("ошибка ввода id навыка: %w", err)
-	}
-	conId = strings.TrimSpace(conId)
+			err = a.PolicySvc.CanManageContact(ctx, actor, contact.ID)
+			if err != nil {
+				return fmt.Errorf("удаление средства связи: %w", err)
+			}
 
-	conUuid, err := uuid.Parse(conId)
-	if err != nil {
-		return fmt.Errorf("парсинг uuid из строки: %w", err)
-	}
+			err = a.ConSvc.DeleteById(ctx, contact.ID)
+			if err != nil {
+				return fmt.Errorf("удаление средства связи: %w", err)
+			}
 
-	err = a.ConSvc.DeleteById(ctx, conUuid)
-	if err != nil {
-		return fmt.Errorf("удаление средства связи: %w", err)
-	}
+			return nil
+		})
+	})
 
-	return nil
+	return table.Show("Удаление средства связи")
 }
 
-func UpdateContact(a *app.App, args ...any) (err error) {
-	ctx := context.Background()
-	err = GetMyContacts(a, args...)
-	if err != nil {
-		return fmt.Errorf("обновление информации о средстве связи: %w", err)
-	}
-
-	reader := bufio.NewReader(os.Stdin)
-
-	fmt.Printf("Введите id средства связи: ")
-	conId, err := reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("ошибка ввода id навыка: %w", err)
-	}
-	conId = strings.TrimSpace(conId)
-
-	conUuid, err := uuid.Parse(conId)
-	if err != nil {
-		return fmt.Errorf("парсинг uuid из строки: %w", err)
-	}
-
-	contact, err := a.ConSvc.GetById(ctx, conUuid)
-	if err != nil {
-		return fmt.Errorf("получение средства связи по id: %w", err)
-	}
-
-	var name, value string
-	fmt.Printf("Введите название средства связи (%s): ", contact.Name)
-	name, err = reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("ошибка ввода названия навыка: %w", err)
-	}
-	name = strings.TrimSpace(name)
-	if name != "" {
-		contact.Name = name
-	}
-
-	fmt.Printf("Введите значение (%s): ", contact.Value)
-	value, err = reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("ошибка ввода описания: %w", err)
-	}
-	value = strings.TrimSpace(value)
-	if value != "" {
-		contact.Value = value
-	}
-
-	err = a.ConSvc.Update(ctx, contact)
-	if err != nil {
-		return fmt.Errorf("ошибка обновления средства связи: %w", err)
-	}
+func UpdateContact(s *utils.Screen, a *app.App, actor *domain.Actor) (err error) {
+	table := utils.NewTable(s, contactColumns(), myContacts(a, actor))
+	table.OnSelect(func(contact *domain.Contact) {
+		err := a.PolicySvc.CanManageContact(context.Background(), actor, contact.ID)
+		if err != nil {
+			s.Error(fmt.Errorf("обновление информации о средстве связи: %w", err))
+			return
+		}
 
-	return nil
-}
+		form := utils.NewForm(s).
+			Input("name", "Средство связи", contact.Name).
+			Input("value", "Значение", contact.Value)
 
-func GetMyContacts(a *app.App, args ...any) (err error) {
-	ctx := context.Background()
-	var username string
-	var ok bool
-	if len(args) > 0 {
-		username, ok = args[0].(string)
-		if !ok {
-			return fmt.Errorf("приведение аргумента к string")
-		}
-	}
+		form.OnSubmit(func() (err error) {
+			updated := *contact
+			updated.Name = form.Text("name")
+			updated.Value = form.Text("value")
 
-	user, err := a.UserSvc.GetByUsername(ctx, username)
-	if err != nil {
-		return fmt.Errorf("пользователь не найден")
-	}
+			err = a.ConSvc.Update(actorContext(actor), &updated)
+			if err != nil {
+				return fmt.Errorf("ошибка обновления средства связи: %w", err)
+			}
 
-	err = utils.PrintPaginatedCollectionArgs("Средства связи", a.ConSvc.GetByOwnerId, ctx, user.ID, true)
-	if err != nil {
-		return fmt.Errorf("вывод средств связи с пагинацией: %w", err)
-	}
+			return nil
+		}).OnDone(table.Refresh)
 
-	return nil
+		form.Show("Средство связи " + contact.Name)
+	})
+
+	return table.Show("Обновление средства связи")
+}
+
+func GetMyContacts(s *utils.Screen, a *app.App, actor *domain.Actor) (err error) {
+	return utils.NewTable(s, contactColumns(), myContacts(a, actor)).Show("Мои средства связи")
 }
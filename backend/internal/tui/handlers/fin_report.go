@@ -1,333 +1,216 @@
 package handlers
 
 import (
-	"bufio"
 	"context"
 	"fmt"
-	"github.com/google/uuid"
-	"os"
 	"ppo/domain"
 	"ppo/internal/app"
 	"ppo/internal/tui/utils"
 	"strconv"
-	"strings"
-)
-
-func AddReport(a *app.App, args ...any) (err error) {
-	ctx := context.Background()
-	reader := bufio.NewReader(os.Stdin)
-
-	err = GetMyCompanies(a, args...)
-	if err != nil {
-		return fmt.Errorf("добавление финансового отчета: %w", err)
-	}
-
-	fmt.Printf("Введите id компании: ")
-	compId, err := reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("ошибка ввода id компании: %w", err)
-	}
-	compId = strings.TrimSpace(compId)
-
-	compUuid, err := uuid.Parse(compId)
-	if err != nil {
-		return fmt.Errorf("парсинг uuid из строки: %w", err)
-	}
+	"time"
 
-	var revenue, costs float32
-	var year, quarter int
-
-	fmt.Printf("Введите выручку: ")
-	_, err = fmt.Scanf("%f", &revenue)
-	if err != nil {
-		return fmt.Errorf("ошибка ввода выручки: %w", err)
-	}
+	"github.com/google/uuid"
+)
 
-	fmt.Printf("Введите расходы: ")
-	_, err = fmt.Scanf("%f", &costs)
-	if err != nil {
-		return fmt.Errorf("ошибка ввода расходов: %w", err)
-	}
+var quarters = []string{"1", "2", "3", "4"}
+
+// reportForm — форма квартального отчёта; поле компании выводится только для нового отчёта.
+func reportForm(s *utils.Screen, a *app.App, actor *domain.Actor, rep *domain.FinancialReport,
+	save func(*domain.FinancialReport) error) *utils.Form {
+	form := utils.NewForm(s)
+	if rep.CompanyID == uuid.Nil {
+		form.Picker("company_id", "Компания", "", nil, pickMyCompany(s, a, actor))
+	}
+
+	form.
+		Number("revenue", "Выручка", formatFloat(rep.Revenue)).
+		Number("costs", "Расходы", formatFloat(rep.Costs)).
+		Integer("year", "Год", strconv.Itoa(rep.Year)).
+		Select("quarter", "Квартал", quarters, max(rep.Quarter-1, 0))
+
+	return form.OnSubmit(func() (err error) {
+		updated := *rep
+		if rep.CompanyID == uuid.Nil {
+			updated.CompanyID, err = form.UUID("company_id")
+			if err != nil {
+				return err
+			}
+		}
 
-	fmt.Printf("Введите год: ")
-	_, err = fmt.Scanf("%d", &year)
-	if err != nil {
-		return fmt.Errorf("ошибка ввода года: %w", err)
-	}
+		updated.Revenue, err = form.Float("revenue")
+		if err != nil {
+			return err
+		}
 
-	fmt.Printf("Введите квартал (1-4): ")
-	_, err = fmt.Scanf("%d", &quarter)
-	if err != nil {
-		return fmt.Errorf("ошибка ввода квартала: %w", err)
-	}
+		updated.Costs, err = form.Float("costs")
+		if err != nil {
+			return err
+		}
 
-	var report domain.FinancialReport
-	report.CompanyID = compUuid
-	report.Revenue = revenue
-	report.Costs = costs
-	report.Year = year
-	report.Quarter = quarter
+		updated.Year, err = form.Int("year")
+		if err != nil {
+			return err
+		}
 
-	err = a.FinSvc.Create(ctx, &report)
-	if err != nil {
-		return fmt.Errorf("ошибка добавления финансового отчета: %w", err)
-	}
+		quarter, _ := form.Option("quarter")
+		updated.Quarter = quarter + 1
 
-	return nil
+		return save(&updated)
+	})
 }
 
-func DeleteFinReport(a *app.App, args ...any) (err error) {
-	ctx := context.Background()
-	reader := bufio.NewReader(os.Stdin)
-
-	err = GetMyCompanies(a, args...)
-	if err != nil {
-		return fmt.Errorf("удаление финансового отчета: %w", err)
-	}
-
-	fmt.Printf("Введите id компании: ")
-	compId, err := reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("ошибка ввода id компании: %w", err)
-	}
-	compId = strings.TrimSpace(compId)
-
-	var year int
-	fmt.Printf("Укажите год: ")
-	_, err = fmt.Scanf("%d", &year)
-	if err != nil {
-		return fmt.Errorf("ошибка ввода года: %w", err)
-	}
+func AddReport(s *utils.Screen, a *app.App, actor *domain.Actor) (err error) {
+	year, quarter := domain.LastClosedQuarter(time.Now())
+	rep := &domain.FinancialReport{Year: year, Quarter: quarter}
 
-	err = GetCompanyReports(a, compId, year)
-	if err != nil {
-		return fmt.Errorf("обновление финансового отчета: %w", err)
-	}
+	reportForm(s, a, actor, rep, func(rep *domain.FinancialReport) (err error) {
+		ctx := actorContext(actor)
 
-	fmt.Printf("Введите id отчета: ")
-	repId, err := reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("ошибка ввода id отчета: %w", err)
-	}
-	repId = strings.TrimSpace(repId)
+		err = a.PolicySvc.CanManageCompany(ctx, actor, rep.CompanyID)
+		if err != nil {
+			return fmt.Errorf("добавление финансового отчета: %w", err)
+		}
 
-	repUuid, err := uuid.Parse(repId)
-	if err != nil {
-		return fmt.Errorf("парсинг uuid из строки: %w", err)
-	}
+		err = a.FinSvc.Create(ctx, rep)
+		if err != nil {
+			return fmt.Errorf("ошибка добавления финансового отчета: %w", err)
+		}
 
-	err = a.FinSvc.DeleteById(ctx, repUuid)
-	if err != nil {
-		return fmt.Errorf("ошибка удаления финансового отчета: %w", err)
-	}
+		return nil
+	}).Show("Новый финансовый отчёт")
 
 	return nil
 }
 
-func UpdateFinReport(a *app.App, args ...any) (err error) {
-	ctx := context.Background()
-	reader := bufio.NewReader(os.Stdin)
-
-	err = GetMyCompanies(a, args...)
-	if err != nil {
-		return fmt.Errorf("обновление финансового отчета: %w", err)
-	}
-
-	fmt.Printf("Введите id компании: ")
-	compId, err := reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("ошибка ввода id компании: %w", err)
-	}
-	compId = strings.TrimSpace(compId)
-
-	var year int
-	fmt.Printf("Укажите год: ")
-	_, err = fmt.Scanf("%d", &year)
-	if err != nil {
-		return fmt.Errorf("ошибка ввода года: %w", err)
-	}
-
-	err = GetCompanyReports(a, compId, year)
-	if err != nil {
-		return fmt.Errorf("обновление финансового отчета: %w", err)
-	}
-
-	fmt.Printf("Введите id отчета: ")
-	repId, err := reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("ошибка ввода id отчета: %w", err)
-	}
-	repId = strings.TrimSpace(repId)
-
-	repUuid, err := uuid.Parse(repId)
-	if err != nil {
-		return fmt.Errorf("парсинг uuid из строки: %w", err)
-	}
+// pickCompanyReports выбирает компанию пользователя и год и открывает таблицу отчётов
+// компании за этот год; выбор строки передаётся в onSelect.
+func pickCompanyReports(s *utils.Screen, a *app.App, actor *domain.Actor,
+	onSelect func(*utils.Table[domain.FinancialReport], *domain.FinancialReport)) error {
+	return utils.Pick(s, "Выбор компании", companyColumns(a), myCompanies(a, actor), func(comp *domain.Company) {
+		lastYear, _ := domain.LastClosedQuarter(time.Now())
+
+		var year int
+		form := utils.NewForm(s).Integer("year", "Год", strconv.Itoa(lastYear))
+		form.OnSubmit(func() (err error) {
+			year, err = form.Int("year")
+			return err
+		}).OnDone(func() {
+			err := GetCompanyReports(s, a, comp, year, onSelect)
+			if err != nil {
+				s.Error(err)
+			}
+		})
+
+		form.Show("Отчёты компании " + comp.Name)
+	})
+}
 
-	rep, err := a.FinSvc.GetById(ctx, repUuid)
-	if err != nil {
-		return fmt.Errorf("получение отчета по id: %w", err)
-	}
+func DeleteFinReport(s *utils.Screen, a *app.App, actor *domain.Actor) (err error) {
+	return pickCompanyReports(s, a, actor, func(table *utils.Table[domain.FinancialReport], rep *domain.FinancialReport) {
+		msg := fmt.Sprintf("Удалить отчёт за %d Q%d?", rep.Year, rep.Quarter)
+		table.Confirm(msg, func() (err error) {
+			ctx := actorContext(actor)
+
+			err = a.PolicySvc.CanManageFinReport(ctx, actor, rep.ID)
+			if err != nil {
+				return fmt.Errorf("удаление финансового отчета: %w", err)
+			}
+
+			err = a.FinSvc.DeleteById(ctx, rep.ID)
+			if err != nil {
+				return fmt.Errorf("ошибка удаления финансового отчета: %w", err)
+			}
+
+			return nil
+		})
+	})
+}
 
-	var revenue, costs float64
-	var yearUpd, quarter int
-	var revenueStr, costsStr, yearStr, quarterStr string
+func UpdateFinReport(s *utils.Screen, a *app.App, actor *domain.Actor) (err error) {
+	return pickCompanyReports(s, a, actor, func(table *utils.Table[domain.FinancialReport], rep *domain.FinancialReport) {
+		ctx := actorContext(actor)
 
-	fmt.Printf("Введите выручку (%f): ", rep.Revenue)
-	revenueStr, err = reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("ошибка ввода выручки: %w", err)
-	}
-	revenueStr = strings.TrimSpace(revenueStr)
-	if revenueStr != "" {
-		revenue, err = strconv.ParseFloat(revenueStr, 32)
+		err := a.PolicySvc.CanManageFinReport(ctx, actor, rep.ID)
 		if err != nil {
-			return fmt.Errorf("парсинг строки в revenue: %w", err)
+			s.Error(fmt.Errorf("обновление финансового отчета: %w", err))
+			return
 		}
 
-		rep.Revenue = float32(revenue)
-	}
+		reportForm(s, a, actor, rep, func(rep *domain.FinancialReport) error {
+			err := a.FinSvc.Update(ctx, rep)
+			if err != nil {
+				return fmt.Errorf("ошибка обновления финансового отчета: %w", err)
+			}
 
-	fmt.Printf("Введите расходы (%f): ", rep.Costs)
-	costsStr, err = reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("ошибка ввода расходов: %w", err)
-	}
-	costsStr = strings.TrimSpace(costsStr)
-	if costsStr != "" {
-		costs, err = strconv.ParseFloat(costsStr, 32)
-		if err != nil {
-			return fmt.Errorf("парсинг строки в cost: %w", err)
-		}
+			return nil
+		}).OnDone(table.Refresh).Show(fmt.Sprintf("Отчёт за %d Q%d", rep.Year, rep.Quarter))
+	})
+}
 
-		rep.Costs = float32(costs)
-	}
+// GetCompanyReports открывает таблицу отчётов компании comp за год year.
+func GetCompanyReports(s *utils.Screen, a *app.App, comp *domain.Company, year int,
+	onSelect func(*utils.Table[domain.FinancialReport], *domain.FinancialReport)) error {
+	load := utils.All(func(ctx context.Context) ([]*domain.FinancialReport, error) {
+		period := &domain.Period{StartYear: year, StartQuarter: 1, EndYear: year, EndQuarter: 4}
 
-	fmt.Printf("Введите год (%d): ", rep.Year)
-	yearStr, err = reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("ошибка ввода года: %w", err)
-	}
-	yearStr = strings.TrimSpace(yearStr)
-	if yearStr != "" {
-		yearUpd, err = strconv.Atoi(yearStr)
+		reports, err := a.FinSvc.GetByCompany(ctx, comp.ID, period)
 		if err != nil {
-			return fmt.Errorf("парсинг строки в year: %w", err)
+			return nil, fmt.Errorf("получение отчетов компании: %w", err)
 		}
 
-		rep.Year = yearUpd
-	}
-
-	fmt.Printf("Введите квартал (%d): ", rep.Quarter)
-	quarterStr, err = reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("ошибка ввода квартала: %w", err)
-	}
-	quarterStr = strings.TrimSpace(quarterStr)
-	if quarterStr != "" {
-		quarter, err = strconv.Atoi(quarterStr)
-		if err != nil {
-			return fmt.Errorf("парсинг строки в quarter: %w", err)
+		items := make([]*domain.FinancialReport, len(reports.Reports))
+		for i := range reports.Reports {
+			items[i] = &reports.Reports[i]
 		}
 
-		rep.Quarter = quarter
-	}
+		return items, nil
+	})
 
-	err = a.FinSvc.Update(ctx, rep)
-	if err != nil {
-		return fmt.Errorf("ошибка обновления финансового отчета: %w", err)
-	}
+	table := utils.NewTable(s, reportColumns(), load)
+	table.OnSelect(func(rep *domain.FinancialReport) {
+		onSelect(table, rep)
+	})
 
-	return nil
+	return table.Show(fmt.Sprintf("Отчёты %s за %d год", comp.Name, year))
 }
 
-func GetCompanyReports(a *app.App, args ...any) (err error) {
-	ctx := context.Background()
-	var compId string
-	var year int
-	var ok bool
-	if len(args) == 2 {
-		compId, ok = args[0].(string)
-		if !ok {
-			return fmt.Errorf("приведение аргумента к string")
-		}
+func GetUserFinReport(s *utils.Screen, a *app.App, actor *domain.Actor) (err error) {
+	year, quarter := domain.LastClosedQuarter(time.Now())
 
-		year, ok = args[1].(int)
-		if !ok {
-			return fmt.Errorf("приведение аргумента к int")
-		}
-	} else {
-		return fmt.Errorf("некорректное число аргументов: %w", err)
-	}
-	compId = strings.TrimSpace(compId)
+	form := utils.NewForm(s).
+		Integer("start_year", "Год начала периода", strconv.Itoa(year)).
+		Select("start_quarter", "Квартал начала периода", quarters, 0).
+		Integer("end_year", "Год конца периода", strconv.Itoa(year)).
+		Select("end_quarter", "Квартал конца периода", quarters, quarter-1)
 
-	compUuid, err := uuid.Parse(compId)
-	if err != nil {
-		return fmt.Errorf("парсинг uuid из строки: %w", err)
-	}
-
-	err = utils.PrintYearCollection("Отчёты", a.FinSvc.GetByCompany, ctx, compUuid, year)
-	if err != nil {
-		return fmt.Errorf("вывод отчетов с пагинацией: %w", err)
-	}
-
-	return nil
-}
+	var rep *domain.FinancialReportByPeriod
+	form.OnSubmit(func() (err error) {
+		period := new(domain.Period)
 
-func GetUserFinReport(a *app.App, args ...any) (err error) {
-	ctx := context.Background()
-	var username string
-	var ok bool
-	if len(args) > 0 {
-		username, ok = args[0].(string)
-		if !ok {
-			return fmt.Errorf("приведение аргумента к string")
+		period.StartYear, err = form.Int("start_year")
+		if err != nil {
+			return err
 		}
-	}
-
-	user, err := a.UserSvc.GetByUsername(ctx, username)
-	if err != nil {
-		return fmt.Errorf("пользователь не найден")
-	}
-
-	var startYear, endYear, startQuarter, endQuarter int
-	fmt.Printf("Укажите год начала периода: ")
-	_, err = fmt.Scanf("%d", &startYear)
-	if err != nil {
-		return fmt.Errorf("ошибка ввода года: %w", err)
-	}
 
-	fmt.Printf("Укажите год конца периода: ")
-	_, err = fmt.Scanf("%d", &endYear)
-	if err != nil {
-		return fmt.Errorf("ошибка ввода года: %w", err)
-	}
-
-	fmt.Printf("Укажите квартал начала периода (1-4): ")
-	_, err = fmt.Scanf("%d", &startQuarter)
-	if err != nil {
-		return fmt.Errorf("ошибка ввода квартала: %w", err)
-	}
-
-	fmt.Printf("Укажите квартал конца периода (1-4): ")
-	_, err = fmt.Scanf("%d", &endQuarter)
-	if err != nil {
-		return fmt.Errorf("ошибка ввода квартала: %w", err)
-	}
+		period.EndYear, err = form.Int("end_year")
+		if err != nil {
+			return err
+		}
 
-	period := &domain.Period{
-		StartYear:    startYear,
-		EndYear:      endYear,
-		StartQuarter: startQuarter,
-		EndQuarter:   endQuarter,
-	}
+		startQuarter, _ := form.Option("start_quarter")
+		endQuarter, _ := form.Option("end_quarter")
+		period.StartQuarter, period.EndQuarter = startQuarter+1, endQuarter+1
 
-	rep, err := a.Interactor.GetUserFinancialReport(ctx, user.ID, period)
-	if err != nil {
-		return fmt.Errorf("формирование отчёта предпринимателя: %w", err)
-	}
+		rep, err = a.Interactor.GetUserFinancialReport(context.Background(), actor.ID, period)
+		if err != nil {
+			return fmt.Errorf("формирование отчёта предпринимателя: %w", err)
+		}
 
-	fmt.Printf("Прибыль=%f, Выручка=%f, Затраты=%f", rep.Profit(), rep.Revenue(), rep.Costs())
+		return nil
+	}).OnDone(func() {
+		s.Info(fmt.Sprintf("Прибыль: %.2f\nВыручка: %.2f\nЗатраты: %.2f", rep.Profit(), rep.Revenue(), rep.Costs()))
+	})
 
+	form.Show("Полный финансовый отчёт")
 	return nil
 }
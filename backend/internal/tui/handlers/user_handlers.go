@@ -1,79 +1,92 @@
 package handlers
 
 import (
-	"bufio"
 	"context"
 	"fmt"
-	"os"
+	"ppo/domain"
 	"ppo/internal/app"
-	"ppo/internal/config"
 	"ppo/internal/tui/utils"
 	"strings"
-
-	"github.com/google/uuid"
 )
 
-func GetAllUsers(a *app.App, args ...any) (err error) {
-	ctx := context.Background()
-	page := 1
-	for {
-		users, err := a.UserSvc.GetAll(ctx, page)
-		if err != nil {
-			return fmt.Errorf("получение пользователей: %w", err)
-		}
-
-		utils.PrintCollection("Предприниматели", users)
+func GetAllUsers(s *utils.Screen, a *app.App, actor *domain.Actor) (err error) {
+	return utils.NewTable(s, userColumns(), a.UserSvc.GetAll).Show("Предприниматели")
+}
 
-		fmt.Printf("1. Предыдущая страница.\n2. Следующая страница.\n0. Назад.\n\nВыберите действие: ")
-		var option int
-		_, err = fmt.Scanf("%d", &option)
+func CalculateRating(s *utils.Screen, a *app.App, actor *domain.Actor) (err error) {
+	return pickUser(s, a, func(user *domain.User) {
+		rating, err := a.Interactor.CalculateUserRating(context.Background(), user.ID)
 		if err != nil {
-			return fmt.Errorf("ошибка ввода следующего действия: %w", err)
+			s.Error(fmt.Errorf("расчёт рейтинга: %w", err))
+			return
 		}
 
-		switch option {
-		case 1:
-			if page > 1 {
-				page--
-			}
-		case 2:
-			if len(users) == config.PageSize {
-				page++
-			}
-		case 0:
-			return nil
-		}
-	}
+		s.Info(fmt.Sprintf("Рейтинг предпринимателя %s равен %f", user.Username, rating))
+	})
 }
 
-func CalculateRating(a *app.App, args ...any) (err error) {
-	ctx := context.Background()
-	reader := bufio.NewReader(os.Stdin)
-	var idStr string
+// ratingBarWidth — длина полосы, соответствующей рейтингу 1.
+const ratingBarWidth = 40
 
-	err = GetAllUsers(a, args...)
-	if err != nil {
-		return fmt.Errorf("вывод пользователей: %w", err)
-	}
+// ratingRow — строка истории рейтинга с изменением относительно предыдущего квартала.
+type ratingRow struct {
+	*domain.RatingSnapshot
+	change string
+}
 
-	fmt.Printf("Введите id: ")
-	idStr, err = reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("ошибка ввода id: %w", err)
-	}
-	idStr = strings.TrimSpace(idStr)
+func RatingHistory(s *utils.Screen, a *app.App, actor *domain.Actor) (err error) {
+	return pickUser(s, a, func(user *domain.User) {
+		load := utils.All(func(ctx context.Context) ([]*ratingRow, error) {
+			history, err := a.RatingSvc.GetHistory(ctx, user.ID, nil)
+			if err != nil {
+				return nil, fmt.Errorf("получение истории рейтинга: %w", err)
+			}
 
-	id, err := uuid.Parse(idStr)
-	if err != nil {
-		return fmt.Errorf("парсинг uuid из строки: %w", err)
-	}
+			rows := make([]*ratingRow, len(history))
+			for i, snapshot := range history {
+				rows[i] = &ratingRow{RatingSnapshot: snapshot}
+				if i == 0 {
+					continue
+				}
 
-	rating, err := a.Interactor.CalculateUserRating(ctx, id)
-	if err != nil {
-		return fmt.Errorf("расчёт рейтинга: %w", err)
-	}
+				diff := snapshot.Rating - history[i-1].Rating
+				arrow := "→"
+				if diff > 0 {
+					arrow = "↑"
+				} else if diff < 0 {
+					arrow = "↓"
+				}
+				rows[i].change = fmt.Sprintf("%s %+.4f", arrow, diff)
+			}
 
-	fmt.Printf("Рейтинг пользователя с id=%s равен %f\n", id, rating)
+			return rows, nil
+		})
 
-	return nil
+		columns := []utils.Column[ratingRow]{
+			{
+				Title: "Период",
+				Value: func(r *ratingRow) string { return fmt.Sprintf("%d Q%d", r.Year, r.Quarter) },
+				Less: func(a, b *ratingRow) bool {
+					return a.Year < b.Year || a.Year == b.Year && a.Quarter < b.Quarter
+				},
+			},
+			{
+				Title: "Рейтинг",
+				Value: func(r *ratingRow) string { return fmt.Sprintf("%f", r.Rating) },
+				Less:  func(a, b *ratingRow) bool { return a.Rating < b.Rating },
+			},
+			{Title: "Изменение", Value: func(r *ratingRow) string { return r.change }},
+			{
+				Title: "",
+				Value: func(r *ratingRow) string {
+					return strings.Repeat("█", int(max(0, min(1, r.Rating))*ratingBarWidth))
+				},
+			},
+		}
+
+		err := utils.NewTable(s, columns, load).Show("История рейтинга " + user.Username)
+		if err != nil {
+			s.Error(err)
+		}
+	})
 }
@@ -1,132 +1,47 @@
 package handlers
 
 import (
-	"bufio"
 	"context"
 	"fmt"
-	"github.com/google/uuid"
-	"os"
 	"ppo/domain"
 	"ppo/internal/app"
 	"ppo/internal/tui/utils"
-	"strings"
 )
 
-func AddUserSkill(a *app.App, args ...any) (err error) {
-	ctx := context.Background()
-	reader := bufio.NewReader(os.Stdin)
-
-	var username string
-	var ok bool
-	if len(args) > 0 {
-		username, ok = args[0].(string)
-		if !ok {
-			return fmt.Errorf("приведение аргумента к string")
-		}
+func mySkills(a *app.App, actor *domain.Actor) utils.PageFunc[domain.Skill] {
+	return func(ctx context.Context, page int) ([]*domain.Skill, int, error) {
+		return a.UserSkillSvc.GetSkillsForUser(ctx, actor.ID, page, true)
 	}
-
-	user, err := a.UserSvc.GetByUsername(ctx, username)
-	if err != nil {
-		return fmt.Errorf("пользователь не найден: %w", err)
-	}
-
-	err = GetSkills(a, args...)
-	if err != nil {
-		return fmt.Errorf("добавление навыка пользователю: %w", err)
-	}
-
-	fmt.Printf("Введите id навыка: ")
-	skillId, err := reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("ошибка ввода id навыка: %w", err)
-	}
-	skillId = strings.TrimSpace(skillId)
-
-	skillUuid, err := uuid.Parse(skillId)
-	if err != nil {
-		return fmt.Errorf("парсинг uuid из строки: %w", err)
-	}
-
-	var userSkill domain.UserSkill
-	userSkill.UserId = user.ID
-	userSkill.SkillId = skillUuid
-
-	err = a.UserSkillSvc.Create(ctx, &userSkill)
-	if err != nil {
-		return fmt.Errorf("ошибка добавления навыка пользователю: %w", err)
-	}
-
-	return nil
 }
 
-func DeleteUserSkill(a *app.App, args ...any) (err error) {
-	ctx := context.Background()
-	var username string
-	var ok bool
-	if len(args) > 0 {
-		username, ok = args[0].(string)
-		if !ok {
-			return fmt.Errorf("приведение аргумента к string")
+func AddUserSkill(s *utils.Screen, a *app.App, actor *domain.Actor) (err error) {
+	return utils.Pick(s, "Выбор навыка", skillColumns(), a.SkillSvc.GetAll, func(skill *domain.Skill) {
+		err := a.UserSkillSvc.Create(actorContext(actor), &domain.UserSkill{UserId: actor.ID, SkillId: skill.ID})
+		if err != nil {
+			s.Error(fmt.Errorf("ошибка добавления навыка пользователю: %w", err))
+			return
 		}
-	}
-
-	user, err := a.UserSvc.GetByUsername(ctx, username)
-	if err != nil {
-		return fmt.Errorf("пользователь не найден: %w", err)
-	}
 
-	err = GetMySkills(a, args...)
-	if err != nil {
-		return fmt.Errorf("удаление навыка пользователя: %w", err)
-	}
-
-	reader := bufio.NewReader(os.Stdin)
-
-	fmt.Printf("Введите id навыка: ")
-	skillId, err := reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("ошибка ввода id навыка: %w", err)
-	}
-	skillId = strings.TrimSpace(skillId)
-
-	skillUuid, err := uuid.Parse(skillId)
-	if err != nil {
-		return fmt.Errorf("парсинг uuid из строки: %w", err)
-	}
-
-	pair := &domain.UserSkill{
-		UserId:  user.ID,
-		SkillId: skillUuid,
-	}
-
-	err = a.UserSkillSvc.Delete(ctx, pair)
-	if err != nil {
-		return fmt.Errorf("удаление навыка пользователя: %w", err)
-	}
-
-	return nil
+		s.Info(fmt.Sprintf("Навык «%s» добавлен", skill.Name))
+	})
 }
 
-func GetMySkills(a *app.App, args ...any) (err error) {
-	ctx := context.Background()
-	var username string
-	var ok bool
-	if len(args) > 0 {
-		username, ok = args[0].(string)
-		if !ok {
-			return fmt.Errorf("приведение аргумента к string")
-		}
-	}
+func DeleteUserSkill(s *utils.Screen, a *app.App, actor *domain.Actor) (err error) {
+	table := utils.NewTable(s, skillColumns(), mySkills(a, actor))
+	table.OnSelect(func(skill *domain.Skill) {
+		table.Confirm(fmt.Sprintf("Удалить навык «%s»?", skill.Name), func() (err error) {
+			err = a.UserSkillSvc.Delete(actorContext(actor), &domain.UserSkill{UserId: actor.ID, SkillId: skill.ID})
+			if err != nil {
+				return fmt.Errorf("удаление навыка пользователя: %w", err)
+			}
 
-	user, err := a.UserSvc.GetByUsername(ctx, username)
-	if err != nil {
-		return fmt.Errorf("пользователь не найден")
-	}
+			return nil
+		})
+	})
 
-	err = utils.PrintPaginatedCollectionArgs("Навыки", a.UserSkillSvc.GetSkillsForUser, ctx, user.ID, true)
-	if err != nil {
-		return fmt.Errorf("вывод навыков с пагинацией: %w", err)
-	}
+	return table.Show("Удаление навыка")
+}
 
-	return nil
+func GetMySkills(s *utils.Screen, a *app.App, actor *domain.Actor) (err error) {
+	return utils.NewTable(s, skillColumns(), mySkills(a, actor)).Show("Мои навыки")
 }
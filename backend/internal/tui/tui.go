@@ -1,335 +1,337 @@
 package tui
 
 import (
-	"bufio"
 	"context"
+	"errors"
 	"fmt"
-	"os"
 	"ppo/domain"
 	"ppo/internal/app"
-	"ppo/internal/config"
 	"ppo/internal/tui/handlers"
 	"ppo/internal/tui/utils"
 	"ppo/pkg/base"
-)
+	"ppo/pkg/i18n"
 
-const (
-	admin = "admin"
-	user  = "user"
+	"github.com/google/uuid"
+	"github.com/rivo/tview"
 )
 
+// Action без разрешения (Permission == "") доступно любому авторизованному пользователю.
 type Action struct {
-	Role string
-	Name string
-	Func func(*app.App, ...any) error
+	Permission domain.Permission
+	Name       i18n.Key
+	Func       func(*utils.Screen, *app.App, *domain.Actor) error
 }
 
 type TUI struct {
-	userInfo *base.JwtPayload
-	app      *app.App
+	// actor — пользователь, вошедший в систему; nil, пока вход не выполнен.
+	actor  *domain.Actor
+	app    *app.App
+	screen *utils.Screen
 }
 
 func NewTUI(app *app.App) *TUI {
 	return &TUI{
-		app: app,
+		app:    app,
+		screen: utils.NewScreen(tview.NewApplication(), app.Config.Lang),
 	}
 }
 
+func (t *TUI) SetLanguage(lang i18n.Lang) {
+	t.screen.SetLang(lang)
+}
+
 var actions = []Action{
 	{
-		Role: admin,
-		Name: "[ Предприниматели ] Редактировать карточку предпринимателя",
-		Func: handlers.UpdateUser,
+		Permission: domain.PermUsersManage,
+		Name:       i18n.TuiActUpdateUser,
+		Func:       handlers.UpdateUser,
+	},
+	{
+		Permission: domain.PermUsersManage,
+		Name:       i18n.TuiActCreateUser,
+		Func:       handlers.CreateUser,
+	},
+	{
+		Permission: domain.PermCompaniesWrite,
+		Name:       i18n.TuiActAddCompany,
+		Func:       handlers.AddCompany,
+	},
+	{
+		Permission: domain.PermCompaniesWrite,
+		Name:       i18n.TuiActDeleteCompany,
+		Func:       handlers.DeleteCompany,
 	},
 	{
-		Role: admin,
-		Name: "[ Предприниматели ] Создать карточку предпринимателя",
-		Func: handlers.CreateUser,
+		Permission: domain.PermCompaniesWrite,
+		Name:       i18n.TuiActUpdateCompany,
+		Func:       handlers.UpdateCompany,
 	},
 	{
-		Role: user,
-		Name: "[ Компании ] Добавить компанию",
-		Func: handlers.AddCompany,
+		Permission: domain.PermCompaniesWrite,
+		Name:       i18n.TuiActMyCompanies,
+		Func:       handlers.GetMyCompanies,
 	},
 	{
-		Role: user,
-		Name: "[ Компании ] Удалить компанию",
-		Func: handlers.DeleteCompany,
+		Permission: domain.PermFinanceRead,
+		Name:       i18n.TuiActCalculateRating,
+		Func:       handlers.CalculateRating,
 	},
 	{
-		Role: user,
-		Name: "[ Компании ] Обновить информацию о компании",
-		Func: handlers.UpdateCompany,
+		Permission: domain.PermFinanceRead,
+		Name:       i18n.TuiActRatingHistory,
+		Func:       handlers.RatingHistory,
 	},
 	{
-		Role: user,
-		Name: "[ Компании ] Посмотреть список своих компаний",
-		Func: handlers.GetMyCompanies,
+		Permission: domain.PermRolesManage,
+		Name:       i18n.TuiActChangeUserRole,
+		Func:       handlers.ChangeUserRole,
 	},
 	{
-		Role: user,
-		Name: "[ Предприниматели ] Просчитать рейтинг",
-		Func: handlers.CalculateRating,
+		Permission: domain.PermRolesManage,
+		Name:       i18n.TuiActRoles,
+		Func:       handlers.GetRoles,
 	},
 	{
-		Role: admin,
-		Name: "[ Предприниматели ] Сменить роль пользователя",
-		Func: handlers.ChangeUserRole,
+		Permission: "",
+		Name:       i18n.TuiActUsers,
+		Func:       handlers.GetAllUsers,
 	},
 	{
-		Role: user,
-		Name: "[ Предприниматели ] Просмотреть список предпринимателей",
-		Func: handlers.GetAllUsers,
+		Permission: domain.PermCatalogEdit,
+		Name:       i18n.TuiActAddActivityField,
+		Func:       handlers.AddActivityField,
 	},
 	{
-		Role: admin,
-		Name: "[ Сферы деятельности ] Добавить сферу деятельности",
-		Func: handlers.AddActivityField,
+		Permission: domain.PermCatalogEdit,
+		Name:       i18n.TuiActDeleteActField,
+		Func:       handlers.DeleteActivityField,
 	},
 	{
-		Role: admin,
-		Name: "[ Сферы деятельности ] Удалить сферу деятельности",
-		Func: handlers.DeleteActivityField,
+		Permission: domain.PermCatalogEdit,
+		Name:       i18n.TuiActUpdateActField,
+		Func:       handlers.UpdateActivityField,
 	},
 	{
-		Role: admin,
-		Name: "[ Сферы деятельности ] Редактировать сферу деятельности",
-		Func: handlers.UpdateActivityField,
+		Permission: domain.PermCatalogEdit,
+		Name:       i18n.TuiActAddSkill,
+		Func:       handlers.AddSkill,
 	},
 	{
-		Role: admin,
-		Name: "[ Навыки ] Добавить навык",
-		Func: handlers.AddSkill,
+		Permission: domain.PermCatalogEdit,
+		Name:       i18n.TuiActDeleteSkill,
+		Func:       handlers.DeleteSkill,
 	},
 	{
-		Role: admin,
-		Name: "[ Навыки ] Удалить навык",
-		Func: handlers.DeleteSkill,
+		Permission: domain.PermCatalogEdit,
+		Name:       i18n.TuiActUpdateSkill,
+		Func:       handlers.UpdateSkill,
 	},
 	{
-		Role: admin,
-		Name: "[ Навыки ] Редактировать навык",
-		Func: handlers.UpdateSkill,
+		Permission: domain.PermProfileEdit,
+		Name:       i18n.TuiActMySkills,
+		Func:       handlers.GetMySkills,
 	},
 	{
-		Role: user,
-		Name: "[ Навыки ] Добавить навык",
-		Func: handlers.AddUserSkill,
+		Permission: domain.PermProfileEdit,
+		Name:       i18n.TuiActAddUserSkill,
+		Func:       handlers.AddUserSkill,
 	},
 	{
-		Role: user,
-		Name: "[ Навыки ] Удалить навык",
-		Func: handlers.DeleteUserSkill,
+		Permission: domain.PermProfileEdit,
+		Name:       i18n.TuiActDeleteUserSkill,
+		Func:       handlers.DeleteUserSkill,
 	},
 	{
-		Role: user,
-		Name: "[ Средства связи ] Добавить средство связи",
-		Func: handlers.AddContact,
+		Permission: domain.PermContactsWrite,
+		Name:       i18n.TuiActMyContacts,
+		Func:       handlers.GetMyContacts,
 	},
 	{
-		Role: user,
-		Name: "[ Средства связи ] Редактировать средство связи",
-		Func: handlers.UpdateContact,
+		Permission: domain.PermContactsWrite,
+		Name:       i18n.TuiActAddContact,
+		Func:       handlers.AddContact,
 	},
 	{
-		Role: user,
-		Name: "[ Средства связи ] Удалить средство связи",
-		Func: handlers.DeleteContact,
+		Permission: domain.PermContactsWrite,
+		Name:       i18n.TuiActUpdateContact,
+		Func:       handlers.UpdateContact,
 	},
 	{
-		Role: user,
-		Name: "[ Финансовые показатели ] Добавить отчёт",
-		Func: handlers.AddReport,
+		Permission: domain.PermContactsWrite,
+		Name:       i18n.TuiActDeleteContact,
+		Func:       handlers.DeleteContact,
 	},
 	{
-		Role: user,
-		Name: "[ Финансовые показатели ] Редактировать отчёт",
-		Func: handlers.UpdateFinReport,
+		Permission: domain.PermFinanceWrite,
+		Name:       i18n.TuiActAddReport,
+		Func:       handlers.AddReport,
 	},
 	{
-		Role: user,
-		Name: "[ Финансовые показатели ] Удалить отчёт",
-		Func: handlers.DeleteFinReport,
+		Permission: domain.PermFinanceWrite,
+		Name:       i18n.TuiActUpdateReport,
+		Func:       handlers.UpdateFinReport,
 	},
 	{
-		Role: user,
-		Name: "[ Финансовые показатели ] Сформировать полный отчёт",
-		Func: handlers.GetUserFinReport,
+		Permission: domain.PermFinanceWrite,
+		Name:       i18n.TuiActDeleteReport,
+		Func:       handlers.DeleteFinReport,
+	},
+	{
+		Permission: domain.PermFinanceRead,
+		Name:       i18n.TuiActUserFinReport,
+		Func:       handlers.GetUserFinReport,
 	},
 }
 
+// Run открывает главное меню и работает до выхода из него или Ctrl+C.
 func (t *TUI) Run() (err error) {
-	ctx := context.Background()
+	t.mainMenu()
 
-	var choice int
-	reader := bufio.NewReader(os.Stdin)
-	_ = reader
-	for {
-		_, err = fmt.Scanf("%d", &choice)
-		if err != nil {
-			fmt.Println("ошибка ввода: %w", err)
-			continue
-		}
-
-		switch choice {
-		case 0:
-			return nil
-		case 1:
-			err = t.guestMenu()
-			if err != nil {
-				fmt.Println(err)
-				continue
-			}
-		case 2:
-			var login, password string
-			fmt.Printf("Введите логин: ")
-			_, err = fmt.Scanf("%s", &login)
-			if err != nil {
-				return fmt.Errorf("ошибка ввода логина")
-			}
-
-			fmt.Printf("Введите пароль: ")
-			_, err = fmt.Scanf("%s", &password)
-			if err != nil {
-				return fmt.Errorf("ошибка ввода пароля")
-			}
-
-			ua := &domain.UserAuth{Username: login, Password: password}
-			token, err := t.app.AuthSvc.Login(ctx, ua)
-			if err != nil {
-				fmt.Printf("ошибка авторизации: %v\n", err)
-				continue
-			}
-
-			payload, err := base.VerifyAuthToken(token, t.app.Config.JwtKey)
-			if err != nil {
-				return fmt.Errorf("ошибка верификации JWT токена: %w", err)
-			}
-
-			t.userInfo = payload
-			err = t.userMenu()
-			if err != nil {
-				fmt.Println(err)
-				continue
-			}
-		}
+	err = t.screen.App().SetRoot(t.screen.Root(), true).EnableMouse(true).Run()
+	if err != nil {
+		return fmt.Errorf("работа TUI: %w", err)
 	}
+
+	return nil
 }
 
-func (t *TUI) userMenu() (err error) {
-	for {
-		allowedActions, prompt := generateActionsPrompt(t.userInfo.Role)
-		fmt.Println(prompt)
+func (t *TUI) mainMenu() {
+	s := t.screen
 
-		var choice int
-		_, err = fmt.Scanf("%d", &choice)
-		if err != nil {
-			fmt.Printf("Ошибка ввода: %v", err)
-			continue
-		}
+	menu := utils.NewMenu([]utils.MenuItem{
+		{Label: s.T(i18n.TuiEntrepreneurs), Action: t.run(handlers.GetAllUsers)},
+		{Label: s.T(i18n.TuiMenuLogin), Action: t.loginForm},
+		{Label: s.T(i18n.TuiMenuSignUp), Action: t.signUpForm},
+		{Label: s.T(i18n.TuiMenuLanguage), Action: t.languageMenu},
+		{Label: s.T(i18n.TuiMenuExit), Action: s.Stop},
+	})
 
-		if choice == 0 {
-			return nil
-		} else if choice > len(allowedActions) || choice < 0 {
-			fmt.Println("Действия с таким номером нет.")
-		} else {
-			err = allowedActions[choice-1].Func(t.app, t.userInfo.Username)
-			if err != nil {
-				fmt.Println(err)
-			}
+	s.Push("", menu, s.T(i18n.TuiHintMenu))
+}
+
+// run выполняет действие от имени вошедшего пользователя и выводит его ошибку.
+func (t *TUI) run(fn func(*utils.Screen, *app.App, *domain.Actor) error) func() {
+	return func() {
+		err := fn(t.screen, t.app, t.actor)
+		if err != nil {
+			t.screen.Error(err)
 		}
 	}
 }
 
-func (t *TUI) guestMenu() (err error) {
-	ctx := context.Background()
+func (t *TUI) loginForm() {
+	s := t.screen
 
-	var choice int
-	for {
-		fmt.Println(guestPrompt)
-		_, err = fmt.Scanf("%d", &choice)
+	form := utils.NewForm(s).
+		Input("username", s.T(i18n.TuiLogin), "").
+		Password("password", s.T(i18n.TuiPassword))
+
+	form.OnSubmit(func() (err error) {
+		ua := &domain.UserAuth{Username: form.Text("username"), Password: form.Text("password")}
+		token, err := t.app.AuthSvc.Login(context.Background(), ua)
 		if err != nil {
-			fmt.Printf("ошибка ввода: %v", err)
+			return errors.New(s.T(i18n.TuiAuthError, domain.LocalizeError(s.Lang(), err)))
 		}
 
-		switch choice {
-		case 1:
-			var login, password string
-
-			fmt.Printf("Введите логин: ")
-			_, err = fmt.Scanf("%s", &login)
-			if err != nil {
-				return fmt.Errorf("ошибка ввода логина")
-			}
-
-			fmt.Printf("Введите пароль: ")
-			_, err = fmt.Scanf("%s", &password)
-			if err != nil {
-				return fmt.Errorf("ошибка ввода пароля")
-			}
-
-			ua := &domain.UserAuth{Username: login, Password: password}
-			err = t.app.AuthSvc.Register(ctx, ua)
-			if err != nil {
-				return fmt.Errorf("ошибка регистрации: %w", err)
-			}
-			return nil
-		case 2:
-			err = printPaginatedUsers(ctx, t.app)
-			if err != nil {
-				return fmt.Errorf("ошибка просмотра списка предпринимателей: %w", err)
-			}
-		case 0:
-			return nil
-		}
-	}
+		t.actor, err = actorFromToken(token, t.app.Config.JwtKey)
+		return err
+	}).OnDone(t.userMenu)
+
+	form.Show(s.T(i18n.TuiMenuLogin))
 }
 
-func printPaginatedUsers(ctx context.Context, app *app.App) (err error) {
-	page := 1
+func (t *TUI) signUpForm() {
+	s := t.screen
+
+	form := utils.NewForm(s).
+		Input("username", s.T(i18n.TuiLogin), "").
+		Password("password", s.T(i18n.TuiPassword))
 
-	for {
-		tmp, err := app.UserSvc.GetAll(ctx, page)
+	form.OnSubmit(func() (err error) {
+		ua := &domain.UserAuth{Username: form.Text("username"), Password: form.Text("password")}
+		err = t.app.AuthSvc.Register(context.Background(), ua)
 		if err != nil {
-			return fmt.Errorf("получение пагинированных данных: %w", err)
+			return fmt.Errorf("ошибка регистрации: %w", err)
 		}
 
-		utils.PrintCollection("Предприниматели", tmp)
+		return nil
+	}).OnDone(func() {
+		s.Info(s.T(i18n.TuiSignedUp))
+	})
 
-		fmt.Printf("1. Предыдущая страница.\n2. Следующая страница.\n0. Назад.\n\nВыберите действие: ")
-		var option int
-		_, err = fmt.Scanf("%d", &option)
-		if err != nil {
-			return fmt.Errorf("ошибка ввода следующего действия: %w", err)
-		}
+	form.Show(s.T(i18n.TuiMenuSignUp))
+}
+
+// actorFromToken извлекает из JWT, выданного при входе, идентификатор и роль пользователя.
+func actorFromToken(token, jwtKey string) (actor *domain.Actor, err error) {
+	payload, err := base.VerifyAuthToken(token, jwtKey)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка верификации JWT токена: %w", err)
+	}
+
+	id, err := uuid.Parse(payload.ID)
+	if err != nil {
+		return nil, fmt.Errorf("парсинг id пользователя из JWT токена: %w", err)
+	}
+
+	return &domain.Actor{ID: id, Role: payload.Role}, nil
+}
+
+func (t *TUI) languageMenu() {
+	s := t.screen
 
-		switch option {
-		case 1:
-			if page > 1 {
-				page--
-			}
-		case 2:
-			if len(tmp) == config.PageSize {
-				page++
-			}
-		case 0:
-			return nil
+	items := make([]utils.MenuItem, len(i18n.Langs))
+	for i, lang := range i18n.Langs {
+		lang := lang
+		items[i] = utils.MenuItem{
+			Label: langNames[lang],
+			Action: func() {
+				// подписи меню берутся из каталога при создании, поэтому меню строится заново
+				t.SetLanguage(lang)
+				s.Reset()
+				t.mainMenu()
+			},
 		}
 	}
+
+	s.Push(s.T(i18n.TuiMenuLanguage), utils.NewMenu(items), s.T(i18n.TuiHintMenu))
 }
 
-func generateActionsPrompt(role string) (actionsList []Action, actionsPrompt string) {
-	actionsList = make([]Action, 0)
+var langNames = map[i18n.Lang]string{
+	i18n.Ru: "Русский",
+	i18n.En: "English",
+}
+
+// userMenu показывает действия, разрешённые роли вошедшего пользователя.
+// Закрытие меню завершает сеанс.
+func (t *TUI) userMenu() {
+	s := t.screen
+
+	role, err := t.app.RoleSvc.GetByName(context.Background(), t.actor.Role)
+	if err != nil {
+		t.actor = nil
+		s.Error(fmt.Errorf("получение разрешений роли: %w", err))
+		return
+	}
+
+	items := make([]utils.MenuItem, 0, len(actions)+1)
+	for _, action := range allowedActions(role) {
+		items = append(items, utils.MenuItem{Label: s.T(action.Name), Action: t.run(action.Func)})
+	}
+	items = append(items, utils.MenuItem{Label: s.T(i18n.TuiMenuLogout), Action: s.Pop})
+
+	s.Push(role.Name, utils.NewMenu(items), s.T(i18n.TuiHintMenu))
+	s.OnClose(func() {
+		t.actor = nil
+	})
+}
 
-	j := 1
+func allowedActions(role *domain.Role) (allowed []Action) {
 	for _, action := range actions {
-		if role == action.Role {
-			actionsList = append(actionsList, action)
-			actionsPrompt += fmt.Sprintf("\n%d. %s", j, action.Name)
-			j++
+		if action.Permission == "" || role.HasPermission(action.Permission) {
+			allowed = append(allowed, action)
 		}
 	}
-	actionsPrompt += "\n\nВыберите действие: "
 
-	return actionsList, actionsPrompt
+	return allowed
 }
@@ -0,0 +1,63 @@
+package tui
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"ppo/domain"
+	"ppo/pkg/base"
+	"testing"
+	"time"
+)
+
+func Test_actorFromToken(t *testing.T) {
+	jwtKey := "abcdefgh123"
+	id := uuid.New()
+
+	testCases := []struct {
+		name    string
+		token   func() string
+		want    *domain.Actor
+		wantErr bool
+	}{
+		{
+			name: "идентификатор и роль из токена",
+			token: func() string {
+				token, err := base.GenerateAuthToken(id.String(), jwtKey, "admin", time.Hour)
+				require.NoError(t, err)
+				return token
+			},
+			want: &domain.Actor{ID: id, Role: "admin"},
+		},
+		{
+			name: "токен подписан другим ключом",
+			token: func() string {
+				token, err := base.GenerateAuthToken(id.String(), "other", "admin", time.Hour)
+				require.NoError(t, err)
+				return token
+			},
+			wantErr: true,
+		},
+		{
+			name: "в токене не uuid",
+			token: func() string {
+				token, err := base.GenerateAuthToken("test123", jwtKey, "user", time.Hour)
+				require.NoError(t, err)
+				return token
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actor, err := actorFromToken(tc.token(), jwtKey)
+
+			if tc.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.want, actor)
+			}
+		})
+	}
+}
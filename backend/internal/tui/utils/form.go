@@ -0,0 +1,256 @@
+package utils
+
+import (
+	"errors"
+	"ppo/domain"
+	"ppo/pkg/i18n"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/google/uuid"
+	"github.com/rivo/tview"
+)
+
+const dateLayout = "2006-01-02"
+
+// Form — форма ввода с сообщением об ошибке под полями. Поля регистрируются
+// под ключами, совпадающими с ValidationError.Field сервисов, поэтому при ошибке
+// валидации фокус переходит на поле с некорректным значением.
+type Form struct {
+	*tview.Flex
+	form   *tview.Form
+	status *tview.TextView
+	screen *Screen
+	index  map[string]int
+	values map[string]any
+	submit func() error
+	done   func()
+}
+
+func NewForm(s *Screen) *Form {
+	f := &Form{
+		form:   tview.NewForm(),
+		status: tview.NewTextView().SetDynamicColors(true),
+		screen: s,
+		index:  make(map[string]int),
+		values: make(map[string]any),
+	}
+
+	f.Flex = tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(f.form, 0, 1, true).
+		AddItem(f.status, 2, 0, false)
+
+	return f
+}
+
+// Input добавляет текстовое поле key.
+func (f *Form) Input(key, label, value string) *Form {
+	f.add(key, tview.NewInputField().SetLabel(label).SetText(value).SetFieldWidth(40))
+	return f
+}
+
+// Password добавляет поле key, скрывающее ввод.
+func (f *Form) Password(key, label string) *Form {
+	f.add(key, tview.NewInputField().SetLabel(label).SetMaskCharacter('*').SetFieldWidth(40))
+	return f
+}
+
+// Number добавляет поле key, принимающее только запись числа.
+func (f *Form) Number(key, label, value string) *Form {
+	f.add(key, tview.NewInputField().SetLabel(label).SetText(value).SetFieldWidth(20).
+		SetAcceptanceFunc(tview.InputFieldFloat))
+	return f
+}
+
+// Integer добавляет поле key, принимающее только запись целого числа.
+func (f *Form) Integer(key, label, value string) *Form {
+	f.add(key, tview.NewInputField().SetLabel(label).SetText(value).SetFieldWidth(10).
+		SetAcceptanceFunc(tview.InputFieldInteger))
+	return f
+}
+
+// Select добавляет выпадающий список key с вариантами options.
+func (f *Form) Select(key, label string, options []string, current int) *Form {
+	f.add(key, tview.NewDropDown().SetLabel(label).SetOptions(options, nil).SetCurrentOption(current))
+	return f
+}
+
+// Picker добавляет поле выбора key: Enter вызывает open, которая показывает список
+// и передаёт выбранное в set — text выводится в поле, value возвращает Value(key).
+func (f *Form) Picker(key, label, text string, value any, open func(set func(text string, value any))) *Form {
+	field := tview.NewInputField().SetLabel(label).SetText(text).SetFieldWidth(40).
+		SetPlaceholder(f.screen.T(i18n.TuiPick)).
+		SetAcceptanceFunc(func(string, rune) bool { return false })
+
+	set := func(text string, value any) {
+		field.SetText(text)
+		f.values[key] = value
+	}
+	field.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEnter {
+			open(set)
+			return nil
+		}
+
+		return event
+	})
+
+	if value != nil {
+		f.values[key] = value
+	}
+	f.add(key, field)
+
+	return f
+}
+
+// Show открывает форму.
+func (f *Form) Show(title string) {
+	f.screen.Push(title, f, f.screen.T(i18n.TuiHintForm))
+}
+
+// OnSubmit добавляет кнопки «Сохранить» и «Отмена». При сохранении вызывается fn;
+// её ошибка выводится в форме, а при успехе форма закрывается и вызывается OnDone.
+func (f *Form) OnSubmit(fn func() error) *Form {
+	f.submit = fn
+	f.form.AddButton(f.screen.T(i18n.TuiSave), f.Submit)
+	f.form.AddButton(f.screen.T(i18n.TuiCancel), f.screen.Pop)
+
+	return f
+}
+
+// OnDone задаёт fn, вызываемую после успешного сохранения и закрытия формы.
+func (f *Form) OnDone(fn func()) *Form {
+	f.done = fn
+	return f
+}
+
+func (f *Form) Submit() {
+	err := f.submit()
+	if err != nil {
+		f.Fail(err)
+		return
+	}
+
+	f.screen.Pop()
+	if f.done != nil {
+		f.done()
+	}
+}
+
+// Fail выводит err под полями и переводит фокус на поле, к которому она относится.
+func (f *Form) Fail(err error) {
+	f.status.SetText("[red]" + tview.Escape(domain.LocalizeError(f.screen.Lang(), err)))
+
+	var validationErr *domain.ValidationError
+	if errors.As(err, &validationErr) {
+		if i, ok := f.index[validationErr.Field]; ok {
+			f.form.SetFocus(i)
+			f.screen.App().SetFocus(f.form)
+		}
+	}
+}
+
+// Status возвращает текст, выведенный под полями.
+func (f *Form) Status() string {
+	return f.status.GetText(true)
+}
+
+// Text возвращает значение поля key без пробелов по краям.
+func (f *Form) Text(key string) string {
+	field, ok := f.item(key).(*tview.InputField)
+	if !ok {
+		return ""
+	}
+
+	return strings.TrimSpace(field.GetText())
+}
+
+// Float разбирает число из поля key.
+func (f *Form) Float(key string) (float32, error) {
+	v, err := strconv.ParseFloat(f.Text(key), 32)
+	if err != nil {
+		return 0, domain.NewValidationError(key, i18n.TuiNotANumber)
+	}
+
+	return float32(v), nil
+}
+
+// Int разбирает целое число из поля key.
+func (f *Form) Int(key string) (int, error) {
+	v, err := strconv.Atoi(f.Text(key))
+	if err != nil {
+		return 0, domain.NewValidationError(key, i18n.TuiNotAnInteger)
+	}
+
+	return v, nil
+}
+
+// Date разбирает дату в формате ГГГГ-ММ-ДД из поля key.
+func (f *Form) Date(key string) (time.Time, error) {
+	v, err := time.Parse(dateLayout, f.Text(key))
+	if err != nil {
+		return time.Time{}, domain.NewValidationError(key, i18n.TuiInvalidDate)
+	}
+
+	return v, nil
+}
+
+// Option возвращает номер и текст варианта, выбранного в списке key.
+func (f *Form) Option(key string) (index int, text string) {
+	dropDown, ok := f.item(key).(*tview.DropDown)
+	if !ok {
+		return -1, ""
+	}
+
+	return dropDown.GetCurrentOption()
+}
+
+// Value возвращает значение, выбранное в поле выбора key, или nil.
+func (f *Form) Value(key string) any {
+	return f.values[key]
+}
+
+// UUID возвращает идентификатор, выбранный в поле выбора key.
+func (f *Form) UUID(key string) (uuid.UUID, error) {
+	id, ok := f.values[key].(uuid.UUID)
+	if !ok {
+		return uuid.Nil, domain.NewValidationError(key, i18n.TuiNothingChosen)
+	}
+
+	return id, nil
+}
+
+func (f *Form) add(key string, item tview.FormItem) {
+	f.index[key] = f.form.GetFormItemCount()
+	f.form.AddFormItem(item)
+}
+
+func (f *Form) item(key string) tview.FormItem {
+	i, ok := f.index[key]
+	if !ok {
+		return nil
+	}
+
+	return f.form.GetFormItem(i)
+}
+
+func (f *Form) dropDownOpen() bool {
+	for i := 0; i < f.form.GetFormItemCount(); i++ {
+		if dropDown, ok := f.form.GetFormItem(i).(*tview.DropDown); ok && dropDown.IsOpen() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FormatDate выводит дату в формате полей дат формы.
+func FormatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(dateLayout)
+}
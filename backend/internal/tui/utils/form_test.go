@@ -0,0 +1,127 @@
+package utils
+
+import (
+	"errors"
+	"github.com/gdamore/tcell/v2"
+	"github.com/google/uuid"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/require"
+	"ppo/domain"
+	"ppo/pkg/i18n"
+	"testing"
+)
+
+func enterKey() *tcell.EventKey {
+	return tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone)
+}
+
+func TestForm_Submit(t *testing.T) {
+	testCases := []struct {
+		name       string
+		submit     func(form *Form) error
+		wantDepth  int
+		wantStatus string
+		wantFocus  string
+	}{
+		{
+			name:      "успешное сохранение закрывает форму",
+			submit:    func(form *Form) error { return nil },
+			wantDepth: 1,
+		},
+		{
+			name: "ошибка валидации выводится и фокусирует поле",
+			submit: func(form *Form) error {
+				_, err := form.Float("cost")
+				return err
+			},
+			wantDepth:  2,
+			wantStatus: "ожидается число",
+			wantFocus:  "Вес",
+		},
+		{
+			name: "ошибка сервиса по ключу поля",
+			submit: func(form *Form) error {
+				return domain.NewValidationError("name", i18n.MsgCompanyNameRequired)
+			},
+			wantDepth:  2,
+			wantStatus: "должно быть указано название компании",
+			wantFocus:  "Название",
+		},
+		{
+			name: "не выбрано значение поля выбора",
+			submit: func(form *Form) error {
+				_, err := form.UUID("field_id")
+				return err
+			},
+			wantDepth:  2,
+			wantStatus: "значение не выбрано",
+			wantFocus:  "Сфера",
+		},
+		{
+			name:       "прочая ошибка выводится как есть",
+			submit:     func(form *Form) error { return errors.New("нет соединения") },
+			wantDepth:  2,
+			wantStatus: "нет соединения",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewScreen(tview.NewApplication(), i18n.Ru)
+			s.Push("Меню", tview.NewBox(), "")
+
+			form := NewForm(s).
+				Input("name", "Название", "").
+				Number("cost", "Вес", "").
+				Picker("field_id", "Сфера", "", nil, func(func(string, any)) {})
+			form.OnSubmit(func() error { return tc.submit(form) })
+			form.Show("Форма")
+
+			form.Submit()
+
+			require.Equal(t, tc.wantDepth, s.Depth())
+			require.Equal(t, tc.wantStatus, form.Status())
+			if tc.wantFocus != "" {
+				field, ok := s.App().GetFocus().(*tview.InputField)
+				require.True(t, ok)
+				require.Equal(t, tc.wantFocus, field.GetLabel())
+			}
+		})
+	}
+}
+
+func TestForm_Picker(t *testing.T) {
+	s := NewScreen(tview.NewApplication(), i18n.Ru)
+	id := uuid.New()
+
+	form := NewForm(s).Picker("field_id", "Сфера", "", nil, func(set func(string, any)) {
+		set("Торговля", id)
+	})
+	form.Show("Форма")
+
+	field := s.App().GetFocus().(*tview.InputField)
+	field.InputHandler()(enterKey(), func(tview.Primitive) {})
+
+	got, err := form.UUID("field_id")
+	require.NoError(t, err)
+	require.Equal(t, id, got)
+	require.Equal(t, "Торговля", field.GetText())
+}
+
+func TestScreen_Escape(t *testing.T) {
+	s := NewScreen(tview.NewApplication(), i18n.Ru)
+	s.Push("Меню", tview.NewBox(), "")
+
+	closed := false
+	s.Push("Страница", tview.NewBox(), "")
+	s.OnClose(func() { closed = true })
+
+	esc := tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone)
+	s.Root().InputHandler()(esc, func(p tview.Primitive) { s.App().SetFocus(p) })
+	require.Equal(t, 1, s.Depth())
+	require.True(t, closed)
+
+	// нижняя страница по Esc не закрывается
+	s.Root().InputHandler()(esc, func(p tview.Primitive) { s.App().SetFocus(p) })
+	require.Equal(t, 1, s.Depth())
+}
@@ -0,0 +1,28 @@
+package utils
+
+import (
+	"github.com/rivo/tview"
+)
+
+// MenuItem — пункт меню и действие, выполняемое при его выборе.
+type MenuItem struct {
+	Label  string
+	Action func()
+}
+
+const shortcuts = "123456789abcdefghijklmnopqrstuvwxyz"
+
+// NewMenu создаёт меню, пункты которого выбираются стрелками или клавишами 1–9, a–z.
+func NewMenu(items []MenuItem) *tview.List {
+	list := tview.NewList().ShowSecondaryText(false)
+	for i, item := range items {
+		var shortcut rune
+		if i < len(shortcuts) {
+			shortcut = rune(shortcuts[i])
+		}
+
+		list.AddItem(item.Label, "", shortcut, item.Action)
+	}
+
+	return list
+}
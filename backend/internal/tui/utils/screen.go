@@ -0,0 +1,215 @@
+package utils
+
+import (
+	"fmt"
+	"ppo/domain"
+	"ppo/pkg/i18n"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// Screen — полноэкранный интерфейс: стек страниц, заголовок и строка подсказок.
+// Esc закрывает верхнюю страницу; нижняя страница стека закрывается только явно.
+type Screen struct {
+	app    *tview.Application
+	pages  *tview.Pages
+	title  *tview.TextView
+	hints  *tview.TextView
+	root   *tview.Flex
+	lang   i18n.Lang
+	stack  []*page
+	nextID int
+}
+
+type page struct {
+	name    string
+	title   string
+	hints   string
+	onClose func()
+	// focus — элемент страницы, в котором был фокус, когда поверх неё открыли следующую.
+	focus tview.Primitive
+}
+
+func NewScreen(app *tview.Application, lang i18n.Lang) *Screen {
+	s := &Screen{
+		app:   app,
+		pages: tview.NewPages(),
+		title: tview.NewTextView().SetDynamicColors(true),
+		hints: tview.NewTextView().SetDynamicColors(true),
+		lang:  lang,
+	}
+
+	s.root = tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(s.title, 1, 0, false).
+		AddItem(s.pages, 0, 1, true).
+		AddItem(s.hints, 1, 0, false)
+
+	return s
+}
+
+func (s *Screen) Root() tview.Primitive {
+	return s.root
+}
+
+func (s *Screen) App() *tview.Application {
+	return s.app
+}
+
+func (s *Screen) Lang() i18n.Lang {
+	return s.lang
+}
+
+func (s *Screen) SetLang(lang i18n.Lang) {
+	s.lang = lang
+}
+
+// T возвращает сообщение key на языке интерфейса.
+func (s *Screen) T(key i18n.Key, args ...any) string {
+	return i18n.T(s.lang, key, args...)
+}
+
+// Push показывает p поверх текущей страницы. Заголовки открытых страниц
+// выводятся цепочкой, hints — в строке подсказок.
+func (s *Screen) Push(title string, p tview.Primitive, hints string) {
+	s.nextID++
+	pg := &page{name: fmt.Sprintf("page-%d", s.nextID), title: title, hints: hints}
+	s.rememberFocus()
+	s.stack = append(s.stack, pg)
+
+	s.pages.AddAndSwitchToPage(pg.name, s.capture(p), true)
+	s.app.SetFocus(p)
+	s.refresh()
+}
+
+// OnClose задаёт fn, вызываемую при закрытии верхней страницы.
+func (s *Screen) OnClose(fn func()) {
+	if len(s.stack) != 0 {
+		s.stack[len(s.stack)-1].onClose = fn
+	}
+}
+
+// Pop закрывает верхнюю страницу.
+func (s *Screen) Pop() {
+	if len(s.stack) == 0 {
+		return
+	}
+
+	pg := s.stack[len(s.stack)-1]
+	s.stack = s.stack[:len(s.stack)-1]
+	s.pages.RemovePage(pg.name)
+	if pg.onClose != nil {
+		pg.onClose()
+	}
+
+	if len(s.stack) != 0 {
+		top := s.stack[len(s.stack)-1]
+		s.pages.SwitchToPage(top.name)
+		if top.focus != nil {
+			s.app.SetFocus(top.focus)
+		} else if _, p := s.pages.GetFrontPage(); p != nil {
+			s.app.SetFocus(p)
+		}
+	}
+	s.refresh()
+}
+
+func (s *Screen) rememberFocus() {
+	if len(s.stack) != 0 {
+		s.stack[len(s.stack)-1].focus = s.app.GetFocus()
+	}
+}
+
+// Reset закрывает все страницы.
+func (s *Screen) Reset() {
+	for len(s.stack) != 0 {
+		s.Pop()
+	}
+}
+
+// Depth возвращает число открытых страниц.
+func (s *Screen) Depth() int {
+	return len(s.stack)
+}
+
+// Stop завершает работу приложения.
+func (s *Screen) Stop() {
+	s.app.Stop()
+}
+
+// Error показывает ошибку err на языке интерфейса.
+func (s *Screen) Error(err error) {
+	s.modal("[red]"+tview.Escape(domain.LocalizeError(s.lang, err)), []string{s.T(i18n.TuiOK)}, nil)
+}
+
+// Info показывает сообщение msg.
+func (s *Screen) Info(msg string) {
+	s.modal(tview.Escape(msg), []string{s.T(i18n.TuiOK)}, nil)
+}
+
+// Confirm спрашивает подтверждение и при согласии вызывает onYes.
+func (s *Screen) Confirm(msg string, onYes func()) {
+	s.modal(tview.Escape(msg), []string{s.T(i18n.TuiYes), s.T(i18n.TuiNo)}, func(index int) {
+		if index == 0 {
+			onYes()
+		}
+	})
+}
+
+// modal показывает диалог с кнопками buttons; done получает номер нажатой кнопки
+// и вызывается после закрытия диалога, поэтому может открывать новые страницы.
+func (s *Screen) modal(text string, buttons []string, done func(index int)) {
+	modal := tview.NewModal().SetText(text).AddButtons(buttons)
+	modal.SetDoneFunc(func(index int, _ string) {
+		s.Pop()
+		if done != nil {
+			done(index)
+		}
+	})
+
+	s.nextID++
+	pg := &page{name: fmt.Sprintf("modal-%d", s.nextID)}
+	if len(s.stack) != 0 {
+		top := s.stack[len(s.stack)-1]
+		pg.title, pg.hints = top.title, top.hints
+	}
+	s.rememberFocus()
+	s.stack = append(s.stack, pg)
+
+	s.pages.AddPage(pg.name, modal, true, true)
+	s.app.SetFocus(modal)
+}
+
+// capture оборачивает p так, чтобы Esc закрывал страницу. Открытый выпадающий
+// список получает Esc сам и закрывается, не закрывая страницу.
+func (s *Screen) capture(p tview.Primitive) tview.Primitive {
+	wrapper := tview.NewFlex().AddItem(p, 0, 1, true)
+	wrapper.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() != tcell.KeyEscape || len(s.stack) < 2 {
+			return event
+		}
+
+		if form, ok := p.(*Form); ok && form.dropDownOpen() {
+			return event
+		}
+
+		s.Pop()
+		return nil
+	})
+
+	return wrapper
+}
+
+func (s *Screen) refresh() {
+	title := "[::b]" + tview.Escape(s.T(i18n.TuiTitle)) + "[::-]"
+	var hints string
+	for _, pg := range s.stack {
+		if pg.title != "" {
+			title += " › " + tview.Escape(pg.title)
+		}
+		hints = pg.hints
+	}
+
+	s.title.SetText(title)
+	s.hints.SetText("[gray]" + tview.Escape(hints))
+}
@@ -0,0 +1,255 @@
+package utils
+
+import (
+	"context"
+	"ppo/pkg/i18n"
+	"sort"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// Column описывает столбец таблицы.
+type Column[T any] struct {
+	Title string
+	Value func(*T) string
+	// Less задаёт порядок при сортировке по столбцу; без неё строки сравниваются по Value.
+	Less func(a, b *T) bool
+}
+
+// PageFunc возвращает страницу page (с 1) и общее число страниц.
+type PageFunc[T any] func(ctx context.Context, page int) (items []*T, numPages int, err error)
+
+// All превращает загрузку непагинированного списка в PageFunc с единственной страницей.
+func All[T any](fn func(ctx context.Context) ([]*T, error)) PageFunc[T] {
+	return func(ctx context.Context, _ int) ([]*T, int, error) {
+		items, err := fn(ctx)
+		return items, 1, err
+	}
+}
+
+// Table — постраничная таблица. ←/→ листают страницы, цифра N сортирует текущую
+// страницу по N-му столбцу (повторное нажатие меняет направление), Enter выбирает строку.
+type Table[T any] struct {
+	*tview.Flex
+	table    *tview.Table
+	footer   *tview.TextView
+	screen   *Screen
+	columns  []Column[T]
+	load     PageFunc[T]
+	onSelect func(*T)
+
+	items    []*T
+	page     int
+	numPages int
+	// sortColumn — номер столбца сортировки; -1 — порядок, в котором вернул сервис.
+	sortColumn int
+	desc       bool
+}
+
+func NewTable[T any](s *Screen, columns []Column[T], load PageFunc[T]) *Table[T] {
+	t := &Table[T]{
+		table:      tview.NewTable().SetSelectable(true, false).SetFixed(1, 0),
+		footer:     tview.NewTextView().SetDynamicColors(true),
+		screen:     s,
+		columns:    columns,
+		load:       load,
+		page:       1,
+		sortColumn: -1,
+	}
+
+	t.Flex = tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(t.table, 0, 1, true).
+		AddItem(t.footer, 1, 0, false)
+
+	t.table.SetSelectedFunc(func(row, _ int) {
+		if item := t.Selected(); item != nil && t.onSelect != nil {
+			t.onSelect(item)
+		}
+	})
+	t.table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyLeft:
+			t.turn(-1)
+		case event.Key() == tcell.KeyRight:
+			t.turn(1)
+		case event.Key() == tcell.KeyRune && event.Rune() >= '1' && event.Rune() <= '9':
+			t.SortBy(int(event.Rune() - '1'))
+		default:
+			return event
+		}
+
+		return nil
+	})
+
+	return t
+}
+
+// OnSelect задаёт fn, вызываемую при выборе строки.
+func (t *Table[T]) OnSelect(fn func(*T)) *Table[T] {
+	t.onSelect = fn
+	return t
+}
+
+// Show загружает первую страницу и открывает таблицу.
+func (t *Table[T]) Show(title string) error {
+	err := t.Reload()
+	if err != nil {
+		return err
+	}
+
+	t.screen.Push(title, t, t.screen.T(i18n.TuiHintTable))
+	return nil
+}
+
+// Reload заново загружает текущую страницу.
+func (t *Table[T]) Reload() error {
+	items, numPages, err := t.load(context.Background(), t.page)
+	if err != nil {
+		return err
+	}
+
+	// после удаления последней записи страницы её может больше не быть
+	if numPages > 0 && t.page > numPages {
+		t.page = numPages
+		return t.Reload()
+	}
+
+	// сортировка не должна менять срез, который вернул загрузчик
+	t.items, t.numPages = append([]*T(nil), items...), numPages
+	t.sort()
+	t.render()
+
+	return nil
+}
+
+// Refresh перезагружает текущую страницу и выводит ошибку загрузки на экран.
+func (t *Table[T]) Refresh() {
+	err := t.Reload()
+	if err != nil {
+		t.screen.Error(err)
+	}
+}
+
+// Confirm спрашивает подтверждение msg, выполняет fn и перезагружает таблицу.
+func (t *Table[T]) Confirm(msg string, fn func() error) {
+	t.screen.Confirm(msg, func() {
+		err := fn()
+		if err != nil {
+			t.screen.Error(err)
+			return
+		}
+
+		t.Refresh()
+	})
+}
+
+// Selected возвращает запись выбранной строки или nil.
+func (t *Table[T]) Selected() *T {
+	row, _ := t.table.GetSelection()
+	if row < 1 || row > len(t.items) {
+		return nil
+	}
+
+	return t.items[row-1]
+}
+
+// Items возвращает записи текущей страницы в порядке вывода.
+func (t *Table[T]) Items() []*T {
+	return t.items
+}
+
+// Page возвращает номер текущей страницы.
+func (t *Table[T]) Page() int {
+	return t.page
+}
+
+// SortBy сортирует текущую страницу по столбцу column, при повторном вызове — в обратном порядке.
+func (t *Table[T]) SortBy(column int) {
+	if column < 0 || column >= len(t.columns) {
+		return
+	}
+
+	if t.sortColumn == column {
+		t.desc = !t.desc
+	} else {
+		t.sortColumn, t.desc = column, false
+	}
+
+	t.sort()
+	t.render()
+}
+
+func (t *Table[T]) turn(delta int) {
+	page := t.page + delta
+	if page < 1 || page > t.numPages {
+		return
+	}
+
+	prev := t.page
+	t.page = page
+	err := t.Reload()
+	if err != nil {
+		t.page = prev
+		t.screen.Error(err)
+	}
+}
+
+func (t *Table[T]) sort() {
+	if t.sortColumn < 0 {
+		return
+	}
+
+	col := t.columns[t.sortColumn]
+	less := col.Less
+	if less == nil {
+		less = func(a, b *T) bool { return col.Value(a) < col.Value(b) }
+	}
+
+	sort.SliceStable(t.items, func(i, j int) bool {
+		if t.desc {
+			return less(t.items[j], t.items[i])
+		}
+		return less(t.items[i], t.items[j])
+	})
+}
+
+func (t *Table[T]) render() {
+	t.table.Clear()
+
+	for i, col := range t.columns {
+		title := col.Title
+		if i == t.sortColumn {
+			if t.desc {
+				title += " ▼"
+			} else {
+				title += " ▲"
+			}
+		}
+
+		t.table.SetCell(0, i, tview.NewTableCell(tview.Escape(title)).
+			SetAttributes(tcell.AttrBold).SetSelectable(false).SetExpansion(1))
+	}
+
+	for row, item := range t.items {
+		for i, col := range t.columns {
+			t.table.SetCell(row+1, i, tview.NewTableCell(tview.Escape(col.Value(item))).SetExpansion(1))
+		}
+	}
+
+	if len(t.items) == 0 {
+		t.footer.SetText("[gray]" + t.screen.T(i18n.TuiEmpty))
+	} else {
+		t.footer.SetText("[gray]" + t.screen.T(i18n.TuiPage, t.page, max(t.numPages, 1)))
+		t.table.Select(1, 0)
+	}
+}
+
+// Pick открывает таблицу для выбора записи: выбранная запись передаётся в onPick
+// после закрытия таблицы.
+func Pick[T any](s *Screen, title string, columns []Column[T], load PageFunc[T], onPick func(*T)) error {
+	return NewTable(s, columns, load).OnSelect(func(item *T) {
+		s.Pop()
+		onPick(item)
+	}).Show(title)
+}
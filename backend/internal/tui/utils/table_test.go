@@ -0,0 +1,159 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/require"
+	"ppo/pkg/i18n"
+	"testing"
+)
+
+type row struct {
+	name  string
+	value int
+}
+
+var rowColumns = []Column[row]{
+	{Title: "Название", Value: func(r *row) string { return r.name }},
+	{
+		Title: "Значение",
+		Value: func(r *row) string { return fmt.Sprint(r.value) },
+		Less:  func(a, b *row) bool { return a.value < b.value },
+	},
+}
+
+// pagesOf отдаёт rows страницами по size записей.
+func pagesOf(rows []*row, size int) PageFunc[row] {
+	return func(_ context.Context, page int) ([]*row, int, error) {
+		numPages := (len(rows) + size - 1) / size
+		start := min((page-1)*size, len(rows))
+		return rows[start:min(start+size, len(rows))], numPages, nil
+	}
+}
+
+func names(rows []*row) (res []string) {
+	for _, r := range rows {
+		res = append(res, r.name)
+	}
+	return res
+}
+
+func TestTable(t *testing.T) {
+	rows := []*row{{"в", 10}, {"а", 2}, {"б", 30}, {"г", 4}, {"д", 5}}
+
+	testCases := []struct {
+		name      string
+		actions   func(table *Table[row])
+		wantPage  int
+		wantNames []string
+	}{
+		{
+			name:      "первая страница в порядке сервиса",
+			actions:   func(table *Table[row]) {},
+			wantPage:  1,
+			wantNames: []string{"в", "а", "б"},
+		},
+		{
+			name:      "сортировка по строковому столбцу",
+			actions:   func(table *Table[row]) { table.SortBy(0) },
+			wantPage:  1,
+			wantNames: []string{"а", "б", "в"},
+		},
+		{
+			name: "повторная сортировка меняет направление",
+			actions: func(table *Table[row]) {
+				table.SortBy(1)
+				table.SortBy(1)
+			},
+			wantPage:  1,
+			wantNames: []string{"б", "в", "а"},
+		},
+		{
+			name: "следующая страница сохраняет сортировку",
+			actions: func(table *Table[row]) {
+				table.SortBy(1)
+				table.turn(1)
+			},
+			wantPage:  2,
+			wantNames: []string{"г", "д"},
+		},
+		{
+			name: "за последнюю страницу не листается",
+			actions: func(table *Table[row]) {
+				table.turn(1)
+				table.turn(1)
+			},
+			wantPage:  2,
+			wantNames: []string{"г", "д"},
+		},
+		{
+			name:      "несуществующий столбец игнорируется",
+			actions:   func(table *Table[row]) { table.SortBy(5) },
+			wantPage:  1,
+			wantNames: []string{"в", "а", "б"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewScreen(tview.NewApplication(), i18n.Ru)
+			table := NewTable(s, rowColumns, pagesOf(rows, 3))
+			require.NoError(t, table.Show("Строки"))
+
+			tc.actions(table)
+
+			require.Equal(t, tc.wantPage, table.Page())
+			require.Equal(t, tc.wantNames, names(table.Items()))
+			require.Equal(t, tc.wantNames[0], table.Selected().name)
+		})
+	}
+}
+
+func TestTable_ReloadAfterDelete(t *testing.T) {
+	rows := []*row{{"а", 1}, {"б", 2}, {"в", 3}, {"г", 4}}
+	s := NewScreen(tview.NewApplication(), i18n.Ru)
+	table := NewTable(s, rowColumns, func(ctx context.Context, page int) ([]*row, int, error) {
+		return pagesOf(rows, 3)(ctx, page)
+	})
+	require.NoError(t, table.Show("Строки"))
+	table.turn(1)
+
+	rows = rows[:3]
+	require.NoError(t, table.Reload())
+
+	require.Equal(t, 1, table.Page())
+	require.Equal(t, []string{"а", "б", "в"}, names(table.Items()))
+}
+
+func TestPick(t *testing.T) {
+	s := NewScreen(tview.NewApplication(), i18n.Ru)
+	s.Push("Меню", tview.NewBox(), "")
+
+	var picked *row
+	err := Pick(s, "Выбор", rowColumns, All(func(context.Context) ([]*row, error) {
+		return []*row{{"а", 1}, {"б", 2}}, nil
+	}), func(r *row) { picked = r })
+	require.NoError(t, err)
+	require.Equal(t, 2, s.Depth())
+
+	table := s.App().GetFocus().(*tview.Table)
+	table.Select(2, 0)
+	table.InputHandler()(enterKey(), nil)
+
+	require.Equal(t, "б", picked.name)
+	require.Equal(t, 1, s.Depth())
+}
+
+func TestPick_LoadError(t *testing.T) {
+	s := NewScreen(tview.NewApplication(), i18n.Ru)
+	loadErr := errors.New("нет соединения")
+
+	err := Pick(s, "Выбор", rowColumns, func(context.Context, int) ([]*row, int, error) {
+		return nil, 0, loadErr
+	}, func(*row) {})
+
+	require.ErrorIs(t, err, loadErr)
+	require.Equal(t, 0, s.Depth())
+}
@@ -2,201 +2,159 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
 	"ppo/internal/app"
 	"ppo/internal/config"
+	"ppo/internal/events"
+	"ppo/internal/scheduler"
+	"ppo/internal/services/webhook"
+	"ppo/internal/storage/postgres"
+	"ppo/rpc"
 	"ppo/web"
+	"sync"
+	"syscall"
 
-	"github.com/go-chi/chi/v5"
-	"github.com/go-chi/chi/v5/middleware"
-	"github.com/go-chi/cors"
 	"github.com/go-chi/jwtauth/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
+	"google.golang.org/grpc"
 )
 
 var tokenAuth *jwtauth.JWTAuth
 
-func newConn(ctx context.Context, cfg *config.DBConfig) (pool *pgxpool.Pool, err error) {
-	connStr := fmt.Sprintf("%s://%s:%s@%s:%s/%s", cfg.Driver, cfg.User, cfg.Password, cfg.Host, cfg.Port, cfg.Database)
+func main() {
+	grpcAddr := flag.String("grpc-addr", "", "адрес gRPC-сервера, например :9090; без флага gRPC не запускается")
 
-	pool, err = pgxpool.New(ctx, connStr)
+	cfg, err := config.Load(flag.CommandLine, os.Args[1:])
 	if err != nil {
-		return nil, fmt.Errorf("подключение к БД: %w", err)
+		log.Fatalln(err)
 	}
 
-	err = pool.Ping(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("пинг БД: %w", err)
+	// подкоманда вместо запуска сервера: ppo [флаги] migrate up
+	if flag.NArg() > 0 {
+		switch flag.Arg(0) {
+		case "migrate":
+			err = runMigrate(context.Background(), cfg, flag.Args()[1:], os.Stdout)
+		case "seed":
+			err = runSeed(context.Background(), cfg, flag.Args()[1:], os.Stdout)
+		default:
+			err = fmt.Errorf("неизвестная команда %q, ожидается migrate или seed", flag.Arg(0))
+		}
+		if err != nil {
+			log.Fatalln(err)
+		}
+		return
 	}
 
-	return pool, nil
-}
+	tokenAuth = jwtauth.New("HS256", []byte(cfg.JwtKey), nil)
 
-func main() {
-	cfg, err := config.ReadConfig()
+	// отменяется по SIGINT/SIGTERM: фоновые обработчики останавливаются, серверы дожидаются запросов
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	pool, err := postgres.Connect(ctx, &cfg.DBConfig)
 	if err != nil {
 		log.Fatalln(err)
 	}
 
-	tokenAuth = jwtauth.New("HS256", []byte(cfg.JwtKey), nil)
+	if cfg.DBConfig.AutoMigrate {
+		err = autoMigrate(pool)
+		if err != nil {
+			log.Fatalln(err)
+		}
+	}
+
+	a := app.NewApp(pool, cfg)
 
-	pool, err := newConn(context.Background(), &cfg.DBConfig)
+	var workers sync.WaitGroup
+	for _, run := range []func(context.Context){
+		func(ctx context.Context) { a.EventBus.Run(ctx, events.DefaultInterval) },
+		func(ctx context.Context) { a.WebhookDispatcher.Run(ctx, webhook.DefaultInterval) },
+		func(ctx context.Context) { a.Scheduler.Run(ctx, scheduler.DefaultInterval) },
+	} {
+		workers.Add(1)
+		go func(run func(context.Context)) {
+			defer workers.Done()
+			run(ctx)
+		}(run)
+	}
+
+	mux, err := web.NewRouter(a, tokenAuth, web.OpenAPIOptions{})
 	if err != nil {
 		log.Fatalln(err)
 	}
 
-	a := app.NewApp(pool, cfg)
+	var grpcServer *grpc.Server
+	if *grpcAddr != "" {
+		lis, err := net.Listen("tcp", *grpcAddr)
+		if err != nil {
+			log.Fatalln(fmt.Errorf("запуск gRPC-сервера: %w", err))
+		}
+
+		grpcServer = rpc.NewServer(a, tokenAuth)
+		go func() {
+			err := grpcServer.Serve(lis)
+			if err != nil {
+				log.Fatalln(fmt.Errorf("работа gRPC-сервера: %w", err))
+			}
+		}()
+
+		fmt.Println("grpc server was started on", *grpcAddr)
+	}
+
+	server := &http.Server{
+		Addr:              cfg.Server.Addr,
+		Handler:           mux,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		if cfg.Server.TLS() {
+			serveErr <- server.ListenAndServeTLS(cfg.Server.TLSCertFile, cfg.Server.TLSKeyFile)
+		} else {
+			serveErr <- server.ListenAndServe()
+		}
+	}()
+
+	fmt.Println("server was started on", cfg.Server.Addr)
+
+	var failed bool
+	select {
+	case err = <-serveErr:
+		log.Println(fmt.Errorf("работа HTTP-сервера: %w", err))
+		failed = true
+		stop()
+	case <-ctx.Done():
+	}
+
+	fmt.Println("shutting down")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
 
-	mux := chi.NewMux()
-
-	mux.Use(cors.Handler(cors.Options{
-		AllowedOrigins:   []string{"https://*", "http://*"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS", "PATCH"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-		ExposedHeaders:   []string{"Link"},
-		AllowCredentials: false,
-		MaxAge:           300, // Maximum value not ignored by any of major browsers
-	}))
-
-	mux.Use(middleware.Logger)
-
-	mux.Route("/skills", func(r chi.Router) {
-		r.Get("/{id}", web.GetSkill(a))
-		// r.Get("/", web.ListEntrepreneurSkills(a))
-		r.Get("/", web.ListSkills(a))
-
-		r.Group(func(r chi.Router) {
-			r.Use(jwtauth.Verifier(tokenAuth))
-			r.Use(jwtauth.Authenticator(tokenAuth))
-			r.Use(web.ValidateAdminRoleJWT)
-
-			r.Post("/create", web.CreateSkill(a))
-			r.Delete("/{id}/delete", web.DeleteSkill(a))
-			r.Patch("/{id}/update", web.UpdateSkill(a))
-		})
-	})
-
-	mux.Route("/entrepreneurs", func(r chi.Router) {
-		r.Get("/{id}", web.GetEntrepreneur(a))
-		r.Get("/", web.ListEntrepreneurs(a))
-		r.Get("/{id}/rating", web.CalculateRating(a))
-
-		r.Group(func(r chi.Router) {
-			r.Use(jwtauth.Verifier(tokenAuth))
-			r.Use(jwtauth.Authenticator(tokenAuth))
-			r.Use(web.ValidateAdminRoleJWT)
-
-			r.Patch("/{id}/update", web.UpdateEntrepreneur(a))
-			r.Delete("/{id}/delete", web.DeleteEntrepreneur(a))
-		})
-	})
-
-	mux.Route("/contacts", func(r chi.Router) {
-		r.Group(func(r chi.Router) {
-			r.Use(jwtauth.Verifier(tokenAuth))
-			r.Use(jwtauth.Authenticator(tokenAuth))
-			r.Use(web.ValidateUserRoleJWT)
-
-			r.Get("/{id}", web.GetContact(a))
-			r.Get("/", web.ListEntrepreneurContacts(a))
-
-			r.Post("/create", web.CreateContact(a))
-			r.Patch("/{id}/update", web.UpdateContact(a))
-			r.Delete("/{id}/delete", web.DeleteContact(a))
-		})
-	})
-
-	mux.Route("/activity_fields", func(r chi.Router) {
-		r.Get("/{id}", web.GetActivityField(a))
-		r.Get("/", web.ListActivityFields(a))
-
-		r.Group(func(r chi.Router) {
-			r.Use(jwtauth.Verifier(tokenAuth))
-			r.Use(jwtauth.Authenticator(tokenAuth))
-			r.Use(web.ValidateAdminRoleJWT)
-
-			r.Post("/create", web.CreateActivityField(a))
-			r.Patch("/{id}/update", web.UpdateActivityField(a))
-			r.Delete("/{id}/delete", web.DeleteActivityField(a))
-		})
-	})
-
-	mux.Route("/companies", func(r chi.Router) {
-		r.Get("/{id}", web.GetCompany(a))
-		r.Get("/", web.ListEntrepreneurCompanies(a))
-
-		r.Group(func(r chi.Router) {
-			r.Use(jwtauth.Verifier(tokenAuth))
-			r.Use(jwtauth.Authenticator(tokenAuth))
-			r.Use(web.ValidateUserRoleJWT)
-
-			r.Post("/create", web.CreateCompany(a))
-			r.Patch("/{id}/update", web.UpdateCompany(a))
-			r.Delete("/{id}/delete", web.DeleteCompany(a))
-		})
-
-		r.Route("/{id}/financials", func(r chi.Router) {
-			r.Use(jwtauth.Verifier(tokenAuth))
-			r.Use(jwtauth.Authenticator(tokenAuth))
-			r.Use(web.ValidateUserRoleJWT)
-
-			r.Post("/create", web.CreateReport(a))
-			r.Get("/{year-start}_{quarter-start}-{year-end}_{quarter-end}", web.ListCompanyReports(a))
-		})
-	})
-
-	mux.Route("/user-skills", func(r chi.Router) {
-		//r.Get("/{id}", web.GetUserSkill(a))
-		r.Get("/", web.ListEntrepreneurSkills(a))
-
-		r.Group(func(r chi.Router) {
-			r.Use(jwtauth.Verifier(tokenAuth))
-			r.Use(jwtauth.Authenticator(tokenAuth))
-			r.Use(web.ValidateUserRoleJWT)
-
-			r.Post("/create", web.CreateUserSkill(a))
-			r.Delete("/{id}/delete", web.DeleteUserSkill(a))
-		})
-	})
-
-	mux.Route("/financials", func(r chi.Router) {
-		r.Group(func(r chi.Router) {
-			r.Use(jwtauth.Verifier(tokenAuth))
-			r.Use(jwtauth.Authenticator(tokenAuth))
-			r.Use(web.ValidateUserRoleJWT)
-
-			r.Get("/", web.GetEntrepreneurFinancials(a))
-			r.Delete("/{id}/delete", web.DeleteFinReport(a))
-			r.Patch("/{id}/update", web.UpdateFinReport(a))
-		})
-	})
-
-	mux.Route("/reviews", func(r chi.Router) {
-		r.Get("/", web.GetEntrepreneurReviews(a))
-
-		r.Group(func(r chi.Router) {
-			r.Use(jwtauth.Verifier(tokenAuth))
-			r.Use(jwtauth.Authenticator(tokenAuth))
-			r.Use(web.ValidateUserRoleJWT)
-
-			r.Get("/my", web.GetAuthorReviews(a))
-			r.Post("/create", web.CreateReview(a))
-		})
-
-		r.Group(func(r chi.Router) {
-			r.Use(jwtauth.Verifier(tokenAuth))
-			r.Use(jwtauth.Authenticator(tokenAuth))
-			r.Use(web.ValidateAdminRoleJWT)
-
-			r.Delete("/{id}/delete", web.DeleteReview(a))
-		})
-	})
-
-	mux.Post("/login", web.LoginHandler(a))
-	mux.Post("/signup", web.RegisterHandler(a))
-
-	fmt.Println("server was started")
-	http.ListenAndServe(":8081", mux)
+	err = server.Shutdown(shutdownCtx)
+	if err != nil {
+		log.Println(fmt.Errorf("остановка HTTP-сервера: %w", err))
+	}
+
+	if grpcServer != nil {
+		grpcServer.GracefulStop()
+	}
+
+	// пул закрывается, только когда его больше никто не использует
+	workers.Wait()
+	a.Scheduler.Wait()
+	pool.Close()
+
+	if failed {
+		os.Exit(1)
+	}
 }
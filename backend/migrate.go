@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"ppo/internal/config"
+	"ppo/internal/storage/postgres"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const migrateUsage = "использование: migrate up | down [N] | status | force VERSION"
+
+// runMigrate выполняет команду migrate: up применяет все миграции, down откатывает
+// N последних (по умолчанию одну), status печатает состояние схемы, force записывает
+// версию схемы после ручного исправления неудачной миграции.
+func runMigrate(ctx context.Context, cfg *config.Config, args []string, out io.Writer) (err error) {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	var arg int
+	switch {
+	case args[0] == "up" || args[0] == "status":
+		if len(args) != 1 {
+			return errors.New(migrateUsage)
+		}
+	case args[0] == "down" && len(args) == 1:
+		arg = 1
+	case (args[0] == "down" || args[0] == "force") && len(args) == 2:
+		arg, err = strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("migrate %s: %q не является целым числом", args[0], args[1])
+		}
+	default:
+		return errors.New(migrateUsage)
+	}
+
+	pool, err := postgres.Connect(ctx, &cfg.DBConfig)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	m, err := postgres.NewMigrator(pool)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, m.Close())
+	}()
+
+	switch args[0] {
+	case "up":
+		err = m.Up()
+	case "down":
+		err = m.Down(arg)
+	case "force":
+		err = m.Force(arg)
+	}
+	if err != nil {
+		return err
+	}
+
+	return printMigrationStatus(m, out)
+}
+
+func printMigrationStatus(m *postgres.Migrator, out io.Writer) error {
+	status, err := m.Status()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATE")
+	for _, migration := range status.Migrations {
+		state := "pending"
+		switch {
+		case migration.Version == status.Version && status.Dirty:
+			state = "dirty"
+		case migration.Applied:
+			state = "applied"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\n", migration.Version, migration.Name, state)
+	}
+
+	return w.Flush()
+}
+
+// autoMigrate применяет недостающие миграции при запуске сервера.
+func autoMigrate(pool *pgxpool.Pool) (err error) {
+	m, err := postgres.NewMigrator(pool)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, m.Close())
+	}()
+
+	return m.Up()
+}
@@ -1,7 +1,10 @@
-drop table ppo.users;
-drop table ppo.skills;
-drop table ppo.user_skills;
+drop table ppo.reviews;
 drop table ppo.fin_reports;
-drop table ppo.contacts;
 drop table ppo.companies;
-drop table ppo.activity_fields;
\ No newline at end of file
+drop table ppo.activity_fields;
+drop table ppo.contacts;
+drop table ppo.user_skills;
+drop table ppo.skills;
+drop table ppo.users;
+
+drop schema if exists ppo;
@@ -0,0 +1,4 @@
+alter table ppo.users drop constraint fk_role;
+
+drop table ppo.role_permissions;
+drop table ppo.roles;
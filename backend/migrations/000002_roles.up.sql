@@ -0,0 +1,53 @@
+create table if not exists ppo.roles(
+    name varchar(32) primary key,
+    description text not null
+);
+
+create table if not exists ppo.role_permissions(
+    role_name varchar(32) not null,
+    permission varchar(64) not null
+);
+
+alter table ppo.role_permissions
+add constraint r_p_pk primary key (role_name, permission);
+
+alter table ppo.role_permissions add constraint fk_role foreign key (role_name) references ppo.roles(name);
+
+insert into ppo.roles(name, description)
+values
+    ('admin', 'Администратор'),
+    ('user', 'Предприниматель'),
+    ('accountant', 'Бухгалтер'),
+    ('editor', 'Редактор справочников');
+
+insert into ppo.role_permissions(role_name, permission)
+values
+    ('admin', 'users:manage'),
+    ('admin', 'roles:manage'),
+    ('admin', 'catalog:edit'),
+    ('admin', 'reviews:moderate'),
+    ('admin', 'reviews:write'),
+    ('admin', 'finance:read'),
+    ('admin', 'finance:write'),
+    ('admin', 'companies:write'),
+    ('admin', 'contacts:read'),
+    ('admin', 'contacts:write'),
+    ('admin', 'profile:edit'),
+
+    ('user', 'reviews:write'),
+    ('user', 'finance:read'),
+    ('user', 'finance:write'),
+    ('user', 'companies:write'),
+    ('user', 'contacts:read'),
+    ('user', 'contacts:write'),
+    ('user', 'profile:edit'),
+
+    ('accountant', 'finance:read'),
+    ('accountant', 'finance:write'),
+    ('accountant', 'contacts:read'),
+
+    ('editor', 'catalog:edit'),
+    ('editor', 'reviews:moderate'),
+    ('editor', 'contacts:read');
+
+alter table ppo.users add constraint fk_role foreign key (role) references ppo.roles(name) on update cascade;
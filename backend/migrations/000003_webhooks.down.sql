@@ -0,0 +1,6 @@
+delete from ppo.role_permissions where permission = 'webhooks:manage';
+
+drop table ppo.webhook_delivery_attempts;
+drop table ppo.webhook_deliveries;
+drop table ppo.webhook_subscriptions;
+drop table ppo.outbox;
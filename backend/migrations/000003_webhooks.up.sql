@@ -0,0 +1,44 @@
+create table if not exists ppo.outbox(
+    id uuid primary key default gen_random_uuid(),
+    event_type varchar(64) not null,
+    payload jsonb not null,
+    created_at timestamptz not null default now(),
+    processed_at timestamptz
+);
+
+create index if not exists outbox_unprocessed_idx on ppo.outbox(created_at) where processed_at is null;
+
+create table if not exists ppo.webhook_subscriptions(
+    id uuid primary key default gen_random_uuid(),
+    url text not null,
+    secret text not null,
+    event_types varchar(64)[] not null,
+    created_at timestamptz not null default now()
+);
+
+create table if not exists ppo.webhook_deliveries(
+    id uuid primary key default gen_random_uuid(),
+    subscription_id uuid not null references ppo.webhook_subscriptions(id) on delete cascade,
+    event_id uuid not null references ppo.outbox(id),
+    status varchar(16) not null default 'pending',
+    attempts int not null default 0,
+    next_attempt_at timestamptz not null default now(),
+    last_status_code int,
+    last_error text,
+    created_at timestamptz not null default now()
+);
+
+create index if not exists webhook_deliveries_due_idx on ppo.webhook_deliveries(next_attempt_at) where status = 'pending';
+
+create table if not exists ppo.webhook_delivery_attempts(
+    id uuid primary key default gen_random_uuid(),
+    delivery_id uuid not null references ppo.webhook_deliveries(id) on delete cascade,
+    attempt int not null,
+    status_code int,
+    error text,
+    duration_ms int not null,
+    created_at timestamptz not null default now()
+);
+
+insert into ppo.role_permissions(role_name, permission)
+values ('admin', 'webhooks:manage');
@@ -0,0 +1,7 @@
+drop index if exists ppo.outbox_due_idx;
+create index if not exists outbox_unprocessed_idx on ppo.outbox(created_at) where processed_at is null;
+
+alter table ppo.outbox
+    drop column last_error,
+    drop column next_attempt_at,
+    drop column attempts;
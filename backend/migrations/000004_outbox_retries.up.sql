@@ -0,0 +1,7 @@
+alter table ppo.outbox
+    add column if not exists attempts int not null default 0,
+    add column if not exists next_attempt_at timestamptz not null default now(),
+    add column if not exists last_error text;
+
+drop index if exists ppo.outbox_unprocessed_idx;
+create index if not exists outbox_due_idx on ppo.outbox(next_attempt_at) where processed_at is null;
@@ -0,0 +1 @@
+drop table ppo.rating_snapshots;
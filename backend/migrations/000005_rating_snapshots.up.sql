@@ -0,0 +1,13 @@
+create table if not exists ppo.rating_snapshots(
+    user_id uuid not null,
+    year int not null,
+    quarter int not null,
+    rating float4 not null,
+    revenue float4 not null,
+    profit float4 not null,
+    company_id uuid,
+    field_cost float4 not null,
+    max_cost float4 not null,
+    computed_at timestamptz not null default now(),
+    primary key (user_id, year, quarter)
+);
@@ -0,0 +1,3 @@
+delete from ppo.role_permissions where permission = 'jobs:manage';
+
+drop table ppo.job_runs;
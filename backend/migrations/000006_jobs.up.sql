@@ -0,0 +1,14 @@
+create table if not exists ppo.job_runs(
+    id uuid primary key default gen_random_uuid(),
+    job varchar(64) not null,
+    trigger varchar(16) not null,
+    status varchar(16) not null default 'running',
+    error text,
+    started_at timestamptz not null default now(),
+    finished_at timestamptz
+);
+
+create index if not exists job_runs_job_idx on ppo.job_runs(job, started_at desc);
+
+insert into ppo.role_permissions(role_name, permission)
+values ('admin', 'jobs:manage');
@@ -0,0 +1,4 @@
+delete from ppo.role_permissions where permission = 'audit:read';
+
+drop table ppo.audit_log;
+drop function ppo.audit_log_append_only();
@@ -0,0 +1,34 @@
+create table if not exists ppo.audit_log(
+    id uuid primary key default gen_random_uuid(),
+    actor_id uuid,
+    actor_role varchar(32) not null default '',
+    action varchar(32) not null,
+    entity_type varchar(32) not null,
+    entity_id varchar(128) not null,
+    before jsonb,
+    after jsonb,
+    -- clock_timestamp, а не now: записи одной транзакции различаются по времени
+    created_at timestamptz not null default clock_timestamp()
+);
+
+create index if not exists audit_log_entity_idx on ppo.audit_log(entity_type, entity_id, created_at desc);
+create index if not exists audit_log_actor_idx on ppo.audit_log(actor_id, created_at desc);
+create index if not exists audit_log_created_at_idx on ppo.audit_log(created_at desc);
+
+-- журнал только пополняется: изменение, удаление и очистка записей запрещены
+create or replace function ppo.audit_log_append_only() returns trigger as $$
+begin
+    raise exception 'журнал аудита нельзя изменять';
+end;
+$$ language plpgsql;
+
+create trigger audit_log_no_update_delete
+before update or delete on ppo.audit_log
+for each row execute function ppo.audit_log_append_only();
+
+create trigger audit_log_no_truncate
+before truncate on ppo.audit_log
+for each statement execute function ppo.audit_log_append_only();
+
+insert into ppo.role_permissions(role_name, permission)
+values ('admin', 'audit:read');
@@ -0,0 +1 @@
+drop table ppo.fin_report_revisions;
@@ -0,0 +1,21 @@
+create table if not exists ppo.fin_report_revisions(
+    report_id uuid not null,
+    version int not null,
+    revenue float4 not null,
+    costs float4 not null,
+    author_id uuid,
+    reason text not null default '',
+    filed_at timestamptz not null default clock_timestamp(),
+    primary key (report_id, version)
+);
+
+alter table ppo.fin_report_revisions add constraint fk_report foreign key (report_id) references ppo.fin_reports(id) on delete cascade;
+alter table ppo.fin_report_revisions add constraint chk_revenue check ( revenue >= 0.0 );
+alter table ppo.fin_report_revisions add constraint chk_costs check ( costs >= 0.0 );
+alter table ppo.fin_report_revisions add constraint chk_version check ( version > 0 );
+
+-- время подачи отчётов, существовавших до появления истории, неизвестно:
+-- их значения считаются известными с начала эпохи
+insert into ppo.fin_report_revisions(report_id, version, revenue, costs, filed_at)
+select id, 1, revenue, costs, 'epoch'::timestamptz
+from ppo.fin_reports;
@@ -0,0 +1,9 @@
+delete from ppo.role_permissions where permission = 'finance:verify';
+
+drop table ppo.fin_report_documents;
+
+alter table ppo.fin_reports drop constraint chk_status;
+alter table ppo.fin_reports drop column reviewed_at;
+alter table ppo.fin_reports drop column reviewer_id;
+alter table ppo.fin_reports drop column review_comment;
+alter table ppo.fin_reports drop column status;
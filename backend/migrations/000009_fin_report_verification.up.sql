@@ -0,0 +1,29 @@
+alter table ppo.fin_reports add column status varchar(16) not null default 'unverified';
+alter table ppo.fin_reports add column review_comment text not null default '';
+alter table ppo.fin_reports add column reviewer_id uuid;
+alter table ppo.fin_reports add column reviewed_at timestamptz;
+
+alter table ppo.fin_reports add constraint chk_status
+    check ( status in ('unverified', 'submitted', 'verified', 'rejected') );
+
+-- очередь проверки: отчёты, ожидающие решения
+create index if not exists fin_reports_submitted_idx on ppo.fin_reports(year, quarter) where status = 'submitted';
+
+create table if not exists ppo.fin_report_documents(
+    id uuid primary key default gen_random_uuid(),
+    report_id uuid not null,
+    name text not null,
+    content_type varchar(128) not null,
+    size bigint not null,
+    storage_key text not null unique,
+    uploaded_by uuid,
+    uploaded_at timestamptz not null default clock_timestamp()
+);
+
+alter table ppo.fin_report_documents add constraint fk_report foreign key (report_id) references ppo.fin_reports(id) on delete cascade;
+alter table ppo.fin_report_documents add constraint chk_size check ( size >= 0 );
+
+create index if not exists fin_report_documents_report_idx on ppo.fin_report_documents(report_id, uploaded_at);
+
+insert into ppo.role_permissions(role_name, permission)
+values ('admin', 'finance:verify');
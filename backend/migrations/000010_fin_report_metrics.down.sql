@@ -0,0 +1,12 @@
+alter table ppo.fin_reports drop constraint chk_exports_share;
+alter table ppo.fin_reports drop constraint chk_headcount;
+alter table ppo.fin_reports drop constraint chk_liabilities;
+alter table ppo.fin_reports drop constraint chk_assets;
+
+alter table ppo.fin_reports drop column exports_share;
+alter table ppo.fin_reports drop column headcount;
+alter table ppo.fin_reports drop column equity;
+alter table ppo.fin_reports drop column liabilities;
+alter table ppo.fin_reports drop column assets;
+alter table ppo.fin_reports drop column net_profit;
+alter table ppo.fin_reports drop column ebitda;
@@ -0,0 +1,12 @@
+alter table ppo.fin_reports add column ebitda float4;
+alter table ppo.fin_reports add column net_profit float4;
+alter table ppo.fin_reports add column assets float4;
+alter table ppo.fin_reports add column liabilities float4;
+alter table ppo.fin_reports add column equity float4;
+alter table ppo.fin_reports add column headcount int;
+alter table ppo.fin_reports add column exports_share float4;
+
+alter table ppo.fin_reports add constraint chk_assets check ( assets >= 0.0 );
+alter table ppo.fin_reports add constraint chk_liabilities check ( liabilities >= 0.0 );
+alter table ppo.fin_reports add constraint chk_headcount check ( headcount >= 0 );
+alter table ppo.fin_reports add constraint chk_exports_share check ( exports_share >= 0.0 and exports_share <= 100.0 );
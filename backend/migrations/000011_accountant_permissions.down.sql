@@ -0,0 +1,2 @@
+insert into ppo.role_permissions(role_name, permission)
+values ('accountant', 'finance:write');
@@ -0,0 +1,3 @@
+-- отчётами управляет только владелец компании, поэтому finance:write
+-- бухгалтеру ничего не давало; для чтения ему остаётся finance:read
+delete from ppo.role_permissions where role_name = 'accountant' and permission = 'finance:write';
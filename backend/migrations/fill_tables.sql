@@ -1,7 +0,0 @@
-INSERT INTO ppo.fin_reports (id, company_id, revenue, costs, year, quarter) VALUES ('65648dc9-4f2e-475a-bc8f-6360ea2f8fdd', '5731c3c2-69a6-496c-91f2-1b40ea21016f', 1230534, 10542, 2023, 1);
-INSERT INTO ppo.fin_reports (id, company_id, revenue, costs, year, quarter) VALUES ('c5c2ec11-457d-4ebf-b168-e9f46ded0c1e', '5731c3c2-69a6-496c-91f2-1b40ea21016f', 215435, 421242, 2023, 2);
-INSERT INTO ppo.fin_reports (id, company_id, revenue, costs, year, quarter) VALUES ('f4b2e6ae-e689-42e7-9ce2-0b955548c7a3', 'f31f3449-20c5-4677-bcd2-8978693ba258', 534523, 12353, 2023, 3);
-INSERT INTO ppo.fin_reports (id, company_id, revenue, costs, year, quarter) VALUES ('d876eb9b-6068-4022-8537-ecd13e7159aa', '22c91f77-a816-4b54-bc90-922dd7fdbfb4', 124235, 6432, 2023, 2);
-INSERT INTO ppo.fin_reports (id, company_id, revenue, costs, year, quarter) VALUES ('50cd4c6b-0dc1-4528-8cb1-6e9b9f676844', '22c91f77-a816-4b54-bc90-922dd7fdbfb4', 573464, 21324, 2022, 4);
-INSERT INTO ppo.fin_reports (id, company_id, revenue, costs, year, quarter) VALUES ('bb2ce590-8853-4d85-bf4d-c20fe8f6f434', '22c91f77-a816-4b54-bc90-922dd7fdbfb4', 9859464, 213421, 2023, 4);
-INSERT INTO ppo.fin_reports (id, company_id, revenue, costs, year, quarter) VALUES ('99c71db1-f4a7-4f9e-9125-03c4718ad0c6', '52b2cd24-d208-400a-ae4b-fdf233237593', 12415546, 5325212, 2022, 4);
@@ -0,0 +1,11 @@
+// Package migrations встраивает в бинарный файл миграции схемы БД. Каждая миграция —
+// пара файлов NNNNNN_name.up.sql и NNNNNN_name.down.sql; down полностью отменяет up.
+// Изменения схемы добавляются новыми версиями. Роли и пользователи СУБД миграции
+// не создают, для них есть отдельный скрипт sql/db_roles.sql.
+// Демонстрационные данные загружаются командой seed, а не миграциями.
+package migrations
+
+import "embed"
+
+//go:embed *.up.sql *.down.sql
+var FS embed.FS
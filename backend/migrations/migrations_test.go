@@ -0,0 +1,58 @@
+package migrations
+
+import (
+	"io/fs"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var migrationName = regexp.MustCompile(`^(\d{6})_(\w+)\.(up|down)\.sql$`)
+
+func TestFS(t *testing.T) {
+	files, err := fs.ReadDir(FS, ".")
+	require.Nil(t, err)
+
+	ups := make(map[int]string)
+	downs := make(map[int]string)
+	for _, file := range files {
+		match := migrationName.FindStringSubmatch(file.Name())
+		require.NotNil(t, match, "имя миграции %s не в формате NNNNNN_name.up.sql", file.Name())
+
+		version, err := strconv.Atoi(match[1])
+		require.Nil(t, err)
+
+		data, err := fs.ReadFile(FS, file.Name())
+		require.Nil(t, err)
+		require.NotEmpty(t, strings.TrimSpace(string(data)), "пустая миграция %s", file.Name())
+
+		if match[3] == "up" {
+			ups[version] = match[2]
+		} else {
+			downs[version] = match[2]
+		}
+	}
+
+	t.Run("у каждой миграции есть откат", func(t *testing.T) {
+		require.Equal(t, ups, downs)
+	})
+
+	t.Run("версии идут подряд с единицы", func(t *testing.T) {
+		for version := 1; version <= len(ups); version++ {
+			require.Contains(t, ups, version)
+		}
+	})
+
+	t.Run("миграции не создают ролей СУБД", func(t *testing.T) {
+		// роли приложения хранятся в ppo.roles; роли и пользователи СУБД настраиваются вне миграций
+		dbRole := regexp.MustCompile(`(?i)\b(create\s+(user|role)|grant|revoke)\b`)
+		for _, file := range files {
+			data, err := fs.ReadFile(FS, file.Name())
+			require.Nil(t, err)
+			require.False(t, dbRole.Match(data), "миграция %s управляет ролями СУБД", file.Name())
+		}
+	})
+}
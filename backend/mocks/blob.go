@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/blob.go
+//
+// Generated by this command:
+//
+//	mockgen -source=domain/blob.go -destination=mocks/blob.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIBlobStorage is a mock of IBlobStorage interface.
+type MockIBlobStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockIBlobStorageMockRecorder
+}
+
+// MockIBlobStorageMockRecorder is the mock recorder for MockIBlobStorage.
+type MockIBlobStorageMockRecorder struct {
+	mock *MockIBlobStorage
+}
+
+// NewMockIBlobStorage creates a new mock instance.
+func NewMockIBlobStorage(ctrl *gomock.Controller) *MockIBlobStorage {
+	mock := &MockIBlobStorage{ctrl: ctrl}
+	mock.recorder = &MockIBlobStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIBlobStorage) EXPECT() *MockIBlobStorageMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockIBlobStorage) Delete(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIBlobStorageMockRecorder) Delete(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIBlobStorage)(nil).Delete), arg0, arg1)
+}
+
+// Open mocks base method.
+func (m *MockIBlobStorage) Open(arg0 context.Context, arg1 string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", arg0, arg1)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockIBlobStorageMockRecorder) Open(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockIBlobStorage)(nil).Open), arg0, arg1)
+}
+
+// Put mocks base method.
+func (m *MockIBlobStorage) Put(arg0 context.Context, arg1 string, arg2 io.Reader) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockIBlobStorageMockRecorder) Put(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockIBlobStorage)(nil).Put), arg0, arg1, arg2)
+}
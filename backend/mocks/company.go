@@ -100,12 +100,13 @@ func (mr *MockICompanyRepositoryMockRecorder) GetById(arg0, arg1 any) *gomock.Ca
 }
 
 // GetByOwnerId mocks base method.
-func (m *MockICompanyRepository) GetByOwnerId(arg0 context.Context, arg1 uuid.UUID, arg2 int, arg3 bool) ([]*domain.Company, error) {
+func (m *MockICompanyRepository) GetByOwnerId(arg0 context.Context, arg1 uuid.UUID, arg2 int, arg3 bool) ([]*domain.Company, int, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetByOwnerId", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].([]*domain.Company)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetByOwnerId indicates an expected call of GetByOwnerId.
@@ -114,6 +115,21 @@ func (mr *MockICompanyRepositoryMockRecorder) GetByOwnerId(arg0, arg1, arg2, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOwnerId", reflect.TypeOf((*MockICompanyRepository)(nil).GetByOwnerId), arg0, arg1, arg2, arg3)
 }
 
+// GetByOwnerIds mocks base method.
+func (m *MockICompanyRepository) GetByOwnerIds(arg0 context.Context, arg1 []uuid.UUID) ([]*domain.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByOwnerIds", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByOwnerIds indicates an expected call of GetByOwnerIds.
+func (mr *MockICompanyRepositoryMockRecorder) GetByOwnerIds(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOwnerIds", reflect.TypeOf((*MockICompanyRepository)(nil).GetByOwnerIds), arg0, arg1)
+}
+
 // Update mocks base method.
 func (m *MockICompanyRepository) Update(arg0 context.Context, arg1 *domain.Company) error {
 	m.ctrl.T.Helper()
@@ -210,12 +226,13 @@ func (mr *MockICompanyServiceMockRecorder) GetById(arg0, arg1 any) *gomock.Call
 }
 
 // GetByOwnerId mocks base method.
-func (m *MockICompanyService) GetByOwnerId(arg0 context.Context, arg1 uuid.UUID, arg2 int, arg3 bool) ([]*domain.Company, error) {
+func (m *MockICompanyService) GetByOwnerId(arg0 context.Context, arg1 uuid.UUID, arg2 int, arg3 bool) ([]*domain.Company, int, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetByOwnerId", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].([]*domain.Company)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetByOwnerId indicates an expected call of GetByOwnerId.
@@ -224,6 +241,21 @@ func (mr *MockICompanyServiceMockRecorder) GetByOwnerId(arg0, arg1, arg2, arg3 a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOwnerId", reflect.TypeOf((*MockICompanyService)(nil).GetByOwnerId), arg0, arg1, arg2, arg3)
 }
 
+// GetByOwnerIds mocks base method.
+func (m *MockICompanyService) GetByOwnerIds(arg0 context.Context, arg1 []uuid.UUID) ([]*domain.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByOwnerIds", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByOwnerIds indicates an expected call of GetByOwnerIds.
+func (mr *MockICompanyServiceMockRecorder) GetByOwnerIds(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOwnerIds", reflect.TypeOf((*MockICompanyService)(nil).GetByOwnerIds), arg0, arg1)
+}
+
 // Update mocks base method.
 func (m *MockICompanyService) Update(arg0 context.Context, arg1 *domain.Company) error {
 	m.ctrl.T.Helper()
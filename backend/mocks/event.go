@@ -0,0 +1,238 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/event.go
+//
+// Generated by this command:
+//
+//	mockgen -source=domain/event.go -destination=mocks/event.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	domain "ppo/domain"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEvent is a mock of Event interface.
+type MockEvent struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventMockRecorder
+}
+
+// MockEventMockRecorder is the mock recorder for MockEvent.
+type MockEventMockRecorder struct {
+	mock *MockEvent
+}
+
+// NewMockEvent creates a new mock instance.
+func NewMockEvent(ctrl *gomock.Controller) *MockEvent {
+	mock := &MockEvent{ctrl: ctrl}
+	mock.recorder = &MockEventMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEvent) EXPECT() *MockEventMockRecorder {
+	return m.recorder
+}
+
+// EventType mocks base method.
+func (m *MockEvent) EventType() domain.EventType {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EventType")
+	ret0, _ := ret[0].(domain.EventType)
+	return ret0
+}
+
+// EventType indicates an expected call of EventType.
+func (mr *MockEventMockRecorder) EventType() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EventType", reflect.TypeOf((*MockEvent)(nil).EventType))
+}
+
+// MockIOutboxRepository is a mock of IOutboxRepository interface.
+type MockIOutboxRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIOutboxRepositoryMockRecorder
+}
+
+// MockIOutboxRepositoryMockRecorder is the mock recorder for MockIOutboxRepository.
+type MockIOutboxRepositoryMockRecorder struct {
+	mock *MockIOutboxRepository
+}
+
+// NewMockIOutboxRepository creates a new mock instance.
+func NewMockIOutboxRepository(ctrl *gomock.Controller) *MockIOutboxRepository {
+	mock := &MockIOutboxRepository{ctrl: ctrl}
+	mock.recorder = &MockIOutboxRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIOutboxRepository) EXPECT() *MockIOutboxRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockIOutboxRepository) Add(arg0 context.Context, arg1 *domain.OutboxEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockIOutboxRepositoryMockRecorder) Add(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockIOutboxRepository)(nil).Add), arg0, arg1)
+}
+
+// ClaimNext mocks base method.
+func (m *MockIOutboxRepository) ClaimNext(arg0 context.Context, arg1 time.Time) (*domain.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimNext", arg0, arg1)
+	ret0, _ := ret[0].(*domain.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimNext indicates an expected call of ClaimNext.
+func (mr *MockIOutboxRepositoryMockRecorder) ClaimNext(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimNext", reflect.TypeOf((*MockIOutboxRepository)(nil).ClaimNext), arg0, arg1)
+}
+
+// DeleteProcessedBefore mocks base method.
+func (m *MockIOutboxRepository) DeleteProcessedBefore(arg0 context.Context, arg1 time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProcessedBefore", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteProcessedBefore indicates an expected call of DeleteProcessedBefore.
+func (mr *MockIOutboxRepositoryMockRecorder) DeleteProcessedBefore(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProcessedBefore", reflect.TypeOf((*MockIOutboxRepository)(nil).DeleteProcessedBefore), arg0, arg1)
+}
+
+// MarkFailed mocks base method.
+func (m *MockIOutboxRepository) MarkFailed(arg0 context.Context, arg1 uuid.UUID, arg2 string, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkFailed", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkFailed indicates an expected call of MarkFailed.
+func (mr *MockIOutboxRepositoryMockRecorder) MarkFailed(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFailed", reflect.TypeOf((*MockIOutboxRepository)(nil).MarkFailed), arg0, arg1, arg2, arg3)
+}
+
+// MarkProcessed mocks base method.
+func (m *MockIOutboxRepository) MarkProcessed(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkProcessed", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkProcessed indicates an expected call of MarkProcessed.
+func (mr *MockIOutboxRepositoryMockRecorder) MarkProcessed(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkProcessed", reflect.TypeOf((*MockIOutboxRepository)(nil).MarkProcessed), arg0, arg1)
+}
+
+// MockIEventBus is a mock of IEventBus interface.
+type MockIEventBus struct {
+	ctrl     *gomock.Controller
+	recorder *MockIEventBusMockRecorder
+}
+
+// MockIEventBusMockRecorder is the mock recorder for MockIEventBus.
+type MockIEventBusMockRecorder struct {
+	mock *MockIEventBus
+}
+
+// NewMockIEventBus creates a new mock instance.
+func NewMockIEventBus(ctrl *gomock.Controller) *MockIEventBus {
+	mock := &MockIEventBus{ctrl: ctrl}
+	mock.recorder = &MockIEventBusMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIEventBus) EXPECT() *MockIEventBusMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockIEventBus) Publish(arg0 context.Context, arg1 domain.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockIEventBusMockRecorder) Publish(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockIEventBus)(nil).Publish), arg0, arg1)
+}
+
+// Subscribe mocks base method.
+func (m *MockIEventBus) Subscribe(arg0 domain.EventType, arg1 domain.EventHandler) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Subscribe", arg0, arg1)
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockIEventBusMockRecorder) Subscribe(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockIEventBus)(nil).Subscribe), arg0, arg1)
+}
+
+// MockITransactor is a mock of ITransactor interface.
+type MockITransactor struct {
+	ctrl     *gomock.Controller
+	recorder *MockITransactorMockRecorder
+}
+
+// MockITransactorMockRecorder is the mock recorder for MockITransactor.
+type MockITransactorMockRecorder struct {
+	mock *MockITransactor
+}
+
+// NewMockITransactor creates a new mock instance.
+func NewMockITransactor(ctrl *gomock.Controller) *MockITransactor {
+	mock := &MockITransactor{ctrl: ctrl}
+	mock.recorder = &MockITransactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITransactor) EXPECT() *MockITransactorMockRecorder {
+	return m.recorder
+}
+
+// WithinTx mocks base method.
+func (m *MockITransactor) WithinTx(arg0 context.Context, arg1 func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithinTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithinTx indicates an expected call of WithinTx.
+func (mr *MockITransactorMockRecorder) WithinTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithinTx", reflect.TypeOf((*MockITransactor)(nil).WithinTx), arg0, arg1)
+}
@@ -11,6 +11,7 @@ package mocks
 
 import (
 	context "context"
+	io "io"
 	domain "ppo/domain"
 	reflect "reflect"
 
@@ -41,6 +42,34 @@ func (m *MockIFinancialReportRepository) EXPECT() *MockIFinancialReportRepositor
 	return m.recorder
 }
 
+// AddDocument mocks base method.
+func (m *MockIFinancialReportRepository) AddDocument(arg0 context.Context, arg1 *domain.FinancialReportDocument) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDocument", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddDocument indicates an expected call of AddDocument.
+func (mr *MockIFinancialReportRepositoryMockRecorder) AddDocument(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDocument", reflect.TypeOf((*MockIFinancialReportRepository)(nil).AddDocument), arg0, arg1)
+}
+
+// AddRevision mocks base method.
+func (m *MockIFinancialReportRepository) AddRevision(arg0 context.Context, arg1 *domain.FinancialReportRevision) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRevision", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddRevision indicates an expected call of AddRevision.
+func (mr *MockIFinancialReportRepositoryMockRecorder) AddRevision(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRevision", reflect.TypeOf((*MockIFinancialReportRepository)(nil).AddRevision), arg0, arg1)
+}
+
 // Create mocks base method.
 func (m *MockIFinancialReportRepository) Create(arg0 context.Context, arg1 *domain.FinancialReport) error {
 	m.ctrl.T.Helper()
@@ -69,6 +98,35 @@ func (mr *MockIFinancialReportRepositoryMockRecorder) DeleteById(arg0, arg1 any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockIFinancialReportRepository)(nil).DeleteById), arg0, arg1)
 }
 
+// DeleteDocument mocks base method.
+func (m *MockIFinancialReportRepository) DeleteDocument(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDocument", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDocument indicates an expected call of DeleteDocument.
+func (mr *MockIFinancialReportRepositoryMockRecorder) DeleteDocument(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDocument", reflect.TypeOf((*MockIFinancialReportRepository)(nil).DeleteDocument), arg0, arg1)
+}
+
+// GetByCompanies mocks base method.
+func (m *MockIFinancialReportRepository) GetByCompanies(arg0 context.Context, arg1 []uuid.UUID, arg2 *domain.Period) (map[uuid.UUID]*domain.FinancialReportByPeriod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCompanies", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[uuid.UUID]*domain.FinancialReportByPeriod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCompanies indicates an expected call of GetByCompanies.
+func (mr *MockIFinancialReportRepositoryMockRecorder) GetByCompanies(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCompanies", reflect.TypeOf((*MockIFinancialReportRepository)(nil).GetByCompanies), arg0, arg1, arg2)
+}
+
 // GetByCompany mocks base method.
 func (m *MockIFinancialReportRepository) GetByCompany(arg0 context.Context, arg1 uuid.UUID, arg2 *domain.Period) (*domain.FinancialReportByPeriod, error) {
 	m.ctrl.T.Helper()
@@ -99,6 +157,67 @@ func (mr *MockIFinancialReportRepositoryMockRecorder) GetById(arg0, arg1 any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockIFinancialReportRepository)(nil).GetById), arg0, arg1)
 }
 
+// GetByStatus mocks base method.
+func (m *MockIFinancialReportRepository) GetByStatus(arg0 context.Context, arg1 domain.VerificationStatus, arg2 int) ([]*domain.FinancialReport, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByStatus", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*domain.FinancialReport)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByStatus indicates an expected call of GetByStatus.
+func (mr *MockIFinancialReportRepositoryMockRecorder) GetByStatus(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByStatus", reflect.TypeOf((*MockIFinancialReportRepository)(nil).GetByStatus), arg0, arg1, arg2)
+}
+
+// GetDocument mocks base method.
+func (m *MockIFinancialReportRepository) GetDocument(arg0 context.Context, arg1 uuid.UUID) (*domain.FinancialReportDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDocument", arg0, arg1)
+	ret0, _ := ret[0].(*domain.FinancialReportDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDocument indicates an expected call of GetDocument.
+func (mr *MockIFinancialReportRepositoryMockRecorder) GetDocument(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDocument", reflect.TypeOf((*MockIFinancialReportRepository)(nil).GetDocument), arg0, arg1)
+}
+
+// GetDocuments mocks base method.
+func (m *MockIFinancialReportRepository) GetDocuments(arg0 context.Context, arg1 uuid.UUID) ([]*domain.FinancialReportDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDocuments", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.FinancialReportDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDocuments indicates an expected call of GetDocuments.
+func (mr *MockIFinancialReportRepositoryMockRecorder) GetDocuments(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDocuments", reflect.TypeOf((*MockIFinancialReportRepository)(nil).GetDocuments), arg0, arg1)
+}
+
+// GetRevisions mocks base method.
+func (m *MockIFinancialReportRepository) GetRevisions(arg0 context.Context, arg1 uuid.UUID) ([]*domain.FinancialReportRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevisions", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.FinancialReportRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevisions indicates an expected call of GetRevisions.
+func (mr *MockIFinancialReportRepositoryMockRecorder) GetRevisions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevisions", reflect.TypeOf((*MockIFinancialReportRepository)(nil).GetRevisions), arg0, arg1)
+}
+
 // Update mocks base method.
 func (m *MockIFinancialReportRepository) Update(arg0 context.Context, arg1 *domain.FinancialReport) error {
 	m.ctrl.T.Helper()
@@ -136,6 +255,34 @@ func (m *MockIFinancialReportService) EXPECT() *MockIFinancialReportServiceMockR
 	return m.recorder
 }
 
+// Amend mocks base method.
+func (m *MockIFinancialReportService) Amend(arg0 context.Context, arg1 *domain.FinancialReportRevision) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Amend", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Amend indicates an expected call of Amend.
+func (mr *MockIFinancialReportServiceMockRecorder) Amend(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Amend", reflect.TypeOf((*MockIFinancialReportService)(nil).Amend), arg0, arg1)
+}
+
+// AttachDocument mocks base method.
+func (m *MockIFinancialReportService) AttachDocument(arg0 context.Context, arg1 *domain.FinancialReportDocument, arg2 io.Reader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachDocument", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AttachDocument indicates an expected call of AttachDocument.
+func (mr *MockIFinancialReportServiceMockRecorder) AttachDocument(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachDocument", reflect.TypeOf((*MockIFinancialReportService)(nil).AttachDocument), arg0, arg1, arg2)
+}
+
 // Create mocks base method.
 func (m *MockIFinancialReportService) Create(arg0 context.Context, arg1 *domain.FinancialReport) error {
 	m.ctrl.T.Helper()
@@ -178,6 +325,35 @@ func (mr *MockIFinancialReportServiceMockRecorder) DeleteById(arg0, arg1 any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockIFinancialReportService)(nil).DeleteById), arg0, arg1)
 }
 
+// DeleteDocument mocks base method.
+func (m *MockIFinancialReportService) DeleteDocument(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDocument", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDocument indicates an expected call of DeleteDocument.
+func (mr *MockIFinancialReportServiceMockRecorder) DeleteDocument(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDocument", reflect.TypeOf((*MockIFinancialReportService)(nil).DeleteDocument), arg0, arg1)
+}
+
+// GetByCompanies mocks base method.
+func (m *MockIFinancialReportService) GetByCompanies(arg0 context.Context, arg1 []uuid.UUID, arg2 *domain.Period) (map[uuid.UUID]*domain.FinancialReportByPeriod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCompanies", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[uuid.UUID]*domain.FinancialReportByPeriod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCompanies indicates an expected call of GetByCompanies.
+func (mr *MockIFinancialReportServiceMockRecorder) GetByCompanies(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCompanies", reflect.TypeOf((*MockIFinancialReportService)(nil).GetByCompanies), arg0, arg1, arg2)
+}
+
 // GetByCompany mocks base method.
 func (m *MockIFinancialReportService) GetByCompany(arg0 context.Context, arg1 uuid.UUID, arg2 *domain.Period) (*domain.FinancialReportByPeriod, error) {
 	m.ctrl.T.Helper()
@@ -208,6 +384,96 @@ func (mr *MockIFinancialReportServiceMockRecorder) GetById(arg0, arg1 any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockIFinancialReportService)(nil).GetById), arg0, arg1)
 }
 
+// GetByStatus mocks base method.
+func (m *MockIFinancialReportService) GetByStatus(arg0 context.Context, arg1 domain.VerificationStatus, arg2 int) ([]*domain.FinancialReport, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByStatus", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*domain.FinancialReport)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByStatus indicates an expected call of GetByStatus.
+func (mr *MockIFinancialReportServiceMockRecorder) GetByStatus(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByStatus", reflect.TypeOf((*MockIFinancialReportService)(nil).GetByStatus), arg0, arg1, arg2)
+}
+
+// GetDocuments mocks base method.
+func (m *MockIFinancialReportService) GetDocuments(arg0 context.Context, arg1 uuid.UUID) ([]*domain.FinancialReportDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDocuments", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.FinancialReportDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDocuments indicates an expected call of GetDocuments.
+func (mr *MockIFinancialReportServiceMockRecorder) GetDocuments(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDocuments", reflect.TypeOf((*MockIFinancialReportService)(nil).GetDocuments), arg0, arg1)
+}
+
+// GetRevisions mocks base method.
+func (m *MockIFinancialReportService) GetRevisions(arg0 context.Context, arg1 uuid.UUID) ([]*domain.FinancialReportRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevisions", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.FinancialReportRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevisions indicates an expected call of GetRevisions.
+func (mr *MockIFinancialReportServiceMockRecorder) GetRevisions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevisions", reflect.TypeOf((*MockIFinancialReportService)(nil).GetRevisions), arg0, arg1)
+}
+
+// OpenDocument mocks base method.
+func (m *MockIFinancialReportService) OpenDocument(arg0 context.Context, arg1 uuid.UUID) (*domain.FinancialReportDocument, io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenDocument", arg0, arg1)
+	ret0, _ := ret[0].(*domain.FinancialReportDocument)
+	ret1, _ := ret[1].(io.ReadCloser)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// OpenDocument indicates an expected call of OpenDocument.
+func (mr *MockIFinancialReportServiceMockRecorder) OpenDocument(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenDocument", reflect.TypeOf((*MockIFinancialReportService)(nil).OpenDocument), arg0, arg1)
+}
+
+// Review mocks base method.
+func (m *MockIFinancialReportService) Review(arg0 context.Context, arg1 uuid.UUID, arg2 domain.VerificationStatus, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Review", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Review indicates an expected call of Review.
+func (mr *MockIFinancialReportServiceMockRecorder) Review(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Review", reflect.TypeOf((*MockIFinancialReportService)(nil).Review), arg0, arg1, arg2, arg3)
+}
+
+// Submit mocks base method.
+func (m *MockIFinancialReportService) Submit(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Submit", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Submit indicates an expected call of Submit.
+func (mr *MockIFinancialReportServiceMockRecorder) Submit(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Submit", reflect.TypeOf((*MockIFinancialReportService)(nil).Submit), arg0, arg1)
+}
+
 // Update mocks base method.
 func (m *MockIFinancialReportService) Update(arg0 context.Context, arg1 *domain.FinancialReport) error {
 	m.ctrl.T.Helper()
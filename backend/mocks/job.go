@@ -0,0 +1,201 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/job.go
+//
+// Generated by this command:
+//
+//	mockgen -source=domain/job.go -destination=mocks/job.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	domain "ppo/domain"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIJobRepository is a mock of IJobRepository interface.
+type MockIJobRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIJobRepositoryMockRecorder
+}
+
+// MockIJobRepositoryMockRecorder is the mock recorder for MockIJobRepository.
+type MockIJobRepositoryMockRecorder struct {
+	mock *MockIJobRepository
+}
+
+// NewMockIJobRepository creates a new mock instance.
+func NewMockIJobRepository(ctrl *gomock.Controller) *MockIJobRepository {
+	mock := &MockIJobRepository{ctrl: ctrl}
+	mock.recorder = &MockIJobRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIJobRepository) EXPECT() *MockIJobRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteRunsBefore mocks base method.
+func (m *MockIJobRepository) DeleteRunsBefore(arg0 context.Context, arg1 time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRunsBefore", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRunsBefore indicates an expected call of DeleteRunsBefore.
+func (mr *MockIJobRepositoryMockRecorder) DeleteRunsBefore(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRunsBefore", reflect.TypeOf((*MockIJobRepository)(nil).DeleteRunsBefore), arg0, arg1)
+}
+
+// FinishRun mocks base method.
+func (m *MockIJobRepository) FinishRun(arg0 context.Context, arg1 *domain.JobRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishRun", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FinishRun indicates an expected call of FinishRun.
+func (mr *MockIJobRepositoryMockRecorder) FinishRun(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishRun", reflect.TypeOf((*MockIJobRepository)(nil).FinishRun), arg0, arg1)
+}
+
+// GetLastRuns mocks base method.
+func (m *MockIJobRepository) GetLastRuns(arg0 context.Context) (map[string]*domain.JobRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastRuns", arg0)
+	ret0, _ := ret[0].(map[string]*domain.JobRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastRuns indicates an expected call of GetLastRuns.
+func (mr *MockIJobRepositoryMockRecorder) GetLastRuns(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastRuns", reflect.TypeOf((*MockIJobRepository)(nil).GetLastRuns), arg0)
+}
+
+// GetRuns mocks base method.
+func (m *MockIJobRepository) GetRuns(arg0 context.Context, arg1 string, arg2 int) ([]*domain.JobRun, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRuns", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*domain.JobRun)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetRuns indicates an expected call of GetRuns.
+func (mr *MockIJobRepositoryMockRecorder) GetRuns(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuns", reflect.TypeOf((*MockIJobRepository)(nil).GetRuns), arg0, arg1, arg2)
+}
+
+// StartRun mocks base method.
+func (m *MockIJobRepository) StartRun(arg0 context.Context, arg1 *domain.JobRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartRun", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartRun indicates an expected call of StartRun.
+func (mr *MockIJobRepositoryMockRecorder) StartRun(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartRun", reflect.TypeOf((*MockIJobRepository)(nil).StartRun), arg0, arg1)
+}
+
+// TryLock mocks base method.
+func (m *MockIJobRepository) TryLock(arg0 context.Context, arg1 string) (func(), bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TryLock", arg0, arg1)
+	ret0, _ := ret[0].(func())
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// TryLock indicates an expected call of TryLock.
+func (mr *MockIJobRepositoryMockRecorder) TryLock(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TryLock", reflect.TypeOf((*MockIJobRepository)(nil).TryLock), arg0, arg1)
+}
+
+// MockIJobService is a mock of IJobService interface.
+type MockIJobService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIJobServiceMockRecorder
+}
+
+// MockIJobServiceMockRecorder is the mock recorder for MockIJobService.
+type MockIJobServiceMockRecorder struct {
+	mock *MockIJobService
+}
+
+// NewMockIJobService creates a new mock instance.
+func NewMockIJobService(ctrl *gomock.Controller) *MockIJobService {
+	mock := &MockIJobService{ctrl: ctrl}
+	mock.recorder = &MockIJobServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIJobService) EXPECT() *MockIJobServiceMockRecorder {
+	return m.recorder
+}
+
+// GetJobs mocks base method.
+func (m *MockIJobService) GetJobs(arg0 context.Context) ([]*domain.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJobs", arg0)
+	ret0, _ := ret[0].([]*domain.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJobs indicates an expected call of GetJobs.
+func (mr *MockIJobServiceMockRecorder) GetJobs(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobs", reflect.TypeOf((*MockIJobService)(nil).GetJobs), arg0)
+}
+
+// GetRuns mocks base method.
+func (m *MockIJobService) GetRuns(arg0 context.Context, arg1 string, arg2 int) ([]*domain.JobRun, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRuns", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*domain.JobRun)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetRuns indicates an expected call of GetRuns.
+func (mr *MockIJobServiceMockRecorder) GetRuns(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuns", reflect.TypeOf((*MockIJobService)(nil).GetRuns), arg0, arg1, arg2)
+}
+
+// Trigger mocks base method.
+func (m *MockIJobService) Trigger(arg0 context.Context, arg1 string) (*domain.JobRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trigger", arg0, arg1)
+	ret0, _ := ret[0].(*domain.JobRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Trigger indicates an expected call of Trigger.
+func (mr *MockIJobServiceMockRecorder) Trigger(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trigger", reflect.TypeOf((*MockIJobService)(nil).Trigger), arg0, arg1)
+}
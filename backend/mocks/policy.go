@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/policy.go
+//
+// Generated by this command:
+//
+//	mockgen -source=domain/policy.go -destination=mocks/policy.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	domain "ppo/domain"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIPolicyService is a mock of IPolicyService interface.
+type MockIPolicyService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPolicyServiceMockRecorder
+}
+
+// MockIPolicyServiceMockRecorder is the mock recorder for MockIPolicyService.
+type MockIPolicyServiceMockRecorder struct {
+	mock *MockIPolicyService
+}
+
+// NewMockIPolicyService creates a new mock instance.
+func NewMockIPolicyService(ctrl *gomock.Controller) *MockIPolicyService {
+	mock := &MockIPolicyService{ctrl: ctrl}
+	mock.recorder = &MockIPolicyServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPolicyService) EXPECT() *MockIPolicyServiceMockRecorder {
+	return m.recorder
+}
+
+// CanDeleteReview mocks base method.
+func (m *MockIPolicyService) CanDeleteReview(arg0 context.Context, arg1 *domain.Actor, arg2 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CanDeleteReview", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CanDeleteReview indicates an expected call of CanDeleteReview.
+func (mr *MockIPolicyServiceMockRecorder) CanDeleteReview(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanDeleteReview", reflect.TypeOf((*MockIPolicyService)(nil).CanDeleteReview), arg0, arg1, arg2)
+}
+
+// CanManageCompany mocks base method.
+func (m *MockIPolicyService) CanManageCompany(arg0 context.Context, arg1 *domain.Actor, arg2 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CanManageCompany", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CanManageCompany indicates an expected call of CanManageCompany.
+func (mr *MockIPolicyServiceMockRecorder) CanManageCompany(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanManageCompany", reflect.TypeOf((*MockIPolicyService)(nil).CanManageCompany), arg0, arg1, arg2)
+}
+
+// CanManageContact mocks base method.
+func (m *MockIPolicyService) CanManageContact(arg0 context.Context, arg1 *domain.Actor, arg2 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CanManageContact", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CanManageContact indicates an expected call of CanManageContact.
+func (mr *MockIPolicyServiceMockRecorder) CanManageContact(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanManageContact", reflect.TypeOf((*MockIPolicyService)(nil).CanManageContact), arg0, arg1, arg2)
+}
+
+// CanManageFinReport mocks base method.
+func (m *MockIPolicyService) CanManageFinReport(arg0 context.Context, arg1 *domain.Actor, arg2 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CanManageFinReport", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CanManageFinReport indicates an expected call of CanManageFinReport.
+func (mr *MockIPolicyServiceMockRecorder) CanManageFinReport(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanManageFinReport", reflect.TypeOf((*MockIPolicyService)(nil).CanManageFinReport), arg0, arg1, arg2)
+}
+
+// CanReadFinDocuments mocks base method.
+func (m *MockIPolicyService) CanReadFinDocuments(arg0 context.Context, arg1 *domain.Actor, arg2 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CanReadFinDocuments", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CanReadFinDocuments indicates an expected call of CanReadFinDocuments.
+func (mr *MockIPolicyServiceMockRecorder) CanReadFinDocuments(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanReadFinDocuments", reflect.TypeOf((*MockIPolicyService)(nil).CanReadFinDocuments), arg0, arg1, arg2)
+}
@@ -0,0 +1,138 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/rating.go
+//
+// Generated by this command:
+//
+//	mockgen -source=domain/rating.go -destination=mocks/rating.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	domain "ppo/domain"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIRatingRepository is a mock of IRatingRepository interface.
+type MockIRatingRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRatingRepositoryMockRecorder
+}
+
+// MockIRatingRepositoryMockRecorder is the mock recorder for MockIRatingRepository.
+type MockIRatingRepositoryMockRecorder struct {
+	mock *MockIRatingRepository
+}
+
+// NewMockIRatingRepository creates a new mock instance.
+func NewMockIRatingRepository(ctrl *gomock.Controller) *MockIRatingRepository {
+	mock := &MockIRatingRepository{ctrl: ctrl}
+	mock.recorder = &MockIRatingRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRatingRepository) EXPECT() *MockIRatingRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetHistory mocks base method.
+func (m *MockIRatingRepository) GetHistory(arg0 context.Context, arg1 uuid.UUID, arg2 *domain.Period) ([]*domain.RatingSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*domain.RatingSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockIRatingRepositoryMockRecorder) GetHistory(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockIRatingRepository)(nil).GetHistory), arg0, arg1, arg2)
+}
+
+// Save mocks base method.
+func (m *MockIRatingRepository) Save(arg0 context.Context, arg1 *domain.RatingSnapshot) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockIRatingRepositoryMockRecorder) Save(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockIRatingRepository)(nil).Save), arg0, arg1)
+}
+
+// MockIRatingService is a mock of IRatingService interface.
+type MockIRatingService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRatingServiceMockRecorder
+}
+
+// MockIRatingServiceMockRecorder is the mock recorder for MockIRatingService.
+type MockIRatingServiceMockRecorder struct {
+	mock *MockIRatingService
+}
+
+// NewMockIRatingService creates a new mock instance.
+func NewMockIRatingService(ctrl *gomock.Controller) *MockIRatingService {
+	mock := &MockIRatingService{ctrl: ctrl}
+	mock.recorder = &MockIRatingServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRatingService) EXPECT() *MockIRatingServiceMockRecorder {
+	return m.recorder
+}
+
+// GetHistory mocks base method.
+func (m *MockIRatingService) GetHistory(arg0 context.Context, arg1 uuid.UUID, arg2 *domain.Period) ([]*domain.RatingSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*domain.RatingSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockIRatingServiceMockRecorder) GetHistory(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockIRatingService)(nil).GetHistory), arg0, arg1, arg2)
+}
+
+// Recompute mocks base method.
+func (m *MockIRatingService) Recompute(arg0 context.Context, arg1 uuid.UUID, arg2 *domain.Period) ([]*domain.RatingSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recompute", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*domain.RatingSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recompute indicates an expected call of Recompute.
+func (mr *MockIRatingServiceMockRecorder) Recompute(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recompute", reflect.TypeOf((*MockIRatingService)(nil).Recompute), arg0, arg1, arg2)
+}
+
+// RecomputeHistory mocks base method.
+func (m *MockIRatingService) RecomputeHistory(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecomputeHistory", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecomputeHistory indicates an expected call of RecomputeHistory.
+func (mr *MockIRatingServiceMockRecorder) RecomputeHistory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecomputeHistory", reflect.TypeOf((*MockIRatingService)(nil).RecomputeHistory), arg0, arg1)
+}
@@ -70,12 +70,13 @@ func (mr *MockISkillRepositoryMockRecorder) DeleteById(arg0, arg1 any) *gomock.C
 }
 
 // GetAll mocks base method.
-func (m *MockISkillRepository) GetAll(arg0 context.Context, arg1 int) ([]*domain.Skill, error) {
+func (m *MockISkillRepository) GetAll(arg0 context.Context, arg1 int) ([]*domain.Skill, int, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetAll", arg0, arg1)
 	ret0, _ := ret[0].([]*domain.Skill)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetAll indicates an expected call of GetAll.
@@ -99,6 +100,21 @@ func (mr *MockISkillRepositoryMockRecorder) GetById(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockISkillRepository)(nil).GetById), arg0, arg1)
 }
 
+// GetByIds mocks base method.
+func (m *MockISkillRepository) GetByIds(arg0 context.Context, arg1 []uuid.UUID) ([]*domain.Skill, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIds", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.Skill)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIds indicates an expected call of GetByIds.
+func (mr *MockISkillRepositoryMockRecorder) GetByIds(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIds", reflect.TypeOf((*MockISkillRepository)(nil).GetByIds), arg0, arg1)
+}
+
 // Update mocks base method.
 func (m *MockISkillRepository) Update(arg0 context.Context, arg1 *domain.Skill) error {
 	m.ctrl.T.Helper()
@@ -165,12 +181,13 @@ func (mr *MockISkillServiceMockRecorder) DeleteById(arg0, arg1 any) *gomock.Call
 }
 
 // GetAll mocks base method.
-func (m *MockISkillService) GetAll(arg0 context.Context, arg1 int) ([]*domain.Skill, error) {
+func (m *MockISkillService) GetAll(arg0 context.Context, arg1 int) ([]*domain.Skill, int, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetAll", arg0, arg1)
 	ret0, _ := ret[0].([]*domain.Skill)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetAll indicates an expected call of GetAll.
@@ -70,12 +70,13 @@ func (mr *MockIUserRepositoryMockRecorder) DeleteById(arg0, arg1 any) *gomock.Ca
 }
 
 // GetAll mocks base method.
-func (m *MockIUserRepository) GetAll(arg0 context.Context, arg1 int) ([]*domain.User, error) {
+func (m *MockIUserRepository) GetAll(arg0 context.Context, arg1 int) ([]*domain.User, int, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetAll", arg0, arg1)
 	ret0, _ := ret[0].([]*domain.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetAll indicates an expected call of GetAll.
@@ -99,6 +100,21 @@ func (mr *MockIUserRepositoryMockRecorder) GetById(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockIUserRepository)(nil).GetById), arg0, arg1)
 }
 
+// GetByIds mocks base method.
+func (m *MockIUserRepository) GetByIds(arg0 context.Context, arg1 []uuid.UUID) ([]*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIds", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIds indicates an expected call of GetByIds.
+func (mr *MockIUserRepositoryMockRecorder) GetByIds(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIds", reflect.TypeOf((*MockIUserRepository)(nil).GetByIds), arg0, arg1)
+}
+
 // GetByUsername mocks base method.
 func (m *MockIUserRepository) GetByUsername(arg0 context.Context, arg1 string) (*domain.User, error) {
 	m.ctrl.T.Helper()
@@ -180,12 +196,13 @@ func (mr *MockIUserServiceMockRecorder) DeleteById(arg0, arg1 any) *gomock.Call
 }
 
 // GetAll mocks base method.
-func (m *MockIUserService) GetAll(arg0 context.Context, arg1 int) ([]*domain.User, error) {
+func (m *MockIUserService) GetAll(arg0 context.Context, arg1 int) ([]*domain.User, int, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetAll", arg0, arg1)
 	ret0, _ := ret[0].([]*domain.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetAll indicates an expected call of GetAll.
@@ -209,6 +226,21 @@ func (mr *MockIUserServiceMockRecorder) GetById(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockIUserService)(nil).GetById), arg0, arg1)
 }
 
+// GetByIds mocks base method.
+func (m *MockIUserService) GetByIds(arg0 context.Context, arg1 []uuid.UUID) ([]*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIds", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIds indicates an expected call of GetByIds.
+func (mr *MockIUserServiceMockRecorder) GetByIds(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIds", reflect.TypeOf((*MockIUserService)(nil).GetByIds), arg0, arg1)
+}
+
 // GetByUsername mocks base method.
 func (m *MockIUserService) GetByUsername(arg0 context.Context, arg1 string) (*domain.User, error) {
 	m.ctrl.T.Helper()
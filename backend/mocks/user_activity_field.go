@@ -13,6 +13,7 @@ import (
 	context "context"
 	domain "ppo/domain"
 	reflect "reflect"
+	time "time"
 
 	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
@@ -41,6 +42,21 @@ func (m *MockIInteractor) EXPECT() *MockIInteractorMockRecorder {
 	return m.recorder
 }
 
+// CalculateRatingSnapshot mocks base method.
+func (m *MockIInteractor) CalculateRatingSnapshot(arg0 context.Context, arg1 uuid.UUID, arg2, arg3 int, arg4 time.Time) (*domain.RatingSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CalculateRatingSnapshot", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*domain.RatingSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CalculateRatingSnapshot indicates an expected call of CalculateRatingSnapshot.
+func (mr *MockIInteractorMockRecorder) CalculateRatingSnapshot(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalculateRatingSnapshot", reflect.TypeOf((*MockIInteractor)(nil).CalculateRatingSnapshot), arg0, arg1, arg2, arg3, arg4)
+}
+
 // CalculateUserRating mocks base method.
 func (m *MockIInteractor) CalculateUserRating(arg0 context.Context, arg1 uuid.UUID) (float32, error) {
 	m.ctrl.T.Helper()
@@ -56,6 +72,21 @@ func (mr *MockIInteractorMockRecorder) CalculateUserRating(arg0, arg1 any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalculateUserRating", reflect.TypeOf((*MockIInteractor)(nil).CalculateUserRating), arg0, arg1)
 }
 
+// CalculateUserRatings mocks base method.
+func (m *MockIInteractor) CalculateUserRatings(arg0 context.Context, arg1 []uuid.UUID) (map[uuid.UUID]float32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CalculateUserRatings", arg0, arg1)
+	ret0, _ := ret[0].(map[uuid.UUID]float32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CalculateUserRatings indicates an expected call of CalculateUserRatings.
+func (mr *MockIInteractorMockRecorder) CalculateUserRatings(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalculateUserRatings", reflect.TypeOf((*MockIInteractor)(nil).CalculateUserRatings), arg0, arg1)
+}
+
 // GetMostProfitableCompany mocks base method.
 func (m *MockIInteractor) GetMostProfitableCompany(arg0 context.Context, arg1 *domain.Period, arg2 []*domain.Company) (*domain.Company, error) {
 	m.ctrl.T.Helper()
@@ -85,3 +116,18 @@ func (mr *MockIInteractorMockRecorder) GetUserFinancialReport(arg0, arg1, arg2 a
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserFinancialReport", reflect.TypeOf((*MockIInteractor)(nil).GetUserFinancialReport), arg0, arg1, arg2)
 }
+
+// GetUserFinancialReports mocks base method.
+func (m *MockIInteractor) GetUserFinancialReports(arg0 context.Context, arg1 []uuid.UUID, arg2 *domain.Period) (map[uuid.UUID]*domain.FinancialReportByPeriod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserFinancialReports", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[uuid.UUID]*domain.FinancialReportByPeriod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserFinancialReports indicates an expected call of GetUserFinancialReports.
+func (mr *MockIInteractorMockRecorder) GetUserFinancialReports(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserFinancialReports", reflect.TypeOf((*MockIInteractor)(nil).GetUserFinancialReports), arg0, arg1, arg2)
+}
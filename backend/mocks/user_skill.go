@@ -85,12 +85,13 @@ func (mr *MockIUserSkillRepositoryMockRecorder) GetUserSkillsBySkillId(arg0, arg
 }
 
 // GetUserSkillsByUserId mocks base method.
-func (m *MockIUserSkillRepository) GetUserSkillsByUserId(arg0 context.Context, arg1 uuid.UUID, arg2 int, arg3 bool) ([]*domain.UserSkill, error) {
+func (m *MockIUserSkillRepository) GetUserSkillsByUserId(arg0 context.Context, arg1 uuid.UUID, arg2 int, arg3 bool) ([]*domain.UserSkill, int, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetUserSkillsByUserId", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].([]*domain.UserSkill)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetUserSkillsByUserId indicates an expected call of GetUserSkillsByUserId.
@@ -99,6 +100,21 @@ func (mr *MockIUserSkillRepositoryMockRecorder) GetUserSkillsByUserId(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSkillsByUserId", reflect.TypeOf((*MockIUserSkillRepository)(nil).GetUserSkillsByUserId), arg0, arg1, arg2, arg3)
 }
 
+// GetUserSkillsByUserIds mocks base method.
+func (m *MockIUserSkillRepository) GetUserSkillsByUserIds(arg0 context.Context, arg1 []uuid.UUID) ([]*domain.UserSkill, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSkillsByUserIds", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.UserSkill)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserSkillsByUserIds indicates an expected call of GetUserSkillsByUserIds.
+func (mr *MockIUserSkillRepositoryMockRecorder) GetUserSkillsByUserIds(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSkillsByUserIds", reflect.TypeOf((*MockIUserSkillRepository)(nil).GetUserSkillsByUserIds), arg0, arg1)
+}
+
 // MockIUserSkillService is a mock of IUserSkillService interface.
 type MockIUserSkillService struct {
 	ctrl     *gomock.Controller
@@ -165,12 +181,13 @@ func (mr *MockIUserSkillServiceMockRecorder) DeleteSkillsForUser(arg0, arg1 any)
 }
 
 // GetSkillsForUser mocks base method.
-func (m *MockIUserSkillService) GetSkillsForUser(arg0 context.Context, arg1 uuid.UUID, arg2 int, arg3 bool) ([]*domain.Skill, error) {
+func (m *MockIUserSkillService) GetSkillsForUser(arg0 context.Context, arg1 uuid.UUID, arg2 int, arg3 bool) ([]*domain.Skill, int, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetSkillsForUser", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].([]*domain.Skill)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetSkillsForUser indicates an expected call of GetSkillsForUser.
@@ -179,6 +196,21 @@ func (mr *MockIUserSkillServiceMockRecorder) GetSkillsForUser(arg0, arg1, arg2,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSkillsForUser", reflect.TypeOf((*MockIUserSkillService)(nil).GetSkillsForUser), arg0, arg1, arg2, arg3)
 }
 
+// GetSkillsForUsers mocks base method.
+func (m *MockIUserSkillService) GetSkillsForUsers(arg0 context.Context, arg1 []uuid.UUID) (map[uuid.UUID][]*domain.Skill, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSkillsForUsers", arg0, arg1)
+	ret0, _ := ret[0].(map[uuid.UUID][]*domain.Skill)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSkillsForUsers indicates an expected call of GetSkillsForUsers.
+func (mr *MockIUserSkillServiceMockRecorder) GetSkillsForUsers(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSkillsForUsers", reflect.TypeOf((*MockIUserSkillService)(nil).GetSkillsForUsers), arg0, arg1)
+}
+
 // GetUsersForSkill mocks base method.
 func (m *MockIUserSkillService) GetUsersForSkill(arg0 context.Context, arg1 uuid.UUID, arg2 int) ([]*domain.User, error) {
 	m.ctrl.T.Helper()
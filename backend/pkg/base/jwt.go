@@ -11,12 +11,12 @@ type JwtPayload struct {
 	Role string
 }
 
-func GenerateAuthToken(id, jwtKey, role string) (tokenString string, err error) {
+func GenerateAuthToken(id, jwtKey, role string, ttl time.Duration) (tokenString string, err error) {
 	token := jwt.NewWithClaims(
 		jwt.SigningMethodHS256,
 		jwt.MapClaims{
 			"sub":  id,
-			"exp":  time.Now().Add(time.Hour * 24).Unix(),
+			"exp":  time.Now().Add(ttl).Unix(),
 			"role": role,
 		})
 
@@ -0,0 +1,64 @@
+package cache
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestLRU_Eviction(t *testing.T) {
+	c := NewLRU[string, int](2, time.Minute)
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+
+	// обращение к a делает вытесняемой запись b
+	value, ok := c.Get("a")
+	require.True(t, ok)
+	require.Equal(t, 1, value)
+
+	c.Set("c", 3)
+
+	_, ok = c.Get("b")
+	require.False(t, ok)
+	_, ok = c.Get("a")
+	require.True(t, ok)
+	_, ok = c.Get("c")
+	require.True(t, ok)
+	require.Equal(t, 2, c.Len())
+}
+
+func TestLRU_TTL(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewLRU[string, int](2, time.Minute)
+	c.now = func() time.Time { return now }
+
+	c.Set("a", 1)
+
+	now = now.Add(59 * time.Second)
+	_, ok := c.Get("a")
+	require.True(t, ok)
+
+	now = now.Add(time.Second)
+	_, ok = c.Get("a")
+	require.False(t, ok)
+	require.Equal(t, 0, c.Len())
+}
+
+func TestLRU_Invalidation(t *testing.T) {
+	c := NewLRU[string, int](4, time.Minute)
+
+	c.Set("a", 1)
+	c.Set("a", 2)
+	value, _ := c.Get("a")
+	require.Equal(t, 2, value)
+
+	c.Set("b", 3)
+	c.Delete("a")
+	_, ok := c.Get("a")
+	require.False(t, ok)
+	require.Equal(t, 1, c.Len())
+
+	c.Purge()
+	require.Equal(t, 0, c.Len())
+}
@@ -0,0 +1,160 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule — разобранное выражение cron из пяти полей:
+// минута, час, день месяца, месяц, день недели (0 — воскресенье).
+// Поддерживаются *, списки через запятую, диапазоны a-b и шаг /n.
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+	// domAny и dowAny отмечают поля «*»: как и в классическом cron, если ограничены
+	// оба поля дня, подходит день, удовлетворяющий любому из них.
+	domAny, dowAny bool
+}
+
+type field struct {
+	name     string
+	min, max int
+}
+
+var fields = []field{
+	{"минута", 0, 59},
+	{"час", 0, 23},
+	{"день месяца", 1, 31},
+	{"месяц", 1, 12},
+	{"день недели", 0, 6},
+}
+
+// Parse разбирает выражение spec, например "0 3 * * *" — ежедневно в 03:00.
+func Parse(spec string) (*Schedule, error) {
+	parts := strings.Fields(spec)
+	if len(parts) != len(fields) {
+		return nil, fmt.Errorf("выражение cron %q должно состоять из %d полей", spec, len(fields))
+	}
+
+	bits := make([]uint64, len(fields))
+	for i, part := range parts {
+		var err error
+		bits[i], err = parseField(part, fields[i])
+		if err != nil {
+			return nil, fmt.Errorf("выражение cron %q: %w", spec, err)
+		}
+	}
+
+	return &Schedule{
+		minute: bits[0],
+		hour:   bits[1],
+		dom:    bits[2],
+		month:  bits[3],
+		dow:    bits[4],
+		domAny: parts[2] == "*",
+		dowAny: parts[4] == "*",
+	}, nil
+}
+
+func parseField(expr string, f field) (bits uint64, err error) {
+	for _, item := range strings.Split(expr, ",") {
+		rangeExpr, step := item, 1
+		if i := strings.Index(item, "/"); i >= 0 {
+			rangeExpr = item[:i]
+			step, err = strconv.Atoi(item[i+1:])
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("%s: некорректный шаг в %q", f.name, item)
+			}
+		}
+
+		lo, hi := f.min, f.max
+		switch {
+		case rangeExpr == "*":
+		case strings.Contains(rangeExpr, "-"):
+			bounds := strings.SplitN(rangeExpr, "-", 2)
+			lo, err = strconv.Atoi(bounds[0])
+			if err == nil {
+				hi, err = strconv.Atoi(bounds[1])
+			}
+			if err != nil {
+				return 0, fmt.Errorf("%s: некорректный диапазон %q", f.name, item)
+			}
+		default:
+			lo, err = strconv.Atoi(rangeExpr)
+			if err != nil {
+				return 0, fmt.Errorf("%s: некорректное значение %q", f.name, item)
+			}
+			hi = lo
+			if step > 1 {
+				hi = f.max
+			}
+		}
+
+		if lo < f.min || hi > f.max || lo > hi {
+			return 0, fmt.Errorf("%s: значение %q вне отрезка от %d до %d", f.name, item, f.min, f.max)
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+
+	return bits, nil
+}
+
+func has(bits uint64, v int) bool {
+	return bits&(1<<uint(v)) != 0
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domOk := has(s.dom, t.Day())
+	dowOk := has(s.dow, int(t.Weekday()))
+
+	switch {
+	case s.domAny && s.dowAny:
+		return true
+	case s.domAny:
+		return dowOk
+	case s.dowAny:
+		return domOk
+	default:
+		return domOk || dowOk
+	}
+}
+
+// maxSearch ограничивает поиск: расписание вида "0 0 30 2 *" никогда не срабатывает.
+const maxSearch = 5 * 366 * 24 * time.Hour
+
+// Next возвращает первый момент срабатывания строго после t с точностью до минуты
+// или нулевое время, если в ближайшие пять лет срабатываний нет.
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(maxSearch)
+
+	for t.Before(limit) {
+		if !has(s.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if !has(s.hour, t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if !has(s.minute, t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
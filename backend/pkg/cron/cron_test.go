@@ -0,0 +1,82 @@
+package cron
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestSchedule_Next(t *testing.T) {
+	// 2024-01-10 — среда
+	from := time.Date(2024, 1, 10, 14, 25, 30, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		spec     string
+		from     time.Time
+		expected time.Time
+	}{
+		{
+			name:     "каждую минуту",
+			spec:     "* * * * *",
+			from:     from,
+			expected: time.Date(2024, 1, 10, 14, 26, 0, 0, time.UTC),
+		},
+		{
+			name:     "ежедневно в 03:00",
+			spec:     "0 3 * * *",
+			from:     from,
+			expected: time.Date(2024, 1, 11, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "срабатывание строго после заданного момента",
+			spec:     "0 3 * * *",
+			from:     time.Date(2024, 1, 11, 3, 0, 0, 0, time.UTC),
+			expected: time.Date(2024, 1, 12, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "шаг и диапазон",
+			spec:     "*/15 9-17 * * *",
+			from:     from,
+			expected: time.Date(2024, 1, 10, 14, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "по понедельникам",
+			spec:     "0 9 * * 1",
+			from:     from,
+			expected: time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "первое число квартала",
+			spec:     "0 0 1 1,4,7,10 *",
+			from:     from,
+			expected: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "день месяца или день недели",
+			spec:     "0 0 20 * 5",
+			from:     from,
+			expected: time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "несуществующая дата",
+			spec:     "0 0 30 2 *",
+			from:     from,
+			expected: time.Time{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := Parse(tc.spec)
+			require.Nil(t, err)
+			require.Equal(t, tc.expected, s.Next(tc.from))
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* 5-3 * * *", "*/0 * * * *", "a * * * *", "* * * * 7"} {
+		_, err := Parse(spec)
+		require.NotNil(t, err, spec)
+	}
+}
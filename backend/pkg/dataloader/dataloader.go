@@ -0,0 +1,86 @@
+package dataloader
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultWait — сколько загрузчик ждёт новые ключи перед вызовом пакетной функции.
+const DefaultWait = 2 * time.Millisecond
+
+// BatchFunc загружает значения сразу для всех ключей.
+// Для ключей, которых нет в результате, Load вернёт нулевое значение без ошибки.
+type BatchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+type result[V any] struct {
+	done chan struct{}
+	val  V
+	err  error
+}
+
+// Loader собирает ключи, запрошенные в течение короткого окна, и загружает
+// их одним вызовом BatchFunc. Результаты кэшируются на всё время жизни
+// загрузчика, поэтому его создают заново для каждого запроса.
+type Loader[K comparable, V any] struct {
+	batch BatchFunc[K, V]
+	wait  time.Duration
+
+	mu      sync.Mutex
+	cache   map[K]*result[V]
+	pending map[K]*result[V]
+}
+
+func New[K comparable, V any](batch BatchFunc[K, V], wait time.Duration) *Loader[K, V] {
+	return &Loader[K, V]{
+		batch: batch,
+		wait:  wait,
+		cache: make(map[K]*result[V]),
+	}
+}
+
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	res, ok := l.cache[key]
+	if !ok {
+		res = &result[V]{done: make(chan struct{})}
+		l.cache[key] = res
+
+		if l.pending == nil {
+			l.pending = make(map[K]*result[V])
+			time.AfterFunc(l.wait, func() { l.dispatch(ctx) })
+		}
+		l.pending[key] = res
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-res.done:
+		return res.val, res.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+func (l *Loader[K, V]) dispatch(ctx context.Context) {
+	l.mu.Lock()
+	pending := l.pending
+	l.pending = nil
+	l.mu.Unlock()
+
+	keys := make([]K, 0, len(pending))
+	for key := range pending {
+		keys = append(keys, key)
+	}
+
+	vals, err := l.batch(ctx, keys)
+	for key, res := range pending {
+		if err != nil {
+			res.err = err
+		} else {
+			res.val = vals[key]
+		}
+		close(res.done)
+	}
+}
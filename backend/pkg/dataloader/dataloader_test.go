@@ -0,0 +1,98 @@
+package dataloader
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLoader_Load(t *testing.T) {
+	testCases := []struct {
+		name      string
+		keys      []int
+		batchErr  error
+		wantCalls [][]int
+		want      map[int]string
+		wantErr   bool
+	}{
+		{
+			name:      "ключи собираются в один пакет",
+			keys:      []int{1, 2, 3},
+			wantCalls: [][]int{{1, 2, 3}},
+			want:      map[int]string{1: "1", 2: "2", 3: "3"},
+		},
+		{
+			name:      "повторные ключи загружаются один раз",
+			keys:      []int{1, 1, 2, 2},
+			wantCalls: [][]int{{1, 2}},
+			want:      map[int]string{1: "1", 2: "2"},
+		},
+		{
+			name:      "отсутствующий ключ даёт нулевое значение",
+			keys:      []int{1, 42},
+			wantCalls: [][]int{{1, 42}},
+			want:      map[int]string{1: "1", 42: ""},
+		},
+		{
+			name:      "ошибка пакетной функции возвращается всем",
+			keys:      []int{1, 2},
+			batchErr:  errors.New("sql error"),
+			wantCalls: [][]int{{1, 2}},
+			wantErr:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var calls [][]int
+
+			loader := New(func(ctx context.Context, keys []int) (map[int]string, error) {
+				sorted := append([]int(nil), keys...)
+				sort.Ints(sorted)
+
+				mu.Lock()
+				calls = append(calls, sorted)
+				mu.Unlock()
+
+				if tc.batchErr != nil {
+					return nil, tc.batchErr
+				}
+
+				vals := make(map[int]string)
+				for _, key := range keys {
+					if key != 42 {
+						vals[key] = string(rune('0' + key))
+					}
+				}
+				return vals, nil
+			}, 50*time.Millisecond)
+
+			var wg sync.WaitGroup
+			got := make([]string, len(tc.keys))
+			errs := make([]error, len(tc.keys))
+			for i, key := range tc.keys {
+				wg.Add(1)
+				go func(i, key int) {
+					defer wg.Done()
+					got[i], errs[i] = loader.Load(context.Background(), key)
+				}(i, key)
+			}
+			wg.Wait()
+
+			require.Equal(t, tc.wantCalls, calls)
+			for i, key := range tc.keys {
+				if tc.wantErr {
+					require.Equal(t, tc.batchErr, errs[i])
+				} else {
+					require.Nil(t, errs[i])
+					require.Equal(t, tc.want[key], got[i])
+				}
+			}
+		})
+	}
+}
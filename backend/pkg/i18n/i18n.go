@@ -0,0 +1,116 @@
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type Lang string
+
+const (
+	Ru Lang = "ru"
+	En Lang = "en"
+
+	Default = Ru
+)
+
+var Langs = []Lang{Ru, En}
+
+// Key — идентификатор сообщения в каталоге.
+type Key string
+
+// T возвращает сообщение key на языке lang. Если перевода нет,
+// используется язык по умолчанию, а для неизвестного ключа — сам ключ.
+func T(lang Lang, key Key, args ...any) string {
+	msgs, ok := catalog[key]
+	if !ok {
+		return string(key)
+	}
+
+	msg, ok := msgs[lang]
+	if !ok {
+		msg = msgs[Default]
+	}
+
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+
+	return msg
+}
+
+func Parse(s string) (lang Lang, ok bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if i := strings.IndexAny(s, "-_"); i >= 0 {
+		s = s[:i]
+	}
+
+	for _, l := range Langs {
+		if string(l) == s {
+			return l, true
+		}
+	}
+
+	return Default, false
+}
+
+// ParseAcceptLanguage выбирает поддерживаемый язык с наибольшим весом
+// из значения заголовка Accept-Language.
+func ParseAcceptLanguage(header string) Lang {
+	type candidate struct {
+		lang Lang
+		q    float64
+	}
+
+	candidates := make([]candidate, 0)
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+
+		lang, ok := Parse(tag)
+		if !ok {
+			continue
+		}
+
+		q := 1.0
+		params = strings.TrimSpace(params)
+		if strings.HasPrefix(params, "q=") {
+			val, err := strconv.ParseFloat(strings.TrimPrefix(params, "q="), 64)
+			if err != nil {
+				continue
+			}
+			q = val
+		}
+
+		if q > 0 {
+			candidates = append(candidates, candidate{lang: lang, q: q})
+		}
+	}
+
+	if len(candidates) == 0 {
+		return Default
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].q > candidates[j].q
+	})
+
+	return candidates[0].lang
+}
+
+type ctxKey struct{}
+
+func WithLang(ctx context.Context, lang Lang) context.Context {
+	return context.WithValue(ctx, ctxKey{}, lang)
+}
+
+func FromContext(ctx context.Context) Lang {
+	lang, ok := ctx.Value(ctxKey{}).(Lang)
+	if !ok {
+		return Default
+	}
+
+	return lang
+}
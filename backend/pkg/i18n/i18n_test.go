@@ -0,0 +1,102 @@
+package i18n
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	testCases := []struct {
+		name   string
+		header string
+		want   Lang
+	}{
+		{
+			name:   "пустой заголовок",
+			header: "",
+			want:   Default,
+		},
+		{
+			name:   "английский с регионом",
+			header: "en-US",
+			want:   En,
+		},
+		{
+			name:   "выбор по весу",
+			header: "ru;q=0.5, en;q=0.8",
+			want:   En,
+		},
+		{
+			name:   "неподдерживаемые языки пропускаются",
+			header: "de-DE, fr;q=0.9, en;q=0.1",
+			want:   En,
+		},
+		{
+			name:   "нет поддерживаемых языков",
+			header: "de, fr",
+			want:   Default,
+		},
+		{
+			name:   "нулевой вес",
+			header: "en;q=0",
+			want:   Default,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, ParseAcceptLanguage(tc.header))
+		})
+	}
+}
+
+func TestT(t *testing.T) {
+	testCases := []struct {
+		name string
+		lang Lang
+		key  Key
+		args []any
+		want string
+	}{
+		{
+			name: "русский",
+			lang: Ru,
+			key:  MsgCompanyNameRequired,
+			want: "должно быть указано название компании",
+		},
+		{
+			name: "английский с аргументами",
+			lang: En,
+			key:  MsgParamRequired,
+			args: []any{"id"},
+			want: "parameter id is required",
+		},
+		{
+			name: "неизвестный язык",
+			lang: "de",
+			key:  MsgCompanyNameRequired,
+			want: "должно быть указано название компании",
+		},
+		{
+			name: "неизвестный ключ",
+			lang: En,
+			key:  "unknown.key",
+			want: "unknown.key",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, T(tc.lang, tc.key, tc.args...))
+		})
+	}
+}
+
+func TestCatalogCompleteness(t *testing.T) {
+	for key, msgs := range catalog {
+		for _, lang := range Langs {
+			_, ok := msgs[lang]
+			require.True(t, ok, "нет перевода %s для %s", key, lang)
+		}
+	}
+}
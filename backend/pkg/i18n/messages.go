@@ -0,0 +1,273 @@
+package i18n
+
+const (
+	MsgNotFound     Key = "error.not_found"
+	MsgConflict     Key = "error.conflict"
+	MsgForbidden    Key = "error.forbidden"
+	MsgBadRequest   Key = "error.bad_request"
+	MsgUnauthorized Key = "error.unauthorized"
+	MsgInternal     Key = "error.internal"
+
+	MsgParamRequired       Key = "request.param_required"
+	MsgParamInvalidUUID    Key = "request.param_invalid_uuid"
+	MsgParamInvalidNumber  Key = "request.param_invalid_number"
+	MsgParamInvalidTime    Key = "request.param_invalid_time"
+	MsgRequestInvalid      Key = "request.invalid"
+	MsgRequestFieldInvalid Key = "request.field_invalid"
+	MsgRequestTooLarge     Key = "request.too_large"
+
+	MsgUsernameRequired   Key = "auth.username_required"
+	MsgPasswordRequired   Key = "auth.password_required"
+	MsgInvalidCredentials Key = "auth.invalid_credentials"
+
+	MsgUserGenderUnknown     Key = "user.gender_unknown"
+	MsgUserBirthdayRequired  Key = "user.birthday_required"
+	MsgUserFullNameRequired  Key = "user.full_name_required"
+	MsgUserFullNameWordCount Key = "user.full_name_word_count"
+	MsgUserRoleInvalid       Key = "user.role_invalid"
+
+	MsgCityRequired Key = "common.city_required"
+
+	MsgCompanyNameRequired Key = "company.name_required"
+
+	MsgContactNameRequired  Key = "contact.name_required"
+	MsgContactValueRequired Key = "contact.value_required"
+	MsgContactLimit         Key = "contact.limit"
+
+	MsgSkillNameRequired        Key = "skill.name_required"
+	MsgSkillDescriptionRequired Key = "skill.description_required"
+
+	MsgActFieldNameRequired        Key = "activity_field.name_required"
+	MsgActFieldDescriptionRequired Key = "activity_field.description_required"
+	MsgActFieldCostZero            Key = "activity_field.cost_zero"
+
+	MsgReviewRatingRange  Key = "review.rating_range"
+	MsgReviewProsRequired Key = "review.pros_required"
+	MsgReviewConsRequired Key = "review.cons_required"
+
+	MsgFinRevenueNegative       Key = "fin_report.revenue_negative"
+	MsgFinCostsNegative         Key = "fin_report.costs_negative"
+	MsgFinQuarterRange          Key = "fin_report.quarter_range"
+	MsgFinYearInFuture          Key = "fin_report.year_in_future"
+	MsgFinQuarterUnfinished     Key = "fin_report.quarter_unfinished"
+	MsgFinPeriodOrder           Key = "fin_report.period_end_before_start"
+	MsgFinAmendReasonRequired   Key = "fin_report.amend_reason_required"
+	MsgFinSubmitStatus          Key = "fin_report.submit_status"
+	MsgFinSubmitNoDocuments     Key = "fin_report.submit_no_documents"
+	MsgFinReviewStatus          Key = "fin_report.review_status"
+	MsgFinReviewDecision        Key = "fin_report.review_decision"
+	MsgFinRejectCommentRequired Key = "fin_report.reject_comment_required"
+	MsgFinStatusUnknown         Key = "fin_report.status_unknown"
+	MsgFinDocumentsLocked       Key = "fin_report.documents_locked"
+	MsgFinDocumentNameRequired  Key = "fin_report.document_name_required"
+	MsgFinDocumentType          Key = "fin_report.document_type"
+	MsgFinDocumentEmpty         Key = "fin_report.document_empty"
+	MsgFinAssetsNegative        Key = "fin_report.assets_negative"
+	MsgFinLiabilitiesNegative   Key = "fin_report.liabilities_negative"
+	MsgFinHeadcountNegative     Key = "fin_report.headcount_negative"
+	MsgFinExportsShareRange     Key = "fin_report.exports_share_range"
+	MsgFinBalanceMismatch       Key = "fin_report.balance_mismatch"
+
+	MsgRoleNameRequired      Key = "role.name_required"
+	MsgRoleUnknownPermission Key = "role.unknown_permission"
+
+	MsgWebhookURLInvalid     Key = "webhook.url_invalid"
+	MsgWebhookSecretShort    Key = "webhook.secret_short"
+	MsgWebhookEventsRequired Key = "webhook.events_required"
+	MsgWebhookEventUnknown   Key = "webhook.event_unknown"
+
+	MsgAuditPeriodOrder Key = "audit.period_end_before_start"
+)
+
+const (
+	TuiTitle         Key = "tui.title"
+	TuiAuthError     Key = "tui.auth_error"
+	TuiEntrepreneurs Key = "tui.entrepreneurs"
+	TuiMenuLogin     Key = "tui.menu.login"
+	TuiMenuSignUp    Key = "tui.menu.sign_up"
+	TuiMenuLanguage  Key = "tui.menu.language"
+	TuiMenuLogout    Key = "tui.menu.logout"
+	TuiMenuExit      Key = "tui.menu.exit"
+	TuiLogin         Key = "tui.login"
+	TuiPassword      Key = "tui.password"
+	TuiSignedUp      Key = "tui.signed_up"
+	TuiSave          Key = "tui.save"
+	TuiCancel        Key = "tui.cancel"
+	TuiYes           Key = "tui.yes"
+	TuiNo            Key = "tui.no"
+	TuiOK            Key = "tui.ok"
+	TuiPage          Key = "tui.page"
+	TuiEmpty         Key = "tui.empty"
+	TuiPick          Key = "tui.pick"
+	TuiHintMenu      Key = "tui.hint.menu"
+	TuiHintTable     Key = "tui.hint.table"
+	TuiHintForm      Key = "tui.hint.form"
+	TuiNotANumber    Key = "tui.not_a_number"
+	TuiNotAnInteger  Key = "tui.not_an_integer"
+	TuiInvalidDate   Key = "tui.invalid_date"
+	TuiNothingChosen Key = "tui.nothing_chosen"
+
+	TuiActUpdateUser       Key = "tui.action.update_user"
+	TuiActCreateUser       Key = "tui.action.create_user"
+	TuiActAddCompany       Key = "tui.action.add_company"
+	TuiActDeleteCompany    Key = "tui.action.delete_company"
+	TuiActUpdateCompany    Key = "tui.action.update_company"
+	TuiActMyCompanies      Key = "tui.action.my_companies"
+	TuiActCalculateRating  Key = "tui.action.calculate_rating"
+	TuiActRatingHistory    Key = "tui.action.rating_history"
+	TuiActChangeUserRole   Key = "tui.action.change_user_role"
+	TuiActRoles            Key = "tui.action.roles"
+	TuiActUsers            Key = "tui.action.users"
+	TuiActAddActivityField Key = "tui.action.add_activity_field"
+	TuiActDeleteActField   Key = "tui.action.delete_activity_field"
+	TuiActUpdateActField   Key = "tui.action.update_activity_field"
+	TuiActAddSkill         Key = "tui.action.add_skill"
+	TuiActDeleteSkill      Key = "tui.action.delete_skill"
+	TuiActUpdateSkill      Key = "tui.action.update_skill"
+	TuiActMySkills         Key = "tui.action.my_skills"
+	TuiActAddUserSkill     Key = "tui.action.add_user_skill"
+	TuiActDeleteUserSkill  Key = "tui.action.delete_user_skill"
+	TuiActMyContacts       Key = "tui.action.my_contacts"
+	TuiActAddContact       Key = "tui.action.add_contact"
+	TuiActUpdateContact    Key = "tui.action.update_contact"
+	TuiActDeleteContact    Key = "tui.action.delete_contact"
+	TuiActAddReport        Key = "tui.action.add_report"
+	TuiActUpdateReport     Key = "tui.action.update_report"
+	TuiActDeleteReport     Key = "tui.action.delete_report"
+	TuiActUserFinReport    Key = "tui.action.user_fin_report"
+)
+
+var catalog = map[Key]map[Lang]string{
+	MsgNotFound:     {Ru: "объект не найден", En: "resource not found"},
+	MsgConflict:     {Ru: "конфликт данных", En: "conflicting data"},
+	MsgForbidden:    {Ru: "доступ запрещен", En: "access denied"},
+	MsgBadRequest:   {Ru: "некорректный запрос", En: "bad request"},
+	MsgUnauthorized: {Ru: "требуется аутентификация", En: "authentication required"},
+	MsgInternal:     {Ru: "внутренняя ошибка сервера", En: "internal server error"},
+
+	MsgParamRequired:       {Ru: "должен быть указан параметр %s", En: "parameter %s is required"},
+	MsgParamInvalidUUID:    {Ru: "параметр %s должен быть UUID", En: "parameter %s must be a UUID"},
+	MsgParamInvalidNumber:  {Ru: "параметр %s должен быть целым числом", En: "parameter %s must be an integer"},
+	MsgParamInvalidTime:    {Ru: "параметр %s должен быть временем в формате RFC 3339", En: "parameter %s must be an RFC 3339 timestamp"},
+	MsgRequestInvalid:      {Ru: "запрос не соответствует спецификации API", En: "request does not match the API specification"},
+	MsgRequestFieldInvalid: {Ru: "некорректное значение %s", En: "invalid value of %s"},
+	MsgRequestTooLarge:     {Ru: "тело запроса больше %d байт", En: "request body exceeds %d bytes"},
+
+	MsgUsernameRequired:   {Ru: "должно быть указано имя пользователя", En: "username is required"},
+	MsgPasswordRequired:   {Ru: "должен быть указан пароль", En: "password is required"},
+	MsgInvalidCredentials: {Ru: "неверное имя пользователя или пароль", En: "invalid username or password"},
+
+	MsgUserGenderUnknown:     {Ru: "неизвестный пол", En: "unknown gender"},
+	MsgUserBirthdayRequired:  {Ru: "должна быть указана дата рождения", En: "birthday is required"},
+	MsgUserFullNameRequired:  {Ru: "должны быть указаны ФИО", En: "full name is required"},
+	MsgUserFullNameWordCount: {Ru: "некорректное количество слов (должны быть фамилия, имя и отчество)", En: "invalid word count (last name, first name and patronymic are expected)"},
+	MsgUserRoleInvalid:       {Ru: "невалидная роль: %v", En: "invalid role: %v"},
+
+	MsgCityRequired: {Ru: "должно быть указано название города", En: "city is required"},
+
+	MsgCompanyNameRequired: {Ru: "должно быть указано название компании", En: "company name is required"},
+
+	MsgContactNameRequired:  {Ru: "должно быть указано название средства связи", En: "contact name is required"},
+	MsgContactValueRequired: {Ru: "должно быть указано значение средства связи", En: "contact value is required"},
+	MsgContactLimit:         {Ru: "добавление средства связи: количество не должно быть более %d", En: "adding contact: no more than %d contacts are allowed"},
+
+	MsgSkillNameRequired:        {Ru: "должно быть указано название навыка", En: "skill name is required"},
+	MsgSkillDescriptionRequired: {Ru: "должно быть указано описание навыка", En: "skill description is required"},
+
+	MsgActFieldNameRequired:        {Ru: "должно быть указано название сферы деятельности", En: "activity field name is required"},
+	MsgActFieldDescriptionRequired: {Ru: "должно быть указано описание сферы деятельности", En: "activity field description is required"},
+	MsgActFieldCostZero:            {Ru: "вес сферы деятельности не может быть равен 0", En: "activity field weight must not be 0"},
+
+	MsgReviewRatingRange:  {Ru: "оценка должна быть целым числом от 1 до 5", En: "rating must be an integer from 1 to 5"},
+	MsgReviewProsRequired: {Ru: "описание преимуществ не должно быть пустым", En: "pros must not be empty"},
+	MsgReviewConsRequired: {Ru: "описание недостатков не должно быть пустым", En: "cons must not be empty"},
+
+	MsgFinRevenueNegative:       {Ru: "выручка не может быть отрицательной", En: "revenue must not be negative"},
+	MsgFinCostsNegative:         {Ru: "расходы не могут быть отрицательными", En: "costs must not be negative"},
+	MsgFinQuarterRange:          {Ru: "значение квартала должно находиться в отрезке от 1 до 4", En: "quarter must be between 1 and 4"},
+	MsgFinYearInFuture:          {Ru: "значение года не может быть больше текущего года", En: "year must not be later than the current year"},
+	MsgFinQuarterUnfinished:     {Ru: "нельзя добавить отчет за квартал, который еще не закончился", En: "cannot add a report for a quarter that has not ended yet"},
+	MsgFinPeriodOrder:           {Ru: "дата конца периода должна быть позже даты начала", En: "period end must be later than period start"},
+	MsgFinAmendReasonRequired:   {Ru: "должна быть указана причина исправления", En: "amendment reason is required"},
+	MsgFinSubmitStatus:          {Ru: "отправить на проверку можно только неподтвержденный или отклоненный отчет", En: "only an unverified or rejected report can be submitted for verification"},
+	MsgFinSubmitNoDocuments:     {Ru: "для отправки на проверку к отчету должен быть приложен хотя бы один документ", En: "at least one document must be attached to submit a report for verification"},
+	MsgFinReviewStatus:          {Ru: "проверить можно только отчет, отправленный на проверку", En: "only a report submitted for verification can be reviewed"},
+	MsgFinReviewDecision:        {Ru: "решение проверки должно быть verified или rejected", En: "review decision must be verified or rejected"},
+	MsgFinRejectCommentRequired: {Ru: "при отклонении отчета должен быть указан комментарий", En: "a comment is required to reject a report"},
+	MsgFinStatusUnknown:         {Ru: "статус проверки должен быть одним из: unverified, submitted, verified, rejected", En: "verification status must be one of: unverified, submitted, verified, rejected"},
+	MsgFinDocumentsLocked:       {Ru: "документы нельзя изменять, пока отчет на проверке или подтвержден", En: "documents cannot be changed while the report is submitted or verified"},
+	MsgFinDocumentNameRequired:  {Ru: "должно быть указано имя документа", En: "document name is required"},
+	MsgFinDocumentType:          {Ru: "тип документа должен быть одним из: %s", En: "document type must be one of: %s"},
+	MsgFinDocumentEmpty:         {Ru: "документ не может быть пустым", En: "document must not be empty"},
+	MsgFinAssetsNegative:        {Ru: "активы не могут быть отрицательными", En: "assets must not be negative"},
+	MsgFinLiabilitiesNegative:   {Ru: "обязательства не могут быть отрицательными", En: "liabilities must not be negative"},
+	MsgFinHeadcountNegative:     {Ru: "численность сотрудников не может быть отрицательной", En: "headcount must not be negative"},
+	MsgFinExportsShareRange:     {Ru: "доля экспорта должна быть от 0 до 100 процентов", En: "exports share must be between 0 and 100 percent"},
+	MsgFinBalanceMismatch:       {Ru: "активы должны быть равны сумме обязательств и капитала", En: "assets must equal liabilities plus equity"},
+
+	MsgRoleNameRequired:      {Ru: "должно быть указано название роли", En: "role name is required"},
+	MsgRoleUnknownPermission: {Ru: "неизвестное разрешение: %s", En: "unknown permission: %s"},
+
+	MsgWebhookURLInvalid:     {Ru: "адрес вебхука должен быть абсолютным URL со схемой http или https", En: "webhook URL must be an absolute http or https URL"},
+	MsgWebhookSecretShort:    {Ru: "секрет должен содержать не менее %d символов", En: "secret must be at least %d characters long"},
+	MsgWebhookEventsRequired: {Ru: "должен быть указан хотя бы один тип события", En: "at least one event type is required"},
+	MsgWebhookEventUnknown:   {Ru: "неизвестный тип события: %s", En: "unknown event type: %s"},
+
+	MsgAuditPeriodOrder: {Ru: "конец периода должен быть позже его начала", En: "period end must be later than period start"},
+
+	TuiTitle:         {Ru: "ППО — сервис поиска контрагентов", En: "PPO — counterparty search"},
+	TuiAuthError:     {Ru: "ошибка авторизации: %v", En: "authorization error: %v"},
+	TuiEntrepreneurs: {Ru: "Предприниматели", En: "Entrepreneurs"},
+	TuiMenuLogin:     {Ru: "Войти", En: "Log in"},
+	TuiMenuSignUp:    {Ru: "Зарегистрироваться", En: "Sign up"},
+	TuiMenuLanguage:  {Ru: "Язык интерфейса", En: "Interface language"},
+	TuiMenuLogout:    {Ru: "Выйти из учётной записи", En: "Log out"},
+	TuiMenuExit:      {Ru: "Выход", En: "Exit"},
+	TuiLogin:         {Ru: "Логин", En: "Login"},
+	TuiPassword:      {Ru: "Пароль", En: "Password"},
+	TuiSignedUp:      {Ru: "Регистрация выполнена, теперь можно войти.", En: "Signed up, you can log in now."},
+	TuiSave:          {Ru: "Сохранить", En: "Save"},
+	TuiCancel:        {Ru: "Отмена", En: "Cancel"},
+	TuiYes:           {Ru: "Да", En: "Yes"},
+	TuiNo:            {Ru: "Нет", En: "No"},
+	TuiOK:            {Ru: "OK", En: "OK"},
+	TuiPage:          {Ru: "стр. %d из %d", En: "page %d of %d"},
+	TuiEmpty:         {Ru: "Нет записей", En: "No entries"},
+	TuiPick:          {Ru: "Enter — выбрать", En: "Enter to choose"},
+	TuiHintMenu:      {Ru: "↑/↓ — выбор · Enter или клавиша пункта — открыть · Esc — назад", En: "↑/↓ select · Enter or item key open · Esc back"},
+	TuiHintTable:     {Ru: "←/→ — страницы · 1–9 — сортировка по столбцу · Enter — выбрать · Esc — назад", En: "←/→ pages · 1–9 sort by column · Enter choose · Esc back"},
+	TuiHintForm:      {Ru: "Tab/Shift+Tab — поля · Enter в поле выбора — список · Esc — отмена", En: "Tab/Shift+Tab fields · Enter on a picker opens the list · Esc cancel"},
+	TuiNotANumber:    {Ru: "ожидается число", En: "a number is expected"},
+	TuiNotAnInteger:  {Ru: "ожидается целое число", En: "an integer is expected"},
+	TuiInvalidDate:   {Ru: "ожидается дата в формате ГГГГ-ММ-ДД", En: "a date in YYYY-MM-DD format is expected"},
+	TuiNothingChosen: {Ru: "значение не выбрано", En: "nothing is chosen"},
+
+	TuiActUpdateUser:       {Ru: "[ Предприниматели ] Редактировать карточку предпринимателя", En: "[ Entrepreneurs ] Edit entrepreneur card"},
+	TuiActCreateUser:       {Ru: "[ Предприниматели ] Создать карточку предпринимателя", En: "[ Entrepreneurs ] Create entrepreneur card"},
+	TuiActAddCompany:       {Ru: "[ Компании ] Добавить компанию", En: "[ Companies ] Add company"},
+	TuiActDeleteCompany:    {Ru: "[ Компании ] Удалить компанию", En: "[ Companies ] Delete company"},
+	TuiActUpdateCompany:    {Ru: "[ Компании ] Обновить информацию о компании", En: "[ Companies ] Update company"},
+	TuiActMyCompanies:      {Ru: "[ Компании ] Посмотреть список своих компаний", En: "[ Companies ] My companies"},
+	TuiActCalculateRating:  {Ru: "[ Предприниматели ] Просчитать рейтинг", En: "[ Entrepreneurs ] Calculate rating"},
+	TuiActRatingHistory:    {Ru: "[ Предприниматели ] История рейтинга", En: "[ Entrepreneurs ] Rating history"},
+	TuiActChangeUserRole:   {Ru: "[ Предприниматели ] Сменить роль пользователя", En: "[ Entrepreneurs ] Change user role"},
+	TuiActRoles:            {Ru: "[ Роли ] Просмотреть список ролей", En: "[ Roles ] List roles"},
+	TuiActUsers:            {Ru: "[ Предприниматели ] Просмотреть список предпринимателей", En: "[ Entrepreneurs ] Browse entrepreneurs"},
+	TuiActAddActivityField: {Ru: "[ Сферы деятельности ] Добавить сферу деятельности", En: "[ Activity fields ] Add activity field"},
+	TuiActDeleteActField:   {Ru: "[ Сферы деятельности ] Удалить сферу деятельности", En: "[ Activity fields ] Delete activity field"},
+	TuiActUpdateActField:   {Ru: "[ Сферы деятельности ] Редактировать сферу деятельности", En: "[ Activity fields ] Edit activity field"},
+	TuiActAddSkill:         {Ru: "[ Навыки ] Добавить навык", En: "[ Skills ] Add skill"},
+	TuiActDeleteSkill:      {Ru: "[ Навыки ] Удалить навык", En: "[ Skills ] Delete skill"},
+	TuiActUpdateSkill:      {Ru: "[ Навыки ] Редактировать навык", En: "[ Skills ] Edit skill"},
+	TuiActMySkills:         {Ru: "[ Мои навыки ] Посмотреть свои навыки", En: "[ My skills ] List my skills"},
+	TuiActAddUserSkill:     {Ru: "[ Мои навыки ] Добавить навык", En: "[ My skills ] Add skill"},
+	TuiActDeleteUserSkill:  {Ru: "[ Мои навыки ] Удалить навык", En: "[ My skills ] Delete skill"},
+	TuiActMyContacts:       {Ru: "[ Средства связи ] Посмотреть свои средства связи", En: "[ Contacts ] My contacts"},
+	TuiActAddContact:       {Ru: "[ Средства связи ] Добавить средство связи", En: "[ Contacts ] Add contact"},
+	TuiActUpdateContact:    {Ru: "[ Средства связи ] Редактировать средство связи", En: "[ Contacts ] Edit contact"},
+	TuiActDeleteContact:    {Ru: "[ Средства связи ] Удалить средство связи", En: "[ Contacts ] Delete contact"},
+	TuiActAddReport:        {Ru: "[ Финансовые показатели ] Добавить отчёт", En: "[ Financials ] Add report"},
+	TuiActUpdateReport:     {Ru: "[ Финансовые показатели ] Редактировать отчёт", En: "[ Financials ] Edit report"},
+	TuiActDeleteReport:     {Ru: "[ Финансовые показатели ] Удалить отчёт", En: "[ Financials ] Delete report"},
+	TuiActUserFinReport:    {Ru: "[ Финансовые показатели ] Сформировать полный отчёт", En: "[ Financials ] Build full report"},
+}
@@ -0,0 +1,81 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"ppo/domain"
+	"ppo/internal/app"
+	"ppo/pkg/i18n"
+	"ppo/rpc/pb"
+	"strings"
+
+	"github.com/go-chi/jwtauth/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// methodPermissions — права, которые нужны для вызова метода помимо JWT-токена.
+var methodPermissions = map[string]domain.Permission{
+	pb.UserService_GetUserFinancials_FullMethodName:             domain.PermFinanceRead,
+	pb.FinancialReportService_GetFinancialReport_FullMethodName: domain.PermFinanceRead,
+	pb.FinancialReportService_ListCompanyReports_FullMethodName: domain.PermFinanceRead,
+}
+
+// AuthInterceptor пропускает только вызовы с действительным JWT-токеном
+// в метаданных authorization и проверяет права из methodPermissions.
+// Проверенный токен кладётся в контекст так же, как это делает jwtauth.Verifier.
+func AuthInterceptor(a *app.App, tokenAuth *jwtauth.JWTAuth) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		lang := i18n.FromContext(ctx)
+
+		token, err := jwtauth.VerifyToken(tokenAuth, tokenFromMetadata(ctx))
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, i18n.T(lang, i18n.MsgUnauthorized))
+		}
+
+		ctx = jwtauth.NewContext(ctx, token, nil)
+
+		perm, ok := methodPermissions[info.FullMethod]
+		if ok {
+			role, _ := token.Get("role")
+			roleStr, _ := role.(string)
+
+			allowed, err := a.RoleSvc.HasPermission(ctx, roleStr, perm)
+			// роль из токена могла быть удалена после его выдачи
+			if errors.Is(err, domain.ErrNotFound) {
+				return nil, fmt.Errorf("%w: неизвестная роль '%s'", domain.ErrForbidden, roleStr)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("проверка прав: %w", err)
+			}
+
+			if !allowed {
+				return nil, fmt.Errorf("%w: для вызова требуется право '%s'", domain.ErrForbidden, perm)
+			}
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func tokenFromMetadata(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	vals := md.Get("authorization")
+	if len(vals) == 0 {
+		return ""
+	}
+
+	token, found := strings.CutPrefix(vals[0], "Bearer ")
+	if !found {
+		return ""
+	}
+
+	return token
+}
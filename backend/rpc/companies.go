@@ -0,0 +1,46 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"ppo/internal/app"
+	"ppo/rpc/pb"
+)
+
+type companyServer struct {
+	pb.UnimplementedCompanyServiceServer
+	app *app.App
+}
+
+func (s *companyServer) GetCompany(ctx context.Context, req *pb.GetCompanyRequest) (*pb.Company, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	company, err := s.app.CompSvc.GetById(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("получение компании по id: %w", err)
+	}
+
+	return toCompanyMessage(company), nil
+}
+
+func (s *companyServer) ListCompanies(ctx context.Context, req *pb.ListCompaniesRequest) (*pb.ListCompaniesResponse, error) {
+	ownerId, err := parseID("owner_id", req.GetOwnerId())
+	if err != nil {
+		return nil, err
+	}
+
+	companies, numPages, err := s.app.CompSvc.GetByOwnerId(ctx, ownerId, pageOrFirst(req.GetPage()), true)
+	if err != nil {
+		return nil, fmt.Errorf("получение списка компаний предпринимателя: %w", err)
+	}
+
+	res := &pb.ListCompaniesResponse{Companies: make([]*pb.Company, len(companies)), NumPages: int32(numPages)}
+	for i, company := range companies {
+		res.Companies[i] = toCompanyMessage(company)
+	}
+
+	return res, nil
+}
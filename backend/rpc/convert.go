@@ -0,0 +1,126 @@
+package rpc
+
+import (
+	"ppo/domain"
+	"ppo/pkg/i18n"
+	"ppo/rpc/pb"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func parseID(field string, val string) (uuid.UUID, error) {
+	id, err := uuid.Parse(val)
+	if err != nil {
+		return uuid.UUID{}, domain.NewValidationError(field, i18n.MsgParamInvalidUUID, field)
+	}
+
+	return id, nil
+}
+
+// pageOrFirst возвращает номер страницы, считая неуказанную страницу первой.
+func pageOrFirst(page int32) int {
+	if page <= 0 {
+		return 1
+	}
+
+	return int(page)
+}
+
+// periodOrPrevYear возвращает период из запроса или прошлый год, если период не указан.
+func periodOrPrevYear(period *pb.Period) *domain.Period {
+	if period == nil {
+		prevYear := time.Now().AddDate(-1, 0, 0).Year()
+		return &domain.Period{StartYear: prevYear, StartQuarter: 1, EndYear: prevYear, EndQuarter: 4}
+	}
+
+	return toPeriodModel(period)
+}
+
+func toPeriodModel(period *pb.Period) *domain.Period {
+	return &domain.Period{
+		StartYear:    int(period.GetStartYear()),
+		StartQuarter: int(period.GetStartQuarter()),
+		EndYear:      int(period.GetEndYear()),
+		EndQuarter:   int(period.GetEndQuarter()),
+	}
+}
+
+func toPeriodMessage(period *domain.Period) *pb.Period {
+	return &pb.Period{
+		StartYear:    int32(period.StartYear),
+		StartQuarter: int32(period.StartQuarter),
+		EndYear:      int32(period.EndYear),
+		EndQuarter:   int32(period.EndQuarter),
+	}
+}
+
+func toUserMessage(user *domain.User) *pb.User {
+	res := &pb.User{
+		Id:       user.ID.String(),
+		Username: user.Username,
+		FullName: user.FullName,
+		Gender:   user.Gender,
+		City:     user.City,
+		Role:     user.Role,
+	}
+
+	if !user.Birthday.IsZero() {
+		res.Birthday = timestamppb.New(user.Birthday)
+	}
+
+	return res
+}
+
+func toCompanyMessage(company *domain.Company) *pb.Company {
+	return &pb.Company{
+		Id:              company.ID.String(),
+		OwnerId:         company.OwnerID.String(),
+		ActivityFieldId: company.ActivityFieldId.String(),
+		Name:            company.Name,
+		City:            company.City,
+	}
+}
+
+func toFinReportMessage(rep *domain.FinancialReport) *pb.FinancialReport {
+	return &pb.FinancialReport{
+		Id:        rep.ID.String(),
+		CompanyId: rep.CompanyID.String(),
+		Year:      int32(rep.Year),
+		Quarter:   int32(rep.Quarter),
+		Revenue:   rep.Revenue,
+		Costs:     rep.Costs,
+		Profit:    rep.Revenue - rep.Costs,
+	}
+}
+
+func toFinSummaryMessage(rep *domain.FinancialReportByPeriod, period *domain.Period) *pb.FinancialSummary {
+	res := &pb.FinancialSummary{
+		Period:  toPeriodMessage(period),
+		Reports: make([]*pb.FinancialReport, len(rep.Reports)),
+		Revenue: rep.Revenue(),
+		Costs:   rep.Costs(),
+		Profit:  rep.Profit(),
+		Taxes:   rep.Taxes,
+		TaxLoad: rep.TaxLoad,
+	}
+
+	for i := range rep.Reports {
+		res.Reports[i] = toFinReportMessage(&rep.Reports[i])
+	}
+
+	return res
+}
+
+func toReviewMessage(rev *domain.Review) *pb.Review {
+	return &pb.Review{
+		Id:          rev.ID.String(),
+		ReviewerId:  rev.Reviewer.String(),
+		TargetId:    rev.Target.String(),
+		Pros:        rev.Pros,
+		Cons:        rev.Cons,
+		Description: rev.Description,
+		Rating:      int32(rev.Rating),
+	}
+}
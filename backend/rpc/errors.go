@@ -0,0 +1,57 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"log"
+	"ppo/domain"
+	"ppo/pkg/i18n"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// toStatus переводит ошибку домена в статус gRPC с сообщением на языке lang.
+func toStatus(lang i18n.Lang, err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrValidation):
+		return status.Error(codes.InvalidArgument, domain.LocalizeError(lang, err))
+	case errors.Is(err, domain.ErrNotFound):
+		return status.Error(codes.NotFound, domain.LocalizeError(lang, err))
+	case errors.Is(err, domain.ErrConflict):
+		return status.Error(codes.AlreadyExists, domain.LocalizeError(lang, err))
+	case errors.Is(err, domain.ErrForbidden):
+		return status.Error(codes.PermissionDenied, domain.LocalizeError(lang, err))
+	case errors.Is(err, domain.ErrInvalidCredentials):
+		return status.Error(codes.Unauthenticated, domain.LocalizeError(lang, err))
+	}
+
+	log.Println(err)
+	return status.Error(codes.Internal, i18n.T(lang, i18n.MsgInternal))
+}
+
+// ErrorInterceptor выбирает язык по метаданным accept-language
+// и переводит ошибки обработчиков в статусы gRPC.
+func ErrorInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	lang := i18n.Default
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if vals := md.Get("accept-language"); len(vals) > 0 {
+			lang = i18n.ParseAcceptLanguage(vals[0])
+		}
+	}
+
+	ctx = i18n.WithLang(ctx, lang)
+
+	resp, err := handler(ctx, req)
+	if err != nil {
+		return nil, toStatus(lang, err)
+	}
+
+	return resp, nil
+}
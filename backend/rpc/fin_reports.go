@@ -0,0 +1,48 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"ppo/domain"
+	"ppo/internal/app"
+	"ppo/pkg/i18n"
+	"ppo/rpc/pb"
+)
+
+type finReportServer struct {
+	pb.UnimplementedFinancialReportServiceServer
+	app *app.App
+}
+
+func (s *finReportServer) GetFinancialReport(ctx context.Context, req *pb.GetFinancialReportRequest) (*pb.FinancialReport, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	rep, err := s.app.FinSvc.GetById(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("получение финансового отчета по id: %w", err)
+	}
+
+	return toFinReportMessage(rep), nil
+}
+
+func (s *finReportServer) ListCompanyReports(ctx context.Context, req *pb.ListCompanyReportsRequest) (*pb.FinancialSummary, error) {
+	companyId, err := parseID("company_id", req.GetCompanyId())
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetPeriod() == nil {
+		return nil, domain.NewValidationError("period", i18n.MsgParamRequired, "period")
+	}
+
+	period := toPeriodModel(req.GetPeriod())
+	rep, err := s.app.FinSvc.GetByCompany(ctx, companyId, period)
+	if err != nil {
+		return nil, fmt.Errorf("получение финансовых отчетов компании: %w", err)
+	}
+
+	return toFinSummaryMessage(rep, period), nil
+}
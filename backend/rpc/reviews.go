@@ -0,0 +1,65 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"ppo/domain"
+	"ppo/internal/app"
+	"ppo/rpc/pb"
+)
+
+type reviewServer struct {
+	pb.UnimplementedReviewServiceServer
+	app *app.App
+}
+
+func (s *reviewServer) GetReview(ctx context.Context, req *pb.GetReviewRequest) (*pb.Review, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	rev, err := s.app.RevSvc.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("получение отзыва по id: %w", err)
+	}
+
+	return toReviewMessage(rev), nil
+}
+
+func (s *reviewServer) ListReviewsForTarget(ctx context.Context, req *pb.ListReviewsForTargetRequest) (*pb.ListReviewsResponse, error) {
+	targetId, err := parseID("target_id", req.GetTargetId())
+	if err != nil {
+		return nil, err
+	}
+
+	reviews, numPages, err := s.app.RevSvc.GetAllForTarget(ctx, targetId, pageOrFirst(req.GetPage()))
+	if err != nil {
+		return nil, fmt.Errorf("получение отзывов о предпринимателе: %w", err)
+	}
+
+	return toReviewsResponse(reviews, numPages), nil
+}
+
+func (s *reviewServer) ListReviewsByReviewer(ctx context.Context, req *pb.ListReviewsByReviewerRequest) (*pb.ListReviewsResponse, error) {
+	reviewerId, err := parseID("reviewer_id", req.GetReviewerId())
+	if err != nil {
+		return nil, err
+	}
+
+	reviews, numPages, err := s.app.RevSvc.GetAllForReviewer(ctx, reviewerId, pageOrFirst(req.GetPage()))
+	if err != nil {
+		return nil, fmt.Errorf("получение отзывов автора: %w", err)
+	}
+
+	return toReviewsResponse(reviews, numPages), nil
+}
+
+func toReviewsResponse(reviews []*domain.Review, numPages int) *pb.ListReviewsResponse {
+	res := &pb.ListReviewsResponse{Reviews: make([]*pb.Review, len(reviews)), NumPages: int32(numPages)}
+	for i, rev := range reviews {
+		res.Reviews[i] = toReviewMessage(rev)
+	}
+
+	return res
+}
@@ -0,0 +1,25 @@
+package rpc
+
+import (
+	"ppo/internal/app"
+	"ppo/rpc/pb"
+
+	"github.com/go-chi/jwtauth/v5"
+	"google.golang.org/grpc"
+)
+
+// NewServer создаёт gRPC-сервер поверх тех же сервисов app, что и HTTP API.
+// Все вызовы требуют JWT-токен, выданный HTTP API.
+func NewServer(a *app.App, tokenAuth *jwtauth.JWTAuth) *grpc.Server {
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		ErrorInterceptor,
+		AuthInterceptor(a, tokenAuth),
+	))
+
+	pb.RegisterUserServiceServer(srv, &userServer{app: a})
+	pb.RegisterCompanyServiceServer(srv, &companyServer{app: a})
+	pb.RegisterFinancialReportServiceServer(srv, &finReportServer{app: a})
+	pb.RegisterReviewServiceServer(srv, &reviewServer{app: a})
+
+	return srv
+}
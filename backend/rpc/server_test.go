@@ -0,0 +1,179 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"net"
+	"ppo/domain"
+	"ppo/internal/app"
+	"ppo/mocks"
+	"ppo/rpc/pb"
+	"testing"
+)
+
+func TestServer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userSvc := mocks.NewMockIUserService(ctrl)
+	finSvc := mocks.NewMockIFinancialReportService(ctrl)
+	roleSvc := mocks.NewMockIRoleService(ctrl)
+	a := &app.App{UserSvc: userSvc, FinSvc: finSvc, RoleSvc: roleSvc}
+
+	tokenAuth := jwtauth.New("HS256", []byte("secret"), nil)
+	_, token, err := tokenAuth.Encode(map[string]interface{}{"sub": uuid.NewString(), "role": "user"})
+	require.Nil(t, err)
+
+	lis := bufconn.Listen(1024 * 1024)
+	srv := NewServer(a, tokenAuth)
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.Nil(t, err)
+	defer conn.Close()
+
+	users := pb.NewUserServiceClient(conn)
+	reports := pb.NewFinancialReportServiceClient(conn)
+
+	userId := uuid.New()
+	reportId := uuid.New()
+
+	testCases := []struct {
+		name       string
+		anonymous  bool
+		role       string
+		call       func(ctx context.Context) (any, error)
+		beforeTest func()
+		wantCode   codes.Code
+		check      func(t *testing.T, resp any)
+	}{
+		{
+			name: "успешное получение предпринимателя",
+			call: func(ctx context.Context) (any, error) {
+				return users.GetUser(ctx, &pb.GetUserRequest{Id: userId.String()})
+			},
+			beforeTest: func() {
+				userSvc.EXPECT().GetById(gomock.Any(), userId).Return(&domain.User{ID: userId, Username: "user"}, nil)
+			},
+			wantCode: codes.OK,
+			check: func(t *testing.T, resp any) {
+				require.Equal(t, "user", resp.(*pb.User).GetUsername())
+				require.Nil(t, resp.(*pb.User).GetBirthday())
+			},
+		},
+		{
+			name:      "вызов без токена",
+			anonymous: true,
+			call: func(ctx context.Context) (any, error) {
+				return users.GetUser(ctx, &pb.GetUserRequest{Id: userId.String()})
+			},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name: "некорректный id",
+			call: func(ctx context.Context) (any, error) {
+				return users.GetUser(ctx, &pb.GetUserRequest{Id: "abc"})
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "предприниматель не найден",
+			call: func(ctx context.Context) (any, error) {
+				return users.GetUser(ctx, &pb.GetUserRequest{Id: userId.String()})
+			},
+			beforeTest: func() {
+				userSvc.EXPECT().GetById(gomock.Any(), userId).
+					Return(nil, domain.NewNotFoundError(fmt.Errorf("no rows")))
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name: "нет права на чтение финансов",
+			call: func(ctx context.Context) (any, error) {
+				return reports.GetFinancialReport(ctx, &pb.GetFinancialReportRequest{Id: reportId.String()})
+			},
+			beforeTest: func() {
+				roleSvc.EXPECT().HasPermission(gomock.Any(), "user", domain.PermFinanceRead).Return(false, nil)
+			},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name: "неизвестная роль в токене",
+			role: "ghost",
+			call: func(ctx context.Context) (any, error) {
+				return reports.GetFinancialReport(ctx, &pb.GetFinancialReportRequest{Id: reportId.String()})
+			},
+			beforeTest: func() {
+				roleSvc.EXPECT().HasPermission(gomock.Any(), "ghost", domain.PermFinanceRead).
+					Return(false, domain.NewNotFoundError(fmt.Errorf("no rows")))
+			},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name: "успешное получение отчета",
+			call: func(ctx context.Context) (any, error) {
+				return reports.GetFinancialReport(ctx, &pb.GetFinancialReportRequest{Id: reportId.String()})
+			},
+			beforeTest: func() {
+				roleSvc.EXPECT().HasPermission(gomock.Any(), "user", domain.PermFinanceRead).Return(true, nil)
+				finSvc.EXPECT().GetById(gomock.Any(), reportId).
+					Return(&domain.FinancialReport{ID: reportId, Revenue: 10, Costs: 4, Year: 2023, Quarter: 1}, nil)
+			},
+			wantCode: codes.OK,
+			check: func(t *testing.T, resp any) {
+				require.Equal(t, float32(6), resp.(*pb.FinancialReport).GetProfit())
+			},
+		},
+		{
+			name: "внутренняя ошибка не раскрывается",
+			call: func(ctx context.Context) (any, error) {
+				return users.ListUsers(ctx, &pb.ListUsersRequest{})
+			},
+			beforeTest: func() {
+				userSvc.EXPECT().GetAll(gomock.Any(), 1).Return(nil, 0, fmt.Errorf("sql error"))
+			},
+			wantCode: codes.Internal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.beforeTest != nil {
+				tc.beforeTest()
+			}
+
+			ctx := context.Background()
+			if !tc.anonymous {
+				callToken := token
+				if tc.role != "" {
+					_, callToken, err = tokenAuth.Encode(map[string]interface{}{"sub": uuid.NewString(), "role": tc.role})
+					require.Nil(t, err)
+				}
+				ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+callToken)
+			}
+
+			resp, err := tc.call(ctx)
+			require.Equal(t, tc.wantCode, status.Code(err))
+			if tc.wantCode == codes.Internal {
+				require.NotContains(t, status.Convert(err).Message(), "sql error")
+			}
+			if tc.check != nil {
+				tc.check(t, resp)
+			}
+		})
+	}
+}
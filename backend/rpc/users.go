@@ -0,0 +1,70 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"ppo/internal/app"
+	"ppo/rpc/pb"
+)
+
+type userServer struct {
+	pb.UnimplementedUserServiceServer
+	app *app.App
+}
+
+func (s *userServer) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.User, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.app.UserSvc.GetById(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("получение предпринимателя по id: %w", err)
+	}
+
+	return toUserMessage(user), nil
+}
+
+func (s *userServer) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+	users, numPages, err := s.app.UserSvc.GetAll(ctx, pageOrFirst(req.GetPage()))
+	if err != nil {
+		return nil, fmt.Errorf("получение списка предпринимателей: %w", err)
+	}
+
+	res := &pb.ListUsersResponse{Users: make([]*pb.User, len(users)), NumPages: int32(numPages)}
+	for i, user := range users {
+		res.Users[i] = toUserMessage(user)
+	}
+
+	return res, nil
+}
+
+func (s *userServer) GetUserRating(ctx context.Context, req *pb.GetUserRatingRequest) (*pb.GetUserRatingResponse, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	rating, err := s.app.Interactor.CalculateUserRating(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("вычисление рейтинга предпринимателя: %w", err)
+	}
+
+	return &pb.GetUserRatingResponse{UserId: id.String(), Rating: rating}, nil
+}
+
+func (s *userServer) GetUserFinancials(ctx context.Context, req *pb.GetUserFinancialsRequest) (*pb.FinancialSummary, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	period := periodOrPrevYear(req.GetPeriod())
+	rep, err := s.app.Interactor.GetUserFinancialReport(ctx, id, period)
+	if err != nil {
+		return nil, fmt.Errorf("получение финансовых показателей предпринимателя: %w", err)
+	}
+
+	return toFinSummaryMessage(rep, period), nil
+}
@@ -9,3 +9,4 @@ export DB_PASSWORD=postgres
 export DB_DRIVER=postgres
 export DB_HOST="localhost"
 export DB_PORT=5441
+export APP_LANG=ru
@@ -11,3 +11,12 @@ mockgen -source=domain/user_skill.go -destination=mocks/user_skill.go -package=m
 mockgen -source=domain/fin_report.go -destination=mocks/fin_report.go -package=mocks
 mockgen -source=domain/contact.go -destination=mocks/contact.go -package=mocks
 mockgen -source=domain/user_activity_field.go -destination=mocks/user_activity_field.go -package=mocks
+mockgen -source=domain/role.go -destination=mocks/role.go -package=mocks
+mockgen -source=domain/review.go -destination=mocks/review.go -package=mocks
+mockgen -source=domain/policy.go -destination=mocks/policy.go -package=mocks
+mockgen -source=domain/webhook.go -destination=mocks/webhook.go -package=mocks
+mockgen -source=domain/event.go -destination=mocks/event.go -package=mocks
+mockgen -source=domain/rating.go -destination=mocks/rating.go -package=mocks
+mockgen -source=domain/job.go -destination=mocks/job.go -package=mocks
+mockgen -source=domain/audit.go -destination=mocks/audit.go -package=mocks
+mockgen -source=domain/blob.go -destination=mocks/blob.go -package=mocks
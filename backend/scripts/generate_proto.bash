@@ -0,0 +1,8 @@
+#!/bin/zsh
+
+set -e
+
+protoc -I rpc/proto \
+  --go_out=. --go_opt=module=ppo \
+  --go-grpc_out=. --go-grpc_opt=module=ppo \
+  rpc/proto/ppo/v1/ppo.proto
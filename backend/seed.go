@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"ppo/domain"
+	"ppo/internal/config"
+	"ppo/internal/seed"
+	"ppo/internal/storage/postgres"
+	"ppo/pkg/base"
+	"strings"
+	"time"
+)
+
+// runSeed выполняет команду seed: генерирует синтетический набор данных и загружает его в БД.
+// Одинаковые --seed, --entrepreneurs, --quarters и --until дают одинаковые данные.
+func runSeed(ctx context.Context, cfg *config.Config, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("seed", flag.ContinueOnError)
+	fs.SetOutput(out)
+	opts := seed.Options{MaxContacts: cfg.Limits.MaxContacts}
+	fs.Int64Var(&opts.Seed, "seed", 1, "зерно генератора")
+	fs.IntVar(&opts.Entrepreneurs, "entrepreneurs", 100, "число предпринимателей")
+	fs.IntVar(&opts.Quarters, "quarters", 8, "глубина истории отчётов в кварталах")
+	until := fs.String("until", "", "последний квартал истории, например 2024Q4; по умолчанию — последний завершившийся")
+	password := fs.String("password", "password", "пароль всех созданных пользователей")
+
+	err := fs.Parse(args)
+	if errors.Is(err, flag.ErrHelp) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return fmt.Errorf("seed: лишние аргументы: %s", strings.Join(fs.Args(), " "))
+	}
+
+	opts.EndYear, opts.EndQuarter = domain.LastClosedQuarter(time.Now())
+	if *until != "" {
+		_, err = fmt.Sscanf(strings.ToUpper(*until), "%dQ%d", &opts.EndYear, &opts.EndQuarter)
+		if err != nil {
+			return fmt.Errorf("seed: квартал %q не в формате YYYYQN, например 2024Q4", *until)
+		}
+	}
+
+	data, err := seed.Generate(opts)
+	if err != nil {
+		return err
+	}
+
+	hash, err := base.NewHashCrypto().GenerateHashPass(*password)
+	if err != nil {
+		return err
+	}
+
+	pool, err := postgres.Connect(ctx, &cfg.DBConfig)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	err = postgres.NewSeedRepository(pool).Insert(ctx, data, hash)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "загружено: предпринимателей %d, компаний %d, отчётов %d, навыков пользователей %d, контактов %d, отзывов %d\n",
+		len(data.Users), len(data.Companies), len(data.Reports), len(data.UserSkills), len(data.Contacts), len(data.Reviews))
+
+	return nil
+}
@@ -0,0 +1,20 @@
+-- Роли и пользователи СУБД для ручной настройки кластера. Права приложения
+-- хранятся в ppo.roles и выдаются миграциями; этот скрипт в миграции не входит
+-- и может выполняться повторно.
+do $$
+declare
+    role_name text;
+begin
+    foreach role_name in array array['accountant', 'admin', 'editor'] loop
+        if not exists (select from pg_roles where rolname = role_name) then
+            execute format('create role %I', role_name);
+        end if;
+
+        if not exists (select from pg_roles where rolname = 'user_' || role_name) then
+            execute format('create user %I', 'user_' || role_name);
+        end if;
+
+        execute format('grant %I to %I', role_name, 'user_' || role_name);
+    end loop;
+end
+$$;
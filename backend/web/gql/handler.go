@@ -0,0 +1,56 @@
+package gql
+
+import (
+	_ "embed"
+	"encoding/json"
+	"net/http"
+	"ppo/internal/app"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+//go:embed schema.graphql
+var schema string
+
+const (
+	// maxDepth ограничивает вложенность запроса: связи owner → companies → owner
+	// позволяют строить запросы произвольной глубины.
+	maxDepth = 10
+	// maxParallelism — сколько полей одного запроса разрешается одновременно.
+	maxParallelism = 10
+)
+
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// NewHandler возвращает обработчик GraphQL-запросов поверх сервисов app.
+// JWT-токен, если он передан, должен быть заранее разобран jwtauth.Verifier:
+// поля с ограничениями доступа берут пользователя из контекста.
+func NewHandler(a *app.App) http.Handler {
+	s := graphql.MustParseSchema(schema, &Resolver{app: a},
+		graphql.MaxDepth(maxDepth),
+		graphql.MaxParallelism(maxParallelism),
+	)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req request
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"errors": []map[string]string{{"message": err.Error()}},
+			})
+			return
+		}
+
+		resp := s.Exec(withLoaders(r.Context(), a), req.Query, req.OperationName, req.Variables)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(resp)
+	})
+}
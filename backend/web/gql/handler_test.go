@@ -0,0 +1,242 @@
+package gql
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"ppo/domain"
+	"ppo/internal/app"
+	"ppo/mocks"
+	"strings"
+	"testing"
+)
+
+type gqlResponse struct {
+	Data   map[string]interface{} `json:"data"`
+	Errors []struct {
+		Message string        `json:"message"`
+		Path    []interface{} `json:"path"`
+	} `json:"errors"`
+}
+
+func TestHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userSvc := mocks.NewMockIUserService(ctrl)
+	compSvc := mocks.NewMockICompanyService(ctrl)
+	actFieldSvc := mocks.NewMockIActivityFieldService(ctrl)
+	conSvc := mocks.NewMockIContactsService(ctrl)
+	roleSvc := mocks.NewMockIRoleService(ctrl)
+	userSkillSvc := mocks.NewMockIUserSkillService(ctrl)
+	revSvc := mocks.NewMockIReviewService(ctrl)
+	interactor := mocks.NewMockIInteractor(ctrl)
+	finSvc := mocks.NewMockIFinancialReportService(ctrl)
+	a := &app.App{UserSvc: userSvc, CompSvc: compSvc, ActFieldSvc: actFieldSvc, ConSvc: conSvc, RoleSvc: roleSvc,
+		UserSkillSvc: userSkillSvc, RevSvc: revSvc, Interactor: interactor, FinSvc: finSvc}
+
+	tokenAuth := jwtauth.New("HS256", []byte("secret"), nil)
+	_, token, err := tokenAuth.Encode(map[string]interface{}{"sub": uuid.NewString(), "role": "user"})
+	require.Nil(t, err)
+
+	handler := jwtauth.Verifier(tokenAuth)(NewHandler(a))
+
+	user1 := &domain.User{ID: uuid.New(), Username: "first"}
+	user2 := &domain.User{ID: uuid.New(), Username: "second"}
+	fieldId := uuid.New()
+	company1 := &domain.Company{ID: uuid.New(), OwnerID: user1.ID, ActivityFieldId: fieldId, Name: "a"}
+	company2 := &domain.Company{ID: uuid.New(), OwnerID: user2.ID, ActivityFieldId: fieldId, Name: "b"}
+
+	testCases := []struct {
+		name       string
+		query      string
+		anonymous  bool
+		beforeTest func()
+		check      func(t *testing.T, resp gqlResponse)
+	}{
+		{
+			name:  "вложенные поля списка загружаются пакетами",
+			query: `{ users(page: 1) { users { username companies { name owner { username } activityField { id } } } } }`,
+			beforeTest: func() {
+				userSvc.EXPECT().GetAll(gomock.Any(), 1).Return([]*domain.User{user1, user2}, 1, nil)
+				compSvc.EXPECT().
+					GetByOwnerIds(gomock.Any(), gomock.InAnyOrder([]uuid.UUID{user1.ID, user2.ID})).
+					Return([]*domain.Company{company1, company2}, nil)
+				userSvc.EXPECT().
+					GetByIds(gomock.Any(), gomock.InAnyOrder([]uuid.UUID{user1.ID, user2.ID})).
+					Return([]*domain.User{user1, user2}, nil)
+				actFieldSvc.EXPECT().
+					GetByIds(gomock.Any(), []uuid.UUID{fieldId}).
+					Return([]*domain.ActivityField{{ID: fieldId}}, nil)
+			},
+			check: func(t *testing.T, resp gqlResponse) {
+				require.Empty(t, resp.Errors)
+
+				users := resp.Data["users"].(map[string]interface{})["users"].([]interface{})
+				require.Len(t, users, 2)
+
+				first := users[0].(map[string]interface{})
+				companies := first["companies"].([]interface{})
+				require.Len(t, companies, 1)
+				require.Equal(t, "first", companies[0].(map[string]interface{})["owner"].(map[string]interface{})["username"])
+			},
+		},
+		{
+			name: "поля пользователей списка загружаются пакетами",
+			query: `{ users(page: 1) { users { username rating skills { name } reviews(page: 2) { numPages } ` +
+				`contacts { value } financials(period: {startYear: 2023, startQuarter: 1, endYear: 2023, endQuarter: 4}) { revenue } } } }`,
+			beforeTest: func() {
+				ids := gomock.InAnyOrder([]uuid.UUID{user1.ID, user2.ID})
+				period := &domain.Period{StartYear: 2023, StartQuarter: 1, EndYear: 2023, EndQuarter: 4}
+
+				userSvc.EXPECT().GetAll(gomock.Any(), 1).Return([]*domain.User{user1, user2}, 1, nil)
+				roleSvc.EXPECT().HasPermission(gomock.Any(), "user", domain.PermContactsRead).Return(true, nil).Times(2)
+				roleSvc.EXPECT().HasPermission(gomock.Any(), "user", domain.PermFinanceRead).Return(true, nil).Times(2)
+				interactor.EXPECT().
+					CalculateUserRatings(gomock.Any(), ids).
+					Return(map[uuid.UUID]float32{user1.ID: 4.5, user2.ID: 1}, nil)
+				userSkillSvc.EXPECT().
+					GetSkillsForUsers(gomock.Any(), ids).
+					Return(map[uuid.UUID][]*domain.Skill{user1.ID: {{ID: uuid.New(), Name: "go"}}}, nil)
+				revSvc.EXPECT().
+					GetAllForTargets(gomock.Any(), ids, 2).
+					Return(map[uuid.UUID]*domain.ReviewPage{user1.ID: {NumPages: 3}, user2.ID: {NumPages: 0}}, nil)
+				conSvc.EXPECT().
+					GetByOwnerIds(gomock.Any(), ids).
+					Return([]*domain.Contact{{ID: uuid.New(), OwnerID: user2.ID, Value: "value"}}, nil)
+				interactor.EXPECT().
+					GetUserFinancialReports(gomock.Any(), ids, period).
+					Return(map[uuid.UUID]*domain.FinancialReportByPeriod{
+						user1.ID: {Reports: []domain.FinancialReport{{Revenue: 100}}, Period: period},
+						user2.ID: {Period: period},
+					}, nil)
+			},
+			check: func(t *testing.T, resp gqlResponse) {
+				require.Empty(t, resp.Errors)
+
+				users := resp.Data["users"].(map[string]interface{})["users"].([]interface{})
+				require.Len(t, users, 2)
+
+				first := users[0].(map[string]interface{})
+				require.Equal(t, 4.5, first["rating"])
+				require.Len(t, first["skills"], 1)
+				require.Equal(t, float64(3), first["reviews"].(map[string]interface{})["numPages"])
+				require.Empty(t, first["contacts"])
+				require.Equal(t, float64(100), first["financials"].(map[string]interface{})["revenue"])
+
+				second := users[1].(map[string]interface{})
+				require.Empty(t, second["skills"])
+				require.Len(t, second["contacts"], 1)
+			},
+		},
+		{
+			name: "отчёты компаний списка загружаются пакетом",
+			query: `{ users(page: 1) { users { companies { name ` +
+				`financials(period: {startYear: 2023, startQuarter: 1, endYear: 2023, endQuarter: 4}) { revenue } } } } }`,
+			beforeTest: func() {
+				period := &domain.Period{StartYear: 2023, StartQuarter: 1, EndYear: 2023, EndQuarter: 4}
+
+				userSvc.EXPECT().GetAll(gomock.Any(), 1).Return([]*domain.User{user1, user2}, 1, nil)
+				compSvc.EXPECT().
+					GetByOwnerIds(gomock.Any(), gomock.InAnyOrder([]uuid.UUID{user1.ID, user2.ID})).
+					Return([]*domain.Company{company1, company2}, nil)
+				roleSvc.EXPECT().HasPermission(gomock.Any(), "user", domain.PermFinanceRead).Return(true, nil).Times(2)
+				finSvc.EXPECT().
+					GetByCompanies(gomock.Any(), gomock.InAnyOrder([]uuid.UUID{company1.ID, company2.ID}), period).
+					Return(map[uuid.UUID]*domain.FinancialReportByPeriod{
+						company1.ID: {Reports: []domain.FinancialReport{{Revenue: 100}}, Period: period},
+						company2.ID: {Period: period},
+					}, nil)
+			},
+			check: func(t *testing.T, resp gqlResponse) {
+				require.Empty(t, resp.Errors)
+
+				users := resp.Data["users"].(map[string]interface{})["users"].([]interface{})
+				company := users[0].(map[string]interface{})["companies"].([]interface{})[0].(map[string]interface{})
+				require.Equal(t, float64(100), company["financials"].(map[string]interface{})["revenue"])
+			},
+		},
+		{
+			name: "слишком глубокий запрос отклоняется",
+			query: `{ user(id: "` + user1.ID.String() + `") { companies { owner { companies { owner { companies ` +
+				`{ owner { companies { owner { companies { owner { username } } } } } } } } } } } }`,
+			check: func(t *testing.T, resp gqlResponse) {
+				require.NotEmpty(t, resp.Errors)
+				require.Contains(t, resp.Errors[0].Message, "exceeds max depth")
+				require.Nil(t, resp.Data)
+			},
+		},
+		{
+			name:      "контакты недоступны анонимному пользователю",
+			query:     `{ user(id: "` + user1.ID.String() + `") { username contacts { value } } }`,
+			anonymous: true,
+			beforeTest: func() {
+				userSvc.EXPECT().GetById(gomock.Any(), user1.ID).Return(user1, nil)
+			},
+			check: func(t *testing.T, resp gqlResponse) {
+				require.Len(t, resp.Errors, 1)
+				require.Equal(t, []interface{}{"user", "contacts"}, resp.Errors[0].Path)
+
+				user := resp.Data["user"].(map[string]interface{})
+				require.Equal(t, "first", user["username"])
+				require.Nil(t, user["contacts"])
+			},
+		},
+		{
+			name:  "контакты доступны при наличии права",
+			query: `{ user(id: "` + user1.ID.String() + `") { contacts { value } } }`,
+			beforeTest: func() {
+				userSvc.EXPECT().GetById(gomock.Any(), user1.ID).Return(user1, nil)
+				roleSvc.EXPECT().HasPermission(gomock.Any(), "user", domain.PermContactsRead).Return(true, nil)
+				conSvc.EXPECT().
+					GetByOwnerIds(gomock.Any(), []uuid.UUID{user1.ID}).
+					Return([]*domain.Contact{{ID: uuid.New(), OwnerID: user1.ID, Value: "value"}}, nil)
+			},
+			check: func(t *testing.T, resp gqlResponse) {
+				require.Empty(t, resp.Errors)
+
+				contacts := resp.Data["user"].(map[string]interface{})["contacts"].([]interface{})
+				require.Equal(t, "value", contacts[0].(map[string]interface{})["value"])
+			},
+		},
+		{
+			name:  "некорректный id",
+			query: `{ user(id: "abc") { username } }`,
+			check: func(t *testing.T, resp gqlResponse) {
+				require.Len(t, resp.Errors, 1)
+				require.Nil(t, resp.Data["user"])
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.beforeTest != nil {
+				tc.beforeTest()
+			}
+
+			body, err := json.Marshal(map[string]string{"query": tc.query})
+			require.Nil(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body))).
+				WithContext(context.Background())
+			req.Header.Set("Content-Type", "application/json")
+			if !tc.anonymous {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			require.Equal(t, http.StatusOK, rec.Code)
+
+			var resp gqlResponse
+			require.Nil(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			tc.check(t, resp)
+		})
+	}
+}
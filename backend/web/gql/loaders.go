@@ -0,0 +1,169 @@
+package gql
+
+import (
+	"context"
+	"ppo/domain"
+	"ppo/internal/app"
+	"ppo/pkg/dataloader"
+
+	"github.com/google/uuid"
+)
+
+type loadersKey struct{}
+
+// loaders собирают обращения вложенных полей к одним и тем же сущностям
+// в пакетные запросы, чтобы список из N объектов не порождал N запросов к БД.
+type loaders struct {
+	users          *dataloader.Loader[uuid.UUID, *domain.User]
+	activityFields *dataloader.Loader[uuid.UUID, *domain.ActivityField]
+	companies      *dataloader.Loader[uuid.UUID, []*domain.Company]
+	ratings        *dataloader.Loader[uuid.UUID, float32]
+	skills         *dataloader.Loader[uuid.UUID, []*domain.Skill]
+	contacts       *dataloader.Loader[uuid.UUID, []*domain.Contact]
+	reviews        *dataloader.Loader[reviewPageKey, *domain.ReviewPage]
+	financials     *dataloader.Loader[periodKey, *domain.FinancialReportByPeriod]
+	compFinancials *dataloader.Loader[periodKey, *domain.FinancialReportByPeriod]
+}
+
+// reviewPageKey — страница отзывов об объекте. Запросы разных страниц
+// в одном пакете выполняются отдельно для каждой страницы.
+type reviewPageKey struct {
+	target uuid.UUID
+	page   int
+}
+
+// periodKey — отчёт пользователя или компании за период. Запросы за разные
+// периоды в одном пакете выполняются отдельно для каждого периода.
+type periodKey struct {
+	id     uuid.UUID
+	period domain.Period
+}
+
+// loadByPeriod группирует ключи по периоду и загружает каждую группу одним вызовом load.
+func loadByPeriod[V any](ctx context.Context, keys []periodKey,
+	load func(context.Context, []uuid.UUID, *domain.Period) (map[uuid.UUID]V, error)) (map[periodKey]V, error) {
+	ids := make(map[domain.Period][]uuid.UUID)
+	for _, key := range keys {
+		ids[key.period] = append(ids[key.period], key.id)
+	}
+
+	res := make(map[periodKey]V, len(keys))
+	for period, group := range ids {
+		period := period
+		vals, err := load(ctx, group, &period)
+		if err != nil {
+			return nil, err
+		}
+
+		for id, val := range vals {
+			res[periodKey{id, period}] = val
+		}
+	}
+
+	return res, nil
+}
+
+func newLoaders(a *app.App) *loaders {
+	return &loaders{
+		users: dataloader.New(func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*domain.User, error) {
+			users, err := a.UserSvc.GetByIds(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+
+			res := make(map[uuid.UUID]*domain.User, len(users))
+			for _, user := range users {
+				res[user.ID] = user
+			}
+
+			return res, nil
+		}, dataloader.DefaultWait),
+
+		activityFields: dataloader.New(func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*domain.ActivityField, error) {
+			fields, err := a.ActFieldSvc.GetByIds(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+
+			res := make(map[uuid.UUID]*domain.ActivityField, len(fields))
+			for _, field := range fields {
+				res[field.ID] = field
+			}
+
+			return res, nil
+		}, dataloader.DefaultWait),
+
+		companies: dataloader.New(func(ctx context.Context, ownerIds []uuid.UUID) (map[uuid.UUID][]*domain.Company, error) {
+			companies, err := a.CompSvc.GetByOwnerIds(ctx, ownerIds)
+			if err != nil {
+				return nil, err
+			}
+
+			res := make(map[uuid.UUID][]*domain.Company, len(ownerIds))
+			for _, company := range companies {
+				res[company.OwnerID] = append(res[company.OwnerID], company)
+			}
+
+			return res, nil
+		}, dataloader.DefaultWait),
+
+		ratings: dataloader.New(func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]float32, error) {
+			return a.Interactor.CalculateUserRatings(ctx, ids)
+		}, dataloader.DefaultWait),
+
+		skills: dataloader.New(func(ctx context.Context, userIds []uuid.UUID) (map[uuid.UUID][]*domain.Skill, error) {
+			return a.UserSkillSvc.GetSkillsForUsers(ctx, userIds)
+		}, dataloader.DefaultWait),
+
+		contacts: dataloader.New(func(ctx context.Context, ownerIds []uuid.UUID) (map[uuid.UUID][]*domain.Contact, error) {
+			contacts, err := a.ConSvc.GetByOwnerIds(ctx, ownerIds)
+			if err != nil {
+				return nil, err
+			}
+
+			res := make(map[uuid.UUID][]*domain.Contact, len(ownerIds))
+			for _, contact := range contacts {
+				res[contact.OwnerID] = append(res[contact.OwnerID], contact)
+			}
+
+			return res, nil
+		}, dataloader.DefaultWait),
+
+		reviews: dataloader.New(func(ctx context.Context, keys []reviewPageKey) (map[reviewPageKey]*domain.ReviewPage, error) {
+			targets := make(map[int][]uuid.UUID)
+			for _, key := range keys {
+				targets[key.page] = append(targets[key.page], key.target)
+			}
+
+			res := make(map[reviewPageKey]*domain.ReviewPage, len(keys))
+			for page, ids := range targets {
+				pages, err := a.RevSvc.GetAllForTargets(ctx, ids, page)
+				if err != nil {
+					return nil, err
+				}
+
+				for id, revs := range pages {
+					res[reviewPageKey{id, page}] = revs
+				}
+			}
+
+			return res, nil
+		}, dataloader.DefaultWait),
+
+		financials: dataloader.New(func(ctx context.Context, keys []periodKey) (map[periodKey]*domain.FinancialReportByPeriod, error) {
+			return loadByPeriod(ctx, keys, a.Interactor.GetUserFinancialReports)
+		}, dataloader.DefaultWait),
+
+		compFinancials: dataloader.New(func(ctx context.Context, keys []periodKey) (map[periodKey]*domain.FinancialReportByPeriod, error) {
+			return loadByPeriod(ctx, keys, a.FinSvc.GetByCompanies)
+		}, dataloader.DefaultWait),
+	}
+}
+
+func withLoaders(ctx context.Context, a *app.App) context.Context {
+	return context.WithValue(ctx, loadersKey{}, newLoaders(a))
+}
+
+func loadersFromContext(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
@@ -0,0 +1,603 @@
+package gql
+
+import (
+	"context"
+	"fmt"
+	"ppo/domain"
+	"ppo/internal/app"
+	"ppo/pkg/i18n"
+	"time"
+
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/google/uuid"
+	"github.com/graph-gophers/graphql-go"
+)
+
+type Resolver struct {
+	app *app.App
+}
+
+type idArgs struct {
+	ID graphql.ID
+}
+
+type pageArgs struct {
+	Page int32
+}
+
+type periodInput struct {
+	StartYear    int32
+	StartQuarter int32
+	EndYear      int32
+	EndQuarter   int32
+}
+
+func (p *periodInput) toModel() *domain.Period {
+	return &domain.Period{
+		StartYear:    int(p.StartYear),
+		StartQuarter: int(p.StartQuarter),
+		EndYear:      int(p.EndYear),
+		EndQuarter:   int(p.EndQuarter),
+	}
+}
+
+func parseID(id graphql.ID) (uuid.UUID, error) {
+	val, err := uuid.Parse(string(id))
+	if err != nil {
+		return uuid.UUID{}, domain.NewValidationError("id", i18n.MsgParamInvalidUUID, "id")
+	}
+
+	return val, nil
+}
+
+// actorFromContext возвращает пользователя из JWT-токена, проверенного jwtauth.Verifier.
+func actorFromContext(ctx context.Context) (*domain.Actor, error) {
+	token, claims, err := jwtauth.FromContext(ctx)
+	if err != nil || token == nil {
+		return nil, fmt.Errorf("%w: требуется аутентификация", domain.ErrForbidden)
+	}
+
+	sub, _ := claims["sub"].(string)
+	id, err := uuid.Parse(sub)
+	if err != nil {
+		return nil, fmt.Errorf("%w: некорректный id пользователя в JWT-токене", domain.ErrForbidden)
+	}
+
+	role, _ := claims["role"].(string)
+
+	return &domain.Actor{ID: id, Role: role}, nil
+}
+
+func (r *Resolver) requirePermission(ctx context.Context, perm domain.Permission) error {
+	actor, err := actorFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	ok, err := r.app.RoleSvc.HasPermission(ctx, actor.Role, perm)
+	if err != nil {
+		return fmt.Errorf("проверка прав: %w", err)
+	}
+
+	if !ok {
+		return fmt.Errorf("%w: требуется право '%s'", domain.ErrForbidden, perm)
+	}
+
+	return nil
+}
+
+func (r *Resolver) User(ctx context.Context, args idArgs) (*userResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := r.app.UserSvc.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &userResolver{r, user}, nil
+}
+
+func (r *Resolver) Users(ctx context.Context, args pageArgs) (*userPageResolver, error) {
+	users, numPages, err := r.app.UserSvc.GetAll(ctx, int(args.Page))
+	if err != nil {
+		return nil, err
+	}
+
+	res := &userPageResolver{numPages: int32(numPages)}
+	for _, user := range users {
+		res.users = append(res.users, &userResolver{r, user})
+	}
+
+	return res, nil
+}
+
+func (r *Resolver) Me(ctx context.Context) (*userResolver, error) {
+	actor, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := r.app.UserSvc.GetById(ctx, actor.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &userResolver{r, user}, nil
+}
+
+func (r *Resolver) Company(ctx context.Context, args idArgs) (*companyResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	company, err := r.app.CompSvc.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &companyResolver{r, company}, nil
+}
+
+func (r *Resolver) ActivityField(ctx context.Context, args idArgs) (*activityFieldResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	field, err := r.app.ActFieldSvc.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &activityFieldResolver{field}, nil
+}
+
+func (r *Resolver) ActivityFields(ctx context.Context, args pageArgs) (*activityFieldPageResolver, error) {
+	fields, numPages, err := r.app.ActFieldSvc.GetAll(ctx, int(args.Page), true)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &activityFieldPageResolver{numPages: int32(numPages)}
+	for _, field := range fields {
+		res.fields = append(res.fields, &activityFieldResolver{field})
+	}
+
+	return res, nil
+}
+
+func (r *Resolver) Skills(ctx context.Context, args pageArgs) (*skillPageResolver, error) {
+	skills, numPages, err := r.app.SkillSvc.GetAll(ctx, int(args.Page))
+	if err != nil {
+		return nil, err
+	}
+
+	res := &skillPageResolver{numPages: int32(numPages)}
+	for _, skill := range skills {
+		res.skills = append(res.skills, &skillResolver{skill})
+	}
+
+	return res, nil
+}
+
+type userResolver struct {
+	root *Resolver
+	user *domain.User
+}
+
+func (u *userResolver) ID() graphql.ID {
+	return graphql.ID(u.user.ID.String())
+}
+
+func (u *userResolver) Username() string {
+	return u.user.Username
+}
+
+func (u *userResolver) FullName() string {
+	return u.user.FullName
+}
+
+func (u *userResolver) Gender() string {
+	return u.user.Gender
+}
+
+func (u *userResolver) Birthday() *string {
+	if u.user.Birthday.IsZero() {
+		return nil
+	}
+
+	birthday := u.user.Birthday.Format(time.DateOnly)
+	return &birthday
+}
+
+func (u *userResolver) City() string {
+	return u.user.City
+}
+
+func (u *userResolver) Role() string {
+	return u.user.Role
+}
+
+func (u *userResolver) Rating(ctx context.Context) (float64, error) {
+	rating, err := loadersFromContext(ctx).ratings.Load(ctx, u.user.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	return float64(rating), nil
+}
+
+func (u *userResolver) Companies(ctx context.Context) ([]*companyResolver, error) {
+	companies, err := loadersFromContext(ctx).companies.Load(ctx, u.user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*companyResolver, len(companies))
+	for i, company := range companies {
+		res[i] = &companyResolver{u.root, company}
+	}
+
+	return res, nil
+}
+
+func (u *userResolver) Skills(ctx context.Context) ([]*skillResolver, error) {
+	skills, err := loadersFromContext(ctx).skills.Load(ctx, u.user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*skillResolver, len(skills))
+	for i, skill := range skills {
+		res[i] = &skillResolver{skill}
+	}
+
+	return res, nil
+}
+
+func (u *userResolver) Reviews(ctx context.Context, args pageArgs) (*reviewPageResolver, error) {
+	page, err := loadersFromContext(ctx).reviews.Load(ctx, reviewPageKey{u.user.ID, int(args.Page)})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &reviewPageResolver{}
+	if page == nil {
+		return res, nil
+	}
+
+	res.numPages = int32(page.NumPages)
+	for _, rev := range page.Reviews {
+		res.reviews = append(res.reviews, &reviewResolver{u.root, rev})
+	}
+
+	return res, nil
+}
+
+func (u *userResolver) Contacts(ctx context.Context) (*[]*contactResolver, error) {
+	err := u.root.requirePermission(ctx, domain.PermContactsRead)
+	if err != nil {
+		return nil, err
+	}
+
+	contacts, err := loadersFromContext(ctx).contacts.Load(ctx, u.user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*contactResolver, len(contacts))
+	for i, contact := range contacts {
+		res[i] = &contactResolver{contact}
+	}
+
+	return &res, nil
+}
+
+func (u *userResolver) Financials(ctx context.Context, args struct{ Period *periodInput }) (*financialSummaryResolver, error) {
+	err := u.root.requirePermission(ctx, domain.PermFinanceRead)
+	if err != nil {
+		return nil, err
+	}
+
+	prevYear := time.Now().AddDate(-1, 0, 0).Year()
+	period := &domain.Period{StartYear: prevYear, StartQuarter: 1, EndYear: prevYear, EndQuarter: 4}
+	if args.Period != nil {
+		period = args.Period.toModel()
+	}
+
+	rep, err := loadersFromContext(ctx).financials.Load(ctx, periodKey{u.user.ID, *period})
+	if err != nil {
+		return nil, err
+	}
+
+	return &financialSummaryResolver{rep, period}, nil
+}
+
+type userPageResolver struct {
+	users    []*userResolver
+	numPages int32
+}
+
+func (p *userPageResolver) Users() []*userResolver {
+	return p.users
+}
+
+func (p *userPageResolver) NumPages() int32 {
+	return p.numPages
+}
+
+type companyResolver struct {
+	root    *Resolver
+	company *domain.Company
+}
+
+func (c *companyResolver) ID() graphql.ID {
+	return graphql.ID(c.company.ID.String())
+}
+
+func (c *companyResolver) Name() string {
+	return c.company.Name
+}
+
+func (c *companyResolver) City() string {
+	return c.company.City
+}
+
+func (c *companyResolver) Owner(ctx context.Context) (*userResolver, error) {
+	user, err := loadersFromContext(ctx).users.Load(ctx, c.company.OwnerID)
+	if err != nil || user == nil {
+		return nil, err
+	}
+
+	return &userResolver{c.root, user}, nil
+}
+
+func (c *companyResolver) ActivityField(ctx context.Context) (*activityFieldResolver, error) {
+	field, err := loadersFromContext(ctx).activityFields.Load(ctx, c.company.ActivityFieldId)
+	if err != nil || field == nil {
+		return nil, err
+	}
+
+	return &activityFieldResolver{field}, nil
+}
+
+func (c *companyResolver) Financials(ctx context.Context, args struct{ Period periodInput }) (*financialSummaryResolver, error) {
+	err := c.root.requirePermission(ctx, domain.PermFinanceRead)
+	if err != nil {
+		return nil, err
+	}
+
+	period := args.Period.toModel()
+	rep, err := loadersFromContext(ctx).compFinancials.Load(ctx, periodKey{c.company.ID, *period})
+	if err != nil {
+		return nil, err
+	}
+	if rep == nil {
+		rep = &domain.FinancialReportByPeriod{Period: period}
+	}
+
+	return &financialSummaryResolver{rep, period}, nil
+}
+
+type activityFieldResolver struct {
+	field *domain.ActivityField
+}
+
+func (a *activityFieldResolver) ID() graphql.ID {
+	return graphql.ID(a.field.ID.String())
+}
+
+func (a *activityFieldResolver) Name() string {
+	return a.field.Name
+}
+
+func (a *activityFieldResolver) Description() string {
+	return a.field.Description
+}
+
+func (a *activityFieldResolver) Cost() float64 {
+	return float64(a.field.Cost)
+}
+
+type activityFieldPageResolver struct {
+	fields   []*activityFieldResolver
+	numPages int32
+}
+
+func (p *activityFieldPageResolver) ActivityFields() []*activityFieldResolver {
+	return p.fields
+}
+
+func (p *activityFieldPageResolver) NumPages() int32 {
+	return p.numPages
+}
+
+type skillResolver struct {
+	skill *domain.Skill
+}
+
+func (s *skillResolver) ID() graphql.ID {
+	return graphql.ID(s.skill.ID.String())
+}
+
+func (s *skillResolver) Name() string {
+	return s.skill.Name
+}
+
+func (s *skillResolver) Description() string {
+	return s.skill.Description
+}
+
+type skillPageResolver struct {
+	skills   []*skillResolver
+	numPages int32
+}
+
+func (p *skillPageResolver) Skills() []*skillResolver {
+	return p.skills
+}
+
+func (p *skillPageResolver) NumPages() int32 {
+	return p.numPages
+}
+
+type contactResolver struct {
+	contact *domain.Contact
+}
+
+func (c *contactResolver) ID() graphql.ID {
+	return graphql.ID(c.contact.ID.String())
+}
+
+func (c *contactResolver) Name() string {
+	return c.contact.Name
+}
+
+func (c *contactResolver) Value() string {
+	return c.contact.Value
+}
+
+type reviewResolver struct {
+	root *Resolver
+	rev  *domain.Review
+}
+
+func (r *reviewResolver) ID() graphql.ID {
+	return graphql.ID(r.rev.ID.String())
+}
+
+func (r *reviewResolver) Pros() string {
+	return r.rev.Pros
+}
+
+func (r *reviewResolver) Cons() string {
+	return r.rev.Cons
+}
+
+func (r *reviewResolver) Description() string {
+	return r.rev.Description
+}
+
+func (r *reviewResolver) Rating() int32 {
+	return int32(r.rev.Rating)
+}
+
+func (r *reviewResolver) Reviewer(ctx context.Context) (*userResolver, error) {
+	return r.loadUser(ctx, r.rev.Reviewer)
+}
+
+func (r *reviewResolver) Target(ctx context.Context) (*userResolver, error) {
+	return r.loadUser(ctx, r.rev.Target)
+}
+
+func (r *reviewResolver) loadUser(ctx context.Context, id uuid.UUID) (*userResolver, error) {
+	user, err := loadersFromContext(ctx).users.Load(ctx, id)
+	if err != nil || user == nil {
+		return nil, err
+	}
+
+	return &userResolver{r.root, user}, nil
+}
+
+type reviewPageResolver struct {
+	reviews  []*reviewResolver
+	numPages int32
+}
+
+func (p *reviewPageResolver) Reviews() []*reviewResolver {
+	return p.reviews
+}
+
+func (p *reviewPageResolver) NumPages() int32 {
+	return p.numPages
+}
+
+type financialSummaryResolver struct {
+	rep    *domain.FinancialReportByPeriod
+	period *domain.Period
+}
+
+func (f *financialSummaryResolver) Period() *periodResolver {
+	return &periodResolver{f.period}
+}
+
+func (f *financialSummaryResolver) Revenue() float64 {
+	return float64(f.rep.Revenue())
+}
+
+func (f *financialSummaryResolver) Costs() float64 {
+	return float64(f.rep.Costs())
+}
+
+func (f *financialSummaryResolver) Profit() float64 {
+	return float64(f.rep.Profit())
+}
+
+func (f *financialSummaryResolver) Taxes() float64 {
+	return float64(f.rep.Taxes)
+}
+
+func (f *financialSummaryResolver) TaxLoad() float64 {
+	return float64(f.rep.TaxLoad)
+}
+
+func (f *financialSummaryResolver) Reports() []*finReportResolver {
+	res := make([]*finReportResolver, len(f.rep.Reports))
+	for i := range f.rep.Reports {
+		res[i] = &finReportResolver{&f.rep.Reports[i]}
+	}
+
+	return res
+}
+
+type periodResolver struct {
+	period *domain.Period
+}
+
+func (p *periodResolver) StartYear() int32 {
+	return int32(p.period.StartYear)
+}
+
+func (p *periodResolver) StartQuarter() int32 {
+	return int32(p.period.StartQuarter)
+}
+
+func (p *periodResolver) EndYear() int32 {
+	return int32(p.period.EndYear)
+}
+
+func (p *periodResolver) EndQuarter() int32 {
+	return int32(p.period.EndQuarter)
+}
+
+type finReportResolver struct {
+	rep *domain.FinancialReport
+}
+
+func (f *finReportResolver) ID() graphql.ID {
+	return graphql.ID(f.rep.ID.String())
+}
+
+func (f *finReportResolver) Year() int32 {
+	return int32(f.rep.Year)
+}
+
+func (f *finReportResolver) Quarter() int32 {
+	return int32(f.rep.Quarter)
+}
+
+func (f *finReportResolver) Revenue() float64 {
+	return float64(f.rep.Revenue)
+}
+
+func (f *finReportResolver) Costs() float64 {
+	return float64(f.rep.Costs)
+}
+
+func (f *finReportResolver) Profit() float64 {
+	return float64(f.rep.Revenue - f.rep.Costs)
+}
@@ -3,11 +3,14 @@ package web
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"math"
+	"mime"
 	"net/http"
 	"ppo/domain"
 	"ppo/internal/app"
 	"ppo/pkg/base"
+	"ppo/pkg/i18n"
 	"strconv"
 	"time"
 
@@ -27,20 +30,20 @@ func LoginHandler(app *app.App) http.HandlerFunc {
 
 		err := json.NewDecoder(r.Body).Decode(&req)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusBadRequest)
 			return
 		}
 
 		ua := &domain.UserAuth{Username: req.Login, Password: req.Password}
 		token, err := app.AuthSvc.Login(r.Context(), ua)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusUnauthorized)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
 			return
 		}
 
 		_, err = base.VerifyAuthToken(token, app.Config.JwtKey)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("%s: проверка JWT-токена: %w", prompt, err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("%s: проверка JWT-токена: %w", prompt, err), http.StatusInternalServerError)
 			return
 		}
 
@@ -69,18 +72,18 @@ func RegisterHandler(app *app.App) http.HandlerFunc {
 
 		err := json.NewDecoder(r.Body).Decode(&req)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusBadRequest)
 			return
 		}
 
 		ua := &domain.UserAuth{Username: req.Login, Password: req.Password}
 		err = app.AuthSvc.Register(r.Context(), ua)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusBadRequest)
 			return
 		}
 
-		successResponse(w, http.StatusOK, nil)
+		createdResponse(w, r, "/entrepreneurs/"+ua.ID.String(), ua.ID)
 	}
 }
 
@@ -90,19 +93,19 @@ func ListEntrepreneurs(app *app.App) http.HandlerFunc {
 
 		page := r.URL.Query().Get("page")
 		if page == "" {
-			errorResponse(w, fmt.Errorf("%s: пустой номер страницы", prompt).Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, domain.NewValidationError("page", i18n.MsgParamRequired, "page")), http.StatusBadRequest)
 			return
 		}
 
 		pageInt, err := strconv.Atoi(page)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("%s: преобразование номера страницы к int: %w", prompt, err).Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, domain.NewValidationError("page", i18n.MsgParamInvalidNumber, "page")), http.StatusBadRequest)
 			return
 		}
 
 		users, numPages, err := app.UserSvc.GetAll(r.Context(), pageInt)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
 			return
 		}
 
@@ -119,19 +122,19 @@ func ListEmptyEntrepreneurs(app *app.App) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		page := r.URL.Query().Get("page")
 		if page == "" {
-			errorResponse(w, fmt.Errorf("empty page number").Error(), http.StatusBadRequest)
+			handleError(w, r, domain.NewValidationError("page", i18n.MsgParamRequired, "page"), http.StatusBadRequest)
 			return
 		}
 
 		pageInt, err := strconv.Atoi(page)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("converting page to int: %w", err).Error(), http.StatusBadRequest)
+			handleError(w, r, domain.NewValidationError("page", i18n.MsgParamInvalidNumber, "page"), http.StatusBadRequest)
 			return
 		}
 
 		users, _, err := app.UserSvc.GetAll(r.Context(), pageInt)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("getting users: %w", err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("getting users: %w", err), http.StatusInternalServerError)
 			return
 		}
 
@@ -150,19 +153,19 @@ func UpdateEntrepreneur(app *app.App) http.HandlerFunc {
 
 		id := chi.URLParam(r, "id")
 		if id == "" {
-			errorResponse(w, fmt.Errorf("%s: пустой id", prompt).Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, domain.NewValidationError("id", i18n.MsgParamRequired, "id")), http.StatusBadRequest)
 			return
 		}
 
 		idUuid, err := uuid.Parse(id)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("%s: преобразование id к uuid: %w", prompt, err).Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, domain.NewValidationError("id", i18n.MsgParamInvalidUUID, "id")), http.StatusBadRequest)
 			return
 		}
 
 		userDb, err := app.UserSvc.GetById(r.Context(), idUuid)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
 			return
 		}
 
@@ -170,7 +173,7 @@ func UpdateEntrepreneur(app *app.App) http.HandlerFunc {
 
 		err = json.NewDecoder(r.Body).Decode(&req)
 		if err != nil {
-			errorResponse(w, err.Error(), http.StatusBadRequest)
+			handleError(w, r, err, http.StatusBadRequest)
 			return
 		}
 
@@ -195,11 +198,11 @@ func UpdateEntrepreneur(app *app.App) http.HandlerFunc {
 
 		err = app.UserSvc.Update(r.Context(), userDb)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusBadRequest)
 			return
 		}
 
-		successResponse(w, http.StatusOK, nil)
+		noContentResponse(w, r)
 	}
 }
 
@@ -209,29 +212,29 @@ func DeleteEntrepreneur(app *app.App) http.HandlerFunc {
 
 		id := chi.URLParam(r, "id")
 		if id == "" {
-			errorResponse(w, fmt.Errorf("%s: пустой id", prompt).Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, domain.NewValidationError("id", i18n.MsgParamRequired, "id")), http.StatusBadRequest)
 			return
 		}
 
 		idUuid, err := uuid.Parse(id)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("%s: преобразование id к uuid: %w", prompt, err).Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, domain.NewValidationError("id", i18n.MsgParamInvalidUUID, "id")), http.StatusBadRequest)
 			return
 		}
 
 		_, err = app.UserSvc.GetById(r.Context(), idUuid)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusBadRequest)
 			return
 		}
 
 		err = app.UserSvc.DeleteById(r.Context(), idUuid)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
 			return
 		}
 
-		successResponse(w, http.StatusOK, nil)
+		noContentResponse(w, r)
 	}
 }
 
@@ -241,23 +244,23 @@ func GetEntrepreneur(app *app.App) http.HandlerFunc {
 
 		id := chi.URLParam(r, "id")
 		if id == "" {
-			errorResponse(w, fmt.Errorf("%s: пустой id", prompt).Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, domain.NewValidationError("id", i18n.MsgParamRequired, "id")), http.StatusBadRequest)
 			return
 		}
 
 		idUuid, err := uuid.Parse(id)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("%s: преобразование id к uuid: %w", prompt, err).Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, domain.NewValidationError("id", i18n.MsgParamInvalidUUID, "id")), http.StatusBadRequest)
 			return
 		}
 
 		user, err := app.UserSvc.GetById(r.Context(), idUuid)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
 			return
 		}
 
-		successResponse(w, http.StatusOK, map[string]interface{}{"entrepreneur": toUserTransport(user)})
+		etagResponse(w, r, map[string]interface{}{"entrepreneur": toUserTransport(user)})
 	}
 }
 
@@ -268,7 +271,7 @@ func CreateSkill(app *app.App) http.HandlerFunc {
 
 		err := json.NewDecoder(r.Body).Decode(&req)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusBadRequest)
 			return
 		}
 
@@ -276,11 +279,11 @@ func CreateSkill(app *app.App) http.HandlerFunc {
 
 		err = app.SkillSvc.Create(r.Context(), &skill)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusBadRequest)
 			return
 		}
 
-		successResponse(w, http.StatusOK, nil)
+		createdResponse(w, r, "/skills/"+skill.ID.String(), skill.ID)
 	}
 }
 
@@ -290,29 +293,29 @@ func DeleteSkill(app *app.App) http.HandlerFunc {
 
 		id := chi.URLParam(r, "id")
 		if id == "" {
-			errorResponse(w, fmt.Errorf("%s: пустой id", prompt).Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, domain.NewValidationError("id", i18n.MsgParamRequired, "id")), http.StatusBadRequest)
 			return
 		}
 
 		idUuid, err := uuid.Parse(id)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("%s: преобразование id к uuid: %w", prompt, err).Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, domain.NewValidationError("id", i18n.MsgParamInvalidUUID, "id")), http.StatusBadRequest)
 			return
 		}
 
 		_, err = app.SkillSvc.GetById(r.Context(), idUuid)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
 			return
 		}
 
 		err = app.SkillSvc.DeleteById(r.Context(), idUuid)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
 			return
 		}
 
-		successResponse(w, http.StatusOK, nil)
+		noContentResponse(w, r)
 	}
 }
 
@@ -322,19 +325,19 @@ func UpdateSkill(app *app.App) http.HandlerFunc {
 
 		id := chi.URLParam(r, "id")
 		if id == "" {
-			errorResponse(w, fmt.Errorf("%s: пустой id", prompt).Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, domain.NewValidationError("id", i18n.MsgParamRequired, "id")), http.StatusBadRequest)
 			return
 		}
 
 		idUuid, err := uuid.Parse(id)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("%s: преобразование id к uuid: %w", prompt, err).Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, domain.NewValidationError("id", i18n.MsgParamInvalidUUID, "id")), http.StatusBadRequest)
 			return
 		}
 
 		skillDb, err := app.SkillSvc.GetById(r.Context(), idUuid)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
 			return
 		}
 
@@ -342,7 +345,7 @@ func UpdateSkill(app *app.App) http.HandlerFunc {
 
 		err = json.NewDecoder(r.Body).Decode(&req)
 		if err != nil {
-			errorResponse(w, err.Error(), http.StatusBadRequest)
+			handleError(w, r, err, http.StatusBadRequest)
 			return
 		}
 
@@ -355,11 +358,11 @@ func UpdateSkill(app *app.App) http.HandlerFunc {
 
 		err = app.SkillSvc.Update(r.Context(), skillDb)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
 			return
 		}
 
-		successResponse(w, http.StatusOK, nil)
+		noContentResponse(w, r)
 	}
 }
 
@@ -369,19 +372,19 @@ func ListSkills(app *app.App) http.HandlerFunc {
 
 		page := r.URL.Query().Get("page")
 		if page == "" {
-			errorResponse(w, fmt.Errorf("%s: пустой номер страницы", prompt).Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, domain.NewValidationError("page", i18n.MsgParamRequired, "page")), http.StatusBadRequest)
 			return
 		}
 
 		pageInt, err := strconv.Atoi(page)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("%s: преобразование номера страницы к int: %w", prompt, err).Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, domain.NewValidationError("page", i18n.MsgParamInvalidNumber, "page")), http.StatusBadRequest)
 			return
 		}
 
 		skills, numPages, err := app.SkillSvc.GetAll(r.Context(), pageInt)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
 			return
 		}
 
@@ -390,7 +393,7 @@ func ListSkills(app *app.App) http.HandlerFunc {
 			skillsTransport[i] = toSkillTransport(skill)
 		}
 
-		successResponse(w, http.StatusOK, map[string]interface{}{"num_pages": numPages, "skills": skillsTransport})
+		etagResponse(w, r, map[string]interface{}{"num_pages": numPages, "skills": skillsTransport})
 	}
 }
 
@@ -400,19 +403,19 @@ func GetSkill(app *app.App) http.HandlerFunc {
 
 		id := chi.URLParam(r, "id")
 		if id == "" {
-			errorResponse(w, fmt.Errorf("%s: пустой id", prompt).Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, domain.NewValidationError("id", i18n.MsgParamRequired, "id")), http.StatusBadRequest)
 			return
 		}
 
 		idUuid, err := uuid.Parse(id)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("%s: преобразование id к uuid: %w", prompt, err).Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, domain.NewValidationError("id", i18n.MsgParamInvalidUUID, "id")), http.StatusBadRequest)
 			return
 		}
 
 		skill, err := app.SkillSvc.GetById(r.Context(), idUuid)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
 			return
 		}
 
@@ -426,20 +429,20 @@ func CreateContact(app *app.App) http.HandlerFunc {
 
 		idStr, err := getStringClaimFromJWT(r.Context(), "sub")
 		if err != nil {
-			errorResponse(w, fmt.Errorf("%s: получение записей из JWT: %w", prompt, err).Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("%s: получение записей из JWT: %w", prompt, err), http.StatusBadRequest)
 			return
 		}
 
 		idUuid, err := uuid.Parse(idStr)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("%s: преобразование id к uuid: %w", prompt, err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, domain.NewValidationError("sub", i18n.MsgParamInvalidUUID, "sub")), http.StatusBadRequest)
 			return
 		}
 
 		var req Contact
 		err = json.NewDecoder(r.Body).Decode(&req)
 		if err != nil {
-			errorResponse(w, err.Error(), http.StatusBadRequest)
+			handleError(w, r, err, http.StatusBadRequest)
 			return
 		}
 
@@ -448,11 +451,11 @@ func CreateContact(app *app.App) http.HandlerFunc {
 
 		err = app.ConSvc.Create(r.Context(), &contact)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusBadRequest)
 			return
 		}
 
-		successResponse(w, http.StatusOK, nil)
+		createdResponse(w, r, "/contacts/"+contact.ID.String(), contact.ID)
 	}
 }
 
@@ -460,85 +463,69 @@ func DeleteContact(app *app.App) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// prompt := "удаление средства связи"
 
-		ownerIdStr, err := getStringClaimFromJWT(r.Context(), "sub")
+		actor, err := getActorFromJWT(r.Context())
 		if err != nil {
-			errorResponse(w, fmt.Errorf("получение записей из JWT: %w", err).Error(), http.StatusBadRequest)
-			return
-		}
-
-		ownerIdUuid, err := uuid.Parse(ownerIdStr)
-		if err != nil {
-			errorResponse(w, fmt.Errorf("преобразование строки к uuid: %w", err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("получение записей из JWT: %w", err), http.StatusBadRequest)
 			return
 		}
 
 		id := chi.URLParam(r, "id")
 		if id == "" {
-			errorResponse(w, fmt.Errorf("пустой id").Error(), http.StatusBadRequest)
+			handleError(w, r, domain.NewValidationError("id", i18n.MsgParamRequired, "id"), http.StatusBadRequest)
 			return
 		}
 
 		idUuid, err := uuid.Parse(id)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("преобразование строки к uuid: %w", err).Error(), http.StatusBadRequest)
+			handleError(w, r, domain.NewValidationError("id", i18n.MsgParamInvalidUUID, "id"), http.StatusBadRequest)
 			return
 		}
 
-		contact, err := app.ConSvc.GetById(r.Context(), idUuid)
+		err = app.PolicySvc.CanManageContact(r.Context(), actor, idUuid)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("удаление средства связи по id: %w", err).Error(), http.StatusInternalServerError)
-			return
-		}
-
-		if ownerIdUuid != contact.OwnerID {
-			errorResponse(w, fmt.Errorf("только владелец может удалить своё средство связи").Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("удаление средства связи по id: %w", err), http.StatusInternalServerError)
 			return
 		}
 
 		err = app.ConSvc.DeleteById(r.Context(), idUuid)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("удаление средства связи по id: %w", err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("удаление средства связи по id: %w", err), http.StatusInternalServerError)
 			return
 		}
 
-		successResponse(w, http.StatusOK, nil)
+		noContentResponse(w, r)
 	}
 }
 
 func UpdateContact(app *app.App) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		ownerIdStr, err := getStringClaimFromJWT(r.Context(), "sub")
+		actor, err := getActorFromJWT(r.Context())
 		if err != nil {
-			errorResponse(w, fmt.Errorf("getting claim from JWT: %w", err).Error(), http.StatusBadRequest)
-			return
-		}
-
-		ownerIdUuid, err := uuid.Parse(ownerIdStr)
-		if err != nil {
-			errorResponse(w, fmt.Errorf("converting string to uuid: %w", err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("getting claim from JWT: %w", err), http.StatusBadRequest)
 			return
 		}
 
 		id := chi.URLParam(r, "id")
 		if id == "" {
-			errorResponse(w, fmt.Errorf("empty id").Error(), http.StatusBadRequest)
+			handleError(w, r, domain.NewValidationError("id", i18n.MsgParamRequired, "id"), http.StatusBadRequest)
 			return
 		}
 
 		idUuid, err := uuid.Parse(id)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("converting id to uuid: %w", err).Error(), http.StatusBadRequest)
+			handleError(w, r, domain.NewValidationError("id", i18n.MsgParamInvalidUUID, "id"), http.StatusBadRequest)
 			return
 		}
 
-		conDb, err := app.ConSvc.GetById(r.Context(), idUuid)
+		err = app.PolicySvc.CanManageContact(r.Context(), actor, idUuid)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("getting contact from database by id: %w", err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("updating contact info: %w", err), http.StatusInternalServerError)
 			return
 		}
 
-		if ownerIdUuid != conDb.OwnerID {
-			errorResponse(w, fmt.Errorf("только владелец может обновлять информацию о своих средствах связи").Error(), http.StatusInternalServerError)
+		conDb, err := app.ConSvc.GetById(r.Context(), idUuid)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("getting contact from database by id: %w", err), http.StatusInternalServerError)
 			return
 		}
 
@@ -546,7 +533,7 @@ func UpdateContact(app *app.App) http.HandlerFunc {
 
 		err = json.NewDecoder(r.Body).Decode(&req)
 		if err != nil {
-			errorResponse(w, err.Error(), http.StatusBadRequest)
+			handleError(w, r, err, http.StatusBadRequest)
 			return
 		}
 
@@ -559,11 +546,11 @@ func UpdateContact(app *app.App) http.HandlerFunc {
 
 		err = app.ConSvc.Update(r.Context(), conDb)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("updating contact info: %w", err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("updating contact info: %w", err), http.StatusInternalServerError)
 			return
 		}
 
-		successResponse(w, http.StatusOK, nil)
+		noContentResponse(w, r)
 	}
 }
 
@@ -571,19 +558,19 @@ func GetContact(app *app.App) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		id := chi.URLParam(r, "id")
 		if id == "" {
-			errorResponse(w, fmt.Errorf("empty id").Error(), http.StatusBadRequest)
+			handleError(w, r, domain.NewValidationError("id", i18n.MsgParamRequired, "id"), http.StatusBadRequest)
 			return
 		}
 
 		idUuid, err := uuid.Parse(id)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("converting id to uuid: %w", err).Error(), http.StatusBadRequest)
+			handleError(w, r, domain.NewValidationError("id", i18n.MsgParamInvalidUUID, "id"), http.StatusBadRequest)
 			return
 		}
 
 		contact, err := app.ConSvc.GetById(r.Context(), idUuid)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("getting contact by id: %w", err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("getting contact by id: %w", err), http.StatusInternalServerError)
 			return
 		}
 
@@ -595,19 +582,19 @@ func ListEntrepreneurContacts(app *app.App) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		entId := r.URL.Query().Get("entrepreneur-id")
 		if entId == "" {
-			errorResponse(w, fmt.Errorf("empty entrepreneur id").Error(), http.StatusBadRequest)
+			handleError(w, r, domain.NewValidationError("entrepreneur-id", i18n.MsgParamRequired, "entrepreneur-id"), http.StatusBadRequest)
 			return
 		}
 
 		entUuid, err := uuid.Parse(entId)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("converting entrepreneur id to uuid: %w", err).Error(), http.StatusInternalServerError)
+			handleError(w, r, domain.NewValidationError("entrepreneur-id", i18n.MsgParamInvalidUUID, "entrepreneur-id"), http.StatusBadRequest)
 			return
 		}
 
 		contacts, err := app.ConSvc.GetByOwnerId(r.Context(), entUuid)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("getting contacts: %w", err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("getting contacts: %w", err), http.StatusInternalServerError)
 			return
 		}
 
@@ -625,7 +612,7 @@ func CreateActivityField(app *app.App) http.HandlerFunc {
 		var req ActivityField
 		err := json.NewDecoder(r.Body).Decode(&req)
 		if err != nil {
-			errorResponse(w, err.Error(), http.StatusBadRequest)
+			handleError(w, r, err, http.StatusBadRequest)
 			return
 		}
 
@@ -633,11 +620,11 @@ func CreateActivityField(app *app.App) http.HandlerFunc {
 
 		err = app.ActFieldSvc.Create(r.Context(), &actField)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("creating activity field: %w", err).Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("creating activity field: %w", err), http.StatusBadRequest)
 			return
 		}
 
-		successResponse(w, http.StatusOK, nil)
+		createdResponse(w, r, "/activity-fields/"+actField.ID.String(), actField.ID)
 	}
 }
 
@@ -645,29 +632,29 @@ func DeleteActivityField(app *app.App) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		id := chi.URLParam(r, "id")
 		if id == "" {
-			errorResponse(w, fmt.Errorf("empty id").Error(), http.StatusBadRequest)
+			handleError(w, r, domain.NewValidationError("id", i18n.MsgParamRequired, "id"), http.StatusBadRequest)
 			return
 		}
 
 		idUuid, err := uuid.Parse(id)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("converting id to uuid: %w", err).Error(), http.StatusBadRequest)
+			handleError(w, r, domain.NewValidationError("id", i18n.MsgParamInvalidUUID, "id"), http.StatusBadRequest)
 			return
 		}
 
 		_, err = app.ActFieldSvc.GetById(r.Context(), idUuid)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("deleting activity field by id: %w", err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("deleting activity field by id: %w", err), http.StatusInternalServerError)
 			return
 		}
 
 		err = app.ActFieldSvc.DeleteById(r.Context(), idUuid)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("deleting activity field by id: %w", err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("deleting activity field by id: %w", err), http.StatusInternalServerError)
 			return
 		}
 
-		successResponse(w, http.StatusOK, nil)
+		noContentResponse(w, r)
 	}
 }
 
@@ -675,19 +662,19 @@ func UpdateActivityField(app *app.App) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		id := chi.URLParam(r, "id")
 		if id == "" {
-			errorResponse(w, fmt.Errorf("empty id").Error(), http.StatusBadRequest)
+			handleError(w, r, domain.NewValidationError("id", i18n.MsgParamRequired, "id"), http.StatusBadRequest)
 			return
 		}
 
 		idUuid, err := uuid.Parse(id)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("converting id to uuid: %w", err).Error(), http.StatusBadRequest)
+			handleError(w, r, domain.NewValidationError("id", i18n.MsgParamInvalidUUID, "id"), http.StatusBadRequest)
 			return
 		}
 
 		actFieldDb, err := app.ActFieldSvc.GetById(r.Context(), idUuid)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("getting activity field from database by id: %w", err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("getting activity field from database by id: %w", err), http.StatusInternalServerError)
 			return
 		}
 
@@ -695,7 +682,7 @@ func UpdateActivityField(app *app.App) http.HandlerFunc {
 
 		err = json.NewDecoder(r.Body).Decode(&req)
 		if err != nil {
-			errorResponse(w, err.Error(), http.StatusBadRequest)
+			handleError(w, r, err, http.StatusBadRequest)
 			return
 		}
 
@@ -711,11 +698,11 @@ func UpdateActivityField(app *app.App) http.HandlerFunc {
 
 		err = app.ActFieldSvc.Update(r.Context(), actFieldDb)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("updating activity field info: %w", err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("updating activity field info: %w", err), http.StatusInternalServerError)
 			return
 		}
 
-		successResponse(w, http.StatusOK, nil)
+		noContentResponse(w, r)
 	}
 }
 
@@ -723,19 +710,19 @@ func GetActivityField(app *app.App) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		id := chi.URLParam(r, "id")
 		if id == "" {
-			errorResponse(w, fmt.Errorf("empty id").Error(), http.StatusBadRequest)
+			handleError(w, r, domain.NewValidationError("id", i18n.MsgParamRequired, "id"), http.StatusBadRequest)
 			return
 		}
 
 		idUuid, err := uuid.Parse(id)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("converting id to uuid: %w", err).Error(), http.StatusBadRequest)
+			handleError(w, r, domain.NewValidationError("id", i18n.MsgParamInvalidUUID, "id"), http.StatusBadRequest)
 			return
 		}
 
 		actField, err := app.ActFieldSvc.GetById(r.Context(), idUuid)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("getting activity field by id: %w", err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("getting activity field by id: %w", err), http.StatusInternalServerError)
 			return
 		}
 
@@ -755,14 +742,14 @@ func ListActivityFields(app *app.App) http.HandlerFunc {
 
 			pageInt, err = strconv.Atoi(page)
 			if err != nil {
-				errorResponse(w, fmt.Errorf("converting page to int: %w", err).Error(), http.StatusBadRequest)
+				handleError(w, r, domain.NewValidationError("page", i18n.MsgParamInvalidNumber, "page"), http.StatusBadRequest)
 				return
 			}
 		}
 
 		actFields, numPages, err := app.ActFieldSvc.GetAll(r.Context(), pageInt, paginated)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("getting activity fields: %w", err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("getting activity fields: %w", err), http.StatusInternalServerError)
 			return
 		}
 
@@ -771,7 +758,7 @@ func ListActivityFields(app *app.App) http.HandlerFunc {
 			actFieldsTransport[i] = toActFieldTransport(actField)
 		}
 
-		successResponse(w, http.StatusOK, map[string]interface{}{"activity_fields": actFieldsTransport, "num_pages": numPages})
+		etagResponse(w, r, map[string]interface{}{"activity_fields": actFieldsTransport, "num_pages": numPages})
 	}
 }
 
@@ -779,20 +766,20 @@ func CreateCompany(app *app.App) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		idStr, err := getStringClaimFromJWT(r.Context(), "sub")
 		if err != nil {
-			errorResponse(w, fmt.Errorf("getting claim from JWT: %w", err).Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("getting claim from JWT: %w", err), http.StatusBadRequest)
 			return
 		}
 
 		idUuid, err := uuid.Parse(idStr)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("converting string to uuid: %w", err).Error(), http.StatusInternalServerError)
+			handleError(w, r, domain.NewValidationError("sub", i18n.MsgParamInvalidUUID, "sub"), http.StatusBadRequest)
 			return
 		}
 
 		var req Company
 		err = json.NewDecoder(r.Body).Decode(&req)
 		if err != nil {
-			errorResponse(w, err.Error(), http.StatusBadRequest)
+			handleError(w, r, err, http.StatusBadRequest)
 			return
 		}
 
@@ -801,95 +788,79 @@ func CreateCompany(app *app.App) http.HandlerFunc {
 
 		err = app.CompSvc.Create(r.Context(), &company)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("creating company: %w", err).Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("creating company: %w", err), http.StatusBadRequest)
 			return
 		}
 
-		successResponse(w, http.StatusOK, nil)
+		createdResponse(w, r, "/companies/"+company.ID.String(), company.ID)
 	}
 }
 
 func DeleteCompany(app *app.App) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		ownerIdStr, err := getStringClaimFromJWT(r.Context(), "sub")
-		if err != nil {
-			errorResponse(w, fmt.Errorf("getting claim from JWT: %w", err).Error(), http.StatusBadRequest)
-			return
-		}
-
-		ownerIdUuid, err := uuid.Parse(ownerIdStr)
+		actor, err := getActorFromJWT(r.Context())
 		if err != nil {
-			errorResponse(w, fmt.Errorf("converting string to uuid: %w", err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("getting claim from JWT: %w", err), http.StatusBadRequest)
 			return
 		}
 
 		id := chi.URLParam(r, "id")
 		if id == "" {
-			errorResponse(w, fmt.Errorf("empty id").Error(), http.StatusBadRequest)
+			handleError(w, r, domain.NewValidationError("id", i18n.MsgParamRequired, "id"), http.StatusBadRequest)
 			return
 		}
 
 		idUuid, err := uuid.Parse(id)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("converting id to uuid: %w", err).Error(), http.StatusBadRequest)
+			handleError(w, r, domain.NewValidationError("id", i18n.MsgParamInvalidUUID, "id"), http.StatusBadRequest)
 			return
 		}
 
-		company, err := app.CompSvc.GetById(r.Context(), idUuid)
+		err = app.PolicySvc.CanManageCompany(r.Context(), actor, idUuid)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("deleting company by id: %w", err).Error(), http.StatusInternalServerError)
-			return
-		}
-
-		if ownerIdUuid != company.OwnerID {
-			errorResponse(w, fmt.Errorf("только владелец может удалять свои компании").Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("deleting company by id: %w", err), http.StatusInternalServerError)
 			return
 		}
 
 		err = app.CompSvc.DeleteById(r.Context(), idUuid)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("deleting company by id: %w", err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("deleting company by id: %w", err), http.StatusInternalServerError)
 			return
 		}
 
-		successResponse(w, http.StatusOK, nil)
+		noContentResponse(w, r)
 	}
 }
 
 func UpdateCompany(app *app.App) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		ownerIdStr, err := getStringClaimFromJWT(r.Context(), "sub")
-		if err != nil {
-			errorResponse(w, fmt.Errorf("getting claim from JWT: %w", err).Error(), http.StatusBadRequest)
-			return
-		}
-
-		ownerIdUuid, err := uuid.Parse(ownerIdStr)
+		actor, err := getActorFromJWT(r.Context())
 		if err != nil {
-			errorResponse(w, fmt.Errorf("converting string to uuid: %w", err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("getting claim from JWT: %w", err), http.StatusBadRequest)
 			return
 		}
 
 		id := chi.URLParam(r, "id")
 		if id == "" {
-			errorResponse(w, fmt.Errorf("empty id").Error(), http.StatusBadRequest)
+			handleError(w, r, domain.NewValidationError("id", i18n.MsgParamRequired, "id"), http.StatusBadRequest)
 			return
 		}
 
 		idUuid, err := uuid.Parse(id)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("converting id to uuid: %w", err).Error(), http.StatusBadRequest)
+			handleError(w, r, domain.NewValidationError("id", i18n.MsgParamInvalidUUID, "id"), http.StatusBadRequest)
 			return
 		}
 
-		compDb, err := app.CompSvc.GetById(r.Context(), idUuid)
+		err = app.PolicySvc.CanManageCompany(r.Context(), actor, idUuid)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("getting company from database by id: %w", err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("updating company info: %w", err), http.StatusInternalServerError)
 			return
 		}
 
-		if ownerIdUuid != compDb.OwnerID {
-			errorResponse(w, fmt.Errorf("только владелец может обновлять информацию о своих компаниях").Error(), http.StatusInternalServerError)
+		compDb, err := app.CompSvc.GetById(r.Context(), idUuid)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("getting company from database by id: %w", err), http.StatusInternalServerError)
 			return
 		}
 
@@ -897,7 +868,7 @@ func UpdateCompany(app *app.App) http.HandlerFunc {
 
 		err = json.NewDecoder(r.Body).Decode(&req)
 		if err != nil {
-			errorResponse(w, err.Error(), http.StatusBadRequest)
+			handleError(w, r, err, http.StatusBadRequest)
 			return
 		}
 
@@ -913,11 +884,11 @@ func UpdateCompany(app *app.App) http.HandlerFunc {
 
 		err = app.CompSvc.Update(r.Context(), compDb)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("updating company info: %w", err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("updating company info: %w", err), http.StatusInternalServerError)
 			return
 		}
 
-		successResponse(w, http.StatusOK, nil)
+		noContentResponse(w, r)
 	}
 }
 
@@ -925,19 +896,19 @@ func GetCompany(app *app.App) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		id := chi.URLParam(r, "id")
 		if id == "" {
-			errorResponse(w, fmt.Errorf("empty id").Error(), http.StatusBadRequest)
+			handleError(w, r, domain.NewValidationError("id", i18n.MsgParamRequired, "id"), http.StatusBadRequest)
 			return
 		}
 
 		idUuid, err := uuid.Parse(id)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("converting id to uuid: %w", err).Error(), http.StatusBadRequest)
+			handleError(w, r, domain.NewValidationError("id", i18n.MsgParamInvalidUUID, "id"), http.StatusBadRequest)
 			return
 		}
 
 		company, err := app.CompSvc.GetById(r.Context(), idUuid)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("getting company by id: %w", err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("getting company by id: %w", err), http.StatusInternalServerError)
 			return
 		}
 
@@ -949,31 +920,31 @@ func ListEntrepreneurCompanies(app *app.App) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		page := r.URL.Query().Get("page")
 		if page == "" {
-			errorResponse(w, fmt.Errorf("empty page number").Error(), http.StatusBadRequest)
+			handleError(w, r, domain.NewValidationError("page", i18n.MsgParamRequired, "page"), http.StatusBadRequest)
 			return
 		}
 
 		pageInt, err := strconv.Atoi(page)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("converting page to int: %w", err).Error(), http.StatusBadRequest)
+			handleError(w, r, domain.NewValidationError("page", i18n.MsgParamInvalidNumber, "page"), http.StatusBadRequest)
 			return
 		}
 
 		entId := r.URL.Query().Get("entrepreneur-id")
 		if page == "" {
-			errorResponse(w, fmt.Errorf("empty entrepreneur id").Error(), http.StatusBadRequest)
+			handleError(w, r, domain.NewValidationError("entrepreneur-id", i18n.MsgParamRequired, "entrepreneur-id"), http.StatusBadRequest)
 			return
 		}
 
 		entUuid, err := uuid.Parse(entId)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("converting entrepreneur id to uuid: %w", err).Error(), http.StatusInternalServerError)
+			handleError(w, r, domain.NewValidationError("entrepreneur-id", i18n.MsgParamInvalidUUID, "entrepreneur-id"), http.StatusBadRequest)
 			return
 		}
 
 		companies, numPages, err := app.CompSvc.GetByOwnerId(r.Context(), entUuid, pageInt, true)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("getting companies: %w", err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("getting companies: %w", err), http.StatusInternalServerError)
 			return
 		}
 
@@ -988,71 +959,63 @@ func ListEntrepreneurCompanies(app *app.App) http.HandlerFunc {
 
 func CreateUserSkill(app *app.App) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		idStr, err := getStringClaimFromJWT(r.Context(), "sub")
-		if err != nil {
-			errorResponse(w, fmt.Errorf("getting claim from JWT: %w", err).Error(), http.StatusBadRequest)
-			return
-		}
-
-		idUuid, err := uuid.Parse(idStr)
+		actor, err := getActorFromJWT(r.Context())
 		if err != nil {
-			errorResponse(w, fmt.Errorf("converting string to uuid: %w", err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("getting claim from JWT: %w", err), http.StatusBadRequest)
 			return
 		}
 
 		var req UserSkill
 		err = json.NewDecoder(r.Body).Decode(&req)
 		if err != nil {
-			errorResponse(w, err.Error(), http.StatusBadRequest)
+			handleError(w, r, err, http.StatusBadRequest)
 			return
 		}
 
+		// пользователь может добавить навык только себе
 		userSkill := toUserSkillModel(&req)
-		userSkill.UserId = idUuid
+		userSkill.UserId = actor.ID
 
 		err = app.UserSkillSvc.Create(r.Context(), &userSkill)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("creating user-skill pair: %w", err).Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("creating user-skill pair: %w", err), http.StatusBadRequest)
 			return
 		}
 
-		successResponse(w, http.StatusOK, nil)
+		createdResponse(w, r, "", nil)
 	}
 }
 
 func DeleteUserSkill(app *app.App) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		ownerIdStr, err := getStringClaimFromJWT(r.Context(), "sub")
-		if err != nil {
-			errorResponse(w, fmt.Errorf("getting claim from JWT: %w", err).Error(), http.StatusBadRequest)
-			return
-		}
-
-		ownerIdUuid, err := uuid.Parse(ownerIdStr)
+		actor, err := getActorFromJWT(r.Context())
 		if err != nil {
-			errorResponse(w, fmt.Errorf("converting string to uuid: %w", err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("getting claim from JWT: %w", err), http.StatusBadRequest)
 			return
 		}
 
 		id := chi.URLParam(r, "id")
 		if id == "" {
-			errorResponse(w, fmt.Errorf("empty id").Error(), http.StatusBadRequest)
+			handleError(w, r, domain.NewValidationError("id", i18n.MsgParamRequired, "id"), http.StatusBadRequest)
 			return
 		}
 
 		idUuid, err := uuid.Parse(id)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("converting id to uuid: %w", err).Error(), http.StatusBadRequest)
+			handleError(w, r, domain.NewValidationError("id", i18n.MsgParamInvalidUUID, "id"), http.StatusBadRequest)
 			return
 		}
 
-		err = app.UserSkillSvc.Delete(r.Context(), &domain.UserSkill{UserId: ownerIdUuid, SkillId: idUuid})
+		// пользователь может удалить только свой навык
+		userSkill := &domain.UserSkill{UserId: actor.ID, SkillId: idUuid}
+
+		err = app.UserSkillSvc.Delete(r.Context(), userSkill)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("deleting user-skill pair: %w", err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("deleting user-skill pair: %w", err), http.StatusInternalServerError)
 			return
 		}
 
-		successResponse(w, http.StatusOK, nil)
+		noContentResponse(w, r)
 	}
 }
 
@@ -1060,31 +1023,31 @@ func ListEntrepreneurSkills(app *app.App) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		page := r.URL.Query().Get("page")
 		if page == "" {
-			errorResponse(w, fmt.Errorf("empty page number").Error(), http.StatusBadRequest)
+			handleError(w, r, domain.NewValidationError("page", i18n.MsgParamRequired, "page"), http.StatusBadRequest)
 			return
 		}
 
 		pageInt, err := strconv.Atoi(page)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("converting page to int: %w", err).Error(), http.StatusBadRequest)
+			handleError(w, r, domain.NewValidationError("page", i18n.MsgParamInvalidNumber, "page"), http.StatusBadRequest)
 			return
 		}
 
 		entId := r.URL.Query().Get("entrepreneur-id")
 		if entId == "" {
-			errorResponse(w, fmt.Errorf("empty entrepreneur id").Error(), http.StatusBadRequest)
+			handleError(w, r, domain.NewValidationError("entrepreneur-id", i18n.MsgParamRequired, "entrepreneur-id"), http.StatusBadRequest)
 			return
 		}
 
 		entUuid, err := uuid.Parse(entId)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("converting entrepreneur id to uuid: %w", err).Error(), http.StatusInternalServerError)
+			handleError(w, r, domain.NewValidationError("entrepreneur-id", i18n.MsgParamInvalidUUID, "entrepreneur-id"), http.StatusBadRequest)
 			return
 		}
 
 		skills, numPages, err := app.UserSkillSvc.GetSkillsForUser(r.Context(), entUuid, pageInt, true)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("getting companies: %w", err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("getting companies: %w", err), http.StatusInternalServerError)
 			return
 		}
 
@@ -1099,45 +1062,28 @@ func ListEntrepreneurSkills(app *app.App) http.HandlerFunc {
 
 func CreateReport(app *app.App) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		userIdStr, err := getStringClaimFromJWT(r.Context(), "sub")
-		if err != nil {
-			errorResponse(w, fmt.Errorf("getting claim from JWT: %w", err).Error(), http.StatusBadRequest)
-			return
-		}
-
-		userIdUuid, err := uuid.Parse(userIdStr)
+		actor, err := getActorFromJWT(r.Context())
 		if err != nil {
-			errorResponse(w, fmt.Errorf("converting string to uuid: %w", err).Error(), http.StatusInternalServerError)
-			return
-		}
-
-		compIdStr := chi.URLParam(r, "id")
-		if compIdStr == "" {
-			errorResponse(w, fmt.Errorf("empty company id").Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("getting claim from JWT: %w", err), http.StatusBadRequest)
 			return
 		}
 
-		compIdUuid, err := uuid.Parse(compIdStr)
+		compIdUuid, err := parseUUIDFromURL(r, "id", "company")
 		if err != nil {
-			errorResponse(w, fmt.Errorf("converting string to uuid: %w", err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("creating fin report: %w", err), http.StatusBadRequest)
 			return
 		}
 
-		company, err := app.CompSvc.GetById(r.Context(), compIdUuid)
+		err = app.PolicySvc.CanManageCompany(r.Context(), actor, compIdUuid)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("creating fin report: %w", err).Error(), http.StatusInternalServerError)
-			return
-		}
-
-		if company.OwnerID != userIdUuid {
-			errorResponse(w, fmt.Errorf("only company`s owner can create financial report").Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("creating fin report: %w", err), http.StatusInternalServerError)
 			return
 		}
 
 		var req FinancialReport
 		err = json.NewDecoder(r.Body).Decode(&req)
 		if err != nil {
-			errorResponse(w, err.Error(), http.StatusBadRequest)
+			handleError(w, r, err, http.StatusBadRequest)
 			return
 		}
 
@@ -1146,107 +1092,67 @@ func CreateReport(app *app.App) http.HandlerFunc {
 
 		err = app.FinSvc.Create(r.Context(), &report)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("creating financial report: %w", err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("creating financial report: %w", err), http.StatusInternalServerError)
 			return
 		}
 
-		successResponse(w, http.StatusOK, nil)
+		createdResponse(w, r, "/financials/"+report.ID.String(), report.ID)
 	}
 }
 
 func DeleteFinReport(app *app.App) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		userIdStr, err := getStringClaimFromJWT(r.Context(), "sub")
-		if err != nil {
-			errorResponse(w, fmt.Errorf("getting claim from JWT: %w", err).Error(), http.StatusBadRequest)
-			return
-		}
-
-		userIdUuid, err := uuid.Parse(userIdStr)
+		actor, err := getActorFromJWT(r.Context())
 		if err != nil {
-			errorResponse(w, fmt.Errorf("converting string to uuid: %w", err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("getting claim from JWT: %w", err), http.StatusBadRequest)
 			return
 		}
 
-		reportIdStr := chi.URLParam(r, "id")
-		if reportIdStr == "" {
-			errorResponse(w, fmt.Errorf("empty report id").Error(), http.StatusBadRequest)
-			return
-		}
-
-		reportIdUuid, err := uuid.Parse(reportIdStr)
-		if err != nil {
-			errorResponse(w, fmt.Errorf("converting string to uuid: %w", err).Error(), http.StatusInternalServerError)
-			return
-		}
-
-		report, err := app.FinSvc.GetById(r.Context(), reportIdUuid)
+		reportIdUuid, err := parseUUIDFromURL(r, "id", "financial report")
 		if err != nil {
-			errorResponse(w, fmt.Errorf("getting financial report: %w", err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("deleting financial report: %w", err), http.StatusBadRequest)
 			return
 		}
 
-		company, err := app.CompSvc.GetById(r.Context(), report.CompanyID)
+		err = app.PolicySvc.CanManageFinReport(r.Context(), actor, reportIdUuid)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("getting financial report: %w", err).Error(), http.StatusInternalServerError)
-			return
-		}
-
-		if company.OwnerID != userIdUuid {
-			errorResponse(w, fmt.Errorf("only company`s owner can delete financial report").Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("deleting financial report: %w", err), http.StatusInternalServerError)
 			return
 		}
 
 		err = app.FinSvc.DeleteById(r.Context(), reportIdUuid)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("deleting company by id: %w", err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("deleting financial report by id: %w", err), http.StatusInternalServerError)
 			return
 		}
 
-		successResponse(w, http.StatusOK, nil)
+		noContentResponse(w, r)
 	}
 }
 
 func UpdateFinReport(app *app.App) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		userIdStr, err := getStringClaimFromJWT(r.Context(), "sub")
+		actor, err := getActorFromJWT(r.Context())
 		if err != nil {
-			errorResponse(w, fmt.Errorf("getting claim from JWT: %w", err).Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("getting claim from JWT: %w", err), http.StatusBadRequest)
 			return
 		}
 
-		userIdUuid, err := uuid.Parse(userIdStr)
+		reportIdUuid, err := parseUUIDFromURL(r, "id", "financial report")
 		if err != nil {
-			errorResponse(w, fmt.Errorf("converting string to uuid: %w", err).Error(), http.StatusInternalServerError)
-			return
-		}
-
-		reportIdStr := chi.URLParam(r, "id")
-		if reportIdStr == "" {
-			errorResponse(w, fmt.Errorf("empty report id").Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("updating financial report info: %w", err), http.StatusBadRequest)
 			return
 		}
 
-		reportIdUuid, err := uuid.Parse(reportIdStr)
+		err = app.PolicySvc.CanManageFinReport(r.Context(), actor, reportIdUuid)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("converting string to uuid: %w", err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("updating financial report info: %w", err), http.StatusInternalServerError)
 			return
 		}
 
 		reportDb, err := app.FinSvc.GetById(r.Context(), reportIdUuid)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("getting financial report: %w", err).Error(), http.StatusInternalServerError)
-			return
-		}
-
-		company, err := app.CompSvc.GetById(r.Context(), reportDb.CompanyID)
-		if err != nil {
-			errorResponse(w, fmt.Errorf("getting financial report: %w", err).Error(), http.StatusInternalServerError)
-			return
-		}
-
-		if company.OwnerID != userIdUuid {
-			errorResponse(w, fmt.Errorf("only company`s owner can update financial report").Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("getting financial report: %w", err), http.StatusInternalServerError)
 			return
 		}
 
@@ -1254,7 +1160,7 @@ func UpdateFinReport(app *app.App) http.HandlerFunc {
 
 		err = json.NewDecoder(r.Body).Decode(&req)
 		if err != nil {
-			errorResponse(w, err.Error(), http.StatusBadRequest)
+			handleError(w, r, err, http.StatusBadRequest)
 			return
 		}
 
@@ -1270,14 +1176,36 @@ func UpdateFinReport(app *app.App) http.HandlerFunc {
 		if !(math.Abs(float64(req.Costs)) < eps) {
 			reportDb.Costs = req.Costs
 		}
+		// неуказанные расширенные показатели остаются прежними
+		if req.EBITDA != nil {
+			reportDb.EBITDA = req.EBITDA
+		}
+		if req.NetProfit != nil {
+			reportDb.NetProfit = req.NetProfit
+		}
+		if req.Assets != nil {
+			reportDb.Assets = req.Assets
+		}
+		if req.Liabilities != nil {
+			reportDb.Liabilities = req.Liabilities
+		}
+		if req.Equity != nil {
+			reportDb.Equity = req.Equity
+		}
+		if req.Headcount != nil {
+			reportDb.Headcount = req.Headcount
+		}
+		if req.ExportsShare != nil {
+			reportDb.ExportsShare = req.ExportsShare
+		}
 
 		err = app.FinSvc.Update(r.Context(), reportDb)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("updating financial report info: %w", err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("updating financial report info: %w", err), http.StatusInternalServerError)
 			return
 		}
 
-		successResponse(w, http.StatusOK, nil)
+		noContentResponse(w, r)
 	}
 }
 
@@ -1285,19 +1213,19 @@ func GetFinReport(app *app.App) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		id := chi.URLParam(r, "id")
 		if id == "" {
-			errorResponse(w, fmt.Errorf("empty id").Error(), http.StatusBadRequest)
+			handleError(w, r, domain.NewValidationError("id", i18n.MsgParamRequired, "id"), http.StatusBadRequest)
 			return
 		}
 
 		idUuid, err := uuid.Parse(id)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("converting id to uuid: %w", err).Error(), http.StatusBadRequest)
+			handleError(w, r, domain.NewValidationError("id", i18n.MsgParamInvalidUUID, "id"), http.StatusBadRequest)
 			return
 		}
 
 		report, err := app.FinSvc.GetById(r.Context(), idUuid)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("getting financial report by id: %w", err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("getting financial report by id: %w", err), http.StatusInternalServerError)
 			return
 		}
 
@@ -1305,272 +1233,1181 @@ func GetFinReport(app *app.App) http.HandlerFunc {
 	}
 }
 
-func ListCompanyReports(app *app.App) http.HandlerFunc {
+// AmendFinReport подаёт исправление показателей отчёта: прежние значения
+// сохраняются в истории версий, ответ содержит новую текущую версию.
+func AmendFinReport(app *app.App) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		// 	page := r.URL.Query().Get("page")
-		// 	if page == "" {
-		// 		errorResponse(w, fmt.Errorf("empty page number").Error(), http.StatusBadRequest)
-		// 		return
-		// 	}
+		prompt := "исправление финансового отчета"
 
-		// 	pageInt, err := strconv.Atoi(page)
-		// 	if err != nil {
-		// 		errorResponse(w, fmt.Errorf("converting page to int: %w", err).Error(), http.StatusBadRequest)
-		// 		return
-		// 	}
+		actor, err := getActorFromJWT(r.Context())
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusBadRequest)
+			return
+		}
 
-		period, err := parsePeriodFromURL(r)
+		reportId, err := parseUUIDFromURL(r, "id", "financial report")
 		if err != nil {
-			errorResponse(w, fmt.Errorf("parsing period from URL: %w", err).Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusBadRequest)
 			return
 		}
 
-		//compIdStr := chi.URLParam(r, "id")
-		//if compIdStr == "" {
-		//	errorResponse(w, fmt.Errorf("empty company id").Error(), http.StatusBadRequest)
-		//	return
-		//}
-		//
-		//compIdUuid, err := uuid.Parse(compIdStr)
-		//if err != nil {
-		//	errorResponse(w, fmt.Errorf("converting company id to uuid: %w", err).Error(), http.StatusInternalServerError)
-		//	return
-		//}
-		compIdUuid, err := parseUUIDFromURL(r, "id", "company")
+		err = app.PolicySvc.CanManageFinReport(r.Context(), actor, reportId)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("parsing company id from url: %w", err).Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
 			return
 		}
 
-		reports, err := app.FinSvc.GetByCompany(r.Context(), compIdUuid, period)
+		var req FinancialReportAmendment
+		err = json.NewDecoder(r.Body).Decode(&req)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("getting companies: %w", err).Error(), http.StatusInternalServerError)
+			handleError(w, r, err, http.StatusBadRequest)
 			return
 		}
 
-		reportsTransport := make([]FinancialReport, len(reports.Reports))
-		for i, rep := range reports.Reports {
-			reportsTransport[i] = toFinReportTransport(&rep)
+		amendment := &domain.FinancialReportRevision{
+			ReportID: reportId,
+			Revenue:  req.Revenue,
+			Costs:    req.Costs,
+			Reason:   req.Reason,
+		}
+		err = app.FinSvc.Amend(r.Context(), amendment)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
+			return
 		}
 
-		successResponse(w, http.StatusOK,
-			map[string]interface{}{
-				"company_id": compIdUuid,
-				"period":     toPeriodTransport(period),
-				"revenue":    reports.Revenue(),
-				"costs":      reports.Costs(),
-				"profit":     reports.Profit(),
-				"reports":    reportsTransport},
-		)
+		prefix, _ := apiPrefixFromContext(r.Context())
+		w.Header().Set("Location", prefix+"/financials/"+reportId.String()+"/revisions")
+		successResponse(w, http.StatusCreated, map[string]interface{}{"revision": toFinReportRevisionTransport(amendment)})
 	}
 }
 
-func CalculateRating(app *app.App) http.HandlerFunc {
+// ListFinReportRevisions возвращает все версии отчёта от первой к текущей.
+func ListFinReportRevisions(app *app.App) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		id := chi.URLParam(r, "id")
-		if id == "" {
-			errorResponse(w, fmt.Errorf("empty id").Error(), http.StatusBadRequest)
-			return
-		}
+		prompt := "получение версий финансового отчета"
 
-		idUuid, err := uuid.Parse(id)
+		reportId, err := parseUUIDFromURL(r, "id", "financial report")
 		if err != nil {
-			errorResponse(w, fmt.Errorf("converting id to uuid: %w", err).Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusBadRequest)
 			return
 		}
 
-		rating, err := app.Interactor.CalculateUserRating(r.Context(), idUuid)
+		revs, err := app.FinSvc.GetRevisions(r.Context(), reportId)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("calculating entrepreneur rating: %w", err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
 			return
 		}
 
-		successResponse(w, http.StatusOK, map[string]float32{"rating": rating})
+		revisions := make([]FinancialReportRevision, len(revs))
+		for i, rev := range revs {
+			revisions[i] = toFinReportRevisionTransport(rev)
+		}
+
+		successResponse(w, http.StatusOK, map[string]interface{}{"report_id": reportId, "revisions": revisions})
 	}
 }
 
-func GetEntrepreneurFinancials(app *app.App) http.HandlerFunc {
+// SubmitFinReport отправляет отчёт с приложенными документами на проверку.
+func SubmitFinReport(app *app.App) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		id := r.URL.Query().Get("entrepreneur-id")
-		if id == "" {
-			errorResponse(w, fmt.Errorf("empty entrepreneur id").Error(), http.StatusBadRequest)
+		prompt := "отправка финансового отчета на проверку"
+
+		actor, err := getActorFromJWT(r.Context())
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusBadRequest)
 			return
 		}
 
-		idUuid, err := uuid.Parse(id)
+		reportId, err := parseUUIDFromURL(r, "id", "financial report")
 		if err != nil {
-			errorResponse(w, fmt.Errorf("converting id to uuid: %w", err).Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusBadRequest)
 			return
 		}
 
-		prevYear := time.Now().AddDate(-1, 0, 0).Year()
-		period := &domain.Period{
-			StartYear:    prevYear,
-			EndYear:      prevYear,
-			StartQuarter: 1,
-			EndQuarter:   4,
+		err = app.PolicySvc.CanManageFinReport(r.Context(), actor, reportId)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
+			return
 		}
 
-		rep, err := app.Interactor.GetUserFinancialReport(r.Context(), idUuid, period)
+		err = app.FinSvc.Submit(r.Context(), reportId)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("getting entrepreneur financial report: %w", err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
 			return
 		}
 
-		successResponse(w, http.StatusOK, map[string]float32{
-			"revenue": rep.Revenue(),
-			"costs":   rep.Costs(),
-			"profit":  rep.Profit(),
-			"taxes":   rep.Taxes,
-			"taxLoad": rep.TaxLoad,
-		})
+		noContentResponse(w, r)
 	}
 }
 
-func GetEntrepreneurReviews(app *app.App) http.HandlerFunc {
+// ReviewFinReport подтверждает или отклоняет отправленный на проверку отчёт.
+func ReviewFinReport(app *app.App) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		id := r.URL.Query().Get("entrepreneur-id")
-		if id == "" {
-			errorResponse(w, fmt.Errorf("empty entrepreneur id").Error(), http.StatusBadRequest)
+		prompt := "проверка финансового отчета"
+
+		reportId, err := parseUUIDFromURL(r, "id", "financial report")
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusBadRequest)
 			return
 		}
 
-		entUuid, err := uuid.Parse(id)
+		var req FinancialReportReview
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			handleError(w, r, err, http.StatusBadRequest)
+			return
+		}
+
+		err = app.FinSvc.Review(r.Context(), reportId, domain.VerificationStatus(req.Status), req.Comment)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("converting id to uuid: %w", err).Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
 			return
 		}
 
+		noContentResponse(w, r)
+	}
+}
+
+// ListFinReportsByStatus возвращает очередь отчётов с заданным статусом проверки,
+// по умолчанию — ожидающие проверки.
+func ListFinReportsByStatus(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "получение отчетов по статусу проверки"
+
 		page := r.URL.Query().Get("page")
 		if page == "" {
-			errorResponse(w, fmt.Errorf("empty page number").Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, domain.NewValidationError("page", i18n.MsgParamRequired, "page")), http.StatusBadRequest)
 			return
 		}
 
 		pageInt, err := strconv.Atoi(page)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("converting page to int: %w", err).Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, domain.NewValidationError("page", i18n.MsgParamInvalidNumber, "page")), http.StatusBadRequest)
 			return
 		}
 
-		revs, numPages, err := app.RevSvc.GetAllForTarget(r.Context(), entUuid, pageInt)
+		status := domain.VerificationStatus(r.URL.Query().Get("status"))
+		if status == "" {
+			status = domain.VerificationSubmitted
+		}
+
+		reports, numPages, err := app.FinSvc.GetByStatus(r.Context(), status, pageInt)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("getting reviews: %w", err).Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
 			return
 		}
 
-		reviewsTransport := make([]Review, len(revs))
-		for i, rev := range revs {
-			reviewsTransport[i] = toReviewTransport(rev)
+		reportsTransport := make([]FinancialReport, len(reports))
+		for i, report := range reports {
+			reportsTransport[i] = toFinReportTransport(report)
 		}
 
-		successResponse(w, http.StatusOK, map[string]interface{}{"entrepreneur_id": entUuid, "reviews": reviewsTransport, "num_pages": numPages})
+		successResponse(w, http.StatusOK, map[string]interface{}{"num_pages": numPages, "financial_reports": reportsTransport})
 	}
 }
 
-func GetAuthorReviews(app *app.App) http.HandlerFunc {
+// UploadFinReportDocument прикладывает к отчёту документ из тела запроса.
+// Тип документа берётся из заголовка Content-Type, имя — из параметра name.
+func UploadFinReportDocument(app *app.App) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		prompt := "получение собственных отзывов"
+		prompt := "загрузка документа финансового отчета"
 
-		idStr, err := getStringClaimFromJWT(r.Context(), "sub")
+		actor, err := getActorFromJWT(r.Context())
 		if err != nil {
-			errorResponse(w, fmt.Errorf("%s: получение записей из JWT: %w", prompt, err).Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusBadRequest)
 			return
 		}
 
-		entUuid, err := uuid.Parse(idStr)
+		reportId, err := parseUUIDFromURL(r, "id", "financial report")
 		if err != nil {
-			errorResponse(w, fmt.Errorf("%s: преобразование id к uuid: %w", prompt, err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusBadRequest)
 			return
 		}
 
-		page := r.URL.Query().Get("page")
-		if page == "" {
-			errorResponse(w, fmt.Errorf("пустой номер страницы").Error(), http.StatusBadRequest)
+		name := r.URL.Query().Get("name")
+		if name == "" {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, domain.NewValidationError("name", i18n.MsgParamRequired, "name")), http.StatusBadRequest)
 			return
 		}
 
-		pageInt, err := strconv.Atoi(page)
+		err = app.PolicySvc.CanManageFinReport(r.Context(), actor, reportId)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("преобразование страницы к int: %w", err).Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
 			return
 		}
 
-		revs, numPages, err := app.RevSvc.GetAllForReviewer(r.Context(), entUuid, pageInt)
+		// параметры типа вроде charset для документов не нужны
+		contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		doc := &domain.FinancialReportDocument{
+			ReportID:    reportId,
+			Name:        name,
+			ContentType: contentType,
+		}
+		err = app.FinSvc.AttachDocument(r.Context(), doc, r.Body)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
+			return
+		}
 
-		reviewsTransport := make([]Review, len(revs))
-		for i, rev := range revs {
-			reviewsTransport[i] = toReviewTransport(rev)
+		createdResponse(w, r, "/financials/"+reportId.String()+"/documents/"+doc.ID.String(), doc.ID)
+	}
+}
+
+// ListFinReportDocuments возвращает сведения о документах отчёта без содержимого.
+func ListFinReportDocuments(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "получение документов финансового отчета"
+
+		actor, err := getActorFromJWT(r.Context())
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusBadRequest)
+			return
 		}
 
-		successResponse(w, http.StatusOK, map[string]interface{}{"entrepreneur_id": entUuid, "reviews": reviewsTransport, "num_pages": numPages})
+		reportId, err := parseUUIDFromURL(r, "id", "financial report")
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusBadRequest)
+			return
+		}
+
+		err = app.PolicySvc.CanReadFinDocuments(r.Context(), actor, reportId)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
+			return
+		}
+
+		docs, err := app.FinSvc.GetDocuments(r.Context(), reportId)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
+			return
+		}
+
+		docsTransport := make([]FinancialReportDocument, len(docs))
+		for i, doc := range docs {
+			docsTransport[i] = toFinReportDocumentTransport(doc)
+		}
+
+		successResponse(w, http.StatusOK, map[string]interface{}{"report_id": reportId, "documents": docsTransport})
 	}
 }
 
-func CreateReview(app *app.App) http.HandlerFunc {
+// DownloadFinReportDocument отдаёт содержимое документа с исходным типом и именем файла.
+func DownloadFinReportDocument(app *app.App) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		prompt := "создание отзыва"
+		prompt := "получение документа финансового отчета"
 
-		idStr, err := getStringClaimFromJWT(r.Context(), "sub")
+		actor, err := getActorFromJWT(r.Context())
 		if err != nil {
-			errorResponse(w, fmt.Errorf("%s: получение записей из JWT: %w", prompt, err).Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusBadRequest)
 			return
 		}
 
-		idUuid, err := uuid.Parse(idStr)
+		reportId, err := parseUUIDFromURL(r, "id", "financial report")
 		if err != nil {
-			errorResponse(w, fmt.Errorf("%s: преобразование id к uuid: %w", prompt, err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusBadRequest)
 			return
 		}
 
-		var req Review
-		err = json.NewDecoder(r.Body).Decode(&req)
+		docId, err := parseUUIDFromURL(r, "documentId", "document")
 		if err != nil {
-			errorResponse(w, err.Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusBadRequest)
 			return
 		}
 
-		rev := toReviewModel(&req)
-		rev.Reviewer = idUuid
+		err = app.PolicySvc.CanReadFinDocuments(r.Context(), actor, reportId)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
+			return
+		}
 
-		err = app.RevSvc.Create(r.Context(), &rev)
+		doc, content, err := app.FinSvc.OpenDocument(r.Context(), docId)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
 			return
 		}
+		defer content.Close()
 
-		successResponse(w, http.StatusOK, nil)
+		if doc.ReportID != reportId {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, domain.ErrNotFound), http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", doc.ContentType)
+		w.Header().Set("Content-Length", strconv.FormatInt(doc.Size, 10))
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": doc.Name}))
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.Copy(w, content)
 	}
 }
 
-func DeleteReview(app *app.App) http.HandlerFunc {
+// DeleteFinReportDocument удаляет документ отчёта, пока отчёт не отправлен на проверку.
+func DeleteFinReportDocument(app *app.App) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		prompt := "удаление отзыва"
+		prompt := "удаление документа финансового отчета"
 
-		id := chi.URLParam(r, "id")
-		if id == "" {
-			errorResponse(w, fmt.Errorf("%s: пустой id", prompt).Error(), http.StatusBadRequest)
+		actor, err := getActorFromJWT(r.Context())
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusBadRequest)
 			return
 		}
 
-		idUuid, err := uuid.Parse(id)
+		reportId, err := parseUUIDFromURL(r, "id", "financial report")
 		if err != nil {
-			errorResponse(w, fmt.Errorf("%s: преобразование id к uuid: %w", prompt, err).Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusBadRequest)
 			return
 		}
 
-		_, err = app.RevSvc.Get(r.Context(), idUuid)
+		docId, err := parseUUIDFromURL(r, "documentId", "document")
 		if err != nil {
-			errorResponse(w, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusBadRequest)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusBadRequest)
 			return
 		}
 
-		err = app.RevSvc.Delete(r.Context(), idUuid)
+		err = app.PolicySvc.CanManageFinReport(r.Context(), actor, reportId)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
+			return
+		}
+
+		docs, err := app.FinSvc.GetDocuments(r.Context(), reportId)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
+			return
+		}
+
+		// документ другого отчёта считается отсутствующим
+		found := false
+		for _, doc := range docs {
+			found = found || doc.ID == docId
+		}
+		if !found {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, domain.ErrNotFound), http.StatusNotFound)
+			return
+		}
+
+		err = app.FinSvc.DeleteDocument(r.Context(), docId)
 		if err != nil {
-			errorResponse(w, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusInternalServerError)
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
 			return
 		}
 
-		successResponse(w, http.StatusOK, nil)
+		noContentResponse(w, r)
+	}
+}
+
+func ListCompanyReports(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// 	page := r.URL.Query().Get("page")
+		// 	if page == "" {
+		// 		handleError(w, r, fmt.Errorf("empty page number"), http.StatusBadRequest)
+		// 		return
+		// 	}
+
+		// 	pageInt, err := strconv.Atoi(page)
+		// 	if err != nil {
+		// 		handleError(w, r, fmt.Errorf("converting page to int: %w", err), http.StatusBadRequest)
+		// 		return
+		// 	}
+
+		period, err := parsePeriodFromURL(r)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("parsing period from URL: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		//compIdStr := chi.URLParam(r, "id")
+		//if compIdStr == "" {
+		//	handleError(w, r, fmt.Errorf("empty company id"), http.StatusBadRequest)
+		//	return
+		//}
+		//
+		//compIdUuid, err := uuid.Parse(compIdStr)
+		//if err != nil {
+		//	handleError(w, r, fmt.Errorf("converting company id to uuid: %w", err), http.StatusInternalServerError)
+		//	return
+		//}
+		compIdUuid, err := parseUUIDFromURL(r, "id", "company")
+		if err != nil {
+			handleError(w, r, fmt.Errorf("parsing company id from url: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		reports, err := app.FinSvc.GetByCompany(r.Context(), compIdUuid, period)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("getting companies: %w", err), http.StatusInternalServerError)
+			return
+		}
+
+		reportsTransport := make([]FinancialReport, len(reports.Reports))
+		for i, rep := range reports.Reports {
+			reportsTransport[i] = toFinReportTransport(&rep)
+		}
+
+		successResponse(w, http.StatusOK,
+			map[string]interface{}{
+				"company_id": compIdUuid,
+				"period":     toPeriodTransport(period),
+				"revenue":    reports.Revenue(),
+				"costs":      reports.Costs(),
+				"profit":     reports.Profit(),
+				"net_profit": reports.NetProfit(),
+				"ratios":     toFinRatiosTransport(reports),
+				"reports":    reportsTransport},
+		)
+	}
+}
+
+// GetCompanyAnalytics возвращает динамику показателей компании за период.
+func GetCompanyAnalytics(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "получение аналитики компании"
+
+		period, err := parsePeriodFromURL(r)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusBadRequest)
+			return
+		}
+
+		compId, err := parseUUIDFromURL(r, "id", "company")
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusBadRequest)
+			return
+		}
+
+		reports, err := app.FinSvc.GetByCompany(r.Context(), compId, period)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
+			return
+		}
+
+		successResponse(w, http.StatusOK, map[string]interface{}{
+			"company_id": compId,
+			"period":     toPeriodTransport(period),
+			"analytics":  toFinAnalyticsTransport(reports.Analytics()),
+		})
+	}
+}
+
+// GetEntrepreneurAnalytics возвращает динамику показателей по всем компаниям
+// предпринимателя: показатели компаний за один квартал суммируются.
+func GetEntrepreneurAnalytics(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "получение аналитики предпринимателя"
+
+		period, err := parsePeriodFromURL(r)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusBadRequest)
+			return
+		}
+
+		id, err := parseUUIDFromURL(r, "id", "entrepreneur")
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusBadRequest)
+			return
+		}
+
+		_, err = app.UserSvc.GetById(r.Context(), id)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
+			return
+		}
+
+		reports, err := app.Interactor.GetUserFinancialReport(r.Context(), id, period)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
+			return
+		}
+
+		successResponse(w, http.StatusOK, map[string]interface{}{
+			"entrepreneur_id": id,
+			"period":          toPeriodTransport(period),
+			"analytics":       toFinAnalyticsTransport(reports.Analytics()),
+		})
+	}
+}
+
+func CalculateRating(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := chi.URLParam(r, "id")
+		if id == "" {
+			handleError(w, r, domain.NewValidationError("id", i18n.MsgParamRequired, "id"), http.StatusBadRequest)
+			return
+		}
+
+		idUuid, err := uuid.Parse(id)
+		if err != nil {
+			handleError(w, r, domain.NewValidationError("id", i18n.MsgParamInvalidUUID, "id"), http.StatusBadRequest)
+			return
+		}
+
+		rating, err := app.Interactor.CalculateUserRating(r.Context(), idUuid)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("calculating entrepreneur rating: %w", err), http.StatusInternalServerError)
+			return
+		}
+
+		successResponse(w, http.StatusOK, map[string]float32{"rating": rating})
+	}
+}
+
+// GetRatingHistory возвращает сохранённые снимки рейтинга предпринимателя по кварталам.
+// Период необязателен: без year-start возвращается вся история.
+func GetRatingHistory(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "получение истории рейтинга"
+
+		id, err := parseUUIDFromURL(r, "id", "entrepreneur")
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusBadRequest)
+			return
+		}
+
+		var period *domain.Period
+		if r.URL.Query().Get("year-start") != "" {
+			period, err = parsePeriodFromURL(r)
+			if err != nil {
+				handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusBadRequest)
+				return
+			}
+		}
+
+		snapshots, err := app.RatingSvc.GetHistory(r.Context(), id, period)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
+			return
+		}
+
+		history := make([]RatingSnapshot, len(snapshots))
+		for i, snapshot := range snapshots {
+			history[i] = toRatingSnapshotTransport(snapshot)
+		}
+
+		successResponse(w, http.StatusOK, map[string]interface{}{"history": history})
+	}
+}
+
+func GetEntrepreneurFinancials(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := r.URL.Query().Get("entrepreneur-id")
+		if id == "" {
+			handleError(w, r, domain.NewValidationError("entrepreneur-id", i18n.MsgParamRequired, "entrepreneur-id"), http.StatusBadRequest)
+			return
+		}
+
+		idUuid, err := uuid.Parse(id)
+		if err != nil {
+			handleError(w, r, domain.NewValidationError("entrepreneur-id", i18n.MsgParamInvalidUUID, "entrepreneur-id"), http.StatusBadRequest)
+			return
+		}
+
+		prevYear := time.Now().AddDate(-1, 0, 0).Year()
+		period := &domain.Period{
+			StartYear:    prevYear,
+			EndYear:      prevYear,
+			StartQuarter: 1,
+			EndQuarter:   4,
+		}
+
+		rep, err := app.Interactor.GetUserFinancialReport(r.Context(), idUuid, period)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("getting entrepreneur financial report: %w", err), http.StatusInternalServerError)
+			return
+		}
+
+		successResponse(w, http.StatusOK, map[string]float32{
+			"revenue": rep.Revenue(),
+			"costs":   rep.Costs(),
+			"profit":  rep.Profit(),
+			"taxes":   rep.Taxes,
+			"taxLoad": rep.TaxLoad,
+		})
+	}
+}
+
+func GetEntrepreneurReviews(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := r.URL.Query().Get("entrepreneur-id")
+		if id == "" {
+			handleError(w, r, domain.NewValidationError("entrepreneur-id", i18n.MsgParamRequired, "entrepreneur-id"), http.StatusBadRequest)
+			return
+		}
+
+		entUuid, err := uuid.Parse(id)
+		if err != nil {
+			handleError(w, r, domain.NewValidationError("entrepreneur-id", i18n.MsgParamInvalidUUID, "entrepreneur-id"), http.StatusBadRequest)
+			return
+		}
+
+		page := r.URL.Query().Get("page")
+		if page == "" {
+			handleError(w, r, domain.NewValidationError("page", i18n.MsgParamRequired, "page"), http.StatusBadRequest)
+			return
+		}
+
+		pageInt, err := strconv.Atoi(page)
+		if err != nil {
+			handleError(w, r, domain.NewValidationError("page", i18n.MsgParamInvalidNumber, "page"), http.StatusBadRequest)
+			return
+		}
+
+		revs, numPages, err := app.RevSvc.GetAllForTarget(r.Context(), entUuid, pageInt)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("getting reviews: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		reviewsTransport := make([]Review, len(revs))
+		for i, rev := range revs {
+			reviewsTransport[i] = toReviewTransport(rev)
+		}
+
+		successResponse(w, http.StatusOK, map[string]interface{}{"entrepreneur_id": entUuid, "reviews": reviewsTransport, "num_pages": numPages})
+	}
+}
+
+func GetAuthorReviews(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "получение собственных отзывов"
+
+		idStr, err := getStringClaimFromJWT(r.Context(), "sub")
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: получение записей из JWT: %w", prompt, err), http.StatusBadRequest)
+			return
+		}
+
+		entUuid, err := uuid.Parse(idStr)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, domain.NewValidationError("sub", i18n.MsgParamInvalidUUID, "sub")), http.StatusBadRequest)
+			return
+		}
+
+		page := r.URL.Query().Get("page")
+		if page == "" {
+			handleError(w, r, domain.NewValidationError("page", i18n.MsgParamRequired, "page"), http.StatusBadRequest)
+			return
+		}
+
+		pageInt, err := strconv.Atoi(page)
+		if err != nil {
+			handleError(w, r, domain.NewValidationError("page", i18n.MsgParamInvalidNumber, "page"), http.StatusBadRequest)
+			return
+		}
+
+		revs, numPages, err := app.RevSvc.GetAllForReviewer(r.Context(), entUuid, pageInt)
+
+		reviewsTransport := make([]Review, len(revs))
+		for i, rev := range revs {
+			reviewsTransport[i] = toReviewTransport(rev)
+		}
+
+		successResponse(w, http.StatusOK, map[string]interface{}{"entrepreneur_id": entUuid, "reviews": reviewsTransport, "num_pages": numPages})
+	}
+}
+
+func CreateReview(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "создание отзыва"
+
+		idStr, err := getStringClaimFromJWT(r.Context(), "sub")
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: получение записей из JWT: %w", prompt, err), http.StatusBadRequest)
+			return
+		}
+
+		idUuid, err := uuid.Parse(idStr)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, domain.NewValidationError("sub", i18n.MsgParamInvalidUUID, "sub")), http.StatusBadRequest)
+			return
+		}
+
+		var req Review
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			handleError(w, r, err, http.StatusBadRequest)
+			return
+		}
+
+		rev := toReviewModel(&req)
+		rev.Reviewer = idUuid
+
+		err = app.RevSvc.Create(r.Context(), &rev)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusBadRequest)
+			return
+		}
+
+		createdResponse(w, r, "/reviews/"+rev.ID.String(), rev.ID)
+	}
+}
+
+func GetReview(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "получение отзыва"
+
+		id, err := parseUUIDFromURL(r, "id", "review")
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusBadRequest)
+			return
+		}
+
+		rev, err := app.RevSvc.Get(r.Context(), id)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
+			return
+		}
+
+		successResponse(w, http.StatusOK, map[string]interface{}{"review": toReviewTransport(rev)})
+	}
+}
+
+func DeleteReview(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "удаление отзыва"
+
+		actor, err := getActorFromJWT(r.Context())
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: получение записей из JWT: %w", prompt, err), http.StatusBadRequest)
+			return
+		}
+
+		id := chi.URLParam(r, "id")
+		if id == "" {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, domain.NewValidationError("id", i18n.MsgParamRequired, "id")), http.StatusBadRequest)
+			return
+		}
+
+		idUuid, err := uuid.Parse(id)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, domain.NewValidationError("id", i18n.MsgParamInvalidUUID, "id")), http.StatusBadRequest)
+			return
+		}
+
+		err = app.PolicySvc.CanDeleteReview(r.Context(), actor, idUuid)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
+			return
+		}
+
+		err = app.RevSvc.Delete(r.Context(), idUuid)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
+			return
+		}
+
+		noContentResponse(w, r)
+	}
+}
+
+func ListRoles(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "получение списка ролей"
+
+		roles, err := app.RoleSvc.GetAll(r.Context())
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
+			return
+		}
+
+		rolesTransport := make([]Role, len(roles))
+		for i, role := range roles {
+			rolesTransport[i] = toRoleTransport(role)
+		}
+
+		permissions := make([]string, len(domain.Permissions))
+		for i, perm := range domain.Permissions {
+			permissions[i] = string(perm)
+		}
+
+		successResponse(w, http.StatusOK, map[string]interface{}{"roles": rolesTransport, "permissions": permissions})
+	}
+}
+
+func GetRole(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "получение роли"
+
+		name := chi.URLParam(r, "name")
+		if name == "" {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, domain.NewValidationError("name", i18n.MsgRoleNameRequired)), http.StatusBadRequest)
+			return
+		}
+
+		role, err := app.RoleSvc.GetByName(r.Context(), name)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
+			return
+		}
+
+		successResponse(w, http.StatusOK, map[string]interface{}{"role": toRoleTransport(role)})
+	}
+}
+
+func CreateRole(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "создание роли"
+
+		var req Role
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusBadRequest)
+			return
+		}
+
+		role := toRoleModel(&req)
+
+		err = app.RoleSvc.Create(r.Context(), &role)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusBadRequest)
+			return
+		}
+
+		createdResponse(w, r, "/roles/"+role.Name, role.Name)
+	}
+}
+
+func UpdateRole(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "обновление информации о роли"
+
+		name := chi.URLParam(r, "name")
+		if name == "" {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, domain.NewValidationError("name", i18n.MsgRoleNameRequired)), http.StatusBadRequest)
+			return
+		}
+
+		roleDb, err := app.RoleSvc.GetByName(r.Context(), name)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
+			return
+		}
+
+		var req Role
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusBadRequest)
+			return
+		}
+
+		upd := toRoleModel(&req)
+		if upd.Description != "" {
+			roleDb.Description = upd.Description
+		}
+		if req.Permissions != nil {
+			roleDb.Permissions = upd.Permissions
+		}
+
+		err = app.RoleSvc.Update(r.Context(), roleDb)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusBadRequest)
+			return
+		}
+
+		noContentResponse(w, r)
+	}
+}
+
+func DeleteRole(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "удаление роли"
+
+		name := chi.URLParam(r, "name")
+		if name == "" {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, domain.NewValidationError("name", i18n.MsgRoleNameRequired)), http.StatusBadRequest)
+			return
+		}
+
+		err := app.RoleSvc.DeleteByName(r.Context(), name)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
+			return
+		}
+
+		noContentResponse(w, r)
+	}
+}
+
+func AssignRole(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "назначение роли предпринимателю"
+
+		idUuid, err := parseUUIDFromURL(r, "id", "entrepreneur")
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusBadRequest)
+			return
+		}
+
+		type Req struct {
+			Role string `json:"role"`
+		}
+		var req Req
+
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusBadRequest)
+			return
+		}
+
+		err = app.RoleSvc.AssignToUser(r.Context(), idUuid, req.Role)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusBadRequest)
+			return
+		}
+
+		noContentResponse(w, r)
+	}
+}
+
+func ListWebhooks(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "получение списка подписок на вебхуки"
+
+		subs, err := app.WebhookSvc.GetSubscriptions(r.Context())
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
+			return
+		}
+
+		subsTransport := make([]Webhook, len(subs))
+		for i, sub := range subs {
+			subsTransport[i] = toWebhookTransport(sub)
+		}
+
+		eventTypes := make([]string, len(domain.EventTypes))
+		for i, eventType := range domain.EventTypes {
+			eventTypes[i] = string(eventType)
+		}
+
+		successResponse(w, http.StatusOK, map[string]interface{}{"webhooks": subsTransport, "event_types": eventTypes})
+	}
+}
+
+func GetWebhook(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "получение подписки на вебхук"
+
+		id, err := parseUUIDFromURL(r, "id", "webhook")
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusBadRequest)
+			return
+		}
+
+		sub, err := app.WebhookSvc.GetSubscription(r.Context(), id)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
+			return
+		}
+
+		successResponse(w, http.StatusOK, map[string]interface{}{"webhook": toWebhookTransport(sub)})
+	}
+}
+
+func CreateWebhook(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "создание подписки на вебхук"
+
+		var req Webhook
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusBadRequest)
+			return
+		}
+
+		sub := toWebhookModel(&req)
+
+		err = app.WebhookSvc.Subscribe(r.Context(), &sub)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
+			return
+		}
+
+		createdResponse(w, r, "/webhooks/"+sub.ID.String(), sub.ID)
+	}
+}
+
+func DeleteWebhook(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "удаление подписки на вебхук"
+
+		id, err := parseUUIDFromURL(r, "id", "webhook")
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusBadRequest)
+			return
+		}
+
+		err = app.WebhookSvc.Unsubscribe(r.Context(), id)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
+			return
+		}
+
+		noContentResponse(w, r)
+	}
+}
+
+func ListWebhookDeliveries(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "получение журнала доставок вебхука"
+
+		id, err := parseUUIDFromURL(r, "id", "webhook")
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusBadRequest)
+			return
+		}
+
+		page := r.URL.Query().Get("page")
+		if page == "" {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, domain.NewValidationError("page", i18n.MsgParamRequired, "page")), http.StatusBadRequest)
+			return
+		}
+
+		pageInt, err := strconv.Atoi(page)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, domain.NewValidationError("page", i18n.MsgParamInvalidNumber, "page")), http.StatusBadRequest)
+			return
+		}
+
+		deliveries, numPages, err := app.WebhookSvc.GetDeliveries(r.Context(), id, pageInt)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
+			return
+		}
+
+		deliveriesTransport := make([]WebhookDelivery, len(deliveries))
+		for i, delivery := range deliveries {
+			deliveriesTransport[i] = toWebhookDeliveryTransport(delivery)
+		}
+
+		successResponse(w, http.StatusOK, map[string]interface{}{"num_pages": numPages, "deliveries": deliveriesTransport})
+	}
+}
+
+func ListJobs(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "получение списка фоновых задач"
+
+		jobs, err := app.JobSvc.GetJobs(r.Context())
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
+			return
+		}
+
+		jobsTransport := make([]Job, len(jobs))
+		for i, job := range jobs {
+			jobsTransport[i] = toJobTransport(job)
+		}
+
+		successResponse(w, http.StatusOK, map[string]interface{}{"jobs": jobsTransport})
+	}
+}
+
+func ListJobRuns(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "получение журнала запусков задачи"
+
+		page := r.URL.Query().Get("page")
+		if page == "" {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, domain.NewValidationError("page", i18n.MsgParamRequired, "page")), http.StatusBadRequest)
+			return
+		}
+
+		pageInt, err := strconv.Atoi(page)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, domain.NewValidationError("page", i18n.MsgParamInvalidNumber, "page")), http.StatusBadRequest)
+			return
+		}
+
+		runs, numPages, err := app.JobSvc.GetRuns(r.Context(), chi.URLParam(r, "name"), pageInt)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
+			return
+		}
+
+		runsTransport := make([]JobRun, len(runs))
+		for i, run := range runs {
+			runsTransport[i] = toJobRunTransport(run)
+		}
+
+		successResponse(w, http.StatusOK, map[string]interface{}{"num_pages": numPages, "runs": runsTransport})
+	}
+}
+
+// TriggerJob запускает задачу вне расписания. Задача выполняется в фоне,
+// её результат появляется в журнале запусков.
+func TriggerJob(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "запуск фоновой задачи"
+
+		run, err := app.JobSvc.Trigger(r.Context(), chi.URLParam(r, "name"))
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
+			return
+		}
+
+		successResponse(w, http.StatusAccepted, map[string]interface{}{"run": toJobRunTransport(run)})
+	}
+}
+
+// ListAuditEntries возвращает страницу журнала аудита, отфильтрованного
+// по сущности, пользователю и периоду [from, to).
+func ListAuditEntries(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "получение журнала аудита"
+		query := r.URL.Query()
+
+		page := query.Get("page")
+		if page == "" {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, domain.NewValidationError("page", i18n.MsgParamRequired, "page")), http.StatusBadRequest)
+			return
+		}
+
+		pageInt, err := strconv.Atoi(page)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, domain.NewValidationError("page", i18n.MsgParamInvalidNumber, "page")), http.StatusBadRequest)
+			return
+		}
+
+		filter := &domain.AuditFilter{
+			EntityType: domain.AuditEntity(query.Get("entity_type")),
+			EntityID:   query.Get("entity_id"),
+		}
+
+		if actorID := query.Get("actor_id"); actorID != "" {
+			filter.ActorID, err = uuid.Parse(actorID)
+			if err != nil {
+				handleError(w, r, fmt.Errorf("%s: %w", prompt, domain.NewValidationError("actor_id", i18n.MsgParamInvalidUUID, "actor_id")), http.StatusBadRequest)
+				return
+			}
+		}
+
+		bounds := []struct {
+			key string
+			dst *time.Time
+		}{{"from", &filter.From}, {"to", &filter.To}}
+		for _, bound := range bounds {
+			val := query.Get(bound.key)
+			if val == "" {
+				continue
+			}
+
+			*bound.dst, err = time.Parse(time.RFC3339, val)
+			if err != nil {
+				handleError(w, r, fmt.Errorf("%s: %w", prompt, domain.NewValidationError(bound.key, i18n.MsgParamInvalidTime, bound.key)), http.StatusBadRequest)
+				return
+			}
+		}
+
+		entries, numPages, err := app.AuditSvc.Find(r.Context(), filter, pageInt)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("%s: %w", prompt, err), http.StatusInternalServerError)
+			return
+		}
+
+		entriesTransport := make([]AuditEntry, len(entries))
+		for i, entry := range entries {
+			entriesTransport[i] = toAuditEntryTransport(entry)
+		}
+
+		successResponse(w, http.StatusOK, map[string]interface{}{"num_pages": numPages, "entries": entriesTransport})
 	}
 }
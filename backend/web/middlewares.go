@@ -1,54 +1,124 @@
 package web
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"ppo/domain"
+	"ppo/internal/app"
+	"ppo/pkg/i18n"
 
 	"github.com/go-chi/jwtauth/v5"
 )
 
-func ValidateAdminRoleJWT(next http.Handler) http.Handler {
+func RequirePermission(app *app.App, perm domain.Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			role, err := getStringClaimFromJWT(r.Context(), "role")
+			if err != nil {
+				handleError(w, r, fmt.Errorf("%w: getting 'role' claim from JWT: %v", domain.ErrForbidden, err), http.StatusForbidden)
+				return
+			}
+
+			ok, err := app.RoleSvc.HasPermission(r.Context(), role, perm)
+			// роль из токена могла быть удалена после его выдачи
+			if errors.Is(err, domain.ErrNotFound) {
+				handleError(w, r, fmt.Errorf("%w: unknown role '%s'", domain.ErrForbidden, role), http.StatusForbidden)
+				return
+			}
+			if err != nil {
+				handleError(w, r, fmt.Errorf("checking permission: %w", err), http.StatusInternalServerError)
+				return
+			}
+
+			if !ok {
+				handleError(w, r, fmt.Errorf("%w: permission '%s' is required to perform this action", domain.ErrForbidden, perm), http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Authenticator пропускает только запросы с проверенным JWT-токеном.
+// В отличие от jwtauth.Authenticator отвечает в общем формате ошибок API.
+// Пользователь из токена сохраняется в контексте для журнала аудита.
+func Authenticator(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		_, claims, err := jwtauth.FromContext(r.Context())
-		if err != nil {
-			errorResponse(w, fmt.Errorf("getting claims from JWT: %w", err).Error(), http.StatusBadRequest)
-			return
+		token, _, err := jwtauth.FromContext(r.Context())
+		if err == nil && token == nil {
+			err = jwtauth.ErrUnauthorized
 		}
 
-		role, ok := claims["role"]
-		if !ok {
-			errorResponse(w, fmt.Errorf("getting 'role' claim from JWT").Error(), http.StatusBadRequest)
+		if err != nil {
+			handleError(w, r, fmt.Errorf("проверка JWT-токена: %w", err), http.StatusUnauthorized)
 			return
 		}
 
-		if role != "admin" {
-			errorResponse(w, fmt.Errorf("only administrators can use this").Error(), http.StatusForbidden)
-			return
+		ctx := r.Context()
+		actor, err := getActorFromJWT(ctx)
+		if err == nil {
+			ctx = domain.WithActor(ctx, actor)
 		}
 
-		next.ServeHTTP(w, r)
+		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
-func ValidateUserRoleJWT(next http.Handler) http.Handler {
+// Language выбирает язык сообщений по заголовку Accept-Language.
+func Language(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		_, claims, err := jwtauth.FromContext(r.Context())
-		if err != nil {
-			errorResponse(w, fmt.Errorf("getting claims from JWT: %w", err).Error(), http.StatusBadRequest)
-			return
-		}
+		lang := i18n.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+		w.Header().Set("Content-Language", string(lang))
 
-		role, ok := claims["role"]
-		if !ok {
-			errorResponse(w, fmt.Errorf("getting 'role' claim from JWT").Error(), http.StatusBadRequest)
-			return
-		}
+		next.ServeHTTP(w, r.WithContext(i18n.WithLang(r.Context(), lang)))
+	})
+}
 
-		if role != "user" && role != "admin" {
-			errorResponse(w, fmt.Errorf("you need to login to perform this action").Error(), http.StatusForbidden)
-			return
-		}
+// LimitBody ограничивает тело запроса maxBytes байтами. Чтение сверх лимита
+// возвращает *http.MaxBytesError, на который handleError отвечает кодом 413.
+func LimitBody(maxBytes int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > maxBytes {
+				handleError(w, r, &http.MaxBytesError{Limit: maxBytes}, http.StatusRequestEntityTooLarge)
+				return
+			}
 
-		next.ServeHTTP(w, r)
-	})
+			r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+type apiPrefixKey struct{}
+
+// Versioned помечает запросы к версионированному API с префиксом prefix.
+// По этой метке обработчики выбирают коды ответов и строят заголовок Location.
+func Versioned(prefix string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), apiPrefixKey{}, prefix)))
+		})
+	}
+}
+
+func apiPrefixFromContext(ctx context.Context) (string, bool) {
+	prefix, ok := ctx.Value(apiPrefixKey{}).(string)
+	return prefix, ok
+}
+
+// Deprecated отмечает устаревшие маршруты заголовками Deprecation и Link
+// со ссылкой на версию API, которая их заменяет.
+func Deprecated(successor string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Deprecation", "true")
+			w.Header().Add("Link", fmt.Sprintf("<%s>; rel=\"successor-version\"", successor))
+
+			next.ServeHTTP(w, r)
+		})
+	}
 }
@@ -1,6 +1,7 @@
 package web
 
 import (
+	"encoding/json"
 	"ppo/domain"
 	"time"
 
@@ -57,6 +58,58 @@ type FinancialReport struct {
 	Costs     float32   `json:"costs,omitempty"`
 	Year      int       `json:"year,omitempty"`
 	Quarter   int       `json:"quarter,omitempty"`
+	// расширенные показатели необязательны и не возвращаются, если не указаны
+	EBITDA       *float32 `json:"ebitda,omitempty"`
+	NetProfit    *float32 `json:"net_profit,omitempty"`
+	Assets       *float32 `json:"assets,omitempty"`
+	Liabilities  *float32 `json:"liabilities,omitempty"`
+	Equity       *float32 `json:"equity,omitempty"`
+	Headcount    *int     `json:"headcount,omitempty"`
+	ExportsShare *float32 `json:"exports_share,omitempty"`
+	// поля проверки только возвращаются клиенту и не принимаются при записи
+	Status        string     `json:"status,omitempty"`
+	ReviewComment string     `json:"review_comment,omitempty"`
+	ReviewerID    *uuid.UUID `json:"reviewer_id,omitempty"`
+	ReviewedAt    *time.Time `json:"reviewed_at,omitempty"`
+}
+
+// FinancialRatios — производные показатели за период; null, если показатель
+// нельзя рассчитать по указанным в отчётах данным.
+type FinancialRatios struct {
+	Margin             *float32 `json:"margin"`
+	ROE                *float32 `json:"roe"`
+	DebtToEquity       *float32 `json:"debt_to_equity"`
+	RevenuePerEmployee *float32 `json:"revenue_per_employee"`
+}
+
+// Growth — относительные величины по выручке, расходам и прибыли; null, если
+// величину нельзя рассчитать.
+type Growth struct {
+	Revenue *float32 `json:"revenue"`
+	Costs   *float32 `json:"costs"`
+	Profit  *float32 `json:"profit"`
+}
+
+type QuarterFigures struct {
+	Year    int     `json:"year"`
+	Quarter int     `json:"quarter"`
+	Revenue float32 `json:"revenue"`
+	Costs   float32 `json:"costs"`
+	Profit  float32 `json:"profit"`
+}
+
+type QuarterGrowth struct {
+	QuarterFigures
+	QoQ Growth `json:"qoq"`
+	YoY Growth `json:"yoy"`
+}
+
+type FinancialAnalytics struct {
+	Quarters   []QuarterGrowth `json:"quarters"`
+	CAGR       Growth          `json:"cagr"`
+	Volatility Growth          `json:"volatility"`
+	Best       *QuarterFigures `json:"best_quarter"`
+	Worst      *QuarterFigures `json:"worst_quarter"`
 }
 
 type Period struct {
@@ -76,6 +129,112 @@ type Review struct {
 	Rating      int       `json:"rating"`
 }
 
+type Role struct {
+	Name        string   `json:"name,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+}
+
+type Webhook struct {
+	ID         uuid.UUID `json:"id,omitempty"`
+	URL        string    `json:"url"`
+	Secret     string    `json:"secret,omitempty"`
+	EventTypes []string  `json:"event_types"`
+	CreatedAt  time.Time `json:"created_at,omitempty"`
+}
+
+type WebhookDelivery struct {
+	ID             uuid.UUID       `json:"id"`
+	EventID        uuid.UUID       `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	LastStatusCode int             `json:"last_status_code,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+type RatingSnapshot struct {
+	Year       int        `json:"year"`
+	Quarter    int        `json:"quarter"`
+	Rating     float32    `json:"rating"`
+	Revenue    float32    `json:"revenue"`
+	Profit     float32    `json:"profit"`
+	CompanyID  *uuid.UUID `json:"most_profitable_company_id"`
+	FieldCost  float32    `json:"activity_field_cost"`
+	MaxCost    float32    `json:"max_activity_field_cost"`
+	ComputedAt time.Time  `json:"computed_at"`
+}
+
+type Job struct {
+	Name      string    `json:"name"`
+	Schedule  string    `json:"schedule"`
+	NextRunAt time.Time `json:"next_run_at"`
+	LastRun   *JobRun   `json:"last_run"`
+}
+
+type JobRun struct {
+	ID         uuid.UUID  `json:"id"`
+	Job        string     `json:"job"`
+	Trigger    string     `json:"trigger"`
+	Status     string     `json:"status"`
+	Error      string     `json:"error,omitempty"`
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at"`
+}
+
+// FinancialReportRevision — версия квартального отчёта; author_id равен null
+// у версий, поданных от имени системы или перенесённых при миграции.
+type FinancialReportRevision struct {
+	ReportID uuid.UUID  `json:"report_id"`
+	Version  int        `json:"version"`
+	Revenue  float32    `json:"revenue"`
+	Costs    float32    `json:"costs"`
+	AuthorID *uuid.UUID `json:"author_id"`
+	Reason   string     `json:"reason"`
+	FiledAt  time.Time  `json:"filed_at"`
+	Current  bool       `json:"current"`
+}
+
+// FinancialReportReview — решение проверки отчёта: verified или rejected с комментарием.
+type FinancialReportReview struct {
+	Status  string `json:"status"`
+	Comment string `json:"comment"`
+}
+
+// FinancialReportDocument — сведения о подтверждающем документе без содержимого.
+type FinancialReportDocument struct {
+	ID          uuid.UUID  `json:"id"`
+	ReportID    uuid.UUID  `json:"report_id"`
+	Name        string     `json:"name"`
+	ContentType string     `json:"content_type"`
+	Size        int64      `json:"size"`
+	UploadedBy  *uuid.UUID `json:"uploaded_by"`
+	UploadedAt  time.Time  `json:"uploaded_at"`
+}
+
+// FinancialReportAmendment — исправление показателей отчёта с обязательной причиной.
+type FinancialReportAmendment struct {
+	Revenue float32 `json:"revenue"`
+	Costs   float32 `json:"costs"`
+	Reason  string  `json:"reason"`
+}
+
+// AuditEntry — запись журнала аудита; actor_id равен null у изменений от имени системы.
+type AuditEntry struct {
+	ID         uuid.UUID       `json:"id"`
+	ActorID    *uuid.UUID      `json:"actor_id"`
+	ActorRole  string          `json:"actor_role,omitempty"`
+	Action     string          `json:"action"`
+	EntityType string          `json:"entity_type"`
+	EntityID   string          `json:"entity_id"`
+	Before     json.RawMessage `json:"before"`
+	After      json.RawMessage `json:"after"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
 func toUserTransport(user *domain.User) User {
 	return User{
 		ID:       user.ID,
@@ -187,24 +346,105 @@ func toUserSkillModel(userSkill *UserSkill) domain.UserSkill {
 }
 
 func toFinReportTransport(finReport *domain.FinancialReport) FinancialReport {
-	return FinancialReport{
-		ID:        finReport.ID,
-		CompanyID: finReport.CompanyID,
-		Revenue:   finReport.Revenue,
-		Costs:     finReport.Costs,
-		Year:      finReport.Year,
-		Quarter:   finReport.Quarter,
+	report := FinancialReport{
+		ID:            finReport.ID,
+		CompanyID:     finReport.CompanyID,
+		Revenue:       finReport.Revenue,
+		Costs:         finReport.Costs,
+		Year:          finReport.Year,
+		Quarter:       finReport.Quarter,
+		EBITDA:        finReport.EBITDA,
+		NetProfit:     finReport.NetProfit,
+		Assets:        finReport.Assets,
+		Liabilities:   finReport.Liabilities,
+		Equity:        finReport.Equity,
+		Headcount:     finReport.Headcount,
+		ExportsShare:  finReport.ExportsShare,
+		Status:        string(finReport.Status),
+		ReviewComment: finReport.ReviewComment,
+	}
+	if finReport.ReviewerID != uuid.Nil {
+		report.ReviewerID = &finReport.ReviewerID
+	}
+	if !finReport.ReviewedAt.IsZero() {
+		report.ReviewedAt = &finReport.ReviewedAt
 	}
+
+	return report
 }
 
 func toFinReportModel(finReport *FinancialReport) domain.FinancialReport {
 	return domain.FinancialReport{
-		ID:        finReport.ID,
-		CompanyID: finReport.CompanyID,
-		Revenue:   finReport.Revenue,
-		Costs:     finReport.Costs,
-		Year:      finReport.Year,
-		Quarter:   finReport.Quarter,
+		ID:           finReport.ID,
+		CompanyID:    finReport.CompanyID,
+		Revenue:      finReport.Revenue,
+		Costs:        finReport.Costs,
+		Year:         finReport.Year,
+		Quarter:      finReport.Quarter,
+		EBITDA:       finReport.EBITDA,
+		NetProfit:    finReport.NetProfit,
+		Assets:       finReport.Assets,
+		Liabilities:  finReport.Liabilities,
+		Equity:       finReport.Equity,
+		Headcount:    finReport.Headcount,
+		ExportsShare: finReport.ExportsShare,
+	}
+}
+
+func toFinRatiosTransport(byPeriod *domain.FinancialReportByPeriod) FinancialRatios {
+	ratio := func(value float32, ok bool) *float32 {
+		if !ok {
+			return nil
+		}
+		return &value
+	}
+
+	return FinancialRatios{
+		Margin:             ratio(byPeriod.Margin()),
+		ROE:                ratio(byPeriod.ROE()),
+		DebtToEquity:       ratio(byPeriod.DebtToEquity()),
+		RevenuePerEmployee: ratio(byPeriod.RevenuePerEmployee()),
+	}
+}
+
+func toGrowthTransport(growth domain.Growth) Growth {
+	return Growth{
+		Revenue: growth.Revenue,
+		Costs:   growth.Costs,
+		Profit:  growth.Profit,
+	}
+}
+
+func toQuarterFiguresTransport(q *domain.QuarterFigures) *QuarterFigures {
+	if q == nil {
+		return nil
+	}
+
+	return &QuarterFigures{
+		Year:    q.Year,
+		Quarter: q.Quarter,
+		Revenue: q.Revenue,
+		Costs:   q.Costs,
+		Profit:  q.Profit,
+	}
+}
+
+func toFinAnalyticsTransport(analytics *domain.FinancialAnalytics) FinancialAnalytics {
+	quarters := make([]QuarterGrowth, len(analytics.Quarters))
+	for i, q := range analytics.Quarters {
+		quarters[i] = QuarterGrowth{
+			QuarterFigures: *toQuarterFiguresTransport(&q.QuarterFigures),
+			QoQ:            toGrowthTransport(q.QoQ),
+			YoY:            toGrowthTransport(q.YoY),
+		}
+	}
+
+	return FinancialAnalytics{
+		Quarters:   quarters,
+		CAGR:       toGrowthTransport(analytics.CAGR),
+		Volatility: toGrowthTransport(analytics.Volatility),
+		Best:       toQuarterFiguresTransport(analytics.Best),
+		Worst:      toQuarterFiguresTransport(analytics.Worst),
 	}
 }
 
@@ -240,3 +480,178 @@ func toReviewModel(rev *Review) domain.Review {
 		Rating:      rev.Rating,
 	}
 }
+
+func toRoleTransport(role *domain.Role) Role {
+	perms := make([]string, len(role.Permissions))
+	for i, perm := range role.Permissions {
+		perms[i] = string(perm)
+	}
+
+	return Role{
+		Name:        role.Name,
+		Description: role.Description,
+		Permissions: perms,
+	}
+}
+
+func toRoleModel(role *Role) domain.Role {
+	perms := make([]domain.Permission, len(role.Permissions))
+	for i, perm := range role.Permissions {
+		perms[i] = domain.Permission(perm)
+	}
+
+	return domain.Role{
+		Name:        role.Name,
+		Description: role.Description,
+		Permissions: perms,
+	}
+}
+
+// toWebhookTransport не возвращает секрет подписки: он известен только тому, кто её создал.
+func toWebhookTransport(sub *domain.WebhookSubscription) Webhook {
+	eventTypes := make([]string, len(sub.EventTypes))
+	for i, eventType := range sub.EventTypes {
+		eventTypes[i] = string(eventType)
+	}
+
+	return Webhook{
+		ID:         sub.ID,
+		URL:        sub.URL,
+		EventTypes: eventTypes,
+		CreatedAt:  sub.CreatedAt,
+	}
+}
+
+func toWebhookModel(sub *Webhook) domain.WebhookSubscription {
+	eventTypes := make([]domain.EventType, len(sub.EventTypes))
+	for i, eventType := range sub.EventTypes {
+		eventTypes[i] = domain.EventType(eventType)
+	}
+
+	return domain.WebhookSubscription{
+		URL:        sub.URL,
+		Secret:     sub.Secret,
+		EventTypes: eventTypes,
+	}
+}
+
+func toWebhookDeliveryTransport(delivery *domain.WebhookDelivery) WebhookDelivery {
+	return WebhookDelivery{
+		ID:             delivery.ID,
+		EventID:        delivery.EventID,
+		EventType:      string(delivery.EventType),
+		Payload:        delivery.Payload,
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt,
+	}
+}
+
+func toRatingSnapshotTransport(snapshot *domain.RatingSnapshot) RatingSnapshot {
+	var companyId *uuid.UUID
+	if snapshot.CompanyID != uuid.Nil {
+		companyId = &snapshot.CompanyID
+	}
+
+	return RatingSnapshot{
+		Year:       snapshot.Year,
+		Quarter:    snapshot.Quarter,
+		Rating:     snapshot.Rating,
+		Revenue:    snapshot.Revenue,
+		Profit:     snapshot.Profit,
+		CompanyID:  companyId,
+		FieldCost:  snapshot.FieldCost,
+		MaxCost:    snapshot.MaxCost,
+		ComputedAt: snapshot.ComputedAt,
+	}
+}
+
+func toJobTransport(job *domain.Job) Job {
+	var lastRun *JobRun
+	if job.LastRun != nil {
+		run := toJobRunTransport(job.LastRun)
+		lastRun = &run
+	}
+
+	return Job{
+		Name:      job.Name,
+		Schedule:  job.Schedule,
+		NextRunAt: job.NextRunAt,
+		LastRun:   lastRun,
+	}
+}
+
+// toJobRunTransport возвращает finished_at равным null, пока задача выполняется.
+func toJobRunTransport(run *domain.JobRun) JobRun {
+	var finishedAt *time.Time
+	if !run.FinishedAt.IsZero() {
+		finishedAt = &run.FinishedAt
+	}
+
+	return JobRun{
+		ID:         run.ID,
+		Job:        run.Job,
+		Trigger:    string(run.Trigger),
+		Status:     string(run.Status),
+		Error:      run.Error,
+		StartedAt:  run.StartedAt,
+		FinishedAt: finishedAt,
+	}
+}
+
+func toFinReportRevisionTransport(rev *domain.FinancialReportRevision) FinancialReportRevision {
+	var authorID *uuid.UUID
+	if rev.AuthorID != uuid.Nil {
+		authorID = &rev.AuthorID
+	}
+
+	return FinancialReportRevision{
+		ReportID: rev.ReportID,
+		Version:  rev.Version,
+		Revenue:  rev.Revenue,
+		Costs:    rev.Costs,
+		AuthorID: authorID,
+		Reason:   rev.Reason,
+		FiledAt:  rev.FiledAt,
+		Current:  rev.Current,
+	}
+}
+
+func toFinReportDocumentTransport(doc *domain.FinancialReportDocument) FinancialReportDocument {
+	var uploadedBy *uuid.UUID
+	if doc.UploadedBy != uuid.Nil {
+		uploadedBy = &doc.UploadedBy
+	}
+
+	return FinancialReportDocument{
+		ID:          doc.ID,
+		ReportID:    doc.ReportID,
+		Name:        doc.Name,
+		ContentType: doc.ContentType,
+		Size:        doc.Size,
+		UploadedBy:  uploadedBy,
+		UploadedAt:  doc.UploadedAt,
+	}
+}
+
+func toAuditEntryTransport(entry *domain.AuditEntry) AuditEntry {
+	var actorID *uuid.UUID
+	if entry.ActorID != uuid.Nil {
+		actorID = &entry.ActorID
+	}
+
+	return AuditEntry{
+		ID:         entry.ID,
+		ActorID:    actorID,
+		ActorRole:  entry.ActorRole,
+		Action:     string(entry.Action),
+		EntityType: string(entry.EntityType),
+		EntityID:   entry.EntityID,
+		Before:     entry.Before,
+		After:      entry.After,
+		CreatedAt:  entry.CreatedAt,
+	}
+}
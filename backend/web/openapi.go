@@ -0,0 +1,262 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"ppo/domain"
+	"ppo/pkg/i18n"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+)
+
+//go:embed openapi.yaml
+var openAPISpec []byte
+
+const uuidFormat = `^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`
+
+func init() {
+	openapi3.DefineStringFormat("uuid", uuidFormat)
+}
+
+// LoadOpenAPI разбирает встроенную спецификацию API и проверяет её корректность.
+func LoadOpenAPI() (*openapi3.T, error) {
+	doc, err := openapi3.NewLoader().LoadFromData(openAPISpec)
+	if err != nil {
+		return nil, fmt.Errorf("разбор спецификации OpenAPI: %w", err)
+	}
+
+	err = doc.Validate(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("проверка спецификации OpenAPI: %w", err)
+	}
+
+	return doc, nil
+}
+
+// OpenAPIHandler отдаёт спецификацию в формате JSON.
+func OpenAPIHandler(doc *openapi3.T) http.HandlerFunc {
+	spec, err := json.Marshal(doc)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err != nil {
+			handleError(w, r, fmt.Errorf("сериализация спецификации OpenAPI: %w", err), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(spec)
+	}
+}
+
+type OpenAPIOptions struct {
+	// Strict включает строгий режим для тестов: ответы, не соответствующие
+	// спецификации, и неописанные маршруты приводят к ошибке 500.
+	// В обычном режиме такие расхождения только пишутся в лог.
+	Strict bool
+}
+
+// ValidateOpenAPI проверяет запросы и ответы по спецификации doc.
+// Некорректные запросы отклоняются с кодом 400 до вызова обработчика.
+func ValidateOpenAPI(doc *openapi3.T, opts OpenAPIOptions) (func(http.Handler) http.Handler, error) {
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, fmt.Errorf("построение маршрутов по спецификации OpenAPI: %w", err)
+	}
+
+	filterOpts := &openapi3filter.Options{
+		// токен проверяется jwtauth, здесь важна только схема запроса
+		AuthenticationFunc:    openapi3filter.NoopAuthenticationFunc,
+		IncludeResponseStatus: opts.Strict,
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route, pathParams, err := findRoute(router, r)
+			if err != nil {
+				if opts.Strict {
+					handleError(w, r, fmt.Errorf("маршрут не описан в спецификации OpenAPI: %w", err), http.StatusInternalServerError)
+					return
+				}
+
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			reqInput := &openapi3filter.RequestValidationInput{
+				Request:    r,
+				PathParams: pathParams,
+				Route:      route,
+				Options:    filterOpts,
+			}
+
+			err = openapi3filter.ValidateRequest(r.Context(), reqInput)
+			if err != nil {
+				// тело, превысившее лимит LimitBody, — не ошибка схемы запроса
+				var tooLargeErr *http.MaxBytesError
+				if !errors.As(err, &tooLargeErr) {
+					err = toValidationError(err)
+				}
+
+				handleError(w, r, err, http.StatusBadRequest)
+				return
+			}
+
+			rec := &responseRecorder{w: w, header: make(http.Header)}
+			next.ServeHTTP(rec, r)
+			if !rec.wroteHeader {
+				rec.WriteHeader(http.StatusOK)
+			}
+
+			// двоичные ответы уже переданы клиенту и не проверяются
+			if !rec.buffered {
+				return
+			}
+
+			err = openapi3filter.ValidateResponse(r.Context(), &openapi3filter.ResponseValidationInput{
+				RequestValidationInput: reqInput,
+				Status:                 rec.status,
+				Header:                 rec.header,
+				Body:                   io.NopCloser(bytes.NewReader(rec.body.Bytes())),
+				Options:                filterOpts,
+			})
+			if err != nil {
+				err = fmt.Errorf("ответ %s %s не соответствует спецификации OpenAPI: %w", r.Method, r.URL.Path, err)
+				if opts.Strict {
+					handleError(w, r, err, http.StatusInternalServerError)
+					return
+				}
+
+				log.Println(err)
+			}
+
+			rec.writeTo(w)
+		})
+	}, nil
+}
+
+// findRoute ищет операцию для запроса. Маршруты списков в chi доступны
+// и без завершающего слэша, и с ним, а в спецификации описаны без него.
+func findRoute(router routers.Router, r *http.Request) (*routers.Route, map[string]string, error) {
+	route, pathParams, err := router.FindRoute(r)
+	if err == nil || r.URL.Path == "/" || !strings.HasSuffix(r.URL.Path, "/") {
+		return route, pathParams, err
+	}
+
+	trimmed := r.Clone(r.Context())
+	trimmed.URL.Path = strings.TrimSuffix(r.URL.Path, "/")
+
+	return router.FindRoute(trimmed)
+}
+
+// toValidationError переводит ошибку openapi3filter в ошибку валидации домена,
+// чтобы handleError отдал её в общем формате.
+func toValidationError(err error) error {
+	field := ""
+
+	var reqErr *openapi3filter.RequestError
+	var schemaErr *openapi3.SchemaError
+	switch {
+	case errors.As(err, &reqErr) && reqErr.Parameter != nil:
+		field = reqErr.Parameter.Name
+	case errors.As(err, &schemaErr):
+		field = strings.Join(schemaErr.JSONPointer(), ".")
+	}
+
+	validationErr := domain.NewValidationError(field, i18n.MsgRequestInvalid)
+	if field != "" {
+		validationErr = domain.NewValidationError(field, i18n.MsgRequestFieldInvalid, field)
+	}
+
+	return fmt.Errorf("%w: %s", validationErr, err)
+}
+
+// responseRecorder накапливает JSON-ответ обработчика, чтобы проверить его
+// до отправки клиенту. Ответы других типов, например скачиваемые документы,
+// сразу передаются в w без накопления в памяти.
+type responseRecorder struct {
+	w           http.ResponseWriter
+	header      http.Header
+	status      int
+	wroteHeader bool
+	buffered    bool
+	body        bytes.Buffer
+}
+
+// isJSONContentType сообщает, проверяется ли ответ с таким типом содержимого
+// по схеме. Ответы без тела, как 204, тоже проверяются: по коду ответа.
+func isJSONContentType(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+func (rec *responseRecorder) Header() http.Header {
+	return rec.header
+}
+
+func (rec *responseRecorder) WriteHeader(status int) {
+	if rec.wroteHeader {
+		return
+	}
+
+	rec.wroteHeader = true
+	rec.status = status
+	rec.buffered = isJSONContentType(rec.header.Get("Content-Type"))
+	if !rec.buffered {
+		copyHeader(rec.w.Header(), rec.header)
+		rec.w.WriteHeader(status)
+	}
+}
+
+func (rec *responseRecorder) Write(b []byte) (int, error) {
+	if !rec.wroteHeader {
+		rec.WriteHeader(http.StatusOK)
+	}
+
+	if !rec.buffered {
+		return rec.w.Write(b)
+	}
+
+	return rec.body.Write(b)
+}
+
+// Flush передаёт клиенту уже записанную часть двоичного ответа.
+func (rec *responseRecorder) Flush() {
+	if rec.wroteHeader && !rec.buffered {
+		if flusher, ok := rec.w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+	}
+}
+
+func (rec *responseRecorder) writeTo(w http.ResponseWriter) {
+	copyHeader(w.Header(), rec.header)
+	w.WriteHeader(rec.status)
+	w.Write(rec.body.Bytes())
+}
+
+func copyHeader(dst, src http.Header) {
+	for key, values := range src {
+		dst[key] = values
+	}
+}
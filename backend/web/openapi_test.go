@@ -0,0 +1,236 @@
+package web
+
+import (
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"ppo/domain"
+	"ppo/internal/app"
+	"ppo/mocks"
+	"strings"
+	"testing"
+)
+
+func TestOpenAPI_CoversRoutes(t *testing.T) {
+	doc, err := LoadOpenAPI()
+	require.Nil(t, err)
+
+	mux, err := NewRouter(&app.App{}, jwtauth.New("HS256", []byte("secret"), nil), OpenAPIOptions{Strict: true})
+	require.Nil(t, err)
+
+	registered := make(map[string]bool)
+	err = chi.Walk(mux, func(method string, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		if route != "/" {
+			route = strings.TrimSuffix(route, "/")
+		}
+		registered[method+" "+route] = true
+
+		pathItem := doc.Paths.Value(route)
+		require.NotNil(t, pathItem, "маршрут %s не описан в спецификации", route)
+		require.NotNil(t, pathItem.GetOperation(method), "метод %s %s не описан в спецификации", method, route)
+
+		return nil
+	})
+	require.Nil(t, err)
+
+	for path, pathItem := range doc.Paths.Map() {
+		for method := range pathItem.Operations() {
+			require.True(t, registered[method+" "+path], "операция %s %s из спецификации не зарегистрирована", method, path)
+		}
+	}
+}
+
+func TestOpenAPI_Validation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	authSvc := mocks.NewMockIAuthService(ctrl)
+	skillSvc := mocks.NewMockISkillService(ctrl)
+	a := &app.App{AuthSvc: authSvc, SkillSvc: skillSvc}
+
+	mux, err := NewRouter(a, jwtauth.New("HS256", []byte("secret"), nil), OpenAPIOptions{Strict: true})
+	require.Nil(t, err)
+
+	skillId := uuid.New()
+
+	testCases := []struct {
+		name       string
+		method     string
+		target     string
+		body       string
+		beforeTest func()
+		wantStatus int
+		wantField  string
+	}{
+		{
+			name:   "ответ соответствует спецификации",
+			method: http.MethodGet,
+			target: "/skills/" + skillId.String(),
+			beforeTest: func() {
+				skillSvc.EXPECT().
+					GetById(gomock.Any(), skillId).
+					Return(&domain.Skill{ID: skillId, Name: "name", Description: "description"}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "некорректный uuid в пути",
+			method:     http.MethodGet,
+			target:     "/skills/abc",
+			wantStatus: http.StatusBadRequest,
+			wantField:  "id",
+		},
+		{
+			name:       "отсутствует обязательный параметр запроса",
+			method:     http.MethodGet,
+			target:     "/skills/",
+			wantStatus: http.StatusBadRequest,
+			wantField:  "page",
+		},
+		{
+			name:       "неизвестное поле в теле запроса",
+			method:     http.MethodPost,
+			target:     "/login",
+			body:       `{"login": "user", "password": "pass", "role": "admin"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "неверный тип поля в теле запроса",
+			method:     http.MethodPost,
+			target:     "/login",
+			body:       `{"login": 1, "password": "pass"}`,
+			wantStatus: http.StatusBadRequest,
+			wantField:  "login",
+		},
+		{
+			name:       "спецификация",
+			method:     http.MethodGet,
+			target:     "/openapi.json",
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.beforeTest != nil {
+				tc.beforeTest()
+			}
+
+			req := httptest.NewRequest(tc.method, tc.target, strings.NewReader(tc.body))
+			if tc.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			rec := httptest.NewRecorder()
+
+			mux.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.wantStatus, rec.Code, rec.Body.String())
+			if tc.wantStatus == http.StatusBadRequest {
+				var resp ErrorResponse
+				require.Nil(t, json.NewDecoder(rec.Body).Decode(&resp))
+				require.Equal(t, "validation", resp.Code)
+				if tc.wantField != "" {
+					require.Len(t, resp.Fields, 1)
+					require.Equal(t, tc.wantField, resp.Fields[0].Field)
+				}
+			}
+		})
+	}
+}
+
+func TestOpenAPI_StrictResponses(t *testing.T) {
+	doc, err := LoadOpenAPI()
+	require.Nil(t, err)
+
+	// ответ без обязательного поля data.skill
+	invalid := func(w http.ResponseWriter, r *http.Request) {
+		successResponse(w, http.StatusOK, map[string]string{"name": "name"})
+	}
+
+	testCases := []struct {
+		name       string
+		strict     bool
+		target     string
+		wantStatus int
+	}{
+		{
+			name:       "строгий режим отклоняет ответ",
+			strict:     true,
+			target:     "/skills/" + uuid.NewString(),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "обычный режим пропускает ответ",
+			strict:     false,
+			target:     "/skills/" + uuid.NewString(),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "строгий режим отклоняет неописанный маршрут",
+			strict:     true,
+			target:     "/unknown",
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "обычный режим пропускает неописанный маршрут",
+			strict:     false,
+			target:     "/unknown",
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			validator, err := ValidateOpenAPI(doc, OpenAPIOptions{Strict: tc.strict})
+			require.Nil(t, err)
+
+			mux := chi.NewMux()
+			mux.Use(validator)
+			mux.Get("/skills/{id}", invalid)
+			mux.Get("/unknown", invalid)
+
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.target, nil))
+
+			require.Equal(t, tc.wantStatus, rec.Code, rec.Body.String())
+		})
+	}
+}
+
+func TestOpenAPI_BinaryResponses(t *testing.T) {
+	doc, err := LoadOpenAPI()
+	require.Nil(t, err)
+
+	validator, err := ValidateOpenAPI(doc, OpenAPIOptions{Strict: true})
+	require.Nil(t, err)
+
+	rec := httptest.NewRecorder()
+	chunk := []byte("%PDF-1.7")
+
+	mux := chi.NewMux()
+	mux.Use(validator)
+	mux.Get("/api/v1/financials/{id}/documents/{documentId}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Header().Set("Content-Disposition", `attachment; filename="report.pdf"`)
+		w.WriteHeader(http.StatusOK)
+		w.Write(chunk)
+		w.(http.Flusher).Flush()
+
+		// документ передаётся клиенту по мере записи, а не после проверки
+		require.Equal(t, chunk, rec.Body.Bytes())
+		require.True(t, rec.Flushed)
+		w.Write(chunk)
+	})
+
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/api/v1/financials/"+uuid.NewString()+"/documents/"+uuid.NewString(), nil))
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.Equal(t, "application/pdf", rec.Header().Get("Content-Type"))
+	require.Equal(t, append(chunk, chunk...), rec.Body.Bytes())
+}
@@ -0,0 +1,464 @@
+package web
+
+import (
+	"fmt"
+	"ppo/domain"
+	"ppo/internal/app"
+	"ppo/web/gql"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/cors"
+	"github.com/go-chi/jwtauth/v5"
+)
+
+const apiV1Prefix = "/api/v1"
+
+// NewRouter регистрирует все маршруты HTTP API.
+// Запросы и ответы проверяются по спецификации OpenAPI с параметрами opts.
+func NewRouter(a *app.App, tokenAuth *jwtauth.JWTAuth, opts OpenAPIOptions) (*chi.Mux, error) {
+	doc, err := LoadOpenAPI()
+	if err != nil {
+		return nil, err
+	}
+
+	validator, err := ValidateOpenAPI(doc, opts)
+	if err != nil {
+		return nil, fmt.Errorf("создание валидатора запросов: %w", err)
+	}
+
+	mux := chi.NewMux()
+
+	mux.Use(cors.Handler(cors.Options{
+		AllowedOrigins:   []string{"https://*", "http://*"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS", "PATCH"},
+		AllowedHeaders:   []string{"Accept", "Accept-Language", "Authorization", "Content-Type", "X-CSRF-Token"},
+		ExposedHeaders:   []string{"Link", "Location", "Content-Language", "Deprecation"},
+		AllowCredentials: false,
+		MaxAge:           300, // Maximum value not ignored by any of major browsers
+	}))
+
+	mux.Use(middleware.Logger)
+	mux.Use(Language)
+	if a.Config.Server.MaxBodyBytes > 0 {
+		mux.Use(LimitBody(a.Config.Server.MaxBodyBytes))
+	}
+	mux.Use(validator)
+
+	mux.Get("/openapi.json", OpenAPIHandler(doc))
+
+	mux.Route(apiV1Prefix, func(r chi.Router) {
+		r.Use(Versioned(apiV1Prefix))
+		apiV1Routes(r, a, tokenAuth)
+	})
+
+	// маршруты до появления /api/v1, оставлены на время перехода клиентов
+	mux.Group(func(r chi.Router) {
+		r.Use(Deprecated(apiV1Prefix))
+		legacyRoutes(r, a, tokenAuth)
+	})
+
+	return mux, nil
+}
+
+// apiV1Routes регистрирует ресурсные маршруты версии 1: коллекции принимают
+// POST, элементы — PATCH и DELETE, период отчётов передаётся в строке запроса.
+func apiV1Routes(r chi.Router, a *app.App, tokenAuth *jwtauth.JWTAuth) {
+	r.Route("/auth", func(r chi.Router) {
+		r.Post("/login", LoginHandler(a))
+		r.Post("/signup", RegisterHandler(a))
+	})
+
+	// токен необязателен: поля с ограничениями доступа проверяют его сами
+	r.With(jwtauth.Verifier(tokenAuth)).Post("/graphql", gql.NewHandler(a).ServeHTTP)
+
+	r.Route("/entrepreneurs", func(r chi.Router) {
+		r.Get("/", ListEntrepreneurs(a))
+		r.Get("/{id}", GetEntrepreneur(a))
+		r.Get("/{id}/rating", CalculateRating(a))
+		r.Get("/{id}/rating/history", GetRatingHistory(a))
+
+		r.Group(func(r chi.Router) {
+			r.Use(jwtauth.Verifier(tokenAuth))
+			r.Use(Authenticator)
+			r.Use(RequirePermission(a, domain.PermUsersManage))
+
+			r.Patch("/{id}", UpdateEntrepreneur(a))
+			r.Delete("/{id}", DeleteEntrepreneur(a))
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(jwtauth.Verifier(tokenAuth))
+			r.Use(Authenticator)
+			r.Use(RequirePermission(a, domain.PermRolesManage))
+
+			r.Put("/{id}/role", AssignRole(a))
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(jwtauth.Verifier(tokenAuth))
+			r.Use(Authenticator)
+			r.Use(RequirePermission(a, domain.PermFinanceRead))
+
+			r.Get("/{id}/analytics", GetEntrepreneurAnalytics(a))
+		})
+	})
+
+	r.Route("/roles", func(r chi.Router) {
+		r.Use(jwtauth.Verifier(tokenAuth))
+		r.Use(Authenticator)
+		r.Use(RequirePermission(a, domain.PermRolesManage))
+
+		r.Get("/", ListRoles(a))
+		r.Post("/", CreateRole(a))
+		r.Get("/{name}", GetRole(a))
+		r.Patch("/{name}", UpdateRole(a))
+		r.Delete("/{name}", DeleteRole(a))
+	})
+
+	r.Route("/skills", func(r chi.Router) {
+		r.Get("/", ListSkills(a))
+		r.Get("/{id}", GetSkill(a))
+
+		r.Group(func(r chi.Router) {
+			r.Use(jwtauth.Verifier(tokenAuth))
+			r.Use(Authenticator)
+			r.Use(RequirePermission(a, domain.PermCatalogEdit))
+
+			r.Post("/", CreateSkill(a))
+			r.Patch("/{id}", UpdateSkill(a))
+			r.Delete("/{id}", DeleteSkill(a))
+		})
+	})
+
+	r.Route("/user-skills", func(r chi.Router) {
+		r.Get("/", ListEntrepreneurSkills(a))
+
+		r.Group(func(r chi.Router) {
+			r.Use(jwtauth.Verifier(tokenAuth))
+			r.Use(Authenticator)
+			r.Use(RequirePermission(a, domain.PermProfileEdit))
+
+			r.Post("/", CreateUserSkill(a))
+			r.Delete("/{id}", DeleteUserSkill(a))
+		})
+	})
+
+	r.Route("/contacts", func(r chi.Router) {
+		r.Use(jwtauth.Verifier(tokenAuth))
+		r.Use(Authenticator)
+
+		r.Group(func(r chi.Router) {
+			r.Use(RequirePermission(a, domain.PermContactsRead))
+
+			r.Get("/", ListEntrepreneurContacts(a))
+			r.Get("/{id}", GetContact(a))
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(RequirePermission(a, domain.PermContactsWrite))
+
+			r.Post("/", CreateContact(a))
+			r.Patch("/{id}", UpdateContact(a))
+			r.Delete("/{id}", DeleteContact(a))
+		})
+	})
+
+	r.Route("/activity-fields", func(r chi.Router) {
+		r.Get("/", ListActivityFields(a))
+		r.Get("/{id}", GetActivityField(a))
+
+		r.Group(func(r chi.Router) {
+			r.Use(jwtauth.Verifier(tokenAuth))
+			r.Use(Authenticator)
+			r.Use(RequirePermission(a, domain.PermCatalogEdit))
+
+			r.Post("/", CreateActivityField(a))
+			r.Patch("/{id}", UpdateActivityField(a))
+			r.Delete("/{id}", DeleteActivityField(a))
+		})
+	})
+
+	r.Route("/companies", func(r chi.Router) {
+		r.Get("/", ListEntrepreneurCompanies(a))
+		r.Get("/{id}", GetCompany(a))
+
+		r.Group(func(r chi.Router) {
+			r.Use(jwtauth.Verifier(tokenAuth))
+			r.Use(Authenticator)
+			r.Use(RequirePermission(a, domain.PermCompaniesWrite))
+
+			r.Post("/", CreateCompany(a))
+			r.Patch("/{id}", UpdateCompany(a))
+			r.Delete("/{id}", DeleteCompany(a))
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(jwtauth.Verifier(tokenAuth))
+			r.Use(Authenticator)
+
+			r.With(RequirePermission(a, domain.PermFinanceRead)).
+				Get("/{id}/financials", ListCompanyReports(a))
+			r.With(RequirePermission(a, domain.PermFinanceRead)).
+				Get("/{id}/analytics", GetCompanyAnalytics(a))
+			r.With(RequirePermission(a, domain.PermFinanceWrite)).
+				Post("/{id}/financials", CreateReport(a))
+		})
+	})
+
+	r.Route("/financials", func(r chi.Router) {
+		r.Use(jwtauth.Verifier(tokenAuth))
+		r.Use(Authenticator)
+
+		r.Group(func(r chi.Router) {
+			r.Use(RequirePermission(a, domain.PermFinanceRead))
+
+			r.Get("/", GetEntrepreneurFinancials(a))
+			r.Get("/{id}", GetFinReport(a))
+			r.Get("/{id}/revisions", ListFinReportRevisions(a))
+			r.Get("/{id}/documents", ListFinReportDocuments(a))
+			r.Get("/{id}/documents/{documentId}", DownloadFinReportDocument(a))
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(RequirePermission(a, domain.PermFinanceWrite))
+
+			r.Patch("/{id}", UpdateFinReport(a))
+			r.Delete("/{id}", DeleteFinReport(a))
+			r.Post("/{id}/amendments", AmendFinReport(a))
+			r.Post("/{id}/documents", UploadFinReportDocument(a))
+			r.Delete("/{id}/documents/{documentId}", DeleteFinReportDocument(a))
+			r.Post("/{id}/submission", SubmitFinReport(a))
+		})
+
+		r.With(RequirePermission(a, domain.PermFinanceVerify)).
+			Post("/{id}/review", ReviewFinReport(a))
+	})
+
+	r.Route("/financial-reviews", func(r chi.Router) {
+		r.Use(jwtauth.Verifier(tokenAuth))
+		r.Use(Authenticator)
+		r.Use(RequirePermission(a, domain.PermFinanceVerify))
+
+		r.Get("/", ListFinReportsByStatus(a))
+	})
+
+	r.Route("/reviews", func(r chi.Router) {
+		r.Get("/", GetEntrepreneurReviews(a))
+		r.Get("/{id}", GetReview(a))
+
+		r.Group(func(r chi.Router) {
+			r.Use(jwtauth.Verifier(tokenAuth))
+			r.Use(Authenticator)
+
+			r.With(RequirePermission(a, domain.PermReviewsWrite)).
+				Get("/my", GetAuthorReviews(a))
+			r.With(RequirePermission(a, domain.PermReviewsWrite)).
+				Post("/", CreateReview(a))
+
+			// удалять отзыв может автор или модератор, проверка в PolicySvc
+			r.Delete("/{id}", DeleteReview(a))
+		})
+	})
+
+	r.Route("/webhooks", func(r chi.Router) {
+		r.Use(jwtauth.Verifier(tokenAuth))
+		r.Use(Authenticator)
+		r.Use(RequirePermission(a, domain.PermWebhooksManage))
+
+		r.Get("/", ListWebhooks(a))
+		r.Post("/", CreateWebhook(a))
+		r.Get("/{id}", GetWebhook(a))
+		r.Delete("/{id}", DeleteWebhook(a))
+		r.Get("/{id}/deliveries", ListWebhookDeliveries(a))
+	})
+
+	r.Route("/jobs", func(r chi.Router) {
+		r.Use(jwtauth.Verifier(tokenAuth))
+		r.Use(Authenticator)
+		r.Use(RequirePermission(a, domain.PermJobsManage))
+
+		r.Get("/", ListJobs(a))
+		r.Get("/{name}/runs", ListJobRuns(a))
+		r.Post("/{name}/runs", TriggerJob(a))
+	})
+
+	r.Route("/audit", func(r chi.Router) {
+		r.Use(jwtauth.Verifier(tokenAuth))
+		r.Use(Authenticator)
+		r.Use(RequirePermission(a, domain.PermAuditRead))
+
+		r.Get("/", ListAuditEntries(a))
+	})
+}
+
+// legacyRoutes регистрирует устаревшие маршруты с глаголами в пути.
+func legacyRoutes(r chi.Router, a *app.App, tokenAuth *jwtauth.JWTAuth) {
+	r.Route("/skills", func(r chi.Router) {
+		r.Get("/{id}", GetSkill(a))
+		// r.Get("/", ListEntrepreneurSkills(a))
+		r.Get("/", ListSkills(a))
+
+		r.Group(func(r chi.Router) {
+			r.Use(jwtauth.Verifier(tokenAuth))
+			r.Use(Authenticator)
+			r.Use(RequirePermission(a, domain.PermCatalogEdit))
+
+			r.Post("/create", CreateSkill(a))
+			r.Delete("/{id}/delete", DeleteSkill(a))
+			r.Patch("/{id}/update", UpdateSkill(a))
+		})
+	})
+
+	r.Route("/entrepreneurs", func(r chi.Router) {
+		r.Get("/{id}", GetEntrepreneur(a))
+		r.Get("/", ListEntrepreneurs(a))
+		r.Get("/{id}/rating", CalculateRating(a))
+
+		r.Group(func(r chi.Router) {
+			r.Use(jwtauth.Verifier(tokenAuth))
+			r.Use(Authenticator)
+			r.Use(RequirePermission(a, domain.PermUsersManage))
+
+			r.Patch("/{id}/update", UpdateEntrepreneur(a))
+			r.Delete("/{id}/delete", DeleteEntrepreneur(a))
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(jwtauth.Verifier(tokenAuth))
+			r.Use(Authenticator)
+			r.Use(RequirePermission(a, domain.PermRolesManage))
+
+			r.Patch("/{id}/role", AssignRole(a))
+		})
+	})
+
+	r.Route("/roles", func(r chi.Router) {
+		r.Use(jwtauth.Verifier(tokenAuth))
+		r.Use(Authenticator)
+		r.Use(RequirePermission(a, domain.PermRolesManage))
+
+		r.Get("/", ListRoles(a))
+		r.Post("/create", CreateRole(a))
+		r.Patch("/{name}/update", UpdateRole(a))
+		r.Delete("/{name}/delete", DeleteRole(a))
+	})
+
+	r.Route("/contacts", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(jwtauth.Verifier(tokenAuth))
+			r.Use(Authenticator)
+			r.Use(RequirePermission(a, domain.PermContactsRead))
+
+			r.Get("/{id}", GetContact(a))
+			r.Get("/", ListEntrepreneurContacts(a))
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(jwtauth.Verifier(tokenAuth))
+			r.Use(Authenticator)
+			r.Use(RequirePermission(a, domain.PermContactsWrite))
+
+			r.Post("/create", CreateContact(a))
+			r.Patch("/{id}/update", UpdateContact(a))
+			r.Delete("/{id}/delete", DeleteContact(a))
+		})
+	})
+
+	r.Route("/activity_fields", func(r chi.Router) {
+		r.Get("/{id}", GetActivityField(a))
+		r.Get("/", ListActivityFields(a))
+
+		r.Group(func(r chi.Router) {
+			r.Use(jwtauth.Verifier(tokenAuth))
+			r.Use(Authenticator)
+			r.Use(RequirePermission(a, domain.PermCatalogEdit))
+
+			r.Post("/create", CreateActivityField(a))
+			r.Patch("/{id}/update", UpdateActivityField(a))
+			r.Delete("/{id}/delete", DeleteActivityField(a))
+		})
+	})
+
+	r.Route("/companies", func(r chi.Router) {
+		r.Get("/{id}", GetCompany(a))
+		r.Get("/", ListEntrepreneurCompanies(a))
+
+		r.Group(func(r chi.Router) {
+			r.Use(jwtauth.Verifier(tokenAuth))
+			r.Use(Authenticator)
+			r.Use(RequirePermission(a, domain.PermCompaniesWrite))
+
+			r.Post("/create", CreateCompany(a))
+			r.Patch("/{id}/update", UpdateCompany(a))
+			r.Delete("/{id}/delete", DeleteCompany(a))
+		})
+
+		r.Route("/{id}/financials", func(r chi.Router) {
+			r.Use(jwtauth.Verifier(tokenAuth))
+			r.Use(Authenticator)
+
+			r.With(RequirePermission(a, domain.PermFinanceWrite)).
+				Post("/create", CreateReport(a))
+			r.With(RequirePermission(a, domain.PermFinanceRead)).
+				Get("/{year-start}_{quarter-start}-{year-end}_{quarter-end}", ListCompanyReports(a))
+		})
+	})
+
+	r.Route("/user-skills", func(r chi.Router) {
+		//r.Get("/{id}", GetUserSkill(a))
+		r.Get("/", ListEntrepreneurSkills(a))
+
+		r.Group(func(r chi.Router) {
+			r.Use(jwtauth.Verifier(tokenAuth))
+			r.Use(Authenticator)
+			r.Use(RequirePermission(a, domain.PermProfileEdit))
+
+			r.Post("/create", CreateUserSkill(a))
+			r.Delete("/{id}/delete", DeleteUserSkill(a))
+		})
+	})
+
+	r.Route("/financials", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(jwtauth.Verifier(tokenAuth))
+			r.Use(Authenticator)
+			r.Use(RequirePermission(a, domain.PermFinanceRead))
+
+			r.Get("/", GetEntrepreneurFinancials(a))
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(jwtauth.Verifier(tokenAuth))
+			r.Use(Authenticator)
+			r.Use(RequirePermission(a, domain.PermFinanceWrite))
+
+			r.Delete("/{id}/delete", DeleteFinReport(a))
+			r.Patch("/{id}/update", UpdateFinReport(a))
+		})
+	})
+
+	r.Route("/reviews", func(r chi.Router) {
+		r.Get("/", GetEntrepreneurReviews(a))
+
+		r.Group(func(r chi.Router) {
+			r.Use(jwtauth.Verifier(tokenAuth))
+			r.Use(Authenticator)
+			r.Use(RequirePermission(a, domain.PermReviewsWrite))
+
+			r.Get("/my", GetAuthorReviews(a))
+			r.Post("/create", CreateReview(a))
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(jwtauth.Verifier(tokenAuth))
+			r.Use(Authenticator)
+
+			// удалять отзыв может автор или модератор, проверка в PolicySvc
+			r.Delete("/{id}/delete", DeleteReview(a))
+		})
+	})
+
+	r.Post("/login", LoginHandler(a))
+	r.Post("/signup", RegisterHandler(a))
+}
@@ -0,0 +1,1019 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"ppo/domain"
+	"ppo/internal/app"
+	"ppo/internal/services/auth"
+	"ppo/mocks"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRouter_APIv1(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	skillSvc := mocks.NewMockISkillService(ctrl)
+	finSvc := mocks.NewMockIFinancialReportService(ctrl)
+	roleSvc := mocks.NewMockIRoleService(ctrl)
+	webhookSvc := mocks.NewMockIWebhookService(ctrl)
+	ratingSvc := mocks.NewMockIRatingService(ctrl)
+	jobSvc := mocks.NewMockIJobService(ctrl)
+	auditSvc := mocks.NewMockIAuditService(ctrl)
+	policySvc := mocks.NewMockIPolicyService(ctrl)
+	a := &app.App{
+		SkillSvc:   skillSvc,
+		FinSvc:     finSvc,
+		RoleSvc:    roleSvc,
+		WebhookSvc: webhookSvc,
+		RatingSvc:  ratingSvc,
+		JobSvc:     jobSvc,
+		AuditSvc:   auditSvc,
+		PolicySvc:  policySvc,
+	}
+
+	actorId := uuid.New()
+	tokenAuth := jwtauth.New("HS256", []byte("secret"), nil)
+	_, token, err := tokenAuth.Encode(map[string]interface{}{"sub": actorId.String(), "role": "admin"})
+	require.Nil(t, err)
+
+	mux, err := NewRouter(a, tokenAuth, OpenAPIOptions{Strict: true})
+	require.Nil(t, err)
+
+	roleSvc.EXPECT().HasPermission(gomock.Any(), "admin", gomock.Any()).Return(true, nil).AnyTimes()
+
+	skillId := uuid.New()
+	companyId := uuid.New()
+	webhookId := uuid.New()
+	userId := uuid.New()
+	reportId := uuid.New()
+	documentId := uuid.New()
+	filedAt := time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		method         string
+		target         string
+		body           string
+		contentType    string
+		anonymous      bool
+		beforeTest     func()
+		wantStatus     int
+		wantLocation   string
+		wantDeprecated bool
+	}{
+		{
+			name:   "создание ресурса возвращает 201 и Location",
+			method: http.MethodPost,
+			target: "/api/v1/skills",
+			body:   `{"name": "name", "description": "description"}`,
+			beforeTest: func() {
+				skillSvc.EXPECT().
+					Create(gomock.Any(), &domain.Skill{Name: "name", Description: "description"}).
+					DoAndReturn(func(_ context.Context, skill *domain.Skill) error {
+						skill.ID = skillId
+						return nil
+					})
+			},
+			wantStatus:   http.StatusCreated,
+			wantLocation: "/api/v1/skills/" + skillId.String(),
+		},
+		{
+			name:   "удаление ресурса возвращает 204",
+			method: http.MethodDelete,
+			target: "/api/v1/skills/" + skillId.String(),
+			beforeTest: func() {
+				skillSvc.EXPECT().GetById(gomock.Any(), skillId).Return(&domain.Skill{ID: skillId}, nil)
+				skillSvc.EXPECT().DeleteById(gomock.Any(), skillId).Return(nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:   "период отчётов в строке запроса",
+			method: http.MethodGet,
+			target: "/api/v1/companies/" + companyId.String() + "/financials?year-start=2023&quarter-start=1&year-end=2023&quarter-end=4",
+			beforeTest: func() {
+				period := &domain.Period{StartYear: 2023, StartQuarter: 1, EndYear: 2023, EndQuarter: 4}
+				finSvc.EXPECT().
+					GetByCompany(gomock.Any(), companyId, period).
+					Return(&domain.FinancialReportByPeriod{Period: period}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "отчёты в версиях на дату",
+			method: http.MethodGet,
+			target: "/api/v1/companies/" + companyId.String() +
+				"/financials?year-start=2023&quarter-start=1&year-end=2023&quarter-end=4&as-of=2024-01-01T00:00:00Z",
+			beforeTest: func() {
+				period := &domain.Period{
+					StartYear: 2023, StartQuarter: 1, EndYear: 2023, EndQuarter: 4,
+					AsOf: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+				}
+				finSvc.EXPECT().
+					GetByCompany(gomock.Any(), companyId, period).
+					Return(&domain.FinancialReportByPeriod{Period: period}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "некорректная дата версий отчётов",
+			method: http.MethodGet,
+			target: "/api/v1/companies/" + companyId.String() +
+				"/financials?year-start=2023&quarter-start=1&year-end=2023&quarter-end=4&as-of=2024-01-01",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "исправление отчёта",
+			method: http.MethodPost,
+			target: "/api/v1/financials/" + reportId.String() + "/amendments",
+			body:   `{"revenue": 150, "costs": 40, "reason": "опечатка в выручке"}`,
+			beforeTest: func() {
+				policySvc.EXPECT().
+					CanManageFinReport(gomock.Any(), &domain.Actor{ID: actorId, Role: "admin"}, reportId).
+					Return(nil)
+				finSvc.EXPECT().
+					Amend(gomock.Any(), &domain.FinancialReportRevision{
+						ReportID: reportId,
+						Revenue:  150,
+						Costs:    40,
+						Reason:   "опечатка в выручке",
+					}).
+					DoAndReturn(func(_ context.Context, rev *domain.FinancialReportRevision) error {
+						rev.Version = 2
+						rev.AuthorID = actorId
+						rev.FiledAt = filedAt
+						rev.Current = true
+						return nil
+					})
+			},
+			wantStatus:   http.StatusCreated,
+			wantLocation: "/api/v1/financials/" + reportId.String() + "/revisions",
+		},
+		{
+			name:       "исправление отчёта без причины",
+			method:     http.MethodPost,
+			target:     "/api/v1/financials/" + reportId.String() + "/amendments",
+			body:       `{"revenue": 150, "costs": 40}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "история версий отчёта",
+			method: http.MethodGet,
+			target: "/api/v1/financials/" + reportId.String() + "/revisions",
+			beforeTest: func() {
+				finSvc.EXPECT().
+					GetRevisions(gomock.Any(), reportId).
+					Return([]*domain.FinancialReportRevision{
+						{ReportID: reportId, Version: 1, Revenue: 100, Costs: 40, FiledAt: filedAt.AddDate(0, -1, 0)},
+						{ReportID: reportId, Version: 2, Revenue: 150, Costs: 40, AuthorID: actorId,
+							Reason: "опечатка в выручке", FiledAt: filedAt, Current: true},
+					}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "период отчётов без обязательного параметра",
+			method:     http.MethodGet,
+			target:     "/api/v1/companies/" + companyId.String() + "/financials?year-start=2023&quarter-start=1",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "подписка на вебхук",
+			method: http.MethodPost,
+			target: "/api/v1/webhooks",
+			body:   `{"url": "https://example.com/hooks", "secret": "0123456789abcdef", "event_types": ["company.created"]}`,
+			beforeTest: func() {
+				webhookSvc.EXPECT().
+					Subscribe(gomock.Any(), &domain.WebhookSubscription{
+						URL:        "https://example.com/hooks",
+						Secret:     "0123456789abcdef",
+						EventTypes: []domain.EventType{domain.EventCompanyCreated},
+					}).
+					DoAndReturn(func(_ context.Context, sub *domain.WebhookSubscription) error {
+						sub.ID = webhookId
+						return nil
+					})
+			},
+			wantStatus:   http.StatusCreated,
+			wantLocation: "/api/v1/webhooks/" + webhookId.String(),
+		},
+		{
+			name:   "журнал доставок вебхука",
+			method: http.MethodGet,
+			target: "/api/v1/webhooks/" + webhookId.String() + "/deliveries?page=1",
+			beforeTest: func() {
+				webhookSvc.EXPECT().
+					GetDeliveries(gomock.Any(), webhookId, 1).
+					Return([]*domain.WebhookDelivery{{
+						ID:        uuid.New(),
+						EventID:   uuid.New(),
+						EventType: domain.EventCompanyCreated,
+						Payload:   []byte(`{"name": "company"}`),
+						Status:    domain.DeliveryPending,
+						Attempts:  1,
+					}}, 1, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "история рейтинга за всё время",
+			method: http.MethodGet,
+			target: "/api/v1/entrepreneurs/" + userId.String() + "/rating/history",
+			beforeTest: func() {
+				ratingSvc.EXPECT().
+					GetHistory(gomock.Any(), userId, nil).
+					Return([]*domain.RatingSnapshot{
+						{UserID: userId, Year: 2023, Quarter: 4, Rating: 0.4},
+						{UserID: userId, Year: 2024, Quarter: 1, Rating: 0.5, CompanyID: companyId},
+					}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "история рейтинга за период",
+			method: http.MethodGet,
+			target: "/api/v1/entrepreneurs/" + userId.String() + "/rating/history?year-start=2023&quarter-start=1&year-end=2023&quarter-end=4",
+			beforeTest: func() {
+				ratingSvc.EXPECT().
+					GetHistory(gomock.Any(), userId, &domain.Period{StartYear: 2023, StartQuarter: 1, EndYear: 2023, EndQuarter: 4}).
+					Return([]*domain.RatingSnapshot{}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "список фоновых задач",
+			method: http.MethodGet,
+			target: "/api/v1/jobs",
+			beforeTest: func() {
+				jobSvc.EXPECT().
+					GetJobs(gomock.Any()).
+					Return([]*domain.Job{
+						{Name: "cleanup", Schedule: "30 4 * * *", NextRunAt: time.Now()},
+						{
+							Name:      "rating-recompute",
+							Schedule:  "0 3 * * *",
+							NextRunAt: time.Now(),
+							LastRun: &domain.JobRun{
+								ID:         uuid.New(),
+								Job:        "rating-recompute",
+								Trigger:    domain.JobTriggerSchedule,
+								Status:     domain.JobSucceeded,
+								StartedAt:  time.Now(),
+								FinishedAt: time.Now(),
+							},
+						},
+					}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "запуск задачи вне расписания",
+			method: http.MethodPost,
+			target: "/api/v1/jobs/cleanup/runs",
+			beforeTest: func() {
+				jobSvc.EXPECT().
+					Trigger(gomock.Any(), "cleanup").
+					Return(&domain.JobRun{
+						ID:        uuid.New(),
+						Job:       "cleanup",
+						Trigger:   domain.JobTriggerManual,
+						Status:    domain.JobRunning,
+						StartedAt: time.Now(),
+					}, nil)
+			},
+			wantStatus: http.StatusAccepted,
+		},
+		{
+			name:   "запуск уже выполняющейся задачи",
+			method: http.MethodPost,
+			target: "/api/v1/jobs/cleanup/runs",
+			beforeTest: func() {
+				jobSvc.EXPECT().
+					Trigger(gomock.Any(), "cleanup").
+					Return(nil, domain.NewConflictError(errors.New("задача уже выполняется")))
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:   "изменение выполняется от имени пользователя из токена",
+			method: http.MethodDelete,
+			target: "/api/v1/skills/" + skillId.String(),
+			beforeTest: func() {
+				skillSvc.EXPECT().GetById(gomock.Any(), skillId).Return(&domain.Skill{ID: skillId}, nil)
+				skillSvc.EXPECT().
+					DeleteById(gomock.Any(), skillId).
+					DoAndReturn(func(ctx context.Context, _ uuid.UUID) error {
+						require.Equal(t, &domain.Actor{ID: actorId, Role: "admin"}, domain.ActorFromContext(ctx))
+						return nil
+					})
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:   "журнал аудита с фильтрами",
+			method: http.MethodGet,
+			target: "/api/v1/audit?entity_type=fin_report&actor_id=" + actorId.String() +
+				"&from=2024-01-01T00:00:00Z&to=2024-04-01T00:00:00Z&page=1",
+			beforeTest: func() {
+				auditSvc.EXPECT().
+					Find(gomock.Any(), &domain.AuditFilter{
+						EntityType: domain.AuditEntityFinReport,
+						ActorID:    actorId,
+						From:       time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+						To:         time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC),
+					}, 1).
+					Return([]*domain.AuditEntry{
+						{
+							ID:         uuid.New(),
+							ActorID:    actorId,
+							ActorRole:  "admin",
+							Action:     domain.AuditUpdate,
+							EntityType: domain.AuditEntityFinReport,
+							EntityID:   uuid.NewString(),
+							Before:     []byte(`{"revenue": 100}`),
+							After:      []byte(`{"revenue": 200}`),
+							CreatedAt:  time.Now(),
+						},
+						{
+							ID:         uuid.New(),
+							Action:     domain.AuditDelete,
+							EntityType: domain.AuditEntityFinReport,
+							EntityID:   uuid.NewString(),
+							Before:     []byte(`{"revenue": 100}`),
+							CreatedAt:  time.Now(),
+						},
+					}, 1, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "журнал аудита с некорректным временем",
+			method:     http.MethodGet,
+			target:     "/api/v1/audit?from=2024-01-01&page=1",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "загрузка документа отчёта",
+			method:      http.MethodPost,
+			target:      "/api/v1/financials/" + reportId.String() + "/documents?name=act.pdf",
+			body:        "%PDF-1.4",
+			contentType: "application/pdf",
+			beforeTest: func() {
+				policySvc.EXPECT().CanManageFinReport(gomock.Any(), gomock.Any(), reportId).Return(nil)
+				finSvc.EXPECT().
+					AttachDocument(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, doc *domain.FinancialReportDocument, _ io.Reader) error {
+						require.Equal(t, "application/pdf", doc.ContentType)
+						require.Equal(t, "act.pdf", doc.Name)
+						doc.ID = documentId
+						return nil
+					})
+			},
+			wantStatus:   http.StatusCreated,
+			wantLocation: "/api/v1/financials/" + reportId.String() + "/documents/" + documentId.String(),
+		},
+		{
+			name:        "документ неподдерживаемого типа",
+			method:      http.MethodPost,
+			target:      "/api/v1/financials/" + reportId.String() + "/documents?name=act.txt",
+			body:        "text",
+			contentType: "text/plain",
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:   "скачивание документа отчёта",
+			method: http.MethodGet,
+			target: "/api/v1/financials/" + reportId.String() + "/documents/" + documentId.String(),
+			beforeTest: func() {
+				policySvc.EXPECT().CanReadFinDocuments(gomock.Any(), gomock.Any(), reportId).Return(nil)
+				finSvc.EXPECT().
+					OpenDocument(gomock.Any(), documentId).
+					Return(&domain.FinancialReportDocument{
+						ID: documentId, ReportID: reportId, Name: "act.pdf", ContentType: "application/pdf", Size: 8,
+					}, io.NopCloser(strings.NewReader("%PDF-1.4")), nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "документ другого отчёта",
+			method: http.MethodGet,
+			target: "/api/v1/financials/" + reportId.String() + "/documents/" + documentId.String(),
+			beforeTest: func() {
+				policySvc.EXPECT().CanReadFinDocuments(gomock.Any(), gomock.Any(), reportId).Return(nil)
+				finSvc.EXPECT().
+					OpenDocument(gomock.Any(), documentId).
+					Return(&domain.FinancialReportDocument{
+						ID: documentId, ReportID: uuid.New(), Name: "act.pdf", ContentType: "application/pdf", Size: 8,
+					}, io.NopCloser(strings.NewReader("%PDF-1.4")), nil)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:   "список документов отчёта",
+			method: http.MethodGet,
+			target: "/api/v1/financials/" + reportId.String() + "/documents",
+			beforeTest: func() {
+				policySvc.EXPECT().CanReadFinDocuments(gomock.Any(), gomock.Any(), reportId).Return(nil)
+				finSvc.EXPECT().
+					GetDocuments(gomock.Any(), reportId).
+					Return([]*domain.FinancialReportDocument{{
+						ID: documentId, ReportID: reportId, Name: "act.pdf", ContentType: "application/pdf",
+						Size: 8, UploadedBy: actorId, UploadedAt: filedAt,
+					}}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "удаление документа отчёта",
+			method: http.MethodDelete,
+			target: "/api/v1/financials/" + reportId.String() + "/documents/" + documentId.String(),
+			beforeTest: func() {
+				policySvc.EXPECT().CanManageFinReport(gomock.Any(), gomock.Any(), reportId).Return(nil)
+				finSvc.EXPECT().
+					GetDocuments(gomock.Any(), reportId).
+					Return([]*domain.FinancialReportDocument{{ID: documentId, ReportID: reportId}}, nil)
+				finSvc.EXPECT().DeleteDocument(gomock.Any(), documentId).Return(nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:   "отправка отчёта на проверку",
+			method: http.MethodPost,
+			target: "/api/v1/financials/" + reportId.String() + "/submission",
+			beforeTest: func() {
+				policySvc.EXPECT().CanManageFinReport(gomock.Any(), gomock.Any(), reportId).Return(nil)
+				finSvc.EXPECT().Submit(gomock.Any(), reportId).Return(nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:   "отклонение отчёта",
+			method: http.MethodPost,
+			target: "/api/v1/financials/" + reportId.String() + "/review",
+			body:   `{"status": "rejected", "comment": "нет подписи"}`,
+			beforeTest: func() {
+				finSvc.EXPECT().Review(gomock.Any(), reportId, domain.VerificationRejected, "нет подписи").Return(nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "доля экспорта вне диапазона",
+			method:     http.MethodPost,
+			target:     "/api/v1/companies/" + companyId.String() + "/financials",
+			body:       `{"revenue": 1, "costs": 1, "year": 2023, "quarter": 1, "exports_share": 150}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "неизвестное решение проверки",
+			method:     http.MethodPost,
+			target:     "/api/v1/financials/" + reportId.String() + "/review",
+			body:       `{"status": "submitted"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "очередь проверки отчётов",
+			method: http.MethodGet,
+			target: "/api/v1/financial-reviews?page=1&status=verified",
+			beforeTest: func() {
+				finSvc.EXPECT().
+					GetByStatus(gomock.Any(), domain.VerificationVerified, 1).
+					Return([]*domain.FinancialReport{{
+						ID: reportId, CompanyID: companyId, Revenue: 1, Year: 2024, Quarter: 1,
+						Status: domain.VerificationVerified, ReviewerID: actorId, ReviewedAt: filedAt,
+					}}, 1, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "без токена",
+			method:     http.MethodGet,
+			target:     "/api/v1/reviews/my?page=1",
+			anonymous:  true,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:   "устаревший маршрут работает и помечен заголовком",
+			method: http.MethodPost,
+			target: "/skills/create",
+			body:   `{"name": "name", "description": "description"}`,
+			beforeTest: func() {
+				skillSvc.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantStatus:     http.StatusOK,
+			wantDeprecated: true,
+		},
+		{
+			name:   "устаревший маршрут с периодом в пути",
+			method: http.MethodGet,
+			target: "/companies/" + companyId.String() + "/financials/2023_1-2023_4",
+			beforeTest: func() {
+				period := &domain.Period{StartYear: 2023, StartQuarter: 1, EndYear: 2023, EndQuarter: 4}
+				finSvc.EXPECT().
+					GetByCompany(gomock.Any(), companyId, period).
+					Return(&domain.FinancialReportByPeriod{Period: period}, nil)
+			},
+			wantStatus:     http.StatusOK,
+			wantDeprecated: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.beforeTest != nil {
+				tc.beforeTest()
+			}
+
+			req := httptest.NewRequest(tc.method, tc.target, strings.NewReader(tc.body))
+			if !tc.anonymous {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+			if tc.contentType != "" {
+				req.Header.Set("Content-Type", tc.contentType)
+			} else if tc.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			rec := httptest.NewRecorder()
+
+			mux.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.wantStatus, rec.Code, rec.Body.String())
+			require.Equal(t, tc.wantLocation, rec.Header().Get("Location"))
+			if tc.wantDeprecated {
+				require.Equal(t, "true", rec.Header().Get("Deprecation"))
+				require.Contains(t, rec.Header().Get("Link"), "</api/v1>")
+			} else {
+				require.Empty(t, rec.Header().Get("Deprecation"))
+			}
+		})
+	}
+}
+
+func TestRouter_FinancialRatios(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	finSvc := mocks.NewMockIFinancialReportService(ctrl)
+	roleSvc := mocks.NewMockIRoleService(ctrl)
+	a := &app.App{FinSvc: finSvc, RoleSvc: roleSvc}
+
+	tokenAuth := jwtauth.New("HS256", []byte("secret"), nil)
+	_, token, err := tokenAuth.Encode(map[string]interface{}{"sub": uuid.NewString(), "role": "admin"})
+	require.Nil(t, err)
+
+	mux, err := NewRouter(a, tokenAuth, OpenAPIOptions{Strict: true})
+	require.Nil(t, err)
+
+	roleSvc.EXPECT().HasPermission(gomock.Any(), "admin", gomock.Any()).Return(true, nil).AnyTimes()
+
+	companyId := uuid.New()
+	period := &domain.Period{StartYear: 2023, StartQuarter: 1, EndYear: 2023, EndQuarter: 2}
+	netProfit, equity, liabilities, headcount := float32(30), float32(200), float32(100), 4
+	finSvc.EXPECT().
+		GetByCompany(gomock.Any(), companyId, period).
+		Return(&domain.FinancialReportByPeriod{
+			Period: period,
+			Reports: []domain.FinancialReport{
+				{CompanyID: companyId, Year: 2023, Quarter: 1, Revenue: 100, Costs: 80},
+				{CompanyID: companyId, Year: 2023, Quarter: 2, Revenue: 100, Costs: 60, NetProfit: &netProfit,
+					Equity: &equity, Liabilities: &liabilities, Headcount: &headcount},
+			},
+		}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/companies/"+companyId.String()+
+		"/financials?year-start=2023&quarter-start=1&year-end=2023&quarter-end=2", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+
+	mux.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var resp struct {
+		Data struct {
+			NetProfit float32         `json:"net_profit"`
+			Ratios    FinancialRatios `json:"ratios"`
+		} `json:"data"`
+	}
+	require.Nil(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	// чистая прибыль первого квартала не указана и считается как выручка минус расходы
+	require.Equal(t, float32(50), resp.Data.NetProfit)
+	require.InDelta(t, 0.25, *resp.Data.Ratios.Margin, 1e-6)
+	require.InDelta(t, 0.25, *resp.Data.Ratios.ROE, 1e-6)
+	require.InDelta(t, 0.5, *resp.Data.Ratios.DebtToEquity, 1e-6)
+	require.InDelta(t, 50, *resp.Data.Ratios.RevenuePerEmployee, 1e-6)
+}
+
+func TestRouter_CompanyAnalytics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	finSvc := mocks.NewMockIFinancialReportService(ctrl)
+	roleSvc := mocks.NewMockIRoleService(ctrl)
+	a := &app.App{FinSvc: finSvc, RoleSvc: roleSvc}
+
+	tokenAuth := jwtauth.New("HS256", []byte("secret"), nil)
+	_, token, err := tokenAuth.Encode(map[string]interface{}{"sub": uuid.NewString(), "role": "admin"})
+	require.Nil(t, err)
+
+	mux, err := NewRouter(a, tokenAuth, OpenAPIOptions{Strict: true})
+	require.Nil(t, err)
+
+	roleSvc.EXPECT().HasPermission(gomock.Any(), "admin", gomock.Any()).Return(true, nil).AnyTimes()
+
+	companyId := uuid.New()
+	period := &domain.Period{StartYear: 2022, StartQuarter: 1, EndYear: 2023, EndQuarter: 2}
+	finSvc.EXPECT().
+		GetByCompany(gomock.Any(), companyId, period).
+		Return(&domain.FinancialReportByPeriod{
+			Period: period,
+			Reports: []domain.FinancialReport{
+				{CompanyID: companyId, Year: 2022, Quarter: 1, Revenue: 100, Costs: 80},
+				{CompanyID: companyId, Year: 2022, Quarter: 2, Revenue: 120, Costs: 90},
+				{CompanyID: companyId, Year: 2023, Quarter: 1, Revenue: 110, Costs: 100},
+				{CompanyID: companyId, Year: 2023, Quarter: 2, Revenue: 150, Costs: 100},
+			},
+		}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/companies/"+companyId.String()+
+		"/analytics?year-start=2022&quarter-start=1&year-end=2023&quarter-end=2", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+
+	mux.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var resp struct {
+		Data struct {
+			Analytics FinancialAnalytics `json:"analytics"`
+		} `json:"data"`
+	}
+	require.Nil(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	analytics := resp.Data.Analytics
+	require.Len(t, analytics.Quarters, 4)
+
+	// первый квартал периода сравнивать не с чем
+	require.Nil(t, analytics.Quarters[0].QoQ.Revenue)
+	require.Nil(t, analytics.Quarters[0].YoY.Revenue)
+	require.InDelta(t, 0.2, *analytics.Quarters[1].QoQ.Revenue, 1e-6)
+	require.InDelta(t, 0.5, *analytics.Quarters[1].QoQ.Profit, 1e-6)
+	// отчётов за четвёртый квартал 2022 года нет
+	require.Nil(t, analytics.Quarters[2].QoQ.Profit)
+	require.InDelta(t, -0.5, *analytics.Quarters[2].YoY.Profit, 1e-6)
+	require.InDelta(t, 0, *analytics.Quarters[3].QoQ.Costs, 1e-6)
+	require.InDelta(t, 0.25, *analytics.Quarters[3].YoY.Revenue, 1e-6)
+
+	// от первого квартала 2022 года до второго квартала 2023 года — 1,25 года
+	require.InDelta(t, math.Pow(1.5, 0.8)-1, *analytics.CAGR.Revenue, 1e-5)
+	require.InDelta(t, math.Pow(2.5, 0.8)-1, *analytics.CAGR.Profit, 1e-5)
+	require.InDelta(t, (40.0/110-0.2)/2, *analytics.Volatility.Revenue, 1e-6)
+
+	require.Equal(t, &QuarterFigures{Year: 2023, Quarter: 2, Revenue: 150, Costs: 100, Profit: 50}, analytics.Best)
+	require.Equal(t, &QuarterFigures{Year: 2023, Quarter: 1, Revenue: 110, Costs: 100, Profit: 10}, analytics.Worst)
+}
+
+func TestRouter_EntrepreneurAnalytics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	interactor := mocks.NewMockIInteractor(ctrl)
+	roleSvc := mocks.NewMockIRoleService(ctrl)
+	userSvc := mocks.NewMockIUserService(ctrl)
+	a := &app.App{Interactor: interactor, RoleSvc: roleSvc, UserSvc: userSvc}
+
+	tokenAuth := jwtauth.New("HS256", []byte("secret"), nil)
+	_, token, err := tokenAuth.Encode(map[string]interface{}{"sub": uuid.NewString(), "role": "admin"})
+	require.Nil(t, err)
+
+	mux, err := NewRouter(a, tokenAuth, OpenAPIOptions{Strict: true})
+	require.Nil(t, err)
+
+	roleSvc.EXPECT().HasPermission(gomock.Any(), "admin", gomock.Any()).Return(true, nil).AnyTimes()
+
+	userId := uuid.New()
+	period := &domain.Period{StartYear: 2023, StartQuarter: 1, EndYear: 2023, EndQuarter: 2}
+	userSvc.EXPECT().GetById(gomock.Any(), userId).Return(&domain.User{ID: userId}, nil)
+	interactor.EXPECT().
+		GetUserFinancialReport(gomock.Any(), userId, period).
+		Return(&domain.FinancialReportByPeriod{
+			Period: period,
+			Reports: []domain.FinancialReport{
+				{CompanyID: uuid.New(), Year: 2023, Quarter: 1, Revenue: 100, Costs: 150},
+				{CompanyID: uuid.New(), Year: 2023, Quarter: 1, Revenue: 50, Costs: 20},
+				{CompanyID: uuid.New(), Year: 2023, Quarter: 2, Revenue: 100, Costs: 110},
+			},
+		}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/entrepreneurs/"+userId.String()+
+		"/analytics?year-start=2023&quarter-start=1&year-end=2023&quarter-end=2", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+
+	mux.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var resp struct {
+		Data struct {
+			Analytics FinancialAnalytics `json:"analytics"`
+		} `json:"data"`
+	}
+	require.Nil(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	analytics := resp.Data.Analytics
+
+	// отчёты компаний за один квартал суммируются
+	require.Len(t, analytics.Quarters, 2)
+	require.Equal(t, float32(-20), analytics.Quarters[0].Profit)
+	require.InDelta(t, -1.0/3, *analytics.Quarters[1].QoQ.Revenue, 1e-6)
+	// сокращение убытка — положительный рост
+	require.InDelta(t, 0.5, *analytics.Quarters[1].QoQ.Profit, 1e-6)
+	require.Nil(t, analytics.Quarters[1].YoY.Revenue)
+
+	// по убыточному периоду среднегодовой темп роста прибыли не определён
+	require.Nil(t, analytics.CAGR.Profit)
+	require.NotNil(t, analytics.CAGR.Revenue)
+	// один квартальный темп роста — волатильность не определена
+	require.Nil(t, analytics.Volatility.Revenue)
+
+	require.Equal(t, 2, analytics.Best.Quarter)
+	require.Equal(t, 1, analytics.Worst.Quarter)
+
+	// аналитика неизвестного предпринимателя не считается
+	unknownId := uuid.New()
+	userSvc.EXPECT().GetById(gomock.Any(), unknownId).Return(nil, domain.ErrNotFound)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/entrepreneurs/"+unknownId.String()+
+		"/analytics?year-start=2023&quarter-start=1&year-end=2023&quarter-end=2", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec = httptest.NewRecorder()
+
+	mux.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusNotFound, rec.Code, rec.Body.String())
+}
+
+func TestRouter_ETag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	skillSvc := mocks.NewMockISkillService(ctrl)
+	a := &app.App{SkillSvc: skillSvc}
+
+	mux, err := NewRouter(a, jwtauth.New("HS256", []byte("secret"), nil), OpenAPIOptions{Strict: true})
+	require.Nil(t, err)
+
+	skills := []*domain.Skill{{ID: uuid.New(), Name: "name", Description: "description"}}
+	skillSvc.EXPECT().GetAll(gomock.Any(), 1).Return(skills, 1, nil).Times(3)
+
+	get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/skills?page=1", nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	rec := get("")
+	require.Equal(t, http.StatusOK, rec.Code)
+	etag := rec.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	rec = get(`"stale", ` + etag)
+	require.Equal(t, http.StatusNotModified, rec.Code)
+	require.Empty(t, rec.Body.String())
+	require.Equal(t, etag, rec.Header().Get("ETag"))
+
+	rec = get(`"stale"`)
+	require.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestRouter_BodyLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	roleSvc := mocks.NewMockIRoleService(ctrl)
+	a := &app.App{RoleSvc: roleSvc}
+	a.Config.Server.MaxBodyBytes = 32
+
+	tokenAuth := jwtauth.New("HS256", []byte("secret"), nil)
+	_, token, err := tokenAuth.Encode(map[string]interface{}{"sub": uuid.NewString(), "role": "admin"})
+	require.Nil(t, err)
+
+	mux, err := NewRouter(a, tokenAuth, OpenAPIOptions{Strict: true})
+	require.Nil(t, err)
+
+	roleSvc.EXPECT().HasPermission(gomock.Any(), "admin", gomock.Any()).Return(true, nil).AnyTimes()
+
+	body := `{"name": "name", "description": "` + strings.Repeat("a", 64) + `"}`
+
+	testCases := []struct {
+		name          string
+		contentLength int64
+	}{
+		{
+			name:          "размер тела известен из заголовка",
+			contentLength: int64(len(body)),
+		},
+		{
+			name:          "размер тела неизвестен",
+			contentLength: -1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/skills", strings.NewReader(body))
+			req.ContentLength = tc.contentLength
+			req.Header.Set("Authorization", "Bearer "+token)
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			mux.ServeHTTP(rec, req)
+
+			require.Equal(t, http.StatusRequestEntityTooLarge, rec.Code, rec.Body.String())
+			require.Contains(t, rec.Body.String(), `"code":"too_large"`)
+		})
+	}
+}
+
+func TestRouter_RequirePermission(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	roleSvc := mocks.NewMockIRoleService(ctrl)
+	a := &app.App{RoleSvc: roleSvc}
+
+	tokenAuth := jwtauth.New("HS256", []byte("secret"), nil)
+
+	mux, err := NewRouter(a, tokenAuth, OpenAPIOptions{Strict: true})
+	require.Nil(t, err)
+
+	testCases := []struct {
+		name       string
+		claims     map[string]interface{}
+		beforeTest func()
+		wantStatus int
+		wantCode   string
+	}{
+		{
+			name:       "в токене нет роли",
+			claims:     map[string]interface{}{"sub": uuid.NewString()},
+			wantStatus: http.StatusForbidden,
+			wantCode:   "forbidden",
+		},
+		{
+			name:   "роль удалена после выдачи токена",
+			claims: map[string]interface{}{"sub": uuid.NewString(), "role": "removed"},
+			beforeTest: func() {
+				roleSvc.EXPECT().
+					HasPermission(gomock.Any(), "removed", domain.PermRolesManage).
+					Return(false, fmt.Errorf("проверка разрешения: %w", domain.ErrNotFound))
+			},
+			wantStatus: http.StatusForbidden,
+			wantCode:   "forbidden",
+		},
+		{
+			name:   "ошибка проверки разрешения",
+			claims: map[string]interface{}{"sub": uuid.NewString(), "role": "admin"},
+			beforeTest: func() {
+				roleSvc.EXPECT().
+					HasPermission(gomock.Any(), "admin", domain.PermRolesManage).
+					Return(false, errors.New("db"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.beforeTest != nil {
+				tc.beforeTest()
+			}
+
+			_, token, err := tokenAuth.Encode(tc.claims)
+			require.Nil(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/roles", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			rec := httptest.NewRecorder()
+
+			mux.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.wantStatus, rec.Code, rec.Body.String())
+			require.Contains(t, rec.Body.String(), `"message":`)
+			if tc.wantCode != "" {
+				require.Contains(t, rec.Body.String(), `"code":"`+tc.wantCode+`"`)
+			}
+		})
+	}
+}
+
+func TestRouter_Login(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	authRepo := mocks.NewMockIAuthRepository(ctrl)
+	crypto := mocks.NewMockIHashCrypto(ctrl)
+	a := &app.App{AuthSvc: auth.NewService(authRepo, crypto, "secret", time.Hour)}
+
+	mux, err := NewRouter(a, jwtauth.New("HS256", []byte("secret"), nil), OpenAPIOptions{Strict: true})
+	require.Nil(t, err)
+
+	testCases := []struct {
+		name       string
+		beforeTest func()
+	}{
+		{
+			name: "неизвестный пользователь",
+			beforeTest: func() {
+				authRepo.EXPECT().
+					GetByUsername(gomock.Any(), "user").
+					Return(nil, fmt.Errorf("получение пользователя: %w", domain.ErrNotFound))
+			},
+		},
+		{
+			name: "неверный пароль",
+			beforeTest: func() {
+				authRepo.EXPECT().
+					GetByUsername(gomock.Any(), "user").
+					Return(&domain.UserAuth{Username: "user", HashedPass: "hash"}, nil)
+				crypto.EXPECT().CheckPasswordHash("pass", "hash").Return(false)
+			},
+		},
+	}
+
+	// ответы не должны различаться, иначе по ним можно проверить, существует ли пользователь
+	bodies := make([]string, 0, len(testCases))
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.beforeTest()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login",
+				strings.NewReader(`{"login": "user", "password": "pass"}`))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			mux.ServeHTTP(rec, req)
+
+			require.Equal(t, http.StatusUnauthorized, rec.Code, rec.Body.String())
+			require.Contains(t, rec.Body.String(), `"code":"unauthorized"`)
+			bodies = append(bodies, rec.Body.String())
+		})
+	}
+	require.Equal(t, bodies[0], bodies[1])
+}
+
+func TestHandlers_InvalidPathUUID(t *testing.T) {
+	tokenAuth := jwtauth.New("HS256", []byte("secret"), nil)
+	_, token, err := tokenAuth.Encode(map[string]interface{}{"sub": uuid.NewString(), "role": "admin"})
+	require.Nil(t, err)
+
+	// без проверки по спецификации, чтобы запрос дошёл до обработчика
+	a := &app.App{}
+	mux := chi.NewRouter()
+	mux.Use(jwtauth.Verifier(tokenAuth))
+	mux.Post("/companies/{id}/financials", CreateReport(a))
+	mux.Delete("/financials/{id}", DeleteFinReport(a))
+	mux.Patch("/financials/{id}", UpdateFinReport(a))
+	mux.Delete("/contacts/{id}", DeleteContact(a))
+
+	testCases := []struct {
+		name   string
+		method string
+		target string
+	}{
+		{name: "добавление отчёта", method: http.MethodPost, target: "/companies/abc/financials"},
+		{name: "удаление отчёта", method: http.MethodDelete, target: "/financials/abc"},
+		{name: "изменение отчёта", method: http.MethodPatch, target: "/financials/abc"},
+		{name: "удаление средства связи", method: http.MethodDelete, target: "/contacts/abc"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.target, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			rec := httptest.NewRecorder()
+
+			mux.ServeHTTP(rec, req)
+
+			require.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+			require.Contains(t, rec.Body.String(), `"code":"validation"`)
+			require.Contains(t, rec.Body.String(), `"field":"id"`)
+		})
+	}
+}
@@ -2,14 +2,20 @@ package web
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/jwtauth/v5"
 	"github.com/google/uuid"
 	"net/http"
 	"ppo/domain"
+	"ppo/pkg/i18n"
 	"strconv"
+	"strings"
+	"time"
 )
 
 const (
@@ -20,8 +26,16 @@ const (
 const eps = 1e-6
 
 type ErrorResponse struct {
-	Status string `json:"status"`
-	Error  string `json:"error"`
+	Status  string       `json:"status"`
+	Error   string       `json:"error"`
+	Code    string       `json:"code,omitempty"`
+	Message string       `json:"message,omitempty"`
+	Fields  []FieldError `json:"fields,omitempty"`
+}
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
 }
 
 type SuccessResponse struct {
@@ -29,10 +43,51 @@ type SuccessResponse struct {
 	Data   interface{} `json:"data,omitempty"`
 }
 
-func errorResponse(w http.ResponseWriter, err string, statusCode int) {
+// handleError выбирает код ответа по категории ошибки домена.
+// Ошибки без категории отдаются с кодом fallbackStatus.
+// Поле message переводится на язык из Accept-Language.
+func handleError(w http.ResponseWriter, r *http.Request, err error, fallbackStatus int) {
+	lang := i18n.FromContext(r.Context())
+	resp := ErrorResponse{Status: errorMsg, Error: err.Error(), Message: domain.LocalizeError(lang, err)}
+	statusCode := fallbackStatus
+
+	var validationErr *domain.ValidationError
+	var tooLargeErr *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLargeErr):
+		statusCode = http.StatusRequestEntityTooLarge
+		resp.Code = "too_large"
+		resp.Message = i18n.T(lang, i18n.MsgRequestTooLarge, tooLargeErr.Limit)
+	case errors.As(err, &validationErr):
+		statusCode = http.StatusBadRequest
+		resp.Code = "validation"
+		if validationErr.Field != "" {
+			resp.Fields = []FieldError{{Field: validationErr.Field, Message: validationErr.Localize(lang)}}
+		}
+	case errors.Is(err, domain.ErrNotFound):
+		statusCode = http.StatusNotFound
+		resp.Code = "not_found"
+	case errors.Is(err, domain.ErrConflict):
+		statusCode = http.StatusConflict
+		resp.Code = "conflict"
+	case errors.Is(err, domain.ErrForbidden):
+		statusCode = http.StatusForbidden
+		resp.Code = "forbidden"
+	case errors.Is(err, domain.ErrInvalidCredentials):
+		statusCode = http.StatusUnauthorized
+		resp.Code = "unauthorized"
+	case statusCode == http.StatusUnauthorized:
+		resp.Code = "unauthorized"
+		resp.Message = i18n.T(lang, i18n.MsgUnauthorized)
+	case statusCode >= http.StatusInternalServerError:
+		resp.Message = i18n.T(lang, i18n.MsgInternal)
+	default:
+		resp.Message = i18n.T(lang, i18n.MsgBadRequest)
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
-	json.NewEncoder(w).Encode(ErrorResponse{Status: errorMsg, Error: err})
+	json.NewEncoder(w).Encode(resp)
 }
 
 func successResponse(w http.ResponseWriter, statusCode int, data interface{}) {
@@ -41,6 +96,75 @@ func successResponse(w http.ResponseWriter, statusCode int, data interface{}) {
 	json.NewEncoder(w).Encode(SuccessResponse{Status: successMsg, Data: data})
 }
 
+// createdResponse сообщает о созданном ресурсе. В версионированном API ответ
+// имеет код 201 и заголовок Location, если у ресурса есть адрес;
+// устаревшие маршруты отвечают как раньше, кодом 200 без тела.
+func createdResponse(w http.ResponseWriter, r *http.Request, location string, id interface{}) {
+	prefix, ok := apiPrefixFromContext(r.Context())
+	if !ok {
+		successResponse(w, http.StatusOK, nil)
+		return
+	}
+
+	if location == "" {
+		successResponse(w, http.StatusCreated, nil)
+		return
+	}
+
+	w.Header().Set("Location", prefix+location)
+	successResponse(w, http.StatusCreated, map[string]interface{}{"id": id})
+}
+
+// noContentResponse завершает изменение или удаление ресурса:
+// 204 в версионированном API и 200 в устаревших маршрутах.
+func noContentResponse(w http.ResponseWriter, r *http.Request) {
+	if _, ok := apiPrefixFromContext(r.Context()); !ok {
+		successResponse(w, http.StatusOK, nil)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// etagResponse отвечает как successResponse с кодом 200 и заголовком ETag — хешем тела.
+// Если клиент прислал тот же ETag в If-None-Match, тело не передаётся и ответ имеет код 304.
+func etagResponse(w http.ResponseWriter, r *http.Request, data interface{}) {
+	body, err := json.Marshal(SuccessResponse{Status: successMsg, Data: data})
+	if err != nil {
+		handleError(w, r, fmt.Errorf("сериализация ответа: %w", err), http.StatusInternalServerError)
+		return
+	}
+
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	w.Header().Set("ETag", etag)
+	// ответ можно сохранять, но перед использованием нужно сверить ETag с сервером
+	w.Header().Set("Cache-Control", "no-cache")
+
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(append(body, '\n'))
+}
+
+// etagMatches проверяет заголовок If-None-Match: список ETag через запятую или «*».
+// Слабые ETag сравниваются без префикса W/, как требует RFC 9110 для If-None-Match.
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
 func getStringClaimFromJWT(ctx context.Context, claim string) (strVal string, err error) {
 	_, claims, err := jwtauth.FromContext(ctx)
 	if err != nil {
@@ -60,45 +184,75 @@ func getStringClaimFromJWT(ctx context.Context, claim string) (strVal string, er
 	return strVal, nil
 }
 
+func getActorFromJWT(ctx context.Context) (actor *domain.Actor, err error) {
+	idStr, err := getStringClaimFromJWT(ctx, "sub")
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return nil, fmt.Errorf("converting string to uuid: %w", err)
+	}
+
+	role, err := getStringClaimFromJWT(ctx, "role")
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Actor{ID: id, Role: role}, nil
+}
+
+// parsePeriodFromURL читает период из пути устаревшего маршрута
+// или из строки запроса версионированного API. Необязательный параметр as-of
+// (RFC3339) запрашивает версии отчётов, известные на этот момент.
 func parsePeriodFromURL(r *http.Request) (period *domain.Period, err error) {
-	yearStartStr := chi.URLParam(r, "year-start")
+	param := func(key string) string {
+		if val := chi.URLParam(r, key); val != "" {
+			return val
+		}
+
+		return r.URL.Query().Get(key)
+	}
+
+	yearStartStr := param("year-start")
 	if yearStartStr == "" {
-		return nil, fmt.Errorf("empty year start")
+		return nil, domain.NewValidationError("year-start", i18n.MsgParamRequired, "year-start")
 	}
 
 	yearStart, err := strconv.Atoi(yearStartStr)
 	if err != nil {
-		return nil, fmt.Errorf("converting start year to int: %w", err)
+		return nil, domain.NewValidationError("year-start", i18n.MsgParamInvalidNumber, "year-start")
 	}
 
-	yearEndStr := chi.URLParam(r, "year-end")
+	yearEndStr := param("year-end")
 	if yearEndStr == "" {
-		return nil, fmt.Errorf("empty year end")
+		return nil, domain.NewValidationError("year-end", i18n.MsgParamRequired, "year-end")
 	}
 
 	yearEnd, err := strconv.Atoi(yearEndStr)
 	if err != nil {
-		return nil, fmt.Errorf("converting end year to int: %w", err)
+		return nil, domain.NewValidationError("year-end", i18n.MsgParamInvalidNumber, "year-end")
 	}
 
-	quarterStartStr := chi.URLParam(r, "quarter-start")
+	quarterStartStr := param("quarter-start")
 	if quarterStartStr == "" {
-		return nil, fmt.Errorf("empty quarter start")
+		return nil, domain.NewValidationError("quarter-start", i18n.MsgParamRequired, "quarter-start")
 	}
 
 	quarterStart, err := strconv.Atoi(quarterStartStr)
 	if err != nil {
-		return nil, fmt.Errorf("converting start quarter to int: %w, err")
+		return nil, domain.NewValidationError("quarter-start", i18n.MsgParamInvalidNumber, "quarter-start")
 	}
 
-	quarterEndStr := chi.URLParam(r, "quarter-end")
+	quarterEndStr := param("quarter-end")
 	if quarterEndStr == "" {
-		return nil, fmt.Errorf("empty quarter end")
+		return nil, domain.NewValidationError("quarter-end", i18n.MsgParamRequired, "quarter-end")
 	}
 
 	quarterEnd, err := strconv.Atoi(quarterEndStr)
 	if err != nil {
-		return nil, fmt.Errorf("converting end quarter to int: %w", err)
+		return nil, domain.NewValidationError("quarter-end", i18n.MsgParamInvalidNumber, "quarter-end")
 	}
 
 	period = &domain.Period{
@@ -108,18 +262,25 @@ func parsePeriodFromURL(r *http.Request) (period *domain.Period, err error) {
 		EndQuarter:   quarterEnd,
 	}
 
+	if asOf := r.URL.Query().Get("as-of"); asOf != "" {
+		period.AsOf, err = time.Parse(time.RFC3339, asOf)
+		if err != nil {
+			return nil, domain.NewValidationError("as-of", i18n.MsgParamInvalidTime, "as-of")
+		}
+	}
+
 	return period, nil
 }
 
 func parseUUIDFromURL(r *http.Request, key, entityName string) (val uuid.UUID, err error) {
 	compIdStr := chi.URLParam(r, key)
 	if compIdStr == "" {
-		return uuid.UUID{}, fmt.Errorf("empty %s %s", entityName, key)
+		return uuid.UUID{}, fmt.Errorf("%s: %w", entityName, domain.NewValidationError(key, i18n.MsgParamRequired, key))
 	}
 
 	val, err = uuid.Parse(compIdStr)
 	if err != nil {
-		return uuid.UUID{}, fmt.Errorf("converting %s %s to uuid: %w", entityName, key, err)
+		return uuid.UUID{}, fmt.Errorf("%s: %w", entityName, domain.NewValidationError(key, i18n.MsgParamInvalidUUID, key))
 	}
 
 	return val, nil